@@ -114,6 +114,13 @@ type Environment struct {
 	// written.
 	SnapBucket string
 
+	// CrashArtifactBucket is the GCS bucket to which crash artifacts
+	// of builds, such as core dumps, are written. Unlike LogBucket,
+	// it must not be publicly readable, since core dumps can contain
+	// anything that was in the memory of the crashed process.
+	// If empty, crash artifacts aren't saved.
+	CrashArtifactBucket string
+
 	// MaxBuilds is the maximum number of concurrent builds that
 	// can run. Zero means unlimited. This is typically only used
 	// in a development or staging environment.
@@ -251,16 +258,17 @@ var Staging = &Environment{
 		Name:      "go",
 		Namespace: "default",
 	},
-	DashURL:           "https://build-staging.golang.org/",
-	PerfDataURL:       "https://perfdata.golang.org",
-	CoordinatorName:   "farmer",
-	BuildletBucket:    "dev-go-builder-data",
-	LogBucket:         "dev-go-build-log",
-	SnapBucket:        "dev-go-build-snap",
-	COSServiceAccount: "linux-cos-builders@go-dashboard-dev.iam.gserviceaccount.com",
-	AWSSecurityGroup:  "staging-go-builders",
-	AWSRegion:         "us-east-1",
-	iapServiceIDs:     map[string]string{},
+	DashURL:             "https://build-staging.golang.org/",
+	PerfDataURL:         "https://perfdata.golang.org",
+	CoordinatorName:     "farmer",
+	BuildletBucket:      "dev-go-builder-data",
+	LogBucket:           "dev-go-build-log",
+	SnapBucket:          "dev-go-build-snap",
+	CrashArtifactBucket: "dev-go-build-crash",
+	COSServiceAccount:   "linux-cos-builders@go-dashboard-dev.iam.gserviceaccount.com",
+	AWSSecurityGroup:    "staging-go-builders",
+	AWSRegion:           "us-east-1",
+	iapServiceIDs:       map[string]string{},
 }
 
 // Production defines the environment that the coordinator and build
@@ -278,15 +286,16 @@ var Production = &Environment{
 		Name:      "services",
 		Namespace: "prod",
 	},
-	DashURL:           "https://build.golang.org/",
-	PerfDataURL:       "https://perfdata.golang.org",
-	CoordinatorName:   "farmer",
-	BuildletBucket:    "go-builder-data",
-	LogBucket:         "go-build-log",
-	SnapBucket:        "go-build-snap",
-	COSServiceAccount: "linux-cos-builders@symbolic-datum-552.iam.gserviceaccount.com",
-	AWSSecurityGroup:  "go-builders",
-	AWSRegion:         "us-east-2",
+	DashURL:             "https://build.golang.org/",
+	PerfDataURL:         "https://perfdata.golang.org",
+	CoordinatorName:     "farmer",
+	BuildletBucket:      "go-builder-data",
+	LogBucket:           "go-build-log",
+	SnapBucket:          "go-build-snap",
+	CrashArtifactBucket: "go-build-crash",
+	COSServiceAccount:   "linux-cos-builders@symbolic-datum-552.iam.gserviceaccount.com",
+	AWSSecurityGroup:    "go-builders",
+	AWSRegion:           "us-east-2",
 	iapServiceIDs: map[string]string{
 		"coordinator-internal-iap": "7963570695201399464",
 		"relui-internal":           "155577380958854618",
@@ -83,6 +83,13 @@ type VMOpts struct {
 	// disk size is used if unset.
 	// Only valid for GCE resources.
 	DiskSizeGB int64
+
+	// Image optionally overrides the host type's base image: its
+	// ContainerImage for container hosts, or its VMImage otherwise.
+	// The caller is responsible for checking that the image is
+	// allowed for the host type (see dashboard.HostConfig.IsAllowedImage).
+	// Only valid for GCE resources.
+	Image string
 }
 
 // buildletClient returns a buildlet client configured to speak to a VM via the buildlet
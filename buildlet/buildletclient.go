@@ -5,7 +5,10 @@
 package buildlet // import "golang.org/x/build/buildlet"
 
 import (
+	"archive/tar"
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -16,6 +19,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -107,6 +111,27 @@ func (c *client) SetDescription(v string) {
 	c.desc = v
 }
 
+// SetEnv sets the KEY=VALUE pairs to append to the buildlet process's
+// environment in all subsequent Exec calls, replacing any previously
+// set by SetEnv. ExecOpts.ExtraEnv is appended after them, so it takes
+// precedence for any keys set in both.
+func (c *client) SetEnv(env []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.env = append([]string(nil), env...)
+}
+
+// execEnv returns the environment to send for an Exec call with the
+// given ExtraEnv.
+func (c *client) execEnv(extraEnv []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.env) == 0 {
+		return extraEnv
+	}
+	return append(append([]string(nil), c.env...), extraEnv...)
+}
+
 // SetInstanceName sets an instance name for GCE and EC2 buildlets.
 // This value differs from the buildlet name used in the CLI and web interface.
 func (c *client) SetInstanceName(v string) {
@@ -177,7 +202,8 @@ type client struct {
 	deadErr           error         // guarded by peerDead's close
 
 	mu     sync.Mutex
-	broken bool // client is broken in some way
+	broken bool     // client is broken in some way
+	env    []string // base environment for Exec; see SetEnv
 }
 
 func (c *client) String() string {
@@ -449,7 +475,21 @@ func (c *client) Put(ctx context.Context, r io.Reader, path string, mode os.File
 // GetTar returns a .tar.gz stream of the given directory, relative to the buildlet's work dir.
 // The provided dir may be empty to get everything.
 func (c *client) GetTar(ctx context.Context, dir string) (io.ReadCloser, error) {
-	req, err := http.NewRequest("GET", c.URL()+"/tgz?dir="+url.QueryEscape(dir), nil)
+	return c.GetTarExcluding(ctx, dir, nil)
+}
+
+// GetTarExcluding is like GetTar, but omits files and directories
+// (including their contents) whose path relative to dir matches any
+// of the exclude patterns. Each pattern should contain only forward
+// slashes and uses path.Match syntax.
+//
+// Buildlets older than version 30 ignore exclude.
+func (c *client) GetTarExcluding(ctx context.Context, dir string, exclude []string) (io.ReadCloser, error) {
+	param := url.Values{
+		"dir":     {dir},
+		"exclude": exclude,
+	}
+	req, err := http.NewRequest("GET", c.URL()+"/tgz?"+param.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -465,12 +505,114 @@ func (c *client) GetTar(ctx context.Context, dir string) (io.ReadCloser, error)
 	return res.Body, nil
 }
 
+// GetTarStreamOpts are options for GetTarStream.
+type GetTarStreamOpts struct {
+	// Exclude are patterns of paths to omit, as in GetTarExcluding.
+	Exclude []string
+
+	// Progress, if non-nil, is called with the total number of bytes
+	// read so far each time another ProgressInterval bytes have been
+	// read, and once more when the end of the tarball is reached.
+	// It's called from the goroutine reading the returned stream.
+	Progress func(total int64)
+
+	// ProgressInterval is the number of bytes between calls to
+	// Progress. If zero, it's 1 MiB.
+	ProgressInterval int64
+}
+
+// GetTarStream is like GetTarExcluding, but reports the progress of
+// reading the tarball to opts.Progress, so that callers copying large
+// tarballs can tell a slow network apart from a hung buildlet.
+func (c *client) GetTarStream(ctx context.Context, dir string, opts GetTarStreamOpts) (io.ReadCloser, error) {
+	rc, err := c.GetTarExcluding(ctx, dir, opts.Exclude)
+	if err != nil || opts.Progress == nil {
+		return rc, err
+	}
+	return newProgressReader(rc, opts.ProgressInterval, opts.Progress), nil
+}
+
+// progressReader is an io.ReadCloser that reports the number of bytes
+// read from it every interval bytes.
+type progressReader struct {
+	io.ReadCloser
+	interval int64
+	progress func(total int64)
+
+	total int64 // bytes read so far
+	next  int64 // total at which to next call progress
+	done  bool  // whether EOF has been reported
+}
+
+func newProgressReader(rc io.ReadCloser, interval int64, progress func(int64)) *progressReader {
+	if interval <= 0 {
+		interval = 1 << 20
+	}
+	return &progressReader{ReadCloser: rc, interval: interval, progress: progress, next: interval}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.total += int64(n)
+	if r.total >= r.next {
+		r.next = (r.total/r.interval + 1) * r.interval
+		r.progress(r.total)
+	} else if err == io.EOF && !r.done {
+		r.progress(r.total)
+	}
+	if err == io.EOF {
+		r.done = true
+	}
+	return n, err
+}
+
+// CrashArtifactsDir is the directory, relative to the buildlet's work
+// directory, where core dumps and other crash artifacts are collected.
+// Builder images that support collecting crash artifacts arrange for
+// crashing processes to leave them there, for example by setting the
+// kernel's core pattern.
+const CrashArtifactsDir = "cores"
+
+// GetCrashArtifacts returns a .tar.gz stream of the files in
+// CrashArtifactsDir. If there are none, it returns a nil ReadCloser
+// and a nil error.
+func (c *client) GetCrashArtifacts(ctx context.Context) (io.ReadCloser, error) {
+	var haveDir bool
+	if err := c.ListDir(ctx, "", ListDirOpts{}, func(ent DirEntry) {
+		if ent.IsDir() && ent.Name() == CrashArtifactsDir+"/" {
+			haveDir = true
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if !haveDir {
+		return nil, nil
+	}
+	var haveFiles bool
+	if err := c.ListDir(ctx, CrashArtifactsDir, ListDirOpts{Recursive: true}, func(ent DirEntry) {
+		if !ent.IsDir() {
+			haveFiles = true
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if !haveFiles {
+		return nil, nil
+	}
+	return c.GetTar(ctx, CrashArtifactsDir)
+}
+
 // ExecOpts are options for a remote command invocation.
 type ExecOpts struct {
 	// Output is the output of stdout and stderr.
 	// If nil, the output is discarded.
 	Output io.Writer
 
+	// Tail, if non-nil, also receives the output, keeping only its
+	// most recent bytes. It lets the caller show the recent output of
+	// a long-running command without keeping all of it in memory.
+	Tail *TailBuffer
+
 	// Dir is the directory from which to execute the command,
 	// as an absolute or relative path using the buildlet's native
 	// path separator, or a slash-separated relative path.
@@ -484,7 +626,7 @@ type ExecOpts struct {
 	Args []string
 
 	// ExtraEnv are KEY=VALUE pairs to append to the buildlet
-	// process's environment.
+	// process's environment, after any set by Client.SetEnv.
 	ExtraEnv []string
 
 	// Path, if non-nil, specifies the PATH variable of the executed process's
@@ -512,12 +654,105 @@ type ExecOpts struct {
 	// response from the buildlet, but before the output begins
 	// writing to Output.
 	OnStartExec func()
+
+	// Timeout, if non-zero, is the maximum amount of time the command
+	// may run. If it's exceeded, the command is killed and Exec reports
+	// a remoteErr saying so, without the caller's context being done.
+	Timeout time.Duration
+}
+
+// output returns the writer that a command's output is copied to,
+// which is io.Discard if there's neither an Output nor a Tail.
+func (opts *ExecOpts) output() io.Writer {
+	switch {
+	case opts.Output != nil && opts.Tail != nil:
+		return io.MultiWriter(opts.Output, opts.Tail)
+	case opts.Tail != nil:
+		return opts.Tail
+	case opts.Output != nil:
+		return opts.Output
+	}
+	return io.Discard
 }
 
 // ErrTimeout is a sentinel error that represents that waiting
 // for a command to complete has exceeded the given timeout.
 var ErrTimeout = errors.New("buildlet: timeout waiting for command to complete")
 
+// A RemoteError is the remoteErr returned by Exec: the command was run
+// on the buildlet but didn't succeed. Err is an *ExitError if the
+// command exited or was killed, and otherwise describes the failure,
+// such as the command exceeding its ExecOpts.Timeout.
+//
+// Remote errors are the command's own fault, so retrying the command
+// on another buildlet won't help.
+type RemoteError struct {
+	Err error
+}
+
+func (e *RemoteError) Error() string { return e.Err.Error() }
+
+func (e *RemoteError) Unwrap() error { return e.Err }
+
+// A CommError is the execErr returned by Exec: the command's outcome
+// is unknown because of a failure starting it or communicating with the
+// buildlet, such as the buildlet dying or the context's deadline being
+// exceeded, in which case Err is ErrTimeout.
+//
+// Communication errors aren't the command's fault, so the command may
+// succeed if retried, usually on another buildlet.
+type CommError struct {
+	Err error
+}
+
+func (e *CommError) Error() string { return e.Err.Error() }
+
+func (e *CommError) Unwrap() error { return e.Err }
+
+// execErrors wraps the errors returned by an Exec implementation in
+// a RemoteError and CommError, respectively.
+func execErrors(remoteErr, execErr error) (error, error) {
+	if execErr != nil {
+		return nil, &CommError{Err: execErr}
+	}
+	if remoteErr != nil {
+		return &RemoteError{Err: remoteErr}, nil
+	}
+	return nil, nil
+}
+
+// An ExitError is the error wrapped by the RemoteError returned by Exec
+// when the command was run but didn't succeed.
+type ExitError struct {
+	// State is the process state reported by the buildlet, such as
+	// "exit status 1" or "signal: killed". It's also the error text.
+	State string
+	// Code is the exit code of the command, or -1 if it didn't exit
+	// normally, such as when it was killed by a signal or couldn't
+	// be started.
+	Code int
+	// Signaled reports whether the command was terminated by a signal.
+	Signaled bool
+}
+
+func (e *ExitError) Error() string { return e.State }
+
+// parseProcessState returns the ExitError for the process state
+// reported by the buildlet for a failed command, which is the
+// os.ProcessState.String of the command, or the error that kept it
+// from starting.
+func parseProcessState(state string) *ExitError {
+	e := &ExitError{State: state, Code: -1}
+	if s, ok := strings.CutPrefix(state, "exit status "); ok {
+		if code, err := strconv.Atoi(s); err == nil {
+			e.Code = code
+		}
+	} else if strings.HasPrefix(state, "signal: ") {
+		e.Signaled = true
+	}
+	return e
+}
+
 // Exec runs cmd on the buildlet.
 //
 // cmd may be an absolute or relative path using the buildlet's native path
@@ -528,11 +763,31 @@ var ErrTimeout = errors.New("buildlet: timeout waiting for command to complete")
 // remotely (remoteErr), and the second (execErr) is whether there
 // were system errors preventing the command from being started or
 // seen to completition. If execErr is non-nil, the remoteErr is
-// meaningless.
+// meaningless. A non-nil remoteErr is a *RemoteError, which wraps an
+// *ExitError if it reports how the command failed, and a non-nil
+// execErr is a *CommError.
 //
 // If the context's deadline is exceeded while waiting for the command
-// to complete, the returned execErr is ErrTimeout.
+// to complete, the returned execErr wraps ErrTimeout. If opts.Timeout
+// is exceeded instead, execErr is nil and remoteErr reports the timeout.
 func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr, execErr error) {
+	if opts.Timeout <= 0 {
+		return execErrors(c.exec(ctx, cmd, opts))
+	}
+	execCtx, cancel := context.WithTimeoutCause(ctx, opts.Timeout, errCommandTimeout)
+	defer cancel()
+	remoteErr, execErr = c.exec(execCtx, cmd, opts)
+	if execErr != nil && context.Cause(execCtx) == errCommandTimeout {
+		return execErrors(fmt.Errorf("command timed out after %v", opts.Timeout), nil)
+	}
+	return execErrors(remoteErr, execErr)
+}
+
+// errCommandTimeout is the cause of the context of an Exec call that
+// exceeded its ExecOpts.Timeout.
+var errCommandTimeout = errors.New("buildlet: command exceeded its timeout")
+
+func (c *client) exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr, execErr error) {
 	var mode string
 	if opts.SystemLevel {
 		mode = "sys"
@@ -548,7 +803,7 @@ func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr
 		"mode":   {mode},
 		"dir":    {opts.Dir},
 		"cmdArg": opts.Args,
-		"env":    opts.ExtraEnv,
+		"env":    c.execEnv(opts.ExtraEnv),
 		"path":   path,
 		"debug":  {fmt.Sprint(opts.Debug)},
 	}
@@ -584,10 +839,7 @@ func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr
 	resc := make(chan errs, 1)
 	go func() {
 		// Stream the output:
-		out := opts.Output
-		if out == nil {
-			out = io.Discard
-		}
+		out := opts.output()
 		if _, err := io.Copy(out, res.Body); err != nil {
 			resc <- errs{execErr: fmt.Errorf("error copying response: %w", err)}
 			return
@@ -604,7 +856,7 @@ func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr
 			return
 		}
 		if state != "ok" {
-			resc <- errs{remoteErr: errors.New(state)}
+			resc <- errs{remoteErr: parseProcessState(state)}
 		} else {
 			resc <- errs{} // success
 		}
@@ -619,7 +871,11 @@ func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr
 			// but maybe someday we'll want to start to rely on the buildlet to report
 			// such a condition and not mark it as unhealthy.
 
-			c.MarkBroken()
+			// A command that exceeded its own timeout was killed
+			// on purpose; that says nothing about the buildlet.
+			if context.Cause(ctx) != errCommandTimeout {
+				c.MarkBroken()
+			}
 			if errors.Is(res.execErr, context.DeadlineExceeded) {
 				res.execErr = ErrTimeout
 			}
@@ -644,6 +900,99 @@ func (c *client) RemoveAll(ctx context.Context, paths ...string) error {
 	return c.doOK(req.WithContext(ctx))
 }
 
+// RemoveAllInfo describes what RemoveAll would delete for one of its paths.
+type RemoveAllInfo struct {
+	Path   string // as passed to RemoveAllDryRun
+	Exists bool   // whether the path exists
+	Files  int64  // number of files, directories, and other entries
+	Size   int64  // total size of the regular files, in bytes
+}
+
+// RemoveAllDryRun reports what RemoveAll would delete for paths,
+// relative to the work directory, without deleting anything.
+// It returns one RemoveAllInfo per path, in order.
+//
+// Buildlets older than version 32 don't support RemoveAllDryRun.
+func (c *client) RemoveAllDryRun(ctx context.Context, paths ...string) ([]RemoveAllInfo, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	form := url.Values{"path": paths}
+	req, err := http.NewRequest("POST", c.URL()+"/removeall/dryrun", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := c.do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		slurp, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+		return nil, fmt.Errorf("%v; body: %s", res.Status, slurp)
+	}
+	var infos []RemoveAllInfo
+	if err := json.NewDecoder(res.Body).Decode(&infos); err != nil {
+		return nil, fmt.Errorf("decoding /removeall/dryrun response: %v", err)
+	}
+	if len(infos) != len(paths) {
+		return nil, fmt.Errorf("got %d results from /removeall/dryrun for %d paths", len(infos), len(paths))
+	}
+	return infos, nil
+}
+
+// Mkdir creates the directory dir, relative to the work directory,
+// along with any necessary parents. The permission bits of mode are
+// used for the directories it creates. It's not an error if dir
+// already exists.
+//
+// Buildlets older than version 31 don't support Mkdir directly, so
+// for them the directory is created by writing a tar file containing
+// only that directory, and mode is ignored.
+func (c *client) Mkdir(ctx context.Context, dir string, mode os.FileMode) error {
+	form := url.Values{
+		"path": {dir},
+		"mode": {fmt.Sprint(int64(mode.Perm()))},
+	}
+	req, err := http.NewRequest("POST", c.URL()+"/mkdir", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := c.do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		// An old buildlet without the /mkdir handler.
+		return c.PutTar(ctx, bytes.NewReader(dirTarGz(mode)), dir)
+	default:
+		slurp, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+		return fmt.Errorf("%v; body: %s", res.Status, slurp)
+	}
+}
+
+// dirTarGz returns a .tar.gz file containing only a directory entry
+// for its root.
+func dirTarGz(mode os.FileMode) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     "./",
+		Mode:     int64(mode.Perm()),
+	})
+	tw.Close()
+	zw.Close()
+	return buf.Bytes()
+}
+
 // Status provides status information about the buildlet.
 //
 // A coordinator can use the provided information to decide what, if anything,
@@ -755,6 +1104,34 @@ func (de DirEntry) Digest() string {
 	return f[4]
 }
 
+// Size returns the size of a regular file in bytes.
+// It returns -1 if de doesn't describe a regular file.
+func (de DirEntry) Size() int64 {
+	f := strings.Split(de.Line, "\t")
+	if len(f) < 3 {
+		return -1
+	}
+	n, err := strconv.ParseInt(f[2], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// ModTime returns the modification time of a regular file.
+// It returns the zero time if de doesn't describe a regular file.
+func (de DirEntry) ModTime() time.Time {
+	f := strings.Split(de.Line, "\t")
+	if len(f) < 4 {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, f[3])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 // ListDirOpts are options for Client.ListDir.
 type ListDirOpts struct {
 	// Recursive controls whether the directory is listed
@@ -5,16 +5,23 @@
 package buildlet
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 func TestConnectSSHTLS(t *testing.T) {
@@ -206,9 +213,271 @@ func TestExecTimeoutError(t *testing.T) {
 	_, execErr := cl.Exec(ctx, "./bin/test", ExecOpts{
 		OnStartExec: func() { close(ctx.done) },
 	})
-	if execErr != ErrTimeout {
+	if !errors.Is(execErr, ErrTimeout) {
 		t.Errorf("cl.Exec error = %v; want %v", execErr, ErrTimeout)
 	}
+	var ce *CommError
+	if !errors.As(execErr, &ce) {
+		t.Errorf("cl.Exec error = %v (%T); want *CommError", execErr, execErr)
+	}
+}
+
+func TestExecOptsTimeout(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(Status{})
+	})
+	mux.HandleFunc("/exec", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("."))
+		w.(http.Flusher).Flush() // /exec needs to flush headers right away.
+		<-req.Context().Done()   // Simulate that execution hangs, so no more output.
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unable to parse http server url %s", err)
+	}
+	cl := NewClient(u.Host, NoKeyPair)
+	defer cl.Close()
+
+	ctx := context.Background()
+	remoteErr, execErr := cl.Exec(ctx, "./bin/test", ExecOpts{Timeout: 10 * time.Millisecond})
+	if execErr != nil {
+		t.Fatalf("cl.Exec error = %v; want nil", execErr)
+	}
+	if want := "command timed out after 10ms"; remoteErr == nil || remoteErr.Error() != want {
+		t.Errorf("cl.Exec remote error = %v; want %q", remoteErr, want)
+	}
+	var re *RemoteError
+	if !errors.As(remoteErr, &re) {
+		t.Errorf("cl.Exec remote error = %v (%T); want *RemoteError", remoteErr, remoteErr)
+	}
+	if cl.IsBroken() {
+		t.Errorf("cl.IsBroken() = true after command timed out; want false")
+	}
+}
+
+func TestSetEnv(t *testing.T) {
+	var gotEnv []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/exec", func(w http.ResponseWriter, req *http.Request) {
+		req.ParseForm()
+		gotEnv = req.PostForm["env"]
+		w.Header().Set("Trailer", "Process-State")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		w.Header().Set("Process-State", "ok")
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unable to parse http server url %s", err)
+	}
+	cl := NewClient(u.Host, NoKeyPair)
+	defer cl.Close()
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		env      []string // if non-nil, passed to SetEnv before Exec
+		extraEnv []string
+		want     []string
+	}{
+		{extraEnv: []string{"GOOS=linux"}, want: []string{"GOOS=linux"}},
+		{env: []string{"GOROOT_BOOTSTRAP=/go1.4", "GOOS=linux"}, want: []string{"GOROOT_BOOTSTRAP=/go1.4", "GOOS=linux"}},
+		{extraEnv: []string{"GOOS=windows"}, want: []string{"GOROOT_BOOTSTRAP=/go1.4", "GOOS=linux", "GOOS=windows"}},
+		{env: []string{}, extraEnv: []string{"GOOS=windows"}, want: []string{"GOOS=windows"}},
+	} {
+		if tc.env != nil {
+			cl.SetEnv(tc.env)
+		}
+		if remoteErr, execErr := cl.Exec(ctx, "./bin/test", ExecOpts{ExtraEnv: tc.extraEnv}); remoteErr != nil || execErr != nil {
+			t.Fatalf("cl.Exec = %v, %v; want nil, nil", remoteErr, execErr)
+		}
+		if !slices.Equal(gotEnv, tc.want) {
+			t.Errorf("after SetEnv(%q), Exec with ExtraEnv %q sent env %q; want %q", tc.env, tc.extraEnv, gotEnv, tc.want)
+		}
+	}
+}
+
+func TestExecExitError(t *testing.T) {
+	var state string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/exec", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Trailer", "Process-State")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		w.Header().Set("Process-State", state)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unable to parse http server url %s", err)
+	}
+	cl := NewClient(u.Host, NoKeyPair)
+	defer cl.Close()
+
+	ctx := context.Background()
+	for _, want := range []ExitError{
+		{State: "exit status 1", Code: 1},
+		{State: "exit status 2", Code: 2},
+		{State: "signal: killed", Code: -1, Signaled: true},
+		{State: "signal: segmentation fault (core dumped)", Code: -1, Signaled: true},
+		{State: `exec: "./bin/test": permission denied`, Code: -1},
+	} {
+		state = want.State
+		remoteErr, execErr := cl.Exec(ctx, "./bin/test", ExecOpts{})
+		if execErr != nil {
+			t.Fatalf("cl.Exec error = %v; want nil", execErr)
+		}
+		var re *RemoteError
+		if !errors.As(remoteErr, &re) {
+			t.Errorf("cl.Exec remote error = %v (%T); want *RemoteError", remoteErr, remoteErr)
+		}
+		var ee *ExitError
+		if !errors.As(remoteErr, &ee) {
+			t.Fatalf("cl.Exec remote error = %v (%T); want *ExitError", remoteErr, remoteErr)
+		}
+		if *ee != want {
+			t.Errorf("cl.Exec remote error = %+v; want %+v", *ee, want)
+		}
+		if remoteErr.Error() != want.State {
+			t.Errorf("cl.Exec remote error text = %q; want %q", remoteErr.Error(), want.State)
+		}
+	}
+}
+
+func TestProgressReader(t *testing.T) {
+	var got []int64
+	r := newProgressReader(io.NopCloser(iotest.OneByteReader(strings.NewReader("0123456789"))), 4, func(total int64) {
+		got = append(got, total)
+	})
+	b, err := io.ReadAll(r)
+	if err != nil || string(b) != "0123456789" {
+		t.Fatalf("io.ReadAll = %q, %v; want %q, nil", b, err, "0123456789")
+	}
+	if want := []int64{4, 8, 10}; !slices.Equal(got, want) {
+		t.Errorf("progress calls = %v; want %v", got, want)
+	}
+}
+
+func TestGetCrashArtifacts(t *testing.T) {
+	tests := []struct {
+		name string
+		ls   map[string]string // dir -> /ls output
+		want string            // contents of the returned stream; "" for nil
+	}{
+		{
+			name: "no_dir",
+			ls:   map[string]string{"": "drwxr-xr-x\tgo/\n"},
+		},
+		{
+			name: "empty_dir",
+			ls: map[string]string{
+				"":      "drwxr-xr-x\tcores/\ndrwxr-xr-x\tgo/\n",
+				"cores": "",
+			},
+		},
+		{
+			name: "core",
+			ls: map[string]string{
+				"":      "drwxr-xr-x\tcores/\ndrwxr-xr-x\tgo/\n",
+				"cores": "-rw-------\tcore.1234\t4096\t2023-01-01T00:00:00Z\n",
+			},
+			want: "tgz of cores",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/ls", func(w http.ResponseWriter, req *http.Request) {
+				out, ok := tt.ls[req.FormValue("dir")]
+				if !ok {
+					http.Error(w, "Walk error: no such file or directory", http.StatusInternalServerError)
+					return
+				}
+				w.Write([]byte(out))
+			})
+			mux.HandleFunc("/tgz", func(w http.ResponseWriter, req *http.Request) {
+				w.Write([]byte("tgz of " + req.FormValue("dir")))
+			})
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+			u, err := url.Parse(ts.URL)
+			if err != nil {
+				t.Fatalf("unable to parse http server url %s", err)
+			}
+			cl := NewClient(u.Host, NoKeyPair)
+			defer cl.Close()
+
+			rc, err := cl.GetCrashArtifacts(context.Background())
+			if err != nil {
+				t.Fatalf("GetCrashArtifacts: %v", err)
+			}
+			if rc == nil {
+				if tt.want != "" {
+					t.Errorf("GetCrashArtifacts returned no artifacts, want %q", tt.want)
+				}
+				return
+			}
+			defer rc.Close()
+			got, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GetCrashArtifacts = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMkdir(t *testing.T) {
+	for _, hasMkdir := range []bool{true, false} {
+		t.Run(fmt.Sprintf("hasMkdir=%v", hasMkdir), func(t *testing.T) {
+			var got string
+			mux := http.NewServeMux()
+			if hasMkdir {
+				mux.HandleFunc("/mkdir", func(w http.ResponseWriter, req *http.Request) {
+					got = fmt.Sprintf("mkdir %s %s", req.FormValue("path"), req.FormValue("mode"))
+				})
+			}
+			mux.HandleFunc("/writetgz", func(w http.ResponseWriter, req *http.Request) {
+				zr, err := gzip.NewReader(req.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				hdr, err := tar.NewReader(zr).Next()
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				got = fmt.Sprintf("writetgz %s %s %c", req.FormValue("dir"), hdr.Name, hdr.Typeflag)
+			})
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+			u, err := url.Parse(ts.URL)
+			if err != nil {
+				t.Fatalf("unable to parse http server url %s", err)
+			}
+			cl := NewClient(u.Host, NoKeyPair)
+			defer cl.Close()
+
+			if err := cl.Mkdir(context.Background(), "out/bin", 0750); err != nil {
+				t.Fatalf("Mkdir: %v", err)
+			}
+			want := "mkdir out/bin 488"
+			if !hasMkdir {
+				want = "writetgz out/bin ./ 5"
+			}
+			if got != want {
+				t.Errorf("buildlet got request %q, want %q", got, want)
+			}
+		})
+	}
 }
 
 type deadlineOnDemandContext struct {
@@ -225,3 +494,31 @@ func (c deadlineOnDemandContext) Err() error {
 		return context.DeadlineExceeded
 	}
 }
+
+func TestDirEntry(t *testing.T) {
+	for _, tc := range []struct {
+		line    string
+		name    string
+		size    int64
+		modTime time.Time
+		digest  string
+	}{
+		{line: "drwxr-xr-x\tsrc/", name: "src/", size: -1},
+		{line: "-rw-r--r--\tsrc/go.mod\t42\t2025-03-04T05:06:07Z", name: "src/go.mod", size: 42, modTime: time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)},
+		{line: "-rw-r--r--\tVERSION\t0\t2025-03-04T05:06:07Z\tda39a3ee5e6b4b0d3255bfef95601890afd80709", name: "VERSION", size: 0, modTime: time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC), digest: "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+	} {
+		de := DirEntry{Line: tc.line}
+		if got := de.Name(); got != tc.name {
+			t.Errorf("DirEntry{%q}.Name() = %q; want %q", tc.line, got, tc.name)
+		}
+		if got := de.Size(); got != tc.size {
+			t.Errorf("DirEntry{%q}.Size() = %d; want %d", tc.line, got, tc.size)
+		}
+		if got := de.ModTime(); !got.Equal(tc.modTime) {
+			t.Errorf("DirEntry{%q}.ModTime() = %v; want %v", tc.line, got, tc.modTime)
+		}
+		if got := de.Digest(); got != tc.digest {
+			t.Errorf("DirEntry{%q}.Digest() = %q; want %q", tc.line, got, tc.digest)
+		}
+	}
+}
@@ -38,14 +38,20 @@ type RemoteClient interface {
 type Client interface {
 	RemoteClient
 	ConnectSSH(user, authorizedPubKey string) (net.Conn, error)
+	GetCrashArtifacts(ctx context.Context) (io.ReadCloser, error)
+	GetTarExcluding(ctx context.Context, dir string, exclude []string) (io.ReadCloser, error)
+	GetTarStream(ctx context.Context, dir string, opts GetTarStreamOpts) (io.ReadCloser, error)
 	IPPort() string
 	InstanceName() string
 	IsBroken() bool
 	MarkBroken()
+	Mkdir(ctx context.Context, dir string, mode os.FileMode) error
 	Name() string
+	RemoveAllDryRun(ctx context.Context, paths ...string) ([]RemoveAllInfo, error)
 	ProxyRoundTripper() http.RoundTripper
 	SetDescription(v string)
 	SetDialer(dialer func(context.Context) (net.Conn, error))
+	SetEnv(env []string)
 	SetHTTPClient(httpClient *http.Client)
 	SetInstanceName(v string)
 	SetName(name string)
@@ -82,15 +88,16 @@ func (fc *FakeClient) ConnectSSH(user, authorizedPubKey string) (net.Conn, error
 // Exec fakes the execution.
 func (fc *FakeClient) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr, execErr error) {
 	if cmd == "" {
-		return nil, errors.New("invalid command")
+		return execErrors(nil, errors.New("invalid command"))
 	}
-	if opts.Output == nil {
+	if opts.Output == nil && opts.Tail == nil {
 		return nil, nil
 	}
+	w := opts.output()
 	out := []byte("<this is a song that never ends>")
 	for it := 0; it < 3; it++ {
-		if n, err := opts.Output.Write(out); n != len(out) || err != nil {
-			return nil, fmt.Errorf("Output.Write(...) = %d, %q; want %d, no error", n, err, len(out))
+		if n, err := w.Write(out); n != len(out) || err != nil {
+			return execErrors(nil, fmt.Errorf("Output.Write(...) = %d, %q; want %d, no error", n, err, len(out)))
 		}
 	}
 	return nil, nil
@@ -105,6 +112,26 @@ func (fc *FakeClient) GetTar(ctx context.Context, dir string) (io.ReadCloser, er
 	return io.NopCloser(r), nil
 }
 
+// GetTarExcluding gives a fake tar zipped directory.
+func (fc *FakeClient) GetTarExcluding(ctx context.Context, dir string, exclude []string) (io.ReadCloser, error) {
+	return fc.GetTar(ctx, dir)
+}
+
+// GetTarStream gives a fake tar zipped directory, reporting progress
+// as it's read.
+func (fc *FakeClient) GetTarStream(ctx context.Context, dir string, opts GetTarStreamOpts) (io.ReadCloser, error) {
+	rc, err := fc.GetTar(ctx, dir)
+	if err != nil || opts.Progress == nil {
+		return rc, err
+	}
+	return newProgressReader(rc, opts.ProgressInterval, opts.Progress), nil
+}
+
+// GetCrashArtifacts reports that there are no crash artifacts.
+func (fc *FakeClient) GetCrashArtifacts(ctx context.Context) (io.ReadCloser, error) {
+	return nil, nil
+}
+
 // IPPort provides a fake ip and port pair.
 func (fc *FakeClient) IPPort() string { return "" }
 
@@ -179,6 +206,9 @@ func (fc *FakeClient) SetName(name string) {
 	fc.name = name
 }
 
+// SetEnv sets the base environment on a fake client. It has no effect.
+func (fc *FakeClient) SetEnv(env []string) {}
+
 // SetOnHeartbeatFailure sets a function to be called when heartbeats against this fake buildlet fail.
 func (fc *FakeClient) SetOnHeartbeatFailure(fn func()) {}
 
@@ -196,6 +226,24 @@ func (fc *FakeClient) WorkDir(ctx context.Context) (string, error) {
 	return "/work", nil
 }
 
+// Mkdir creates a directory on a fake buildlet.
+func (fc *FakeClient) Mkdir(ctx context.Context, dir string, mode os.FileMode) error {
+	// TODO(go.dev/issue/48742) add a file system implementation which would enable proper testing.
+	if dir == "" {
+		return errors.New("invalid argument")
+	}
+	return nil
+}
+
+// RemoveAllDryRun reports that none of the provided paths exist for a fake buildlet.
+func (fc *FakeClient) RemoveAllDryRun(ctx context.Context, paths ...string) ([]RemoveAllInfo, error) {
+	infos := make([]RemoveAllInfo, len(paths))
+	for i, p := range paths {
+		infos[i].Path = p
+	}
+	return infos, nil
+}
+
 // RemoveAll deletes the provided paths, relative to the work directory for a fake buildlet.
 func (fc *FakeClient) RemoveAll(ctx context.Context, paths ...string) error {
 	// TODO(go.dev/issue/48742) add a file system implementation which would enable proper testing.
@@ -98,6 +98,16 @@ func StartNewVM(creds *google.Credentials, buildEnv *buildenv.Environment, instN
 	if !hconf.IsVM() && !hconf.IsContainer() {
 		return nil, fmt.Errorf("host %q is type %q; want either a VM or container type", hostType, hconf.PoolName())
 	}
+	if opts.Image != "" {
+		// Copy hconf so the override doesn't leak into dashboard.Hosts.
+		h := *hconf
+		if h.IsContainer() {
+			h.ContainerImage = opts.Image
+		} else {
+			h.VMImage = opts.Image
+		}
+		hconf = &h
+	}
 
 	projectID := opts.ProjectID
 	if projectID == "" {
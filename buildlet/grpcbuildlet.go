@@ -73,7 +73,22 @@ func (b *grpcBuildlet) Close() error {
 	return err
 }
 
+// Exec runs cmd like client.Exec, including enforcing opts.Timeout.
 func (b *grpcBuildlet) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr error, execErr error) {
+	if opts.Timeout <= 0 {
+		return execErrors(b.exec(ctx, cmd, opts))
+	}
+	execCtx, cancel := context.WithTimeoutCause(ctx, opts.Timeout, errCommandTimeout)
+	defer cancel()
+	remoteErr, execErr = b.exec(execCtx, cmd, opts)
+	// A canceled stream may be reported as either kind of error.
+	if (remoteErr != nil || execErr != nil) && context.Cause(execCtx) == errCommandTimeout {
+		return execErrors(fmt.Errorf("command timed out after %v", opts.Timeout), nil)
+	}
+	return execErrors(remoteErr, execErr)
+}
+
+func (b *grpcBuildlet) exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr error, execErr error) {
 	stream, err := b.client.ExecuteCommand(ctx, &protos.ExecuteCommandRequest{
 		GomoteId:          b.id,
 		Command:           cmd,
@@ -90,6 +105,7 @@ func (b *grpcBuildlet) Exec(ctx context.Context, cmd string, opts ExecOpts) (rem
 	if opts.OnStartExec != nil {
 		opts.OnStartExec()
 	}
+	out := opts.output()
 	for {
 		update, err := stream.Recv()
 		if errors.Is(err, io.EOF) {
@@ -100,12 +116,14 @@ func (b *grpcBuildlet) Exec(ctx context.Context, cmd string, opts ExecOpts) (rem
 			if status.Code(err) == codes.Aborted {
 				return nil, err
 			}
-			// Unknown, presumed command error.
+			// Unknown, presumed command error. The gomote server
+			// reports how a command failed in the status message.
+			if state, ok := strings.CutPrefix(status.Convert(err).Message(), "command execution failed: "); ok {
+				return parseProcessState(state), nil
+			}
 			return err, nil
 		}
-		if opts.Output != nil {
-			opts.Output.Write(update.Output)
-		}
+		out.Write(update.Output)
 	}
 }
 
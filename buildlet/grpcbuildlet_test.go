@@ -0,0 +1,59 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildlet
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// hangingGomoteClient is a GomoteServiceClient whose commands never
+// finish on their own.
+type hangingGomoteClient struct {
+	protos.GomoteServiceClient
+}
+
+func (hangingGomoteClient) ExecuteCommand(ctx context.Context, in *protos.ExecuteCommandRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[protos.ExecuteCommandResponse], error) {
+	return hangingStream{ctx: ctx}, nil
+}
+
+type hangingStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s hangingStream) Recv() (*protos.ExecuteCommandResponse, error) {
+	<-s.ctx.Done()
+	return nil, status.FromContextError(s.ctx.Err()).Err()
+}
+
+func TestGRPCExecTimeout(t *testing.T) {
+	b := &grpcBuildlet{client: hangingGomoteClient{}, id: "gomote-1"}
+	remoteErr, execErr := b.Exec(context.Background(), "go/bin/go", ExecOpts{Timeout: 10 * time.Millisecond})
+	if execErr != nil {
+		t.Fatalf("Exec: execErr = %v, want nil", execErr)
+	}
+	var re *RemoteError
+	if !errors.As(remoteErr, &re) || !strings.Contains(remoteErr.Error(), "timed out after 10ms") {
+		t.Errorf("Exec: remoteErr = %v, want command timeout", remoteErr)
+	}
+
+	// The caller's own deadline isn't reported as the command timing out.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	remoteErr, execErr = b.Exec(ctx, "go/bin/go", ExecOpts{Timeout: time.Hour})
+	if remoteErr == nil && execErr == nil {
+		t.Errorf("Exec with expired context: no error")
+	} else if remoteErr != nil && strings.Contains(remoteErr.Error(), "timed out after") {
+		t.Errorf("Exec with expired context: remoteErr = %v, want caller's deadline", remoteErr)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildlet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reverse buildlet registration tokens are sent in the X-Go-Builder-Token
+// header, in addition to the builder key, when the coordinator's reverse
+// pool requires them. A token has the form "<expiry>:<signature>", where
+// expiry is a Unix time in seconds and signature is the hex HMAC-SHA256 of
+// "reverse <host type> <expiry>" with the builder master key.
+// cmd/genbuilderkey generates them.
+
+// RegistrationToken returns the registration token that lets reverse
+// buildlets of hostType register until expiry.
+func RegistrationToken(masterKey []byte, hostType string, expiry time.Time) string {
+	exp := strconv.FormatInt(expiry.Unix(), 10)
+	return exp + ":" + registrationSignature(masterKey, hostType, exp)
+}
+
+func registrationSignature(masterKey []byte, hostType, expiry string) string {
+	h := hmac.New(sha256.New, masterKey)
+	io.WriteString(h, "reverse "+hostType+" "+expiry)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CheckRegistrationToken returns an error if token doesn't let reverse
+// buildlets of hostType register at time now.
+func CheckRegistrationToken(masterKey []byte, hostType, token string, now time.Time) error {
+	if len(masterKey) == 0 {
+		return errors.New("no builder master key to check registration tokens with")
+	}
+	if token == "" {
+		return errors.New("missing registration token")
+	}
+	exp, sig, ok := strings.Cut(token, ":")
+	if !ok {
+		return errors.New("malformed registration token")
+	}
+	expiry, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return errors.New("malformed registration token")
+	}
+	if !hmac.Equal([]byte(sig), []byte(registrationSignature(masterKey, hostType, exp))) {
+		return errors.New("invalid registration token")
+	}
+	if t := time.Unix(expiry, 0); now.After(t) {
+		return fmt.Errorf("registration token expired at %v", t.UTC())
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildlet
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckRegistrationToken(t *testing.T) {
+	key := []byte("gophers rule")
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	const hostType = "host-linux-amd64-test"
+	valid := RegistrationToken(key, hostType, now.Add(time.Hour))
+	_, validSig, _ := strings.Cut(valid, ":")
+
+	testCases := []struct {
+		desc     string
+		key      []byte
+		hostType string
+		token    string
+		wantErr  bool
+	}{
+		{"valid", key, hostType, valid, false},
+		{"missing", key, hostType, "", true},
+		{"malformed", key, hostType, "garbage", true},
+		{"bad expiry", key, hostType, "soon:" + registrationSignature(key, hostType, "soon"), true},
+		{"other host type", key, "host-linux-arm64-test", valid, true},
+		{"other master key", []byte("gophers drool"), hostType, valid, true},
+		{"no master key", nil, hostType, valid, true},
+		{"expired", key, hostType, RegistrationToken(key, hostType, now.Add(-time.Second)), true},
+		{"extended expiry", key, hostType, "9999999999:" + validSig, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := CheckRegistrationToken(tc.key, tc.hostType, tc.token, now)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("CheckRegistrationToken = %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildlet
+
+import "sync"
+
+// A TailBuffer is an io.Writer that keeps only the most recent bytes
+// written to it, in a fixed amount of memory. It's safe for concurrent
+// use, so one goroutine may read the tail of a command's output while
+// ExecOpts.Tail is being written.
+type TailBuffer struct {
+	mu    sync.Mutex
+	buf   []byte // ring buffer, len(buf) == its capacity
+	start int    // index of the oldest byte in buf
+	n     int    // number of valid bytes in buf
+	total int64  // number of bytes written
+}
+
+// NewTailBuffer returns a TailBuffer that keeps the last size bytes
+// written to it.
+func NewTailBuffer(size int) *TailBuffer {
+	if size <= 0 {
+		panic("buildlet: non-positive TailBuffer size")
+	}
+	return &TailBuffer{buf: make([]byte, size)}
+}
+
+// Write implements io.Writer. It never returns an error.
+func (t *TailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := len(p)
+	t.total += int64(n)
+	if len(p) >= len(t.buf) {
+		// Only the end of p fits.
+		copy(t.buf, p[len(p)-len(t.buf):])
+		t.start, t.n = 0, len(t.buf)
+		return n, nil
+	}
+	end := (t.start + t.n) % len(t.buf)
+	c := copy(t.buf[end:], p)
+	copy(t.buf, p[c:])
+	if over := t.n + len(p) - len(t.buf); over > 0 {
+		t.start = (t.start + over) % len(t.buf)
+		t.n = len(t.buf)
+	} else {
+		t.n += len(p)
+	}
+	return n, nil
+}
+
+// Bytes returns a copy of the most recent bytes written, up to the
+// buffer's size.
+func (t *TailBuffer) Bytes() []byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]byte, t.n)
+	c := copy(out, t.buf[t.start:min(t.start+t.n, len(t.buf))])
+	copy(out[c:], t.buf)
+	return out
+}
+
+// Truncated reports whether bytes have been dropped from the front of
+// the output because more was written than fits in the buffer.
+func (t *TailBuffer) Truncated() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total > int64(t.n)
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildlet
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTailBuffer(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		writes    []string
+		want      string
+		truncated bool
+	}{
+		{"empty", 4, nil, "", false},
+		{"fits", 8, []string{"abc", "def"}, "abcdef", false},
+		{"exactly full", 6, []string{"abc", "def"}, "abcdef", false},
+		{"wraps", 4, []string{"abc", "def"}, "cdef", true},
+		{"wraps twice", 4, []string{"abc", "def", "ghi"}, "fghi", true},
+		{"large write", 4, []string{"ab", "cdefghij"}, "ghij", true},
+		{"many small writes", 3, []string{"a", "b", "c", "d", "e"}, "cde", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := NewTailBuffer(tt.size)
+			for _, w := range tt.writes {
+				if n, err := tb.Write([]byte(w)); n != len(w) || err != nil {
+					t.Fatalf("Write(%q) = %d, %v; want %d, nil", w, n, err, len(w))
+				}
+			}
+			if got := string(tb.Bytes()); got != tt.want {
+				t.Errorf("Bytes() = %q; want %q", got, tt.want)
+			}
+			if got := tb.Truncated(); got != tt.truncated {
+				t.Errorf("Truncated() = %v; want %v", got, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestExecTail(t *testing.T) {
+	var out bytes.Buffer
+	tail := NewTailBuffer(10)
+	fc := &FakeClient{}
+	if _, err := fc.Exec(context.Background(), "cmd", ExecOpts{Output: &out, Tail: tail}); err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if want := strings.Repeat("<this is a song that never ends>", 3); out.String() != want {
+		t.Errorf("Output = %q; want %q", out.String(), want)
+	}
+	if got, want := string(tail.Bytes()), "ever ends>"; got != want {
+		t.Errorf("Tail = %q; want %q", got, want)
+	}
+}
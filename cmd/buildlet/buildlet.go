@@ -89,7 +89,10 @@ var (
 //	27: export GOPLSCACHE=$workdir/goplscache
 //	28: add support for gomote server
 //	29: fall back to /bin/sh when SHELL is unset
-const buildletVersion = 29
+//	30: /tgz exclude parameter
+//	31: /mkdir handler
+//	32: /removeall/dryrun handler
+const buildletVersion = 32
 
 func defaultListenAddr() string {
 	if runtime.GOOS == "darwin" {
@@ -250,6 +253,8 @@ func main() {
 	http.Handle("/halt", requireAuth(handleHalt))
 	http.Handle("/tgz", requireAuth(handleGetTGZ))
 	http.Handle("/removeall", requireAuth(handleRemoveAll))
+	http.Handle("/removeall/dryrun", requireAuth(handleRemoveAllDryRun))
+	http.Handle("/mkdir", requireAuth(handleMkdir))
 	http.Handle("/workdir", requireAuth(handleWorkDir))
 	http.Handle("/status", requireAuth(handleStatus))
 	http.Handle("/ls", requireAuth(handleLs))
@@ -268,9 +273,20 @@ func main() {
 		if err != nil {
 			log.Fatalf("Error dialing server: %v", err)
 		}
-		srv := &http.Server{}
-		err = srv.Serve(ln)
-		log.Printf("http.Serve on reverse connection complete: %v", err)
+		for {
+			srv := &http.Server{}
+			err = srv.Serve(ln)
+			log.Printf("http.Serve on reverse connection complete: %v", err)
+			if !*swarmingBot {
+				break
+			}
+			// The gomote server may have restarted. Reconnect, so that
+			// it can restore the session using this buildlet.
+			if ln, err = redialGomoteServer(); err != nil {
+				log.Printf("Unable to reconnect to the gomote server: %v", err)
+				break
+			}
+		}
 		log.Printf("buildlet reverse mode exiting.")
 		if *haltEntireOS {
 			// The coordinator disconnects before doHalt has time to
@@ -570,6 +586,13 @@ func handleGetTGZ(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid 'dir' parameter: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	exclude := r.Form["exclude"] // '/'-separated path.Match patterns, relative to dir
+	for _, pattern := range exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			http.Error(w, fmt.Sprintf("invalid 'exclude' parameter %q: %v", pattern, err), http.StatusBadRequest)
+			return
+		}
+	}
 
 	zw := pargzip.NewWriter(w)
 	tw := tar.NewWriter(zw)
@@ -579,6 +602,12 @@ func handleGetTGZ(w http.ResponseWriter, r *http.Request) {
 			return err
 		}
 		rel := strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(path, base)), "/")
+		if tarExcluded(rel, exclude) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		var linkName string
 		if fi.Mode()&os.ModeSymlink != 0 {
 			linkName, err = os.Readlink(path)
@@ -620,6 +649,20 @@ func handleGetTGZ(w http.ResponseWriter, r *http.Request) {
 	zw.Close()
 }
 
+// tarExcluded reports whether the '/'-separated relative path rel
+// matches any of the exclude patterns, as defined by path.Match.
+func tarExcluded(rel string, exclude []string) bool {
+	if rel == "" {
+		return false
+	}
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func handleWriteTGZ(w http.ResponseWriter, r *http.Request) {
 	if !mkdirAllWorkdirOr500(w) {
 		return
@@ -1293,7 +1336,7 @@ func doReboot() {
 	os.Exit(0)
 }
 
-func handleRemoveAll(w http.ResponseWriter, r *http.Request) {
+func handleMkdir(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
 		http.Error(w, "requires POST method", http.StatusBadRequest)
 		return
@@ -1302,21 +1345,43 @@ func handleRemoveAll(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	paths := r.Form["path"]
-	if len(paths) == 0 {
-		http.Error(w, "requires 'path' parameter", http.StatusBadRequest)
+	rel, err := nativeRelPath(r.FormValue("path"))
+	if err != nil {
+		http.Error(w, "invalid 'path' parameter: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	for _, p := range paths {
-		if _, err := nativeRelPath(p); err != nil {
-			http.Error(w, "invalid 'path' parameter: "+err.Error(), http.StatusBadRequest)
-			return
-		}
+	modeInt, err := strconv.ParseInt(r.FormValue("mode"), 10, 64)
+	mode := os.FileMode(modeInt)
+	if err != nil || mode != mode.Perm() {
+		http.Error(w, "bad mode", http.StatusBadRequest)
+		return
 	}
-	for _, p := range paths {
+	if !mkdirAllWorkdirOr500(w) {
+		return
+	}
+	if err := os.MkdirAll(filepath.Join(*workDir, rel), mode); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func handleRemoveAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "requires POST method", http.StatusBadRequest)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	paths, fullPaths, err := removeAllTargets(r.Form["path"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for i, p := range paths {
 		log.Printf("Removing %s", p)
-		fullDir := filepath.Join(*workDir, filepath.FromSlash(p))
-		err := removeAllIncludingReadonly(fullDir)
+		err := removeAllIncludingReadonly(fullPaths[i])
 		if p == "." && err != nil {
 			// If workDir is a mountpoint and/or contains a binary
 			// using it, we can get a "Device or resource busy" error.
@@ -1340,6 +1405,57 @@ func handleRemoveAll(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleRemoveAllDryRun reports what /removeall would delete for the
+// same 'path' parameters, without deleting anything.
+func handleRemoveAllDryRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "requires POST method", http.StatusBadRequest)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	paths, fullPaths, err := removeAllTargets(r.Form["path"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	infos := make([]buildlet.RemoveAllInfo, len(paths))
+	for i, p := range paths {
+		infos[i].Path = p
+		err := walkRemovable(fullPaths[i], false, func(_ string, fi os.FileInfo) {
+			infos[i].Exists = true
+			infos[i].Files++
+			if fi.Mode().IsRegular() {
+				infos[i].Size += fi.Size()
+			}
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(infos)
+}
+
+// removeAllTargets validates the paths of a /removeall or
+// /removeall/dryrun request, which are relative to the work directory,
+// and returns them along with the full paths they refer to.
+func removeAllTargets(paths []string) (_, fullPaths []string, err error) {
+	if len(paths) == 0 {
+		return nil, nil, errors.New("requires 'path' parameter")
+	}
+	for _, p := range paths {
+		if _, err := nativeRelPath(p); err != nil {
+			return nil, nil, errors.New("invalid 'path' parameter: " + err.Error())
+		}
+		fullPaths = append(fullPaths, filepath.Join(*workDir, filepath.FromSlash(p)))
+	}
+	return paths, fullPaths, nil
+}
+
 // mkdirAllWorkdirOr500 reports whether *workDir either exists or was created.
 // If it returns false, it also writes an HTTP 500 error to w.
 // This is used by callers to verify *workDir exists, even if it might've been
@@ -1932,17 +2048,33 @@ func removeAllIncludingReadonly(dir string) error {
 	// Make a best effort (ignoring errors) attempt to make all
 	// files and directories writable before we try to delete them
 	// all again.
-	filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+	walkRemovable(dir, true, func(path string, fi os.FileInfo) {
 		const ownerWritable = 0200
-		if err != nil || fi.Mode().Perm()&ownerWritable != 0 {
-			return nil
+		if fi.Mode().Perm()&ownerWritable == 0 {
+			os.Chmod(path, fi.Mode().Perm()|ownerWritable)
 		}
-		os.Chmod(path, fi.Mode().Perm()|ownerWritable)
-		return nil
 	})
 	return os.RemoveAll(dir)
 }
 
+// walkRemovable calls fn for each file and directory in the tree rooted
+// at dir, which removeAllIncludingReadonly deletes and /removeall/dryrun
+// reports on. A dir that doesn't exist has nothing to walk.
+// If ignoreErrors is set, parts of the tree that can't be read are
+// skipped; otherwise the first such error is returned.
+func walkRemovable(dir string, ignoreErrors bool, fn func(path string, fi os.FileInfo)) error {
+	return filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if ignoreErrors || os.IsNotExist(err) && path == dir {
+				return nil
+			}
+			return err
+		}
+		fn(path, fi)
+		return nil
+	})
+}
+
 var (
 	androidEmuDead = make(chan error) // closed on death
 	androidEmuErr  error              // set prior to channel close
@@ -5,9 +5,18 @@
 package main
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
 	"runtime"
+	"strings"
 	"testing"
+
+	"golang.org/x/build/buildlet"
 )
 
 func TestPathEnv(t *testing.T) {
@@ -110,3 +119,86 @@ func TestPathListSeparator(t *testing.T) {
 		t.Errorf("pathListSeparator(%q) = %q; want %q", runtime.GOOS, sep, want)
 	}
 }
+
+func TestTarExcluded(t *testing.T) {
+	exclude := []string{"doc/gopher", "pkg/bootstrap", "pkg/obj", "*.tmp"}
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{"", false},
+		{"doc", false},
+		{"doc/gopher", true},
+		{"doc/go_spec.html", false},
+		{"pkg/bootstrap", true},
+		{"pkg/obj", true},
+		{"pkg/tool", false},
+		{"foo.tmp", true},
+		{"src/foo.tmp", false},
+	}
+	for _, tt := range tests {
+		if got := tarExcluded(tt.rel, exclude); got != tt.want {
+			t.Errorf("tarExcluded(%q) = %v; want %v", tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestRemoveAllDryRun(t *testing.T) {
+	defer func(old string) { *workDir = old }(*workDir)
+	*workDir = t.TempDir()
+	for name, data := range map[string]string{
+		"go/a.txt":          "hello",
+		"go/pkg/obj/b.o":    "0123456789",
+		"go/pkg/obj/c/d.go": "package d",
+	} {
+		path := filepath.Join(*workDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	request := func(handler http.HandlerFunc, paths ...string) *httptest.ResponseRecorder {
+		form := url.Values{"path": paths}
+		req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	rec := request(handleRemoveAllDryRun, "go/pkg/obj", "go/missing")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("dry run status = %d; want 200; body: %s", rec.Code, rec.Body)
+	}
+	var got []buildlet.RemoveAllInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []buildlet.RemoveAllInfo{
+		{Path: "go/pkg/obj", Exists: true, Files: 4, Size: 19},
+		{Path: "go/missing"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dry run = %+v; want %+v", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(*workDir, "go", "pkg", "obj", "b.o")); err != nil {
+		t.Errorf("dry run deleted files: %v", err)
+	}
+
+	if rec := request(handleRemoveAllDryRun, "../outside"); rec.Code != http.StatusBadRequest {
+		t.Errorf("dry run with invalid path status = %d; want 400", rec.Code)
+	}
+
+	// RemoveAll deletes the same paths.
+	if rec := request(handleRemoveAll, "go/pkg/obj", "go/missing"); rec.Code != http.StatusOK {
+		t.Fatalf("removeall status = %d; want 200; body: %s", rec.Code, rec.Body)
+	}
+	if _, err := os.Stat(filepath.Join(*workDir, "go", "pkg", "obj")); !os.IsNotExist(err) {
+		t.Errorf("go/pkg/obj not deleted: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(*workDir, "go", "a.txt")); err != nil {
+		t.Errorf("go/a.txt deleted: %v", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"net"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	"time"
 
 	"go.chromium.org/luci/auth"
+	"golang.org/x/build/buildlet"
 	"golang.org/x/build/internal/rendezvous"
 	"golang.org/x/build/revdial/v2"
 )
@@ -50,6 +52,26 @@ func keyForMode(mode string) (string, error) {
 	return strings.TrimSpace(string(key)), nil
 }
 
+// tokenForMode returns the registration token to present to the
+// coordinator for mode, if any. Coordinators only require one if run
+// with -reverse_require_token, so a missing token file isn't an error.
+func tokenForMode(mode string) (string, error) {
+	if isDevReverseMode() {
+		return devRegistrationToken(mode), nil
+	}
+	tokenPath := filepath.Join(homedir(), ".gobuildtoken-"+mode)
+	if v := os.Getenv("GO_BUILD_TOKEN_PATH"); v != "" {
+		tokenPath = v
+	}
+	token, err := os.ReadFile(tokenPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("cannot read token file %q: %v", tokenPath, err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
 func isDevReverseMode() bool {
 	return !strings.HasPrefix(*coordinator, "farmer.golang.org")
 }
@@ -74,6 +96,10 @@ func dialCoordinator() (net.Listener, error) {
 	if err != nil {
 		log.Fatalf("failed to find key for %s: %v", *reverseType, err)
 	}
+	token, err := tokenForMode(*reverseType)
+	if err != nil {
+		log.Fatalf("failed to find registration token for %s: %v", *reverseType, err)
+	}
 
 	addr := *coordinator
 	if addr == "farmer.golang.org" {
@@ -123,6 +149,9 @@ func dialCoordinator() (net.Listener, error) {
 		}
 		req.Header.Set("X-Go-Host-Type", *reverseType)
 		req.Header.Set("X-Go-Builder-Key", key)
+		if token != "" {
+			req.Header.Set("X-Go-Builder-Token", token)
+		}
 		req.Header.Set("X-Go-Builder-Hostname", *hostname)
 		req.Header.Set("X-Go-Builder-Version", strconv.Itoa(buildletVersion))
 		req.Header.Set("X-Revdial-Version", "2")
@@ -222,7 +251,7 @@ func dialGomoteServer() (net.Listener, error) {
 		}
 		location, err = revdial.ReadProtoSwitchOrRedirect(bufr, req)
 		if err != nil {
-			return nil, fmt.Errorf("gomote server registration failed: %v", err)
+			return nil, fmt.Errorf("%w: %v", errGomoteRegistration, err)
 		}
 		if location == "" {
 			success = true
@@ -238,6 +267,31 @@ func dialGomoteServer() (net.Listener, error) {
 	return ln, nil
 }
 
+// errGomoteRegistration is returned by dialGomoteServer when the gomote
+// server was reached but didn't accept the buildlet.
+var errGomoteRegistration = errors.New("gomote server registration failed")
+
+// gomoteRedialTimeout is how long a buildlet on a swarming bot keeps
+// trying to reconnect after losing its connection to the gomote server,
+// so that its gomote session survives a gomote server restart.
+const gomoteRedialTimeout = 5 * time.Minute
+
+// redialGomoteServer reconnects to the gomote server after the reverse
+// connection to it was lost. It gives up once the gomote server refuses
+// the buildlet, as it does after the buildlet's session has ended, or
+// after gomoteRedialTimeout.
+func redialGomoteServer() (net.Listener, error) {
+	deadline := time.Now().Add(gomoteRedialTimeout)
+	for {
+		ln, err := dialGomoteServer()
+		if err == nil || errors.Is(err, errGomoteRegistration) || time.Now().After(deadline) {
+			return ln, err
+		}
+		log.Printf("buildlet: unable to reconnect to the gomote server; retrying: %v", err)
+		time.Sleep(10 * time.Second)
+	}
+}
+
 var coordDialer = &net.Dialer{
 	Timeout:   10 * time.Second,
 	KeepAlive: 15 * time.Second,
@@ -303,6 +357,12 @@ func devBuilderKey(builder string) string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
+// devRegistrationToken returns a registration token for builder that's
+// valid for a day, signed with devMasterKey.
+func devRegistrationToken(builder string) string {
+	return buildlet.RegistrationToken([]byte(devMasterKey), builder, time.Now().Add(24*time.Hour))
+}
+
 func homedir() string {
 	switch runtime.GOOS {
 	case "windows":
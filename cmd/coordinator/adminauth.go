@@ -0,0 +1,61 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"golang.org/x/build/internal/access"
+)
+
+// An operatorList is the set of people allowed to use the /admin pages,
+// as parsed from the -admin_operators flag. Entries are email addresses,
+// or domains starting with "@" that allow every address in them.
+type operatorList map[string]bool
+
+func parseOperatorList(s string) operatorList {
+	ops := make(operatorList)
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.ToLower(strings.TrimSpace(e)); e != "" {
+			ops[e] = true
+		}
+	}
+	return ops
+}
+
+// allows reports whether the person with the given email address is an operator.
+func (ops operatorList) allows(email string) bool {
+	email = strings.ToLower(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return false
+	}
+	return ops[email] || ops[email[at:]]
+}
+
+// requireOperator returns a handler that serves h only to operators
+// in ops, as identified by the email address that
+// access.RequireIAPAuthHandler put in the request context.
+func requireOperator(h http.HandlerFunc, ops operatorList) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		email, _ := r.Context().Value("email").(string)
+		if !ops.allows(email) {
+			log.Printf("admin: denied %s %s to %q, not in -admin_operators", r.Method, r.URL.Path, email)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		h(w, r)
+	})
+}
+
+// iapAdminHandler returns a handler that serves h to the operators in ops
+// that authenticated via IAP for audience.
+func iapAdminHandler(h http.HandlerFunc, audience string, ops operatorList) http.Handler {
+	return access.RequireIAPAuthHandler(requireOperator(h, ops), audience)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireOperator(t *testing.T) {
+	ops := parseOperatorList(" gopher@golang.org, @Example.com ,")
+	h := requireOperator(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}, ops)
+	for _, tt := range []struct {
+		email any
+		want  int
+	}{
+		{"gopher@golang.org", http.StatusOK},
+		{"Gopher@golang.org", http.StatusOK},
+		{"someone@example.com", http.StatusOK},
+		{"other@golang.org", http.StatusForbidden},
+		{"someone@example.com.evil", http.StatusForbidden},
+		{"@example.com", http.StatusForbidden},
+		{"", http.StatusForbidden},
+		{nil, http.StatusForbidden},
+	} {
+		req := httptest.NewRequest("GET", "/admin/pause", nil)
+		if tt.email != nil {
+			req = req.WithContext(context.WithValue(req.Context(), "email", tt.email))
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("email %v: got status %d, want %d", tt.email, w.Code, tt.want)
+		}
+	}
+
+	// No operators means no access.
+	h = requireOperator(func(w http.ResponseWriter, r *http.Request) {}, parseOperatorList(""))
+	req := httptest.NewRequest("GET", "/admin/pause", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "email", "gopher@golang.org"))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("with no operators: got status %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
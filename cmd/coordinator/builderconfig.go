@@ -0,0 +1,235 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/coordinator/pool"
+)
+
+var builderConfigURL = flag.String("builder_config_url", "", "Optional gs://bucket/object or local file of JSON overrides of the builder configuration, which can disable builders, add environment variables to them, and add variants of them. It's loaded at startup and whenever /admin/reload-builders is posted to, so builders can be changed without a restart.")
+
+// reloadBuildersMu serializes reloadBuilderConfig.
+var reloadBuildersMu sync.Mutex
+
+// builderOverrides is the JSON format of -builder_config_url.
+// Unknown fields are an error, since they're most likely typos.
+type builderOverrides struct {
+	// Add defines new builders, keyed by name, as variants of
+	// existing ones.
+	Add map[string]builderVariant
+	// Env adds environment ("key=value") pairs to builders, keyed by
+	// builder name.
+	Env map[string][]string
+	// Disabled lists builders to stop building with.
+	Disabled []string
+}
+
+// builderVariant is a builder defined by builderOverrides.Add.
+type builderVariant struct {
+	Base     string   // the existing builder to copy
+	HostType string   // if non-empty, replaces the host type of Base
+	Env      []string // extra environment ("key=value") pairs
+}
+
+// handleReloadBuildersAdmin reloads the builder configuration from
+// -builder_config_url when posted to, and reports what changed.
+//
+// It must only be served to operators.
+func handleReloadBuildersAdmin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if *builderConfigURL == "" {
+		http.Error(w, "no -builder_config_url to reload", http.StatusBadRequest)
+		return
+	}
+	log.Printf("builder config admin: reloading %s (by %v)", *builderConfigURL, r.Context().Value("email"))
+	changes, err := reloadBuilderConfig()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "no changes")
+		return
+	}
+	for _, c := range changes {
+		fmt.Fprintln(w, c)
+	}
+}
+
+// reloadBuilderConfig reads the overrides from -builder_config_url and
+// makes dashboard.Builders with them applied the active builder
+// configuration, as returned by dashboard.ActiveBuilders to the
+// coordinator, trybots, and gomote alike. It logs and returns the changes to the previously
+// active configuration. On error, the previous configuration is kept.
+func reloadBuilderConfig() (changes []string, err error) {
+	reloadBuildersMu.Lock()
+	defer reloadBuildersMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	data, err := readBuilderConfig(ctx, *builderConfigURL)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", *builderConfigURL, err)
+	}
+	o, err := parseBuilderOverrides(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", *builderConfigURL, err)
+	}
+	m, err := applyBuilderOverrides(dashboard.Builders, o)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", *builderConfigURL, err)
+	}
+	old := dashboard.ActiveBuilders()
+	dashboard.SetActiveBuilders(m)
+
+	changes = diffBuilders(old, m)
+	for _, c := range changes {
+		log.Printf("builder config: %s", c)
+	}
+	return changes, nil
+}
+
+// readBuilderConfig reads the contents of the gs://bucket/object URL or
+// local file src.
+func readBuilderConfig(ctx context.Context, src string) ([]byte, error) {
+	if !strings.HasPrefix(src, "gs://") {
+		return os.ReadFile(src)
+	}
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(src, "gs://"), "/")
+	if !ok || bucket == "" || object == "" {
+		return nil, errors.New("want gs://bucket/object")
+	}
+	sc := pool.NewGCEConfiguration().StorageClient()
+	if sc == nil {
+		return nil, errors.New("GCE configuration missing storage client")
+	}
+	r, err := sc.Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(io.LimitReader(r, 1<<20))
+}
+
+// parseBuilderOverrides parses the JSON builder overrides in data.
+func parseBuilderOverrides(data []byte) (*builderOverrides, error) {
+	o := new(builderOverrides)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// applyBuilderOverrides returns a copy of base with the overrides o
+// applied: first the new builders are added, then environment is added
+// to builders, and then builders are disabled. base isn't modified.
+func applyBuilderOverrides(base map[string]*dashboard.BuildConfig, o *builderOverrides) (map[string]*dashboard.BuildConfig, error) {
+	m := maps.Clone(base)
+	for _, name := range sortedKeys(o.Add) {
+		v := o.Add[name]
+		if _, ok := m[name]; ok {
+			return nil, fmt.Errorf("can't add builder %q: it already exists", name)
+		}
+		bc, ok := base[v.Base]
+		if !ok {
+			return nil, fmt.Errorf("can't add builder %q: unknown base builder %q", name, v.Base)
+		}
+		if err := checkEnv(v.Env); err != nil {
+			return nil, fmt.Errorf("can't add builder %q: %v", name, err)
+		}
+		bc = bc.WithEnv(v.Env...)
+		bc.Name = name
+		if v.HostType != "" {
+			if _, ok := dashboard.Hosts[v.HostType]; !ok {
+				return nil, fmt.Errorf("can't add builder %q: unknown host type %q", name, v.HostType)
+			}
+			bc.HostType = v.HostType
+		}
+		m[name] = bc
+	}
+	for _, name := range sortedKeys(o.Env) {
+		bc, ok := m[name]
+		if !ok {
+			return nil, fmt.Errorf("can't add environment to unknown builder %q", name)
+		}
+		if err := checkEnv(o.Env[name]); err != nil {
+			return nil, fmt.Errorf("can't add environment to builder %q: %v", name, err)
+		}
+		m[name] = bc.WithEnv(o.Env[name]...)
+	}
+	for _, name := range o.Disabled {
+		if _, ok := m[name]; !ok {
+			return nil, fmt.Errorf("can't disable unknown builder %q", name)
+		}
+		if name == "linux-amd64" {
+			// Trybots for x repos and TRY= always use it.
+			return nil, fmt.Errorf("can't disable builder %q", name)
+		}
+		delete(m, name)
+	}
+	return m, nil
+}
+
+// checkEnv returns an error if env isn't a list of "key=value" pairs.
+func checkEnv(env []string) error {
+	for _, kv := range env {
+		if k, _, ok := strings.Cut(kv, "="); !ok || k == "" {
+			return fmt.Errorf("invalid environment variable %q; want key=value", kv)
+		}
+	}
+	return nil
+}
+
+// diffBuilders returns the differences between the builder
+// configurations old and new, sorted by builder name.
+func diffBuilders(old, new map[string]*dashboard.BuildConfig) []string {
+	var changes []string
+	names := sortedKeys(old)
+	for name := range new {
+		if _, ok := old[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		o, n := old[name], new[name]
+		switch {
+		case n == nil:
+			changes = append(changes, fmt.Sprintf("removed %s", name))
+		case o == nil:
+			changes = append(changes, fmt.Sprintf("added %s (host type %s, env %q)", name, n.HostType, n.Env()))
+		case o.HostType != n.HostType:
+			changes = append(changes, fmt.Sprintf("changed host type of %s from %s to %s", name, o.HostType, n.HostType))
+		case !slices.Equal(o.Env(), n.Env()):
+			changes = append(changes, fmt.Sprintf("changed env of %s from %q to %q", name, o.Env(), n.Env()))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/dashboard"
+)
+
+func TestApplyBuilderOverrides(t *testing.T) {
+	linux := dashboard.Builders["linux-amd64"]
+	other := dashboard.Builders["linux-amd64-race"]
+	if linux == nil || other == nil {
+		t.Skip("missing linux-amd64 or linux-amd64-race builders")
+	}
+	base := map[string]*dashboard.BuildConfig{
+		"linux-amd64":      linux,
+		"linux-amd64-race": other,
+	}
+
+	for _, tc := range []struct {
+		name    string
+		in      string
+		want    []string // builder names in the result
+		wantErr bool
+	}{
+		{name: "empty", in: `{}`, want: []string{"linux-amd64", "linux-amd64-race"}},
+		{name: "disable", in: `{"Disabled": ["linux-amd64-race"]}`, want: []string{"linux-amd64"}},
+		{name: "env", in: `{"Env": {"linux-amd64-race": ["GODEBUG=x=1"]}}`, want: []string{"linux-amd64", "linux-amd64-race"}},
+		{name: "add", in: `{"Add": {"linux-amd64-new": {"Base": "linux-amd64", "Env": ["GOAMD64=v3"]}}}`, want: []string{"linux-amd64", "linux-amd64-new", "linux-amd64-race"}},
+		{name: "add-and-disable-base", in: `{"Add": {"linux-amd64-new": {"Base": "linux-amd64-race"}}, "Disabled": ["linux-amd64-race"]}`, want: []string{"linux-amd64", "linux-amd64-new"}},
+		{name: "disable-unknown", in: `{"Disabled": ["nosuchbuilder"]}`, wantErr: true},
+		{name: "disable-linux-amd64", in: `{"Disabled": ["linux-amd64"]}`, wantErr: true},
+		{name: "env-unknown", in: `{"Env": {"nosuchbuilder": ["A=1"]}}`, wantErr: true},
+		{name: "env-invalid", in: `{"Env": {"linux-amd64": ["A"]}}`, wantErr: true},
+		{name: "add-existing", in: `{"Add": {"linux-amd64-race": {"Base": "linux-amd64"}}}`, wantErr: true},
+		{name: "add-unknown-base", in: `{"Add": {"linux-amd64-new": {"Base": "nosuchbuilder"}}}`, wantErr: true},
+		{name: "add-unknown-host", in: `{"Add": {"linux-amd64-new": {"Base": "linux-amd64", "HostType": "host-nosuchhost"}}}`, wantErr: true},
+		{name: "unknown-field", in: `{"Disable": ["linux-amd64-race"]}`, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := parseBuilderOverrides([]byte(tc.in))
+			var got map[string]*dashboard.BuildConfig
+			if err == nil {
+				got, err = applyBuilderOverrides(base, o)
+			}
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v; want error: %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, sortedKeys(got)); diff != "" {
+				t.Errorf("builders mismatch (-want +got):\n%s", diff)
+			}
+			if len(base) != 2 || base["linux-amd64"] != linux || base["linux-amd64-race"] != other {
+				t.Errorf("base was modified")
+			}
+		})
+	}
+}
+
+func TestApplyBuilderOverridesVariant(t *testing.T) {
+	linux := dashboard.Builders["linux-amd64"]
+	if linux == nil {
+		t.Skip("missing linux-amd64 builder")
+	}
+	var hostType string
+	for name := range dashboard.Hosts {
+		if name != linux.HostType {
+			hostType = name
+			break
+		}
+	}
+	base := map[string]*dashboard.BuildConfig{"linux-amd64": linux}
+	o := &builderOverrides{
+		Add: map[string]builderVariant{
+			"linux-amd64-new": {Base: "linux-amd64", HostType: hostType, Env: []string{"GOAMD64=v3"}},
+		},
+		Env: map[string][]string{"linux-amd64-new": {"GODEBUG=x=1"}},
+	}
+	m, err := applyBuilderOverrides(base, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc := m["linux-amd64-new"]
+	if bc.Name != "linux-amd64-new" || bc.HostType != hostType {
+		t.Errorf("new builder is %s on %s; want linux-amd64-new on %s", bc.Name, bc.HostType, hostType)
+	}
+	env := bc.Env()
+	if !slices.Contains(env, "GO_BUILDER_NAME=linux-amd64-new") || !slices.Contains(env, "GOAMD64=v3") || !slices.Contains(env, "GODEBUG=x=1") {
+		t.Errorf("new builder env = %q; want GO_BUILDER_NAME, GOAMD64 and GODEBUG", env)
+	}
+	if slices.Contains(linux.Env(), "GOAMD64=v3") || linux.Name != "linux-amd64" {
+		t.Errorf("base builder was modified")
+	}
+}
+
+func TestDiffBuilders(t *testing.T) {
+	linux := dashboard.Builders["linux-amd64"]
+	race := dashboard.Builders["linux-amd64-race"]
+	if linux == nil || race == nil {
+		t.Skip("missing linux-amd64 or linux-amd64-race builders")
+	}
+	old := map[string]*dashboard.BuildConfig{
+		"linux-amd64":      linux,
+		"linux-amd64-race": race,
+	}
+	new := map[string]*dashboard.BuildConfig{
+		"linux-amd64":     linux.WithEnv("GODEBUG=x=1"),
+		"linux-amd64-new": linux.WithEnv(),
+	}
+	got := diffBuilders(old, new)
+	if len(got) != 3 {
+		t.Fatalf("diffBuilders = %q; want 3 changes", got)
+	}
+	for i, prefix := range []string{"changed env of linux-amd64 ", "added linux-amd64-new ", "removed linux-amd64-race"} {
+		if !strings.HasPrefix(got[i], prefix) {
+			t.Errorf("change %d = %q; want prefix %q", i, got[i], prefix)
+		}
+	}
+	if got := diffBuilders(old, old); len(got) != 0 {
+		t.Errorf("diffBuilders of the same builders = %q; want none", got)
+	}
+}
+
+func TestReloadBuilderConfig(t *testing.T) {
+	if dashboard.Builders["linux-amd64-race"] == nil {
+		t.Skip("missing linux-amd64-race builder")
+	}
+	defer func(url string) {
+		*builderConfigURL = url
+		dashboard.SetActiveBuilders(nil)
+	}(*builderConfigURL)
+
+	file := filepath.Join(t.TempDir(), "builders.json")
+	*builderConfigURL = file
+	if err := os.WriteFile(file, []byte(`{"Disabled": ["linux-amd64-race"]}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := reloadBuilderConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"removed linux-amd64-race"}; !slices.Equal(changes, want) {
+		t.Errorf("changes = %q; want %q", changes, want)
+	}
+	if _, ok := dashboard.ActiveBuilders()["linux-amd64-race"]; ok {
+		t.Errorf("linux-amd64-race is still configured after disabling it")
+	}
+	// Trybots use the same configuration.
+	for _, bc := range dashboard.TryBuildersForProject("go", "master", "master") {
+		if bc.Name == "linux-amd64-race" {
+			t.Errorf("linux-amd64-race still runs as a trybot after disabling it")
+		}
+	}
+
+	// An invalid config keeps the previous one.
+	if err := os.WriteFile(file, []byte(`{"Disabled": ["nosuchbuilder"]}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloadBuilderConfig(); err == nil {
+		t.Errorf("reloading an invalid config succeeded")
+	}
+	if _, ok := dashboard.ActiveBuilders()["linux-amd64-race"]; ok {
+		t.Errorf("linux-amd64-race is configured again after a failed reload")
+	}
+
+	if err := os.WriteFile(file, []byte(`{}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	changes, err = reloadBuilderConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || !strings.HasPrefix(changes[0], "added linux-amd64-race ") {
+		t.Errorf("changes = %q; want linux-amd64-race added", changes)
+	}
+	if _, ok := dashboard.ActiveBuilders()["linux-amd64-race"]; !ok {
+		t.Errorf("linux-amd64-race isn't configured after re-enabling it")
+	}
+}
@@ -23,7 +23,7 @@ func handleBuilders(w http.ResponseWriter, r *http.Request) {
 	data := struct {
 		Builders map[string]*dashboard.BuildConfig
 		Hosts    map[string]*dashboard.HostConfig
-	}{dashboard.Builders, dashboard.Hosts}
+	}{dashboard.ActiveBuilders(), dashboard.Hosts}
 	if r.FormValue("mode") == "json" {
 		j, err := json.MarshalIndent(data, "", "\t")
 		if err != nil {
@@ -50,7 +50,7 @@ func newBuild(rev buildgo.BuilderRev, detail commitDetail) (*buildStatus, error)
 	// Note: can't acquire statusMu in newBuild, as this is called
 	// from findTryWork -> newTrySet, which holds statusMu.
 
-	conf, ok := dashboard.Builders[rev.Name]
+	conf, ok := dashboard.ActiveBuilders()[rev.Name]
 	if !ok {
 		return nil, fmt.Errorf("unknown builder type %q", rev.Name)
 	}
@@ -85,6 +85,7 @@ type buildStatus struct {
 	conf      *dashboard.BuildConfig
 	startTime time.Time // actually time of newBuild (~same thing)
 	trySet    *trySet   // or nil
+	restarts  int       // number of earlier attempts at this build that died before completion
 
 	onceInitHelpers sync.Once // guards call of onceInitHelpersFunc
 	helpers         <-chan buildlet.Client
@@ -93,6 +94,8 @@ type buildStatus struct {
 
 	hasBuildlet int32 // atomic: non-zero if this build has a buildlet; for status.go.
 
+	onceLogModuleProxy sync.Once // guards logging the GOPROXY used by modulesEnv
+
 	mu              sync.Mutex       // guards following
 	canceled        bool             // whether this build was forcefully canceled, so errors should be ignored
 	schedItem       *queue.SchedItem // for the initial buildlet (ignoring helpers for now)
@@ -103,6 +106,8 @@ type buildStatus struct {
 	output          livelog.Buffer   // stdout and stderr
 	events          []eventAndTime
 	useSnapshotMemo map[string]bool // memoized result of useSnapshotFor(rev), where the key is rev
+	execs           []execStep      // commands run on buildlets, for handleRepro
+	machineTime     time.Duration   // total time buildlets were held, including test helpers
 }
 
 func (st *buildStatus) NameAndBranch() string {
@@ -383,6 +388,7 @@ func (st *buildStatus) getBuildlet() (buildlet.Client, error) {
 		return nil, err
 	}
 	atomic.StoreInt32(&st.hasBuildlet, 1)
+	bc = st.recordExecs(st.timeBuildlet(bc))
 
 	st.mu.Lock()
 	st.bc = bc
@@ -404,6 +410,7 @@ func (st *buildStatus) build() error {
 			}
 			if !has {
 				st.LogEventTime(eventSkipBuildMissingDep)
+				noteDepSkip(st.BuilderRev, dep, time.Now())
 				fmt.Fprintf(st, "skipping build; commit %s lacks ancestor %s\n", st.Rev, dep)
 				return errSkipBuildDueToDeps
 			}
@@ -445,6 +452,11 @@ func (st *buildStatus) build() error {
 	remoteErr, err := st.runAllSharded()
 	makeTest.Done(err)
 
+	if remoteErr != nil {
+		// Pull any core dumps off the buildlet before it's destroyed.
+		st.saveCrashArtifacts(bc)
+	}
+
 	// bc (aka st.bc) may be invalid past this point, so let's
 	// close it to make sure we don't accidentally use it.
 	bc.Close()
@@ -498,6 +510,9 @@ func (st *buildStatus) build() error {
 			}
 			return fmt.Errorf("Build succeeded but failed to report it to the dashboard: %v", err)
 		}
+		if remoteErr == nil {
+			noteLastGreen(st.BuilderRev, st.commitDetail, time.Now())
+		}
 	}
 	if remoteErr != nil {
 		return remoteErr
@@ -537,6 +552,25 @@ func (st *buildStatus) isSlowBot() bool {
 	return false
 }
 
+// containerHost reports where st's container runs, derived from the
+// pool that serves its host type: "cos" for Container-Optimized OS on
+// GCE, "ec2" for EC2, "reverse" for reverse buildlets, or "" if st is
+// not a container build or its pool is unknown.
+func (st *buildStatus) containerHost() string {
+	if st.conf == nil || !st.conf.IsContainer() {
+		return ""
+	}
+	switch pool.ForHost(st.conf.HostConfig()) {
+	case pool.NewGCEConfiguration().BuildletPool():
+		return "cos"
+	case pool.EC2BuildetPool():
+		return "ec2"
+	case pool.ReversePool():
+		return "reverse"
+	}
+	return ""
+}
+
 func (st *buildStatus) buildRecord() *types.BuildRecord {
 	rec := &types.BuildRecord{
 		ID:        st.buildID,
@@ -550,14 +584,18 @@ func (st *buildStatus) buildRecord() *types.BuildRecord {
 		Builder:   st.Name,
 		OS:        st.conf.GOOS(),
 		Arch:      st.conf.GOARCH(),
+		Restarts:  st.restarts,
 	}
 
-	// Log whether we used COS, so we can do queries to analyze
-	// Kubernetes vs COS performance for containers.
-	if st.conf.IsContainer() && pool.ForHost(st.conf.HostConfig()) == pool.NewGCEConfiguration().BuildletPool() {
-		rec.ContainerHost = "cos"
+	rec.Branch, rec.CommitTime = st.commitDetail.RevBranch, st.commitDetail.RevCommitTime
+	if st.IsSubrepo() {
+		rec.Branch, rec.CommitTime = st.commitDetail.SubRevBranch, st.commitDetail.SubRevCommitTime
 	}
 
+	// Log where containers ran, so we can do queries to compare
+	// performance across container hosts.
+	rec.ContainerHost = st.containerHost()
+
 	st.mu.Lock()
 	defer st.mu.Unlock()
 	// TODO: buildlet instance name
@@ -702,9 +740,14 @@ func (st *buildStatus) doSnapshot(bc buildlet.Client) error {
 		// Build environment isn't configured to do snapshots.
 		return nil
 	}
-	if err := st.cleanForSnapshot(bc); err != nil {
-		return fmt.Errorf("cleanForSnapshot: %v", err)
+	if status, err := bc.Status(st.ctx); err != nil || status.Version < tarExcludeBuildletVersion {
+		// The buildlet would ignore snapshotExclude, so remove the
+		// files instead.
+		if err := st.cleanForSnapshot(bc); err != nil {
+			return fmt.Errorf("cleanForSnapshot: %v", err)
+		}
 	}
+	st.logSnapshotSize(bc)
 	if err := st.writeSnapshot(bc); err != nil {
 		return fmt.Errorf("writeSnapshot: %v", err)
 	}
@@ -759,12 +802,55 @@ func (st *buildStatus) writeBootstrapToolchain() error {
 	return sp.Done(st.bc.PutTarFromURL(st.ctx, u, bootstrapDir))
 }
 
+// snapshotExclude are the path.Match patterns, relative to the "go"
+// directory, of files left out of snapshots. They're plain paths, so
+// that cleanForSnapshot can remove them on older buildlets.
+var snapshotExclude = []string{
+	"doc/gopher",
+	"pkg/bootstrap",
+}
+
+// tarExcludeBuildletVersion is the first buildlet version that supports
+// GetTarStreamOpts.Exclude. Older buildlets ignore it.
+const tarExcludeBuildletVersion = 30
+
+// cleanForSnapshot removes the files in snapshotExclude from bc,
+// for buildlets that don't support excluding them from the snapshot.
 func (st *buildStatus) cleanForSnapshot(bc buildlet.Client) error {
 	sp := st.CreateSpan("clean_for_snapshot")
-	return sp.Done(bc.RemoveAll(st.ctx,
-		"go/doc/gopher",
-		"go/pkg/bootstrap",
-	))
+	var paths []string
+	for _, p := range snapshotExclude {
+		paths = append(paths, "go/"+p)
+	}
+	return sp.Done(bc.RemoveAll(st.ctx, paths...))
+}
+
+// logSnapshotSize logs the size of the tree a snapshot is written from,
+// and of the excluded parts of it, to help debug unexpectedly large
+// snapshots. The excluded parts are assumed to be plain paths, not
+// patterns. It's only informational, so it has its own short deadline
+// rather than taking from the snapshot's.
+func (st *buildStatus) logSnapshotSize(bc buildlet.Client) {
+	ctx, cancel := context.WithTimeout(st.ctx, 30*time.Second)
+	defer cancel()
+	paths := []string{"go"}
+	for _, p := range snapshotExclude {
+		paths = append(paths, "go/"+p)
+	}
+	infos, err := bc.RemoveAllDryRun(ctx, paths...)
+	if err != nil {
+		// Probably an old buildlet. It's only informational.
+		st.logf("unable to get size of snapshot tree: %v", err)
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d files, %d bytes", infos[0].Files, infos[0].Size)
+	for _, info := range infos[1:] {
+		if info.Exists {
+			fmt.Fprintf(&b, "; excluding %s: %d files, %d bytes", info.Path, info.Files, info.Size)
+		}
+	}
+	st.LogEventTime("snapshot_tree_size", b.String())
 }
 
 func (st *buildStatus) writeSnapshot(bc buildlet.Client) (err error) {
@@ -783,12 +869,18 @@ func (st *buildStatus) writeSnapshot(bc buildlet.Client) (err error) {
 	defer cancel()
 
 	tsp := st.CreateSpan("fetch_snapshot_reader_from_buildlet")
-	tgz, err := bc.GetTar(ctx, "go")
+	var read atomic.Int64 // bytes read from the buildlet so far
+	tgz, err := bc.GetTarStream(ctx, "go", buildlet.GetTarStreamOpts{
+		Exclude:  snapshotExclude,
+		Progress: read.Store,
+	})
 	tsp.Done(err)
 	if err != nil {
 		return err
 	}
 	defer tgz.Close()
+	stopWatch := st.watchSnapshotProgress(ctx, &read)
+	defer stopWatch()
 
 	sc := pool.NewGCEConfiguration().StorageClient()
 	if sc == nil {
@@ -808,6 +900,86 @@ func (st *buildStatus) writeSnapshot(bc buildlet.Client) (err error) {
 	return wr.Close()
 }
 
+// snapshotProgressInterval is how often writeSnapshot logs how much of
+// the snapshot it has read from the buildlet.
+const snapshotProgressInterval = 30 * time.Second
+
+// watchSnapshotProgress periodically logs the number of bytes of the
+// snapshot read from the buildlet so far, as stored in read, and the
+// throughput, so that a slow network can be told apart from a hung
+// buildlet before writeSnapshot times out. It returns a function that
+// stops the logging.
+func (st *buildStatus) watchSnapshotProgress(ctx context.Context, read *atomic.Int64) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		t := time.NewTicker(snapshotProgressInterval)
+		defer t.Stop()
+		start := time.Now()
+		var last int64
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			n := read.Load()
+			if n == last {
+				st.logf("snapshot: no data from buildlet in %v, after %d bytes in %v; buildlet may be hung", snapshotProgressInterval, n, time.Since(start).Round(time.Second))
+			} else {
+				st.logf("snapshot: read %d bytes from buildlet in %v (%.2f MB/s)", n, time.Since(start).Round(time.Second), float64(n)/1e6/time.Since(start).Seconds())
+			}
+			last = n
+		}
+	}()
+	return cancel
+}
+
+// saveCrashArtifacts copies any crash artifacts, such as core dumps,
+// left in buildlet.CrashArtifactsDir on bc to the private crash artifact
+// bucket, and notes their location in the build log. The build log is
+// public, so it only links to the object through the authenticated
+// Cloud Storage browser. Errors are logged, not returned, since they
+// shouldn't change the result of the build.
+func (st *buildStatus) saveCrashArtifacts(bc buildlet.Client) {
+	bucket := pool.NewGCEConfiguration().BuildEnv().CrashArtifactBucket
+	if *mode == "dev" || bucket == "" {
+		return
+	}
+	sp := st.CreateSpan("save_crash_artifacts")
+	ctx, cancel := context.WithTimeout(st.ctx, 5*time.Minute)
+	defer cancel()
+	err := func() error {
+		tgz, err := bc.GetCrashArtifacts(ctx)
+		if err != nil || tgz == nil {
+			return err
+		}
+		defer tgz.Close()
+		sc := pool.NewGCEConfiguration().StorageClient()
+		if sc == nil {
+			return errors.New("GCE configuration missing storage client")
+		}
+		objName := fmt.Sprintf("%s/%s_%s_crash.tar.gz", st.Rev[:8], st.Name, randHex(8))
+		wr := sc.Bucket(bucket).Object(objName).NewWriter(ctx)
+		wr.ContentType = "application/octet-stream"
+		// Keep the object private even if the bucket is misconfigured.
+		wr.PredefinedACL = "projectPrivate"
+		setRetention(wr, "crash-artifacts")
+		if _, err := io.Copy(wr, tgz); err != nil {
+			wr.Close()
+			return err
+		}
+		if err := wr.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(st, "\nCrash artifacts saved to https://storage.cloud.google.com/%s/%s (requires access to the bucket)\n", bucket, objName)
+		return nil
+	}()
+	sp.Done(err)
+	if err != nil {
+		st.logf("failed to save crash artifacts: %v", err)
+	}
+}
+
 // toolchainBaselineCommit determines the toolchain baseline commit for this
 // benchmark run.
 func (st *buildStatus) toolchainBaselineCommit() (baseline string, err error) {
@@ -928,7 +1100,7 @@ func (st *buildStatus) reportErr(err error) {
 // form of "<pkg>[:<variant>]", where "<pkg>" means what used to be previously
 // named "go_test:<pkg>". distTestList maps those new dist test names back to
 // that previous format, a combination of "go_test[_bench]:<pkg>" and others.
-func (st *buildStatus) distTestList() (names []distTestName, remoteErr, err error) {
+func (st *buildStatus) distTestList() (names []buildgo.DistTestName, remoteErr, err error) {
 	workDir, err := st.bc.WorkDir(st.ctx)
 	if err != nil {
 		err = fmt.Errorf("distTestList, WorkDir: %v", err)
@@ -963,7 +1135,7 @@ func (st *buildStatus) distTestList() (names []distTestName, remoteErr, err erro
 	// To avoid needing to update all the existing dist test adjust policies,
 	// it's easier to remap new dist test names in "<pkg>[:<variant>]" format
 	// to ones used in Go 1.20 and prior. Do that for now.
-	for _, test := range go120DistTestNames(strings.Fields(buf.String())) {
+	for _, test := range buildgo.Go120DistTestNames(strings.Fields(buf.String())) {
 		isNormalTry := st.isTry() && !st.isSlowBot()
 		if !st.conf.ShouldRunDistTest(test.Old, isNormalTry) {
 			continue
@@ -973,72 +1145,11 @@ func (st *buildStatus) distTestList() (names []distTestName, remoteErr, err erro
 	return names, nil, nil
 }
 
-// go120DistTestNames converts a list of dist test names from
-// an arbitrary Go distribution to the format used in Go 1.20
-// and prior versions. (Go 1.21 introduces a simpler format.)
-//
-// This exists only to avoid rewriting current dist adjust policies.
-// We wish to avoid new dist adjust policies, but if they're truly needed,
-// they can choose to start using new dist test names instead.
-func go120DistTestNames(names []string) []distTestName {
-	if len(names) == 0 {
-		// Only happens if there's a problem, but no need to panic.
-		return nil
-	} else if strings.HasPrefix(names[0], "go_test:") {
-		// In Go 1.21 and newer no dist tests have a "go_test:" prefix.
-		// In Go 1.20 and older, go tool dist test -list always returns
-		// at least one "go_test:*" test first.
-		// So if we see it, the list is already in Go 1.20 format.
-		var s []distTestName
-		for _, old := range names {
-			s = append(s, distTestName{old, old})
-		}
-		return s
-	}
-	// Remap the new Go 1.21+ dist test names to old ones.
-	var s []distTestName
-	for _, new := range names {
-		var old string
-		switch pkg, variant, _ := strings.Cut(new, ":"); {
-		// Special cases. Enough to cover what's used by old dist
-		// adjust policies. Not much use in going far beyond that.
-		case variant == "nolibgcc":
-			old = "nolibgcc:" + pkg
-		case variant == "race":
-			old = "race"
-		case variant == "moved_goroot":
-			old = "moved_goroot"
-		case pkg == "cmd/internal/testdir":
-			if variant == "" {
-				// Handle this too for when we stop doing special-case sharding only for testdir inside dist.
-				variant = "0_1"
-			}
-			old = "test:" + variant
-		case pkg == "cmd/api" && variant == "check":
-			old = "api"
-		case pkg == "cmd/internal/bootstrap_test":
-			old = "reboot"
-
-		// Easy regular cases.
-		case variant == "":
-			old = "go_test:" + pkg
-		case variant == "racebench":
-			old = "go_test_bench:" + pkg
-
-		// Neither a known special case nor a regular case.
-		default:
-			old = new // Less bad than leaving it empty.
-		}
-		s = append(s, distTestName{Old: old, Raw: new})
-	}
-	return s
-}
-
 type token struct{}
 
 // newTestSet returns a new testSet given the dist test names (from "go tool dist test -list")
 // and benchmark items.
-func (st *buildStatus) newTestSet(testStats *buildstats.TestStats, names []distTestName) (*testSet, error) {
+func (st *buildStatus) newTestSet(testStats *buildstats.TestStats, names []buildgo.DistTestName) (*testSet, error) {
 	set := &testSet{
 		st:        st,
 		testStats: testStats,
@@ -1272,7 +1383,11 @@ func (m multiError) Error() string {
 // This internalModuleProxy func in prod mode (when running on GKE) returns an
 // http URL to the current GKE pod's IP with a Kubernetes NodePort service port
 // that forwards back to the coordinator's 8123. See comment below.
+// The -module_proxy flag overrides this.
 func internalModuleProxy() string {
+	if *moduleProxyOverride != "" {
+		return *moduleProxyOverride
+	}
 	// We run a NodePort service on each GKE node
 	// (cmd/coordinator/module-proxy-service.yaml) on port 30157
 	// that maps back the coordinator's port 8123. (We could round
@@ -1285,6 +1400,16 @@ func internalModuleProxy() string {
 	return "http://" + pool.NewGCEConfiguration().GKENodeHostname() + ":30157"
 }
 
+// publicModuleProxy returns the GOPROXY environment value for builds that
+// don't use the internal module proxy: the -module_proxy flag if set,
+// or else https://proxy.golang.org.
+func publicModuleProxy() string {
+	if *moduleProxyOverride != "" {
+		return *moduleProxyOverride
+	}
+	return "https://proxy.golang.org"
+}
+
 // modulesEnv returns the extra module-specific environment variables
 // to append to tests.
 func (st *buildStatus) modulesEnv() (env []string) {
@@ -1294,6 +1419,9 @@ func (st *buildStatus) modulesEnv() (env []string) {
 		env = append(env, "GOPROXY=off")
 	case st.conf.PrivateGoProxy():
 		// Don't add GOPROXY, the builder is pre-configured.
+	case builderModuleProxies[st.Name] != "":
+		// Overridden for this builder by -module_proxy_builders.
+		env = append(env, "GOPROXY="+builderModuleProxies[st.Name])
 	case pool.NewGCEConfiguration().BuildEnv() == nil || !pool.NewGCEConfiguration().BuildEnv().IsProd:
 		// Dev mode; use the system default.
 		env = append(env, "GOPROXY="+os.Getenv("GOPROXY"))
@@ -1302,7 +1430,7 @@ func (st *buildStatus) modulesEnv() (env []string) {
 		env = append(env, "GOPROXY="+internalModuleProxy())
 	default:
 		// Everything else uses the public proxy.
-		env = append(env, "GOPROXY=https://proxy.golang.org")
+		env = append(env, "GOPROXY="+publicModuleProxy())
 
 		if migration.StopInternalModuleProxy {
 			// If the internal module proxy is stopped, we can't disable outbound
@@ -1315,6 +1443,13 @@ func (st *buildStatus) modulesEnv() (env []string) {
 		}
 	}
 
+	st.onceLogModuleProxy.Do(func() {
+		for _, kv := range env {
+			if proxy, ok := strings.CutPrefix(kv, "GOPROXY="); ok {
+				st.logf("using GOPROXY=%s", proxy)
+			}
+		}
+	})
 	return env
 }
 
@@ -1704,7 +1839,7 @@ func (st *buildStatus) runTests(helpers <-chan buildlet.Client) (remoteErr, err
 					st.runTestsOnBuildlet(bc, tis, goroot, gopath)
 				}
 				st.LogEventTime("test_helper_is_broken", bc.Name())
-			}(helper)
+			}(st.timeBuildlet(helper))
 		}
 	}()
 
@@ -1855,6 +1990,7 @@ const maxTestExecErrors = 3
 
 // runTestsOnBuildlet runs tis on bc, using the optional goroot & gopath environment variables.
 func (st *buildStatus) runTestsOnBuildlet(bc buildlet.Client, tis []*testItem, goroot, gopath string) {
+	bc = st.recordExecs(bc)
 	names, rawNames := make([]string, len(tis)), make([]string, len(tis))
 	for i, ti := range tis {
 		names[i], rawNames[i] = ti.name.Old, ti.name.Raw
@@ -1883,6 +2019,10 @@ func (st *buildStatus) runTestsOnBuildlet(bc buildlet.Client, tis []*testItem, g
 	if st.useKeepGoingFlag() {
 		args = append(args, "-k")
 	}
+	useJSON := tis[0].set.useDistTestJSON()
+	if useJSON {
+		args = append(args, "-json")
+	}
 	args = append(args, rawNames...)
 	var buf bytes.Buffer
 	t0 := time.Now()
@@ -1914,11 +2054,18 @@ func (st *buildStatus) runTestsOnBuildlet(bc buildlet.Client, tis []*testItem, g
 	sp.Done(err)
 	if err != nil {
 		bc.MarkBroken() // prevents reuse
+		// Only communication errors are worth retrying on another
+		// buildlet, and not those that were the test running too long.
+		var commErr *buildlet.CommError
+		isCommErr := errors.As(err, &commErr)
+		timedOut := isCommErr && errors.Is(commErr.Err, buildlet.ErrTimeout)
 		for _, ti := range tis {
 			ti.numFail++
 			st.logf("Execution error running %s on %s: %v (numFails = %d)", ti.name, bc, err, ti.numFail)
-			if err == buildlet.ErrTimeout {
+			if timedOut {
 				ti.failf("Test %q ran over %v limit (%v); saw output:\n%s", ti.name, timeout, execDuration, buf.Bytes())
+			} else if !isCommErr {
+				ti.failf("Failed to run %q test: %v\n", ti.name, err)
 			} else if ti.numFail >= maxTestExecErrors {
 				ti.failf("Failed to schedule %q test after %d tries.\n", ti.name, maxTestExecErrors)
 			} else {
@@ -1929,6 +2076,13 @@ func (st *buildStatus) runTestsOnBuildlet(bc buildlet.Client, tis []*testItem, g
 	}
 
 	out := buf.Bytes()
+	if useJSON {
+		var results []distTestResult
+		out, results = parseDistTestJSON(out)
+		if len(tis) > 1 {
+			st.putTestSpanRecords(tis, results, bc.Name())
+		}
+	}
 	out = bytes.Replace(out, []byte("\nALL TESTS PASSED (some were excluded)\n"), nil, 1)
 	out = bytes.Replace(out, []byte("\nALL TESTS PASSED\n"), nil, 1)
 
@@ -2064,6 +2218,9 @@ func (st *buildStatus) htmlStatus(detail buildStatusDetail) template.HTML {
 		t = st.startTime
 	}
 	fmt.Fprintf(&buf, ", %v ago", time.Since(t).Round(time.Second))
+	if st.restarts > 0 {
+		fmt.Fprintf(&buf, " (restarted %d times)", st.restarts)
+	}
 	if detail > singleLine {
 		buf.WriteByte('\n')
 		lastLines := 0
@@ -8,7 +8,6 @@ package main
 
 import (
 	"slices"
-	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -323,7 +322,33 @@ func TestModulesEnv(t *testing.T) {
 			},
 			want: []string{"GOPROXY=https://proxy.golang.org"},
 		},
+		{
+			desc: "builder-module-proxy-override",
+			st: &buildStatus{
+				BuilderRev: buildgo.BuilderRev{Name: "canary", SubName: "bar"},
+				conf: &dashboard.BuildConfig{
+					TestHostConf: &dashboard.HostConfig{
+						IsReverse: true,
+					},
+				},
+			},
+			want: []string{"GOPROXY=https://proxy.example.com,direct"},
+		},
+		{
+			desc: "builder-module-proxy-override-go-no-network",
+			st: &buildStatus{
+				BuilderRev: buildgo.BuilderRev{Name: "canary", SubName: ""}, // go
+				conf: &dashboard.BuildConfig{
+					TestHostConf: &dashboard.HostConfig{
+						IsReverse: true,
+					},
+				},
+			},
+			want: []string{"GOPROXY=off"},
+		},
 	}
+	defer func(old map[string]string) { builderModuleProxies = old }(builderModuleProxies)
+	builderModuleProxies = map[string]string{"canary": "https://proxy.example.com,direct"}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			want := tc.want
@@ -338,52 +363,25 @@ func TestModulesEnv(t *testing.T) {
 	}
 }
 
-// Test that go120DistTestNames remaps both 1.20 (old) and 1.21 (new)
-// dist test names to old, and doesn't forget the original name (raw).
-func TestGo120DistTestNames(t *testing.T) {
-	for _, tc := range [...]struct {
-		name string
-		in   string
-		want string
-	}{
-		{
-			name: "empty",
-			in:   "",
-			want: "",
-		},
-		{
-			name: "old to old",
-			in:   "go_test:archive/tar go_test:cmd/go api reboot test:0_2 test:1_2",
-			want: "go_test:archive/tar go_test:cmd/go api reboot test:0_2 test:1_2",
-		},
-		{
-			name: "new to old",
-			in:   "        archive/tar         cmd/go cmd/api:check cmd/internal/bootstrap_test cmd/internal/testdir:0_2 cmd/internal/testdir:1_2",
-			want: "go_test:archive/tar go_test:cmd/go     api                  reboot                        test:0_2                 test:1_2",
-		},
-		{
-			name: "more special cases",
-			in:   "crypto/x509:nolibgcc fmt:moved_goroot flag:race net:race os:race",
-			want: "nolibgcc:crypto/x509     moved_goroot      race     race    race",
-		},
-		{
-			name: "unhandled special case",
-			in:   "something:something",
-			want: "something:something",
+func TestModulesEnvModuleProxyOverride(t *testing.T) {
+	old := pool.NewGCEConfiguration().BuildEnv()
+	defer pool.NewGCEConfiguration().SetBuildEnv(old)
+	pool.NewGCEConfiguration().SetBuildEnv(&buildenv.Environment{
+		IsProd: true,
+	})
+	defer func(old string) { *moduleProxyOverride = old }(*moduleProxyOverride)
+	*moduleProxyOverride = "https://proxy.example.com,direct"
+
+	st := &buildStatus{
+		BuilderRev: buildgo.BuilderRev{SubName: "bar"},
+		conf: &dashboard.BuildConfig{
+			TestHostConf: &dashboard.HostConfig{
+				IsReverse: true,
+			},
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			got := go120DistTestNames(strings.Fields(tc.in))
-			var want []distTestName
-			for _, old := range strings.Fields(tc.want) {
-				want = append(want, distTestName{Old: old})
-			}
-			for i, raw := range strings.Fields(tc.in) {
-				want[i].Raw = raw
-			}
-			if diff := cmp.Diff(want, got); diff != "" {
-				t.Errorf("go120DistTestNames mismatch (-want +got):\n%s", diff)
-			}
-		})
+	}
+	got := st.modulesEnv()
+	if !slices.Contains(got, "GOPROXY=https://proxy.example.com,direct") {
+		t.Errorf("buildStatus.modulesEnv() = %q; want GOPROXY from -module_proxy", got)
 	}
 }
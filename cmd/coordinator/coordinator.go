@@ -32,9 +32,11 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
 
+	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/compute/metadata"
 	"cloud.google.com/go/storage"
 	"go.chromium.org/luci/auth"
@@ -111,12 +113,23 @@ const devPause = false
 const stagingTryWork = true
 
 var (
-	masterKeyFile = flag.String("masterkey", "", "Path to builder master key. Else fetched using GCE project attribute 'builder-master-key'.")
-	mode          = flag.String("mode", "", "Valid modes are 'dev', 'prod', or '' for auto-detect. dev means localhost development, not be confused with staging on go-dashboard-dev, which is still the 'prod' mode.")
-	buildEnvName  = flag.String("env", "", "The build environment configuration to use. Not required if running in dev mode locally or prod mode on GCE.")
-	devEnableGCE  = flag.Bool("dev_gce", false, "Whether or not to enable the GCE pool when in dev mode. The pool is enabled by default in prod mode.")
-	devEnableEC2  = flag.Bool("dev_ec2", false, "Whether or not to enable the EC2 pool when in dev mode. The pool is enabled by default in prod mode.")
-	sshAddr       = flag.String("ssh_addr", ":2222", "Address the gomote SSH server should listen on")
+	masterKeyFile  = flag.String("masterkey", "", "Path to builder master key. Else fetched using GCE project attribute 'builder-master-key'.")
+	mode           = flag.String("mode", "", "Valid modes are 'dev', 'prod', or '' for auto-detect. dev means localhost development, not be confused with staging on go-dashboard-dev, which is still the 'prod' mode.")
+	buildEnvName   = flag.String("env", "", "The build environment configuration to use. Not required if running in dev mode locally or prod mode on GCE.")
+	devEnableGCE   = flag.Bool("dev_gce", false, "Whether or not to enable the GCE pool when in dev mode. The pool is enabled by default in prod mode.")
+	devEnableEC2   = flag.Bool("dev_ec2", false, "Whether or not to enable the EC2 pool when in dev mode. The pool is enabled by default in prod mode.")
+	sshAddr        = flag.String("ssh_addr", ":2222", "Address the gomote SSH server should listen on")
+	tryMaxDuration = flag.Duration("try_max_duration", 0, "If non-zero, the maximum wall-clock time a trybot run may take. Builds still running after that are canceled, and the run is reported to Gerrit as timed out.")
+	snapBucket     = flag.String("snap_bucket", "", "If non-empty, the GCS bucket to read and write build snapshots in, overriding the build environment's. The coordinator checks at startup that it can write and read a test object there.")
+	reverseMax     = flag.String("reverse_max_per_host_type", "", "Comma-separated limits on the number of reverse buildlets of a host type that may be connected at once, as hostType=N pairs and an optional bare N that applies to all other host types. Registrations beyond a limit are rejected. A missing or zero limit means no limit.")
+	reverseToken   = flag.Bool("reverse_require_token", false, "Whether reverse buildlets must present a registration token for their host type, as generated by genbuilderkey -token-ttl, to register. Registrations without a valid, unexpired token are rejected.")
+	startupGrace   = flag.Duration("startup_grace", 0, "How long after startup to delay deleting running GCE and EC2 buildlets left over from a previous coordinator. Buildlets only come back into use if -remote_sessions_url reattaches their gomote sessions; the rest are deleted once the delay passes. Zero deletes them right away.")
+	bigQueryExport = flag.Bool("bigquery_export", false, "Whether to stream build and span records to BigQuery, in addition to storing them in datastore.")
+	adminOperators = flag.String("admin_operators", "", "Comma-separated email addresses of the operators allowed to use the /admin pages when running behind IAP. An entry starting with @, such as \"@example.com\", allows every address in that domain. If empty, the /admin pages are unavailable behind IAP.")
+	helperAcquire  = flag.Int("helper_acquire_concurrency", 0, "If positive, the maximum number of test helper buildlets of each host type that may be requested from the scheduler at once, across all builds. Builds still get the same number of helpers, just more gradually during bursts.")
+
+	gomoteUserLimit  = flag.Int("gomote_user_limit", 0, "If positive, the maximum number of gomote instances a single user may have at once.")
+	gomoteUserLimits = flag.String("gomote_user_limits", "", "Comma-separated per-user overrides of -gomote_user_limit, such as \"gopher=20,relui-prod=0\". A limit of zero means no limit.")
 )
 
 // LOCK ORDER:
@@ -236,6 +249,14 @@ func main() {
 	flag.Parse()
 
 	pool.SetProcessMetadata(processID, processStartTime)
+	pool.SetStartupGrace(*startupGrace)
+	pool.SetSnapBucket(*snapBucket)
+	if def, byHostType, err := pool.ParseMaxPerHostType(*reverseMax); err != nil {
+		log.Fatalf("invalid -reverse_max_per_host_type: %v", err)
+	} else {
+		pool.ReversePool().SetMaxPerHostType(def, byHostType)
+	}
+	pool.ReversePool().SetRequireRegistrationToken(*reverseToken)
 
 	if Version == "" && *mode == "dev" {
 		Version = "dev"
@@ -248,13 +269,23 @@ func main() {
 	}
 
 	mustInitMasterKeyCache(sc)
+	initStatusRateLimit()
 
 	// TODO(golang.org/issue/38337): remove package level variables where possible.
 	// TODO(golang.org/issue/36841): remove after key functions are moved into
 	// a shared package.
 	pool.SetBuilderMasterKey(masterKey())
 	sp := remote.NewSessionPool(context.Background())
-	err := pool.InitGCE(sc, &basePinErr, sp.IsSession, *buildEnvName, *mode)
+	userLimits, err := remote.ParseUserLimits(*gomoteUserLimits)
+	if err != nil {
+		log.Fatalf("invalid -gomote_user_limits: %v", err)
+	}
+	sp.SetUserLimits(*gomoteUserLimit, userLimits)
+	builderModuleProxies, err = parseBuilderModuleProxies(*moduleProxyBuilders)
+	if err != nil {
+		log.Fatalf("invalid -module_proxy_builders: %v", err)
+	}
+	err = pool.InitGCE(sc, &basePinErr, sp.IsSession, *buildEnvName, *mode)
 	if err != nil {
 		if *mode == "" {
 			*mode = "dev"
@@ -265,6 +296,9 @@ func main() {
 			*mode = "prod"
 		}
 	}
+	// Restore gomote sessions before the pools start cleaning up
+	// buildlets they don't recognize.
+	restoreRemoteSessions(sp)
 
 	gce := pool.NewGCEConfiguration()
 
@@ -305,6 +339,18 @@ func main() {
 	}
 
 	go pool.CoordinatorProcess().UpdateInstanceRecord()
+	if *bigQueryExport && *mode == "prod" {
+		bq, err := bigquery.NewClient(context.Background(), gce.BuildEnv().ProjectName)
+		if err != nil {
+			log.Fatalf("creating BigQuery client: %v", err)
+		}
+		exporter, err := pool.NewRecordExporter(context.Background(), bq, "bigquery-spill/")
+		if err != nil {
+			log.Fatalf("setting up BigQuery export: %v", err)
+		}
+		pool.CoordinatorProcess().SetRecordExporter(exporter)
+		go exporter.Run(context.Background())
+	}
 
 	switch *mode {
 	case "dev", "prod":
@@ -349,6 +395,8 @@ func main() {
 
 	var gomoteBucket string
 	var opts []grpc.ServerOption
+	var useIAP bool
+	var iapAudience string
 	if *buildEnvName == "" && *mode != "dev" && metadata.OnGCE() {
 		projectID, err := metadata.ProjectID()
 		if err != nil {
@@ -360,8 +408,10 @@ func main() {
 		if serviceID = env.IAPServiceID(coordinatorBackend); serviceID == "" {
 			log.Fatalf("unable to retrieve Service ID for backend service=%q", coordinatorBackend)
 		}
+		iapAudience = access.IAPAudienceGCE(env.ProjectNumber, serviceID)
 		opts = append(opts, grpc.UnaryInterceptor(access.RequireIAPAuthUnaryInterceptor(access.IAPSkipAudienceValidation)))
 		opts = append(opts, grpc.StreamInterceptor(access.RequireIAPAuthStreamInterceptor(access.IAPSkipAudienceValidation)))
+		useIAP = true
 	}
 	// grpcServer is a shared gRPC server. It is global, as it needs to be used in places that aren't factored otherwise.
 	grpcServer := grpc.NewServer(opts...)
@@ -397,19 +447,43 @@ func main() {
 	gomoteServer := gomote.New(sp, sched, sshCA, gomoteBucket, mustStorageClient())
 	protos.RegisterCoordinatorServer(grpcServer, gs)
 	gomoteprotos.RegisterGomoteServiceServer(grpcServer, gomoteServer)
-	mux.HandleFunc("/", grpcHandlerFunc(grpcServer, handleStatus)) // Serve a status page at farmer.golang.org.
-	mux.Handle("build.golang.org/", dashV1)                        // Serve a build dashboard at build.golang.org.
+	mux.HandleFunc("/", grpcHandlerFunc(grpcServer, rateLimited(handleStatus))) // Serve a status page at farmer.golang.org.
+	mux.Handle("build.golang.org/", dashV1)                                     // Serve a build dashboard at build.golang.org.
 	mux.Handle("build-staging.golang.org/", dashV1)
-	mux.HandleFunc("/builders", handleBuilders)
-	mux.HandleFunc("/temporarylogs", handleLogs)
+	mux.HandleFunc("/builders", rateLimited(handleBuilders))
+	mux.HandleFunc("/temporarylogs", rateLimited(handleLogs))
 	mux.HandleFunc("/reverse", pool.HandleReverse)
 	mux.Handle("/revdial", revdial.ConnHandler())
 	mux.HandleFunc("/style.css", handleStyleCSS)
-	mux.HandleFunc("/try", serveTryStatus(false))
-	mux.HandleFunc("/try.json", serveTryStatus(true))
-	mux.HandleFunc("/status/post-submit-active.json", handlePostSubmitActiveJSON)
+	mux.HandleFunc("/try", rateLimited(serveTryStatus(false)))
+	mux.HandleFunc("/try.json", rateLimited(serveTryStatus(true)))
+	mux.HandleFunc("/status/post-submit-active.json", rateLimited(handlePostSubmitActiveJSON))
+	mux.HandleFunc("/status/last-green.json", rateLimited(handleLastGreenJSON))
+	mux.HandleFunc("/status/blocked-on-deps.json", rateLimited(handleBlockedOnDepsJSON))
+	mux.HandleFunc("/status/timeline.json", rateLimited(handleTimelineJSON))
+	mux.HandleFunc("/repro", rateLimited(handleRepro))
+	mux.HandleFunc("/why", rateLimited(handleWhy))
+	mux.HandleFunc("/status/reverse.json", rateLimited(pool.ReversePool().ServeReverseStatusJSON))
+	switch {
+	case useIAP:
+		ops := parseOperatorList(*adminOperators)
+		if len(ops) == 0 {
+			log.Printf("no -admin_operators; /admin pages are unavailable")
+		}
+		mux.Handle("/admin/reverse", iapAdminHandler(handleReverseAdmin, iapAudience, ops))
+		mux.Handle("/admin/drain", iapAdminHandler(handleDrainAdmin, iapAudience, ops))
+		mux.Handle("/admin/pause", iapAdminHandler(handlePauseAdmin, iapAudience, ops))
+		mux.Handle("/admin/replay", iapAdminHandler(handleReplayAdmin, iapAudience, ops))
+		mux.Handle("/admin/reload-builders", iapAdminHandler(handleReloadBuildersAdmin, iapAudience, ops))
+	case *mode == "dev":
+		mux.HandleFunc("/admin/reverse", handleReverseAdmin)
+		mux.HandleFunc("/admin/drain", handleDrainAdmin)
+		mux.HandleFunc("/admin/pause", handlePauseAdmin)
+		mux.HandleFunc("/admin/replay", handleReplayAdmin)
+		mux.HandleFunc("/admin/reload-builders", handleReloadBuildersAdmin)
+	}
 	mux.Handle("/dashboard", dashV2)
-	mux.HandleFunc("/queues", handleQueues)
+	mux.HandleFunc("/queues", rateLimited(handleQueues))
 	if *mode == "dev" {
 		// TODO(crawshaw): do more in dev mode
 		gce.BuildletPool().SetEnabled(*devEnableGCE)
@@ -422,11 +496,19 @@ func main() {
 		if gce.InStaging() {
 			dashboard.Builders = stagingClusterBuilders()
 		}
+		if *builderConfigURL != "" {
+			if _, err := reloadBuilderConfig(); err != nil {
+				log.Fatalf("loading builder config: %v", err)
+			}
+		}
 
+		initTryOverrides()
 		go listenAndServeInternalModuleProxy()
 		go findWorkLoop()
 		go findTryWorkLoop()
 		go reportReverseCountMetrics()
+		go manageBuildLogRetention()
+		go seedLastGreen()
 		// TODO(cmang): gccgo will need its own findWorkLoop
 	}
 
@@ -462,10 +544,12 @@ func main() {
 	log.Fatalln(https.ListenAndServe(context.Background(), mux))
 }
 
-// ignoreAllNewWork, when true, prevents addWork from doing anything.
-// It's sometimes set in staging mode when people are debugging
-// certain paths.
-var ignoreAllNewWork bool
+// ignoreAllNewWork, when true, prevents addWork from doing anything
+// and keeps findTryWork from starting new trybot runs. Builds already
+// running finish normally. Operators set it with /admin/pause during
+// incidents, and it's sometimes set in staging mode when people are
+// debugging certain paths.
+var ignoreAllNewWork atomic.Bool
 
 // addWorkTestHook is optionally set by tests.
 var addWorkTestHook func(buildgo.BuilderRev, commitDetail)
@@ -505,12 +589,12 @@ func addWorkDetail(work buildgo.BuilderRev, detail commitDetail) {
 		f(work, detail)
 		return
 	}
-	if ignoreAllNewWork || isBuilding(work) {
+	if ignoreAllNewWork.Load() || isBuilding(work) {
 		return
 	}
 	if !mayBuildRev(work) {
 		if pool.NewGCEConfiguration().InStaging() {
-			if _, ok := dashboard.Builders[work.Name]; ok && logCantBuildStaging.Allow() {
+			if _, ok := dashboard.ActiveBuilders()[work.Name]; ok && logCantBuildStaging.Allow() {
 				log.Printf("may not build %v; skipping", work)
 			}
 		}
@@ -570,31 +654,40 @@ var (
 // It returns true if it's not already building, and if a reverse buildlet is
 // required, if an appropriate machine is registered.
 func mayBuildRev(rev buildgo.BuilderRev) bool {
+	return cantBuildRevReason(rev) == ""
+}
+
+// cantBuildRevReason returns why mayBuildRev reports false for rev,
+// or the empty string if it may be built.
+func cantBuildRevReason(rev buildgo.BuilderRev) string {
 	if isBuilding(rev) {
-		return false
+		return "it's already building"
 	}
 	if rev.SubName != "" {
 		// Don't build repos we don't know about,
 		// so importPathOfRepo won't panic later.
 		if r, ok := repos.ByGerritProject[rev.SubName]; !ok || r.ImportPath == "" || !r.CoordinatorCanBuild {
-			return false
+			return fmt.Sprintf("repo %q isn't built by the coordinator", rev.SubName)
 		}
 	}
-	buildConf, ok := dashboard.Builders[rev.Name]
+	buildConf, ok := dashboard.ActiveBuilders()[rev.Name]
 	if !ok {
 		if logUnknownBuilder.Allow() {
 			log.Printf("unknown builder %q", rev.Name)
 		}
-		return false
+		return fmt.Sprintf("unknown builder %q", rev.Name)
 	}
 	gceBuildEnv := pool.NewGCEConfiguration().BuildEnv()
 	if gceBuildEnv.MaxBuilds > 0 && numCurrentBuilds() >= gceBuildEnv.MaxBuilds {
-		return false
+		return fmt.Sprintf("the coordinator is at its limit of %d builds", gceBuildEnv.MaxBuilds)
+	}
+	if sched.IsDraining(buildConf.HostType) {
+		return fmt.Sprintf("host type %q is draining", buildConf.HostType)
 	}
 	if buildConf.IsReverse() && !pool.ReversePool().CanBuild(buildConf.HostType) {
-		return false
+		return fmt.Sprintf("no reverse buildlet for host type %q can take the build", buildConf.HostType)
 	}
-	return true
+	return ""
 }
 
 func setStatus(work buildgo.BuilderRev, st *buildStatus) {
@@ -727,10 +820,12 @@ func serveTryStatusJSON(w http.ResponseWriter, r *http.Request, ts *trySet, tss
 		return
 	}
 	type litebuild struct {
-		Name      string    `json:"name"`
-		StartTime time.Time `json:"startTime"`
-		Done      bool      `json:"done"`
-		Succeeded bool      `json:"succeeded"`
+		Name          string    `json:"name"`
+		StartTime     time.Time `json:"startTime"`
+		Done          bool      `json:"done"`
+		Succeeded     bool      `json:"succeeded"`
+		ContainerHost string    `json:"containerHost,omitempty"` // "cos", "ec2", "reverse", or empty if not a container build
+		Restarts      int       `json:"restarts,omitempty"`      // times the build died before completion and was restarted
 	}
 	var result struct {
 		ChangeID string      `json:"changeId"`
@@ -745,6 +840,8 @@ func serveTryStatusJSON(w http.ResponseWriter, r *http.Request, ts *trySet, tss
 		bs.mu.Lock()
 		lb.Name = bs.Name
 		lb.StartTime = bs.startTime
+		lb.ContainerHost = bs.containerHost()
+		lb.Restarts = bs.restarts
 		if !bs.done.IsZero() {
 			lb.Done = true
 			lb.Succeeded = bs.succeeded
@@ -913,7 +1010,12 @@ func writeStatusHeader(w http.ResponseWriter, st *buildStatus) {
 	fmt.Fprintf(w, "      rev: %s\n", st.Rev)
 	workaroundFlush(w)
 	fmt.Fprintf(w, " buildlet: %s\n", st.bc)
+	if ch := st.containerHost(); ch != "" {
+		fmt.Fprintf(w, "container: %s\n", ch)
+	}
 	fmt.Fprintf(w, "  started: %v\n", st.startTime)
+	fmt.Fprintf(w, "   recipe: /repro?buildID=%s&format=sh\n", st.buildID)
+	fmt.Fprintf(w, " timeline: /status/timeline.json?buildID=%s\n", st.buildID)
 	done := !st.done.IsZero()
 	if done {
 		fmt.Fprintf(w, "    ended: %v\n", st.done)
@@ -1006,12 +1108,7 @@ func findWork() error {
 	}
 
 	for _, br := range bs.Revisions {
-		if r, ok := repos.ByGerritProject[br.Repo]; !ok || !r.CoordinatorCanBuild {
-			continue
-		}
-		if br.Repo == "grpc-review" {
-			// Skip the grpc repo. It's only for reviews
-			// for now (using LetsUseGerrit).
+		if repoSkipReason(br.Repo) != "" {
 			continue
 		}
 		commitTime[br.Revision] = br.Date
@@ -1041,49 +1138,17 @@ func findWork() error {
 			return errors.New("bogus JSON response from dashboard: results is too long.")
 		}
 		for i, res := range br.Results {
-			if res != "" {
-				// It's either "ok" or a failure URL.
+			rev, skip := postSubmitWork(&br, bs.Builders[i], res, awaitSnapshot)
+			if skip != "" {
 				continue
 			}
-			builder := bs.Builders[i]
-			builderInfo, ok := dashboard.Builders[builder]
-			if !ok {
-				// Not managed by the coordinator.
-				continue
-			}
-			if !builderInfo.BuildsRepoPostSubmit(br.Repo, br.Branch, br.GoBranch) {
-				continue
-			}
-			var rev buildgo.BuilderRev
-			if br.Repo == "go" {
-				rev = buildgo.BuilderRev{
-					Name: builder,
-					Rev:  br.Revision,
-				}
-			} else {
-				rev = buildgo.BuilderRev{
-					Name:    builder,
-					Rev:     br.GoRevision,
-					SubName: br.Repo,
-					SubRev:  br.Revision,
-				}
-				if awaitSnapshot &&
-					// If this is a builder that snapshots after
-					// make.bash but the snapshot doesn't yet exist,
-					// then skip. But some builders on slow networks
-					// don't snapshot, so don't wait for them. They'll
-					// need to run make.bash first for x/ repos tests.
-					!builderInfo.SkipSnapshot && !rev.SnapshotExists(context.TODO(), pool.NewGCEConfiguration().BuildEnv()) {
-					continue
-				}
-			}
 			add(rev)
 		}
 	}
 
 	// And to bootstrap new builders, see if we have any builders
 	// that the dashboard doesn't know about.
-	for b, builderInfo := range dashboard.Builders {
+	for b, builderInfo := range dashboard.ActiveBuilders() {
 		if knownToDashboard[b] {
 			// no need to bootstrap.
 			continue
@@ -1103,6 +1168,61 @@ func findWork() error {
 	return nil
 }
 
+// repoSkipReason returns why findWork skips all commits to repo,
+// or the empty string if it doesn't.
+func repoSkipReason(repo string) string {
+	if r, ok := repos.ByGerritProject[repo]; !ok || !r.CoordinatorCanBuild {
+		return fmt.Sprintf("repo %q isn't built by the coordinator", repo)
+	}
+	if repo == "grpc-review" {
+		// Skip the grpc repo. It's only for reviews
+		// for now (using LetsUseGerrit).
+		return "the grpc-review repo is only for reviews"
+	}
+	return ""
+}
+
+// postSubmitWork returns the work findWork adds for builder on the
+// dashboard revision br, whose result for builder on the dashboard is
+// res. If findWork skips it, postSubmitWork instead returns the reason.
+// awaitSnapshot is whether a subrepo build must wait for a snapshot of
+// its Go revision.
+func postSubmitWork(br *types.BuildRevision, builder, res string, awaitSnapshot bool) (rev buildgo.BuilderRev, skip string) {
+	if res != "" {
+		// It's either "ok" or a failure URL.
+		return rev, fmt.Sprintf("the dashboard already has a result: %s", res)
+	}
+	builderInfo, ok := dashboard.ActiveBuilders()[builder]
+	if !ok {
+		return rev, "the builder isn't managed by the coordinator"
+	}
+	if !builderInfo.BuildsRepoPostSubmit(br.Repo, br.Branch, br.GoBranch) {
+		return rev, fmt.Sprintf("the builder doesn't build repo %q branch %q with Go branch %q post-submit", br.Repo, br.Branch, br.GoBranch)
+	}
+	if br.Repo == "go" {
+		return buildgo.BuilderRev{
+			Name: builder,
+			Rev:  br.Revision,
+		}, ""
+	}
+	rev = buildgo.BuilderRev{
+		Name:    builder,
+		Rev:     br.GoRevision,
+		SubName: br.Repo,
+		SubRev:  br.Revision,
+	}
+	if awaitSnapshot &&
+		// If this is a builder that snapshots after
+		// make.bash but the snapshot doesn't yet exist,
+		// then skip. But some builders on slow networks
+		// don't snapshot, so don't wait for them. They'll
+		// need to run make.bash first for x/ repos tests.
+		!builderInfo.SkipSnapshot && !rev.SnapshotExists(context.TODO(), pool.NewGCEConfiguration().BuildEnv()) {
+		return rev, fmt.Sprintf("awaiting a snapshot of Go revision %s", br.GoRevision)
+	}
+	return rev, ""
+}
+
 // findTryWorkLoop is a goroutine which loops periodically and queries
 // Gerrit for TryBot work.
 func findTryWorkLoop() {
@@ -1144,7 +1264,13 @@ func findTryWork() error {
 		if r, ok := repos.ByGerritProject[work.Project]; !ok || !r.CoordinatorCanBuild {
 			continue
 		}
+		if tryDisabled(work.Project) {
+			continue
+		}
 		key := tryWorkItemKey(work)
+		if _, ok := tries[key]; !ok && ignoreAllNewWork.Load() {
+			continue
+		}
 		tryList = append(tryList, key)
 		if ts, ok := tries[key]; ok {
 			// already in progress
@@ -1198,7 +1324,9 @@ type trySet struct {
 	// mu guards the following fields.
 	// See LOCK ORDER comment above.
 	mu       sync.Mutex
-	canceled bool // try run is no longer wanted and its builds were canceled
+	canceled bool        // try run is no longer wanted and its builds were canceled
+	timedOut bool        // try run exceeded -try_max_duration; also sets canceled
+	timer    *time.Timer // fires after -try_max_duration; nil if there's no limit
 	trySetState
 	errMsg bytes.Buffer
 }
@@ -1298,6 +1426,9 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 	// There may be additional builds, those are handled below.
 	if !testingKnobSkipBuilds {
 		go ts.notifyStarting(invalidSlowBots)
+		if d := *tryMaxDuration; d > 0 {
+			ts.timer = time.AfterFunc(d, func() { ts.noteTimeout(d) })
+		}
 	}
 	for _, bconf := range builders {
 		goVersion := types.MajorMinor{Major: int(work.GoVersion[0].Major), Minor: int(work.GoVersion[0].Minor)}
@@ -1318,7 +1449,7 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 	// The version selection logic is currently in maintapi's GoFindTryWork implementation.
 	if key.Project != "go" && len(work.GoCommit) >= 2 {
 		// linuxBuilder is the standard builder for this purpose.
-		linuxBuilder := dashboard.Builders["linux-amd64"]
+		linuxBuilder := dashboard.ActiveBuilders()["linux-amd64"]
 
 		for i, goRev := range work.GoCommit {
 			if i == 0 {
@@ -1351,9 +1482,9 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 		addXrepo := func(project, customBuilder string) *buildStatus {
 			// linux-amd64 is the default builder as it is the fastest and least
 			// expensive.
-			builder := dashboard.Builders["linux-amd64"]
+			builder := dashboard.ActiveBuilders()["linux-amd64"]
 			if customBuilder != "" {
-				b, ok := dashboard.Builders[customBuilder]
+				b, ok := dashboard.ActiveBuilders()[customBuilder]
 				if !ok {
 					log.Printf("can't resolve requested builder %q", customBuilder)
 					return nil
@@ -1614,6 +1745,12 @@ func (ts *trySet) awaitTryBuild(idx int, bs *buildStatus, brev buildgo.BuilderRe
 			}
 		}
 
+		if ts.isTimedOut() {
+			// The whole try run was given up on;
+			// don't report or retry this build.
+			return
+		}
+
 		if bs.hasEvent(eventDone) || bs.hasEvent(eventSkipBuildMissingDep) {
 			ts.noteBuildComplete(bs)
 			return
@@ -1625,11 +1762,15 @@ func (ts *trySet) awaitTryBuild(idx int, bs *buildStatus, brev buildgo.BuilderRe
 
 		// Sleep a bit and retry.
 		time.Sleep(30 * time.Second)
-		if !ts.wanted() {
+		if !ts.wanted() || ts.isTimedOut() {
 			return
 		}
+		restarts := bs.restarts + 1
+		log.Printf("try build %s at %s died before completion; restarting (restart %d)", brev.Name, brev.SubRevOrGoRev(), restarts)
+		recordBuildRestart(context.Background(), brev.Name)
 		bs, _ = newBuild(brev, bs.commitDetail)
 		bs.trySet = ts
+		bs.restarts = restarts
 		go bs.start()
 		ts.mu.Lock()
 		ts.builds[idx] = bs
@@ -1660,12 +1801,75 @@ func (ts *trySet) cancelBuilds() {
 		return
 	}
 	ts.canceled = true
+	if ts.timer != nil {
+		ts.timer.Stop()
+	}
 
 	for _, bs := range ts.builds {
 		go bs.cancelBuild()
 	}
 }
 
+// isTimedOut reports whether the trySet ran for longer than
+// -try_max_duration and was given up on.
+func (ts *trySet) isTimedOut() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.timedOut
+}
+
+// noteTimeout is called once the trySet has been running for d, the
+// value of -try_max_duration. If builds still remain, it cancels them
+// and reports the try run as failed to Gerrit, so that a CL isn't left
+// waiting indefinitely when the builders are backed up.
+func (ts *trySet) noteTimeout(d time.Duration) {
+	ts.mu.Lock()
+	if ts.canceled || ts.remain == 0 {
+		ts.mu.Unlock()
+		return
+	}
+	ts.canceled = true
+	ts.timedOut = true
+	builds := append([]*buildStatus(nil), ts.builds...)
+	errMsg := ts.errMsg.String()
+	ts.mu.Unlock()
+
+	// Check the builds without holding ts.mu; see LOCK ORDER.
+	var unfinished []string
+	for _, bs := range builds {
+		if bs.hasEvent(eventDone) || bs.hasEvent(eventSkipBuildMissingDep) {
+			continue
+		}
+		unfinished = append(unfinished, bs.NameAndBranch())
+		go bs.cancelBuild()
+	}
+	log.Printf("Try run %s for %v timed out after %v with %d builds unfinished", ts.tryID, ts.tryKey, d, len(unfinished))
+
+	name := "TryBots"
+	if len(ts.slowBots) > 0 {
+		name = "SlowBots"
+	}
+	msg := tryTimeoutMessage(name, d, len(builds), unfinished, errMsg)
+	ts.postReview(tryBotsTag("timeout"), -1, msg)
+}
+
+// tryTimeoutMessage returns the Gerrit message for a try run that took
+// longer than d. unfinished lists the builds that hadn't completed, out
+// of total builds, and errMsg describes the failures, if any, among
+// the builds that had.
+func tryTimeoutMessage(name string, d time.Duration, total int, unfinished []string, errMsg string) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s timed out after %v; %d of %d builds didn't finish in time:\n", name, d, len(unfinished), total)
+	for _, s := range unfinished {
+		fmt.Fprintf(&buf, "* %s\n", s)
+	}
+	if errMsg != "" {
+		fmt.Fprintf(&buf, "\nBuilds that finished with failures:\n%s", errMsg)
+	}
+	fmt.Fprintf(&buf, "\nThis is likely due to a shortage of builders rather than a problem with this change. Try again later.\n")
+	return buf.String()
+}
+
 func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 	bs.mu.Lock()
 	var (
@@ -1682,6 +1886,9 @@ func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 	}
 	numFail := len(ts.failed)
 	canceled := ts.canceled
+	if remain == 0 && ts.timer != nil {
+		ts.timer.Stop()
+	}
 	ts.mu.Unlock()
 
 	if canceled {
@@ -1741,18 +1948,24 @@ func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 		if len(ts.slowBots) > 0 {
 			name = "SlowBots"
 		}
+		ts.mu.Lock()
+		builds := append([]*buildStatus(nil), ts.builds...)
+		ts.mu.Unlock()
+		results := make([]tryBuildResult, len(builds))
+		for i, bs := range builds {
+			bs.mu.Lock()
+			results[i] = tryBuildResult{Name: bs.NameAndBranch(), Succeeded: bs.succeeded, LogURL: bs.logURL}
+			bs.mu.Unlock()
+		}
 
 		if numFail == 0 {
 			gerritScore = 1
-			fmt.Fprintf(gerritMsg, "%s are happy.\n", name)
+			fmt.Fprintf(gerritMsg, "%s are happy.\n\n%s", name, tryResultsSummary(results))
 			gerritTag = tryBotsTag("happy")
 		} else {
 			gerritScore = -1
-			ts.mu.Lock()
-			errMsg := ts.errMsg.String()
-			ts.mu.Unlock()
-			fmt.Fprintf(gerritMsg, "%d of %d %s failed.\n%s\n"+failureFooter,
-				numFail, len(ts.builds), name, errMsg)
+			fmt.Fprintf(gerritMsg, "%d of %d %s failed.\n\n%s\n"+failureFooter,
+				numFail, len(builds), name, tryResultsSummary(results))
 			gerritTag = tryBotsTag("failed")
 		}
 		fmt.Fprintln(gerritMsg)
@@ -1768,8 +1981,58 @@ func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 				fmt.Fprintf(gerritMsg, "* %s\n", st.NameAndBranch())
 			}
 		}
+		fmt.Fprintf(gerritMsg, "\n%s\n", resourcesUsed(builds))
+	}
+
+	ts.postReview(gerritTag, gerritScore, gerritMsg.String())
+}
+
+// tryBuildResult is the outcome of one build of a try run.
+type tryBuildResult struct {
+	Name      string // builder name, with optional " ($branch)" suffix
+	Succeeded bool
+	LogURL    string // empty if the log wasn't written
+}
+
+// tryResultsSummary returns the per-builder part of the final comment
+// for a try run. Failed builds are listed with links to their logs,
+// while passed builds are collapsed into a single <details> section so
+// that runs with many builders stay scannable.
+func tryResultsSummary(results []tryBuildResult) string {
+	var failed, passed []tryBuildResult
+	for _, r := range results {
+		if r.Succeeded {
+			passed = append(passed, r)
+		} else {
+			failed = append(failed, r)
+		}
+	}
+	var buf strings.Builder
+	if len(failed) > 0 {
+		fmt.Fprintf(&buf, "Failed builds:\n")
+		for _, r := range failed {
+			if r.LogURL != "" {
+				fmt.Fprintf(&buf, "* %s ([log](%s))\n", r.Name, r.LogURL)
+			} else {
+				fmt.Fprintf(&buf, "* %s\n", r.Name)
+			}
+		}
+		fmt.Fprintln(&buf)
+	}
+	if len(passed) > 0 {
+		fmt.Fprintf(&buf, "<details><summary>%d of %d builds passed</summary>\n\n", len(passed), len(results))
+		for _, r := range passed {
+			fmt.Fprintf(&buf, "* %s\n", r.Name)
+		}
+		fmt.Fprintf(&buf, "\n</details>\n")
 	}
+	return buf.String()
+}
 
+// postReview posts msg to the trySet's change as a reply to the
+// "beginning" comment, setting the TryBot-Result label to score
+// if it's non-zero.
+func (ts *trySet) postReview(gerritTag string, gerritScore int, msg string) {
 	var inReplyTo string
 	gerritClient := pool.NewGCEConfiguration().GerritClient()
 	if patchSetThreads, err := listPatchSetThreads(gerritClient, ts.ChangeTriple()); err == nil {
@@ -1790,7 +2053,7 @@ func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 		Comments: map[string][]gerrit.CommentInput{
 			"/PATCHSET_LEVEL": {{
 				InReplyTo:  inReplyTo,
-				Message:    gerritMsg.String(),
+				Message:    msg,
 				Unresolved: &unresolved,
 			}},
 		},
@@ -1805,6 +2068,35 @@ func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 	}
 }
 
+// helperAcquireSems bound the number of buildlets of each host type that
+// getBuildlets requests from the scheduler at once, across all builds, to
+// the -helper_acquire_concurrency flag. Each host type has its own, so
+// that waiting for a host type without free buildlets, such as a reverse
+// one, doesn't hold up the others.
+var helperAcquireSems struct {
+	mu sync.Mutex
+	m  map[string]chan struct{} // hostType -> semaphore
+}
+
+// helperAcquireSem returns the semaphore for requesting buildlets of
+// hostType, or nil if there's no limit.
+func helperAcquireSem(hostType string) chan struct{} {
+	if *helperAcquire <= 0 {
+		return nil
+	}
+	helperAcquireSems.mu.Lock()
+	defer helperAcquireSems.mu.Unlock()
+	sem, ok := helperAcquireSems.m[hostType]
+	if !ok {
+		if helperAcquireSems.m == nil {
+			helperAcquireSems.m = make(map[string]chan struct{})
+		}
+		sem = make(chan struct{}, *helperAcquire)
+		helperAcquireSems.m[hostType] = sem
+	}
+	return sem
+}
+
 // getBuildlets creates up to n buildlets and sends them on the returned channel
 // before closing the channel.
 func getBuildlets(ctx context.Context, n int, schedTmpl *queue.SchedItem, lg pool.Logger) <-chan buildlet.Client {
@@ -1817,7 +2109,7 @@ func getBuildlets(ctx context.Context, n int, schedTmpl *queue.SchedItem, lg poo
 			sp := lg.CreateSpan("get_helper", fmt.Sprintf("helper %d/%d", i+1, n))
 			schedItem := *schedTmpl // copy; GetBuildlet takes ownership
 			schedItem.IsHelper = i > 0
-			bc, err := sched.GetBuildlet(ctx, &schedItem)
+			bc, err := getBuildletLimited(ctx, &schedItem)
 			sp.Done(err)
 			if err != nil {
 				if err != context.Canceled {
@@ -1842,6 +2134,20 @@ func getBuildlets(ctx context.Context, n int, schedTmpl *queue.SchedItem, lg poo
 	return ch
 }
 
+// getBuildletLimited is sched.GetBuildlet, but waits for a slot in
+// the host type's helperAcquireSem first, if there is one.
+func getBuildletLimited(ctx context.Context, si *queue.SchedItem) (buildlet.Client, error) {
+	if sem := helperAcquireSem(si.HostType); sem != nil {
+		select {
+		case sem <- struct{}{}:
+			defer func() { <-sem }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return sched.GetBuildlet(ctx, si)
+}
+
 type testSet struct {
 	st        *buildStatus
 	items     []*testItem
@@ -1899,9 +2205,9 @@ func (s *testSet) initInOrder() {
 		namedItem[ti.name.Old] = ti
 	}
 
-	// First do the go_test:* ones. partitionGoTests
+	// First do the go_test:* ones. PartitionGoTests
 	// only returns those, which are the ones we merge together.
-	stdSets := partitionGoTests(s.testStats.Duration, s.st.BuilderRev.Name, names)
+	stdSets := buildgo.PartitionGoTests(s.testStats.Duration, s.st.BuilderRev.Name, names)
 	for _, set := range stdSets {
 		tis := make([]*testItem, len(set))
 		for i, name := range set {
@@ -1919,45 +2225,6 @@ func (s *testSet) initInOrder() {
 	}
 }
 
-func partitionGoTests(testDuration func(string, string) time.Duration, builderName string, tests []string) (sets [][]string) {
-	var srcTests []string
-	var cmdTests []string
-	for _, name := range tests {
-		if strings.HasPrefix(name, "go_test:cmd/") {
-			cmdTests = append(cmdTests, name)
-		} else if strings.HasPrefix(name, "go_test:") {
-			srcTests = append(srcTests, name)
-		}
-	}
-	sort.Strings(srcTests)
-	sort.Strings(cmdTests)
-	goTests := append(srcTests, cmdTests...)
-
-	const sizeThres = 10 * time.Second
-
-	var curSet []string
-	var curDur time.Duration
-
-	flush := func() {
-		if len(curSet) > 0 {
-			sets = append(sets, curSet)
-			curSet = nil
-			curDur = 0
-		}
-	}
-	for _, testName := range goTests {
-		d := testDuration(builderName, testName)
-		if curDur+d > sizeThres {
-			flush() // no-op if empty
-		}
-		curSet = append(curSet, testName)
-		curDur += d
-	}
-
-	flush()
-	return
-}
-
 func (s *testSet) initBiggestFirst() {
 	items := append([]*testItem(nil), s.items...)
 	sort.Sort(sort.Reverse(byTestDuration(items)))
@@ -1968,7 +2235,7 @@ func (s *testSet) initBiggestFirst() {
 
 type testItem struct {
 	set      *testSet
-	name     distTestName
+	name     buildgo.DistTestName
 	duration time.Duration // optional approximate size
 
 	take chan token // buffered size 1: sending takes ownership of rest of fields:
@@ -2012,12 +2279,6 @@ func (ti *testItem) failf(format string, args ...interface{}) {
 	close(ti.done)
 }
 
-// distTestName is the name of a dist test as discovered from 'go tool dist test -list'.
-type distTestName struct {
-	Old string // Old is dist test name converted to Go 1.20 format, like "go_test:sort" or "reboot".
-	Raw string // Raw is unmodified name from dist, suitable as an argument back to 'go tool dist test'.
-}
-
 type byTestDuration []*testItem
 
 func (s byTestDuration) Len() int           { return len(s) }
@@ -2056,6 +2317,7 @@ func getRepoHead(repo string) (string, error) {
 // newBuildLogBlob creates a new object to record a public build log.
 // The objName should be a Google Cloud Storage object name.
 // When developing on localhost, the WriteCloser may be of a different type.
+// The object is subject to -build_log_retention.
 func newBuildLogBlob(objName string) (obj io.WriteCloser, url_ string) {
 	if *mode == "dev" {
 		// TODO(bradfitz): write to disk or something, or
@@ -2075,6 +2337,7 @@ func newBuildLogBlob(objName string) (obj io.WriteCloser, url_ string) {
 
 	wr := pool.NewGCEConfiguration().StorageClient().Bucket(bucket).Object(objName).NewWriter(context.Background())
 	wr.ContentType = "text/plain; charset=utf-8"
+	setRetention(wr, "build-log")
 
 	return wr, fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, objName)
 }
@@ -2118,7 +2381,7 @@ func importPathOfRepo(repo string) string {
 func slowBotsFromComments(work *apipb.GerritTryWorkItem) (builders []*dashboard.BuildConfig, invalidTryTerms []string) {
 	tryTerms := latestTryTerms(work)
 	invalidTryTerms = slices.Clone(tryTerms)
-	for _, bc := range dashboard.Builders {
+	for _, bc := range dashboard.ActiveBuilders() {
 		for _, term := range tryTerms {
 			if bc.MatchesSlowBotTerm(term) {
 				invalidTryTerms = slices.DeleteFunc(invalidTryTerms, func(e string) bool {
@@ -2233,10 +2496,11 @@ func activePostSubmitBuilds() []types.ActivePostSubmitBuild {
 			commit = st.Rev
 		}
 		ret = append(ret, types.ActivePostSubmitBuild{
-			StatusURL: logsURL,
-			Builder:   st.Name,
-			Commit:    commit,
-			GoCommit:  goCommit,
+			StatusURL:     logsURL,
+			Builder:       st.Name,
+			Commit:        commit,
+			GoCommit:      goCommit,
+			ContainerHost: st.containerHost(),
 		})
 	}
 	return ret
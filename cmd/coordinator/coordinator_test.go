@@ -24,50 +24,6 @@ import (
 	"golang.org/x/build/maintner/maintnerd/apipb"
 )
 
-type Seconds float64
-
-func (s Seconds) Duration() time.Duration {
-	return time.Duration(float64(s) * float64(time.Second))
-}
-
-var fixedTestDuration = map[string]Seconds{
-	"go_test:a": 1,
-	"go_test:b": 1.5,
-	"go_test:c": 2,
-	"go_test:d": 2.50,
-	"go_test:e": 3,
-	"go_test:f": 3.5,
-	"go_test:g": 4,
-	"go_test:h": 4.5,
-	"go_test:i": 5,
-	"go_test:j": 5.5,
-	"go_test:k": 6.5,
-}
-
-func TestPartitionGoTests(t *testing.T) {
-	var in []string
-	for name := range fixedTestDuration {
-		in = append(in, name)
-	}
-	testDuration := func(builder, testName string) time.Duration {
-		if s, ok := fixedTestDuration[testName]; ok {
-			return s.Duration()
-		}
-		return 3 * time.Second
-	}
-	sets := partitionGoTests(testDuration, "", in)
-	want := [][]string{
-		{"go_test:a", "go_test:b", "go_test:c", "go_test:d", "go_test:e"},
-		{"go_test:f", "go_test:g"},
-		{"go_test:h", "go_test:i"},
-		{"go_test:j"},
-		{"go_test:k"},
-	}
-	if !reflect.DeepEqual(sets, want) {
-		t.Errorf(" got: %v\nwant: %v", sets, want)
-	}
-}
-
 func TestTryStatusJSON(t *testing.T) {
 	testCases := []struct {
 		desc   string
@@ -112,11 +68,12 @@ func TestTryStatusJSON(t *testing.T) {
 					{
 						BuilderRev: buildgo.BuilderRev{Name: "macOS"},
 						startTime:  time.Time{}.Add(24 * time.Hour),
+						restarts:   2,
 					},
 				},
 			},
 			http.StatusOK,
-			`{"success":true,"payload":{"changeId":"Ifoo","commit":"deadbeef","builds":[{"name":"linux","startTime":"0001-01-02T00:00:00Z","done":true,"succeeded":true},{"name":"macOS","startTime":"0001-01-02T00:00:00Z","done":false,"succeeded":false}]}}` + "\n"},
+			`{"success":true,"payload":{"changeId":"Ifoo","commit":"deadbeef","builds":[{"name":"linux","startTime":"0001-01-02T00:00:00Z","done":true,"succeeded":true},{"name":"macOS","startTime":"0001-01-02T00:00:00Z","done":false,"succeeded":false,"restarts":2}]}}` + "\n"},
 	}
 
 	for _, tc := range testCases {
@@ -519,3 +476,74 @@ func TestInvalidSlowBots(t *testing.T) {
 		t.Errorf("mismatch:\n got: %q\nwant: %q\n", invalidSlowBots, wantInvalid)
 	}
 }
+
+func TestTryTimeoutMessage(t *testing.T) {
+	got := tryTimeoutMessage("TryBots", 3*time.Hour, 4, []string{"linux-arm64", "windows-amd64 (Go 1.21.x)"}, "Failed on linux-386: https://example.com/log\n")
+	want := `TryBots timed out after 3h0m0s; 2 of 4 builds didn't finish in time:
+* linux-arm64
+* windows-amd64 (Go 1.21.x)
+
+Builds that finished with failures:
+Failed on linux-386: https://example.com/log
+
+This is likely due to a shortage of builders rather than a problem with this change. Try again later.
+`
+	if got != want {
+		t.Errorf("tryTimeoutMessage = %q\nwant %q", got, want)
+	}
+
+	got = tryTimeoutMessage("SlowBots", time.Hour, 1, []string{"aix-ppc64"}, "")
+	if strings.Contains(got, "failures") {
+		t.Errorf("tryTimeoutMessage without failures mentions failures:\n%s", got)
+	}
+}
+
+func TestTryResultsSummary(t *testing.T) {
+	got := tryResultsSummary([]tryBuildResult{
+		{Name: "linux-386", LogURL: "https://example.com/log"},
+		{Name: "linux-amd64", Succeeded: true, LogURL: "https://example.com/log2"},
+		{Name: "windows-amd64 (Go 1.21.x)", Succeeded: true},
+		{Name: "aix-ppc64"},
+	})
+	want := `Failed builds:
+* linux-386 ([log](https://example.com/log))
+* aix-ppc64
+
+<details><summary>2 of 4 builds passed</summary>
+
+* linux-amd64
+* windows-amd64 (Go 1.21.x)
+
+</details>
+`
+	if got != want {
+		t.Errorf("tryResultsSummary = %q\nwant %q", got, want)
+	}
+
+	got = tryResultsSummary([]tryBuildResult{{Name: "linux-amd64", Succeeded: true}})
+	if strings.Contains(got, "Failed") {
+		t.Errorf("tryResultsSummary without failures mentions failures:\n%s", got)
+	}
+}
+
+func TestHelperAcquireSem(t *testing.T) {
+	defer func(old int) { *helperAcquire = old }(*helperAcquire)
+	defer func() { helperAcquireSems.m = nil }()
+
+	*helperAcquire = 0
+	if sem := helperAcquireSem("host-linux-amd64"); sem != nil {
+		t.Errorf("helperAcquireSem with no limit = %v; want nil", sem)
+	}
+
+	*helperAcquire = 2
+	amd64, arm64 := helperAcquireSem("host-linux-amd64"), helperAcquireSem("host-linux-arm64")
+	if cap(amd64) != 2 || cap(arm64) != 2 {
+		t.Errorf("helperAcquireSem capacities = %d, %d; want 2", cap(amd64), cap(arm64))
+	}
+	if amd64 == arm64 {
+		t.Errorf("helperAcquireSem returned the same semaphore for different host types")
+	}
+	if again := helperAcquireSem("host-linux-amd64"); again != amd64 {
+		t.Errorf("helperAcquireSem returned a different semaphore for the same host type")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/build/internal/buildgo"
+)
+
+// depSkipTTL is how long after it was last skipped that a build stops
+// being reported as blocked on a missing dependency. findWork retries
+// skipped post-submit builds on every pass, so builds that are still
+// blocked are skipped again well within it.
+const depSkipTTL = time.Hour
+
+// maxDepSkips bounds the number of blocked builds that are tracked.
+const maxDepSkips = 1000
+
+var (
+	depSkipMu sync.Mutex
+	depSkips  = map[buildgo.BuilderRev]*depSkip{}
+)
+
+// depSkip is a build that was skipped because its commit lacks one of
+// the builder's GoDeps.
+type depSkip struct {
+	Builder string    `json:"builder"`
+	Rev     string    `json:"rev"`               // Go commit
+	SubName string    `json:"subName,omitempty"` // subrepo name, if any
+	SubRev  string    `json:"subRev,omitempty"`  // subrepo commit, if any
+	Dep     string    `json:"dep"`               // missing ancestor commit
+	First   time.Time `json:"first"`             // first time the build was skipped
+	Last    time.Time `json:"last"`              // most recent time the build was skipped
+	Count   int       `json:"count"`             // number of times the build was skipped
+}
+
+// noteDepSkip records that the build br was skipped at now because
+// its commit lacks the ancestor dep.
+func noteDepSkip(br buildgo.BuilderRev, dep string, now time.Time) {
+	depSkipMu.Lock()
+	defer depSkipMu.Unlock()
+	pruneDepSkipsLocked(now)
+	ds, ok := depSkips[br]
+	if !ok {
+		if len(depSkips) >= maxDepSkips {
+			evictOldestDepSkipLocked()
+		}
+		ds = &depSkip{Builder: br.Name, Rev: br.Rev, SubName: br.SubName, SubRev: br.SubRev, First: now}
+		depSkips[br] = ds
+	}
+	ds.Dep = dep
+	ds.Last = now
+	ds.Count++
+}
+
+// pruneDepSkipsLocked forgets builds that haven't been skipped within
+// depSkipTTL of now. depSkipMu must be held.
+func pruneDepSkipsLocked(now time.Time) {
+	for br, ds := range depSkips {
+		if now.Sub(ds.Last) > depSkipTTL {
+			delete(depSkips, br)
+		}
+	}
+}
+
+// evictOldestDepSkipLocked forgets the build that was least recently
+// skipped. depSkipMu must be held.
+func evictOldestDepSkipLocked() {
+	var oldest buildgo.BuilderRev
+	var oldestTime time.Time
+	for br, ds := range depSkips {
+		if oldestTime.IsZero() || ds.Last.Before(oldestTime) {
+			oldest, oldestTime = br, ds.Last
+		}
+	}
+	delete(depSkips, oldest)
+}
+
+// blockedOnDeps returns the builds that have been skipped within
+// depSkipTTL of now because of a missing dependency, sorted by builder
+// and then commit.
+func blockedOnDeps(now time.Time) []depSkip {
+	depSkipMu.Lock()
+	defer depSkipMu.Unlock()
+	pruneDepSkipsLocked(now)
+	ret := make([]depSkip, 0, len(depSkips))
+	for _, ds := range depSkips {
+		ret = append(ret, *ds)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Builder != b.Builder {
+			return a.Builder < b.Builder
+		}
+		if a.Rev != b.Rev {
+			return a.Rev < b.Rev
+		}
+		if a.SubName != b.SubName {
+			return a.SubName < b.SubName
+		}
+		return a.SubRev < b.SubRev
+	})
+	return ret
+}
+
+// handleBlockedOnDepsJSON serves /status/blocked-on-deps.json, the
+// builds that are being skipped because their commit predates one of
+// the builder's required dependencies. It helps tell a builder that's
+// idle apart from one that's correctly skipping old commits.
+func handleBlockedOnDepsJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(blockedOnDeps(time.Now()))
+}
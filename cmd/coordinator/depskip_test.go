@@ -0,0 +1,43 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/buildgo"
+)
+
+func TestBlockedOnDeps(t *testing.T) {
+	depSkipMu.Lock()
+	depSkips = map[buildgo.BuilderRev]*depSkip{}
+	depSkipMu.Unlock()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := buildgo.BuilderRev{Name: "linux-amd64", Rev: "bbbb"}
+	older := buildgo.BuilderRev{Name: "linux-amd64", Rev: "aaaa", SubName: "net", SubRev: "cccc"}
+	noteDepSkip(newer, "dddd", start)
+	noteDepSkip(newer, "dddd", start.Add(time.Minute))
+	noteDepSkip(older, "dddd", start.Add(time.Minute))
+
+	got := blockedOnDeps(start.Add(2 * time.Minute))
+	if len(got) != 2 {
+		t.Fatalf("blockedOnDeps = %+v, want 2 builds", got)
+	}
+	if got[0].Rev != "aaaa" || got[0].SubName != "net" || got[0].Count != 1 {
+		t.Errorf("first blocked build = %+v, want the net build of aaaa, skipped once", got[0])
+	}
+	if got[1].Rev != "bbbb" || got[1].Count != 2 || !got[1].First.Equal(start) || !got[1].Last.Equal(start.Add(time.Minute)) {
+		t.Errorf("second blocked build = %+v, want the build of bbbb, skipped twice", got[1])
+	}
+
+	// Builds that haven't been skipped recently are no longer reported.
+	if got := blockedOnDeps(start.Add(time.Minute + depSkipTTL + time.Second)); len(got) != 0 {
+		t.Errorf("blockedOnDeps after depSkipTTL = %+v, want none", got)
+	}
+}
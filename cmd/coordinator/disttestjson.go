@@ -0,0 +1,129 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/types"
+)
+
+var distTestJSON = flag.Bool("dist_test_json", false, "Whether to run dist tests with -json on Go 1.21 and newer, using the structured results for accurate per-test durations. The human-readable output is reconstructed from the JSON, and plain text is still handled if the output isn't JSON.")
+
+// useDistTestJSON reports whether the dist tests in s should be run
+// with -json. That's only done for Go 1.21 and newer, whose dist test
+// names differ from the old ones, as older versions of dist don't
+// support the flag.
+func (s *testSet) useDistTestJSON() bool {
+	if !*distTestJSON {
+		return false
+	}
+	for _, ti := range s.items {
+		if ti.name.Raw != ti.name.Old {
+			return true
+		}
+	}
+	return false
+}
+
+// A testEvent is an event in the output of "go tool dist test -json",
+// as produced by cmd/test2json.
+type testEvent struct {
+	Time    time.Time
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64 // seconds
+	Output  string
+}
+
+// A distTestResult is the result of one dist test, as reported by
+// the package-level events in its JSON output.
+type distTestResult struct {
+	Name    string // dist test name; the Package of its events
+	Action  string // "pass", "fail", or "skip"
+	End     time.Time
+	Elapsed time.Duration
+}
+
+// parseDistTestJSON parses the output of "go tool dist test -json".
+// It returns the human-readable output, reconstructed from the
+// output events, and the result of each dist test that completed.
+// Lines that aren't JSON test events, such as those written by dist
+// itself before the tests start, are passed through as is.
+func parseDistTestJSON(out []byte) (text []byte, results []distTestResult) {
+	var buf bytes.Buffer
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(nil, 16<<20)
+	for sc.Scan() {
+		line := sc.Bytes()
+		var ev testEvent
+		if len(line) == 0 || line[0] != '{' || json.Unmarshal(line, &ev) != nil || ev.Action == "" {
+			buf.Write(line)
+			buf.WriteByte('\n')
+			continue
+		}
+		switch ev.Action {
+		case "output":
+			buf.WriteString(ev.Output)
+		case "pass", "fail", "skip":
+			if ev.Test == "" && ev.Package != "" {
+				results = append(results, distTestResult{
+					Name:    ev.Package,
+					Action:  ev.Action,
+					End:     ev.Time,
+					Elapsed: time.Duration(ev.Elapsed * float64(time.Second)),
+				})
+			}
+		}
+	}
+	if sc.Err() != nil {
+		// A line too long to be a test event; fall back to the
+		// unparsed output.
+		return out, nil
+	}
+	return buf.Bytes(), results
+}
+
+// putTestSpanRecords records a "run_test:" span for each test in tis
+// that passed according to results, with the duration reported by
+// dist. It's used when several tests ran in a single dist invocation,
+// so that the test stats used for sharding reflect the time each test
+// took, rather than only that of the whole group.
+func (st *buildStatus) putTestSpanRecords(tis []*testItem, results []distTestResult, detail string) {
+	byName := make(map[string]distTestResult)
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+	for _, ti := range tis {
+		r, ok := byName[ti.name.Raw]
+		if !ok || r.Action != "pass" || r.End.IsZero() {
+			continue
+		}
+		pool.CoordinatorProcess().PutSpanRecord(&types.SpanRecord{
+			BuildID: st.buildID,
+			IsTry:   st.isTry(),
+			GoRev:   st.Rev,
+			Rev:     st.SubRevOrGoRev(),
+			Repo:    st.RepoOrGo(),
+			Builder: st.Name,
+			OS:      st.conf.GOOS(),
+			Arch:    st.conf.GOARCH(),
+
+			Event:     "run_test:" + ti.name.Old,
+			Detail:    detail,
+			StartTime: r.End.Add(-r.Elapsed),
+			EndTime:   r.End,
+			Seconds:   r.Elapsed.Seconds(),
+		})
+	}
+}
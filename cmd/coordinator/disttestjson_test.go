@@ -0,0 +1,56 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDistTestJSON(t *testing.T) {
+	out := `Building Go cmd/dist using /usr/local/go.
+{"Time":"2025-01-02T03:04:05Z","Action":"start","Package":"fmt"}
+{"Time":"2025-01-02T03:04:05Z","Action":"output","Package":"fmt","Output":"ok  \tfmt\t1.5s\n"}
+{"Time":"2025-01-02T03:04:07Z","Action":"pass","Package":"fmt","Elapsed":1.5}
+{"Time":"2025-01-02T03:04:07Z","Action":"run","Package":"runtime:cpu124","Test":"TestGC"}
+{"Time":"2025-01-02T03:04:08Z","Action":"output","Package":"runtime:cpu124","Test":"TestGC","Output":"--- FAIL: TestGC (0.10s)\n"}
+{"Time":"2025-01-02T03:04:08Z","Action":"fail","Package":"runtime:cpu124","Test":"TestGC","Elapsed":0.1}
+{"Time":"2025-01-02T03:04:09Z","Action":"fail","Package":"runtime:cpu124","Elapsed":2}
+FAILED
+`
+	text, results := parseDistTestJSON([]byte(out))
+	wantText := `Building Go cmd/dist using /usr/local/go.
+ok  	fmt	1.5s
+--- FAIL: TestGC (0.10s)
+FAILED
+`
+	if diff := cmp.Diff(wantText, string(text)); diff != "" {
+		t.Errorf("text mismatch (-want +got):\n%s", diff)
+	}
+	wantResults := []distTestResult{
+		{Name: "fmt", Action: "pass", End: time.Date(2025, 1, 2, 3, 4, 7, 0, time.UTC), Elapsed: 1500 * time.Millisecond},
+		{Name: "runtime:cpu124", Action: "fail", End: time.Date(2025, 1, 2, 3, 4, 9, 0, time.UTC), Elapsed: 2 * time.Second},
+	}
+	if diff := cmp.Diff(wantResults, results); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseDistTestJSONText(t *testing.T) {
+	// Output that isn't JSON, such as from a dist that doesn't
+	// support -json, is passed through.
+	out := "##### Testing packages.\nok  \tfmt\t1.5s\n"
+	text, results := parseDistTestJSON([]byte(out))
+	if string(text) != out {
+		t.Errorf("text = %q; want %q", text, out)
+	}
+	if len(results) != 0 {
+		t.Errorf("results = %v; want none", results)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	_ "embed"
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+
+	"golang.org/x/build/dashboard"
+)
+
+//go:embed templates/drain-admin.html
+var drainAdminTemplateStr string
+
+var drainAdminTemplate = template.Must(baseTmpl.New("drain-admin.html").Parse(drainAdminTemplateStr))
+
+// drainAdminHostType is a row of the host type drain admin page.
+type drainAdminHostType struct {
+	HostType string
+	Waiting  int
+	Draining bool
+}
+
+// handleDrainAdmin serves a page listing the host types, with controls
+// to drain them before reducing their capacity and to undrain them.
+// A POST with the form values "hostType" and "action" ("drain" or
+// "undrain") changes a host type.
+//
+// It must only be served to operators.
+func handleDrainAdmin(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		hostType := r.FormValue("hostType")
+		if _, ok := dashboard.Hosts[hostType]; !ok {
+			http.Error(w, "unknown host type", http.StatusBadRequest)
+			return
+		}
+		switch action := r.FormValue("action"); action {
+		case "drain", "undrain":
+			log.Printf("host type admin: %s %q (by %v)", action, hostType, r.Context().Value("email"))
+			sched.SetDraining(hostType, action == "drain")
+		default:
+			http.Error(w, `action must be "drain" or "undrain"`, http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+		return
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	waiting := make(map[string]int)
+	for _, hst := range sched.State().HostTypes {
+		waiting[hst.HostType] = hst.Total.Count
+	}
+	var data struct{ HostTypes []drainAdminHostType }
+	for hostType := range dashboard.Hosts {
+		data.HostTypes = append(data.HostTypes, drainAdminHostType{
+			HostType: hostType,
+			Waiting:  waiting[hostType],
+			Draining: sched.IsDraining(hostType),
+		})
+	}
+	sort.Slice(data.HostTypes, func(i, j int) bool { return data.HostTypes[i].HostType < data.HostTypes[j].HostType })
+	if err := drainAdminTemplate.Execute(w, data); err != nil {
+		log.Printf("handleDrainAdmin: %v", err)
+	}
+}
@@ -75,7 +75,7 @@ func (d *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 
 	dd := &data{
-		Builders: d.getBuilders(dashboard.Builders, luci),
+		Builders: d.getBuilders(dashboard.ActiveBuilders(), luci),
 		Commits:  d.commits(req.Context(), luci),
 		Package:  dashPackage{Name: "Go"},
 	}
@@ -327,7 +327,7 @@ func isUntested(builder, repo, branch, goBranch string) bool {
 	if goBranch == "tip" {
 		goBranch = "master"
 	}
-	bc, ok := dashboard.Builders[builder]
+	bc, ok := dashboard.ActiveBuilders()[builder]
 	if !ok {
 		// Unknown builder, so not tested.
 		return true
@@ -338,7 +338,7 @@ func isUntested(builder, repo, branch, goBranch string) bool {
 // knownIssue returns a known issue for the named builder,
 // or zero if there isn't a known issue.
 func knownIssue(builder string) int {
-	bc, ok := dashboard.Builders[builder]
+	bc, ok := dashboard.ActiveBuilders()[builder]
 	if !ok {
 		// Unknown builder.
 		return 0
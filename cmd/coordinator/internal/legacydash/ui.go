@@ -783,7 +783,7 @@ func buildersOfCommits(commits []*CommitInfo) map[string]bool {
 // addBuilders adds builders to the provided map that should be active for
 // the named Gerrit project & branch. (Issue 19930)
 func addBuilders(builders map[string]bool, gerritProj, branch string) {
-	for name, bc := range dashboard.Builders {
+	for name, bc := range dashboard.ActiveBuilders() {
 		if bc.BuildsRepoPostSubmit(gerritProj, branch, branch) {
 			builders[name] = true
 		}
@@ -0,0 +1,162 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/types"
+	"google.golang.org/api/iterator"
+)
+
+// lastGreen tracks, for each builder, repo and branch, the most recent
+// commit whose post-submit build passed. At startup, it's seeded from
+// the build records in datastore by seedLastGreen.
+var lastGreen struct {
+	mu sync.Mutex
+	m  map[lastGreenKey]types.LastGreenBuild
+}
+
+type lastGreenKey struct {
+	builder, repo, branch string
+}
+
+// noteLastGreen records that the post-submit build br of the commit
+// described by cd passed. It's ignored if a newer commit is already
+// known to have passed on the same builder.
+func noteLastGreen(br buildgo.BuilderRev, cd commitDetail, now time.Time) {
+	lg := types.LastGreenBuild{
+		Builder:    br.Name,
+		Repo:       "go",
+		Branch:     cd.RevBranch,
+		Commit:     br.Rev,
+		CommitTime: cd.RevCommitTime,
+		Recorded:   now,
+	}
+	if br.IsSubrepo() {
+		lg.Repo = br.SubName
+		lg.Branch = cd.SubRevBranch
+		lg.Commit = br.SubRev
+		lg.GoCommit = br.Rev
+		lg.CommitTime = cd.SubRevCommitTime
+	}
+	addLastGreen(lg)
+}
+
+// addLastGreen records the last green build lg, unless a newer commit
+// is already known to have passed on the same builder.
+func addLastGreen(lg types.LastGreenBuild) {
+	key := lastGreenKey{lg.Builder, lg.Repo, lg.Branch}
+
+	lastGreen.mu.Lock()
+	defer lastGreen.mu.Unlock()
+	if old, ok := lastGreen.m[key]; ok && old.CommitTime.After(lg.CommitTime) {
+		// Builds don't necessarily finish in commit order.
+		return
+	}
+	if lastGreen.m == nil {
+		lastGreen.m = make(map[lastGreenKey]types.LastGreenBuild)
+	}
+	lastGreen.m[key] = lg
+}
+
+// lastGreenSeedWindow is how far back seedLastGreen looks for build records.
+const lastGreenSeedWindow = 30 * 24 * time.Hour
+
+// seedLastGreen loads the last green builds from the records in datastore
+// of the builds that finished in the last lastGreenSeedWindow, so that
+// they're still known after the coordinator restarts.
+func seedLastGreen() {
+	ds := pool.NewGCEConfiguration().DSClient()
+	if ds == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	q := datastore.NewQuery("Build").Filter("EndTime >", time.Now().Add(-lastGreenSeedWindow))
+	it := ds.Run(ctx, q)
+	n := 0
+	for {
+		var br types.BuildRecord
+		_, err := it.Next(&br)
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			log.Printf("seeding last green builds: %v", err)
+			return
+		}
+		if lg, ok := lastGreenFromRecord(&br); ok {
+			addLastGreen(lg)
+			n++
+		}
+	}
+	log.Printf("seeded last green builds from %d build records", n)
+}
+
+// lastGreenFromRecord returns the last green build described by br,
+// if it's the record of a post-submit build that passed.
+// Records written before they had a branch are skipped.
+func lastGreenFromRecord(br *types.BuildRecord) (types.LastGreenBuild, bool) {
+	if br.Result != "ok" || br.IsTry || br.IsSlowBot || br.Branch == "" {
+		return types.LastGreenBuild{}, false
+	}
+	lg := types.LastGreenBuild{
+		Builder:    br.Builder,
+		Repo:       br.Repo,
+		Branch:     br.Branch,
+		Commit:     br.Rev,
+		CommitTime: br.CommitTime,
+		Recorded:   br.EndTime,
+	}
+	if br.Repo != "go" {
+		lg.GoCommit = br.GoRev
+	}
+	return lg, true
+}
+
+// lastGreenBuilds returns the last green builds, sorted by builder,
+// repo and branch. If builder is non-empty, only that builder's
+// builds are returned.
+func lastGreenBuilds(builder string) []types.LastGreenBuild {
+	lastGreen.mu.Lock()
+	defer lastGreen.mu.Unlock()
+	ret := []types.LastGreenBuild{} // non-nil, so it's encoded as [] rather than null
+	for k, lg := range lastGreen.m {
+		if builder == "" || k.builder == builder {
+			ret = append(ret, lg)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Builder != b.Builder {
+			return a.Builder < b.Builder
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Branch < b.Branch
+	})
+	return ret
+}
+
+// handleLastGreenJSON serves the most recent passing post-submit
+// commit of each builder, optionally limited to the builder named by
+// the "builder" query parameter.
+func handleLastGreenJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lastGreenBuilds(r.FormValue("builder")))
+}
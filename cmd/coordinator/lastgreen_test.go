@@ -0,0 +1,90 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/types"
+)
+
+func TestLastGreen(t *testing.T) {
+	defer func() { lastGreen.m = nil }()
+	lastGreen.m = nil
+
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := t0.Add(24 * time.Hour)
+	noteLastGreen(buildgo.BuilderRev{Name: "linux-amd64", Rev: "aaa"}, commitDetail{RevBranch: "master", RevCommitTime: t0.Add(2 * time.Hour)}, now)
+	// An older commit finishing later must not replace the newer one.
+	noteLastGreen(buildgo.BuilderRev{Name: "linux-amd64", Rev: "bbb"}, commitDetail{RevBranch: "master", RevCommitTime: t0.Add(time.Hour)}, now)
+	noteLastGreen(buildgo.BuilderRev{Name: "linux-amd64", Rev: "ccc"}, commitDetail{RevBranch: "release-branch.go1.24", RevCommitTime: t0}, now)
+	noteLastGreen(buildgo.BuilderRev{Name: "linux-amd64", Rev: "ddd", SubName: "net", SubRev: "eee"}, commitDetail{RevBranch: "master", SubRevBranch: "master", SubRevCommitTime: t0}, now)
+	noteLastGreen(buildgo.BuilderRev{Name: "darwin-amd64", Rev: "fff"}, commitDetail{RevBranch: "master", RevCommitTime: t0}, now)
+
+	var got []string
+	for _, lg := range lastGreenBuilds("linux-amd64") {
+		got = append(got, lg.Repo+"@"+lg.Branch+"="+lg.Commit+"/"+lg.GoCommit)
+	}
+	want := []string{"go@master=aaa/", "go@release-branch.go1.24=ccc/", "net@master=eee/ddd"}
+	if len(got) != len(want) {
+		t.Fatalf("lastGreenBuilds(linux-amd64) = %q, want %q", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("lastGreenBuilds(linux-amd64)[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if all := lastGreenBuilds(""); len(all) != 4 {
+		t.Errorf("lastGreenBuilds(\"\") returned %d builds, want 4", len(all))
+	}
+	if none := lastGreenBuilds("windows-amd64"); none == nil || len(none) != 0 {
+		t.Errorf("lastGreenBuilds(windows-amd64) = %v, want empty non-nil slice", none)
+	}
+}
+
+func TestLastGreenFromRecord(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ok := types.BuildRecord{
+		Builder:    "linux-amd64",
+		Repo:       "net",
+		GoRev:      "aaa",
+		Rev:        "bbb",
+		Branch:     "master",
+		CommitTime: t0,
+		EndTime:    t0.Add(time.Hour),
+		Result:     "ok",
+	}
+	want := types.LastGreenBuild{
+		Builder:    "linux-amd64",
+		Repo:       "net",
+		Branch:     "master",
+		Commit:     "bbb",
+		GoCommit:   "aaa",
+		CommitTime: t0,
+		Recorded:   t0.Add(time.Hour),
+	}
+	if got, found := lastGreenFromRecord(&ok); !found || got != want {
+		t.Errorf("lastGreenFromRecord(%+v) = %+v, %v; want %+v, true", ok, got, found, want)
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		modify func(*types.BuildRecord)
+	}{
+		{"failed", func(br *types.BuildRecord) { br.Result = "fail" }},
+		{"trybot", func(br *types.BuildRecord) { br.IsTry = true }},
+		{"no branch", func(br *types.BuildRecord) { br.Branch = "" }},
+	} {
+		br := ok
+		tc.modify(&br)
+		if _, found := lastGreenFromRecord(&br); found {
+			t.Errorf("%s: lastGreenFromRecord found a last green build, want none", tc.desc)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/build/internal/coordinator/pool"
+	"google.golang.org/api/iterator"
+)
+
+var buildLogRetention = flag.Duration("build_log_retention", 0, "If non-zero, how long build logs and crash artifacts are kept in the log and crash artifact buckets, rounded up to whole days. A lifecycle rule on the buckets deletes older ones, except those of revisions with a build that's running or shown on the status page. Zero keeps them forever.")
+
+// retentionKey is the custom metadata key that marks the objects in the
+// log and crash artifact buckets that are subject to -build_log_retention.
+// Its value is the kind of object.
+const retentionKey = "go-build-retention"
+
+// protectInterval is how often the custom times of the objects of
+// current builds are moved forward, to keep them from expiring.
+const protectInterval = 12 * time.Hour
+
+// setRetention makes the object written by w, of the given kind such as
+// "build-log", subject to -build_log_retention. The buckets' lifecycle
+// rule deletes objects some days after their custom time. Objects
+// without one, including logs written before it was introduced, are
+// never deleted.
+func setRetention(w *storage.Writer, kind string) {
+	w.Metadata = map[string]string{retentionKey: kind}
+	w.CustomTime = time.Now()
+}
+
+// manageBuildLogRetention sets the lifecycle rules of the log and crash
+// artifact buckets for -build_log_retention, and then keeps the objects
+// of current builds from expiring. It never returns.
+func manageBuildLogRetention() {
+	sc := pool.NewGCEConfiguration().StorageClient()
+	if sc == nil {
+		log.Printf("not managing build log retention: no storage client")
+		return
+	}
+	env := pool.NewGCEConfiguration().BuildEnv()
+	buckets := []string{env.LogBucket}
+	if env.CrashArtifactBucket != "" {
+		buckets = append(buckets, env.CrashArtifactBucket)
+	}
+	for _, b := range buckets {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		err := setRetentionRule(ctx, sc.Bucket(b), *buildLogRetention)
+		cancel()
+		if err != nil {
+			log.Printf("setting lifecycle rule of %s: %v", b, err)
+		}
+	}
+	if *buildLogRetention <= 0 {
+		return
+	}
+	for {
+		for _, b := range buckets {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			n, err := protectBuildLogs(ctx, sc.Bucket(b), time.Now(), protectedLogDirs())
+			cancel()
+			if err != nil {
+				log.Printf("protecting build logs in %s: %v", b, err)
+			}
+			log.Printf("kept %d build logs of current builds in %s from expiring", n, b)
+		}
+		time.Sleep(protectInterval)
+	}
+}
+
+// setRetentionRule sets the lifecycle rule of bucket that deletes the
+// objects subject to -build_log_retention once they're older than
+// retention, or removes it if retention is zero. The bucket's other
+// lifecycle rules are left alone.
+func setRetentionRule(ctx context.Context, bucket *storage.BucketHandle, retention time.Duration) error {
+	attrs, err := bucket.Attrs(ctx)
+	if err != nil {
+		return err
+	}
+	old := attrs.Lifecycle.Rules
+	rules := retentionRules(old, retention)
+	if len(rules) == 0 && len(old) == 0 || reflect.DeepEqual(rules, old) {
+		return nil
+	}
+	_, err = bucket.If(storage.BucketConditions{MetagenerationMatch: attrs.MetaGeneration}).
+		Update(ctx, storage.BucketAttrsToUpdate{Lifecycle: &storage.Lifecycle{Rules: rules}})
+	return err
+}
+
+// retentionRules returns rules with the rule for the given retention
+// in place of any existing one, or with none if retention is zero.
+func retentionRules(rules []storage.LifecycleRule, retention time.Duration) []storage.LifecycleRule {
+	var out []storage.LifecycleRule
+	for _, r := range rules {
+		if r.Action.Type != storage.DeleteAction || r.Condition.DaysSinceCustomTime == 0 {
+			out = append(out, r)
+		}
+	}
+	if retention > 0 {
+		const day = 24 * time.Hour
+		out = append(out, storage.LifecycleRule{
+			Action:    storage.LifecycleAction{Type: storage.DeleteAction},
+			Condition: storage.LifecycleCondition{DaysSinceCustomTime: int64((retention + day - 1) / day)},
+		})
+	}
+	return out
+}
+
+// protectBuildLogs moves the custom time of the objects in bucket that
+// are in the protected directories forward to now, so that the lifecycle
+// rule doesn't delete them. It returns the number of objects updated.
+func protectBuildLogs(ctx context.Context, bucket *storage.BucketHandle, now time.Time, protected map[string]bool) (int, error) {
+	var updated int
+	for dir := range protected {
+		it := bucket.Objects(ctx, &storage.Query{Prefix: dir + "/"})
+		for {
+			attrs, err := it.Next()
+			if errors.Is(err, iterator.Done) {
+				break
+			} else if err != nil {
+				return updated, err
+			}
+			if !needsProtection(attrs, now) {
+				continue
+			}
+			_, err = bucket.Object(attrs.Name).Update(ctx, storage.ObjectAttrsToUpdate{CustomTime: now})
+			if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+				return updated, err
+			}
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// needsProtection reports whether protectBuildLogs should move the custom
+// time of the object described by attrs forward: whether it's subject to
+// -build_log_retention and wasn't protected recently.
+func needsProtection(attrs *storage.ObjectAttrs, now time.Time) bool {
+	if attrs.Metadata[retentionKey] == "" || attrs.CustomTime.IsZero() {
+		return false
+	}
+	return now.Sub(attrs.CustomTime) >= protectInterval
+}
+
+// protectedLogDirs returns the directories of the log bucket that hold
+// the logs of builds that are running or were shown on the status page
+// recently. Logs are stored under the first 8 characters of the Go
+// revision they're for, as are the logs of the build's other attempts
+// and its crash artifacts, which its log may refer to.
+func protectedLogDirs() map[string]bool {
+	var builds []*buildStatus
+	statusMu.Lock()
+	for _, bs := range status {
+		builds = append(builds, bs)
+	}
+	builds = append(builds, statusDone...)
+	var sets []*trySet
+	for _, ts := range tries {
+		sets = append(sets, ts)
+	}
+	statusMu.Unlock()
+	for _, ts := range sets {
+		ts.mu.Lock()
+		builds = append(builds, ts.builds...)
+		ts.mu.Unlock()
+	}
+
+	dirs := make(map[string]bool)
+	for _, bs := range builds {
+		if len(bs.Rev) >= 8 {
+			dirs[bs.Rev[:8]] = true
+		}
+	}
+	return dirs
+}
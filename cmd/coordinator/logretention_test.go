@@ -0,0 +1,93 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/build/internal/buildgo"
+)
+
+func TestRetentionRules(t *testing.T) {
+	archive := storage.LifecycleRule{
+		Action:    storage.LifecycleAction{Type: storage.SetStorageClassAction, StorageClass: "ARCHIVE"},
+		Condition: storage.LifecycleCondition{AgeInDays: 365},
+	}
+	retention := func(days int64) storage.LifecycleRule {
+		return storage.LifecycleRule{
+			Action:    storage.LifecycleAction{Type: storage.DeleteAction},
+			Condition: storage.LifecycleCondition{DaysSinceCustomTime: days},
+		}
+	}
+	for _, tc := range []struct {
+		desc      string
+		rules     []storage.LifecycleRule
+		retention time.Duration
+		want      []storage.LifecycleRule
+	}{
+		{"add", []storage.LifecycleRule{archive}, 30 * 24 * time.Hour, []storage.LifecycleRule{archive, retention(30)}},
+		{"round up", nil, 36 * time.Hour, []storage.LifecycleRule{retention(2)}},
+		{"replace", []storage.LifecycleRule{retention(7), archive}, 24 * time.Hour, []storage.LifecycleRule{archive, retention(1)}},
+		{"remove", []storage.LifecycleRule{archive, retention(7)}, 0, []storage.LifecycleRule{archive}},
+		{"none", nil, 0, nil},
+	} {
+		if got := retentionRules(tc.rules, tc.retention); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: retentionRules(%v, %v) = %v; want %v", tc.desc, tc.rules, tc.retention, got, tc.want)
+		}
+	}
+}
+
+func TestNeedsProtection(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	md := map[string]string{retentionKey: "build-log"}
+	for _, tc := range []struct {
+		desc       string
+		metadata   map[string]string
+		customTime time.Time
+		want       bool
+	}{
+		{"old", md, now.Add(-protectInterval), true},
+		{"recently protected", md, now.Add(-time.Hour), false},
+		{"no custom time", md, time.Time{}, false},
+		{"not subject to retention", nil, now.Add(-protectInterval), false},
+	} {
+		attrs := &storage.ObjectAttrs{Name: "abcdef01/linux-amd64_01234567.log", Metadata: tc.metadata, CustomTime: tc.customTime}
+		if got := needsProtection(attrs, now); got != tc.want {
+			t.Errorf("%s: needsProtection = %v; want %v", tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestProtectedLogDirs(t *testing.T) {
+	running := &buildStatus{BuilderRev: buildgo.BuilderRev{Name: "linux-amd64", Rev: "0123456789abcdef"}}
+	done := &buildStatus{BuilderRev: buildgo.BuilderRev{Name: "linux-amd64", Rev: "fedcba9876543210"}}
+	try := &buildStatus{BuilderRev: buildgo.BuilderRev{Name: "linux-386", Rev: "aaaaaaaabbbbbbbb"}}
+	tk := tryKey{Commit: "aaaaaaaabbbbbbbb"}
+
+	statusMu.Lock()
+	status[running.BuilderRev] = running
+	statusDone = append(statusDone, done)
+	tries[tk] = &trySet{trySetState: trySetState{builds: []*buildStatus{try}}}
+	statusMu.Unlock()
+	defer func() {
+		statusMu.Lock()
+		delete(status, running.BuilderRev)
+		statusDone = statusDone[:len(statusDone)-1]
+		delete(tries, tk)
+		statusMu.Unlock()
+	}()
+
+	got := protectedLogDirs()
+	for _, dir := range []string{"01234567", "fedcba98", "aaaaaaaa"} {
+		if !got[dir] {
+			t.Errorf("protectedLogDirs() = %v; want it to include %q", got, dir)
+		}
+	}
+}
@@ -27,6 +27,7 @@ var (
 	mGomoteRDPCount     = stats.Int64("go-build/coordinator/gomote_rdp_count", "counter for gomote RDP invocations", stats.UnitDimensionless)
 	mGomoteSSHCount     = stats.Int64("go-build/coordinator/gomote_ssh_count", "counter for gomote SSH invocations", stats.UnitDimensionless)
 	mReverseBuildlets   = stats.Int64("go-build/coordinator/reverse_buildlets_count", "number of reverse buildlets", stats.UnitDimensionless)
+	mBuildRestarts      = stats.Int64("go-build/coordinator/build_restarts_count", "counter for builds restarted after dying before completion", stats.UnitDimensionless)
 )
 
 // views should contain all measurements. All *view.View added to this
@@ -65,6 +66,13 @@ var views = []*view.View{
 		Measure:     mGomoteRDPCount,
 		Aggregation: view.Count(),
 	},
+	{
+		Name:        "go-build/coordinator/build_restarts_count",
+		Description: "Count of builds restarted after dying before completion",
+		Measure:     mBuildRestarts,
+		TagKeys:     []tag.Key{kBuilderType},
+		Aggregation: view.Count(),
+	},
 }
 
 // reportReverseCountMetrics gathers and reports
@@ -113,3 +121,13 @@ func recordSSHPublicKeyAuthHandler(fn ssh.PublicKeyHandler) ssh.PublicKeyHandler
 func recordGomoteRDPUsage(ctx context.Context) {
 	stats.Record(ctx, mGomoteRDPCount.M(1))
 }
+
+// recordBuildRestart records that a build on builderType died before
+// completion and was restarted.
+func recordBuildRestart(ctx context.Context, builderType string) {
+	stats.RecordWithTags(ctx,
+		[]tag.Mutator{
+			tag.Upsert(kBuilderType, builderType),
+		},
+		mBuildRestarts.M(1))
+}
@@ -7,25 +7,124 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
+	"golang.org/x/build/dashboard"
 	"golang.org/x/build/internal/migration"
 )
 
+var (
+	moduleProxyUpstream = flag.String("module_proxy_upstream", envOr("COORDINATOR_MODULE_PROXY_UPSTREAM", "https://proxy.golang.org"), "Base URL of the module proxy that the internal module proxy forwards requests to. Defaults to $COORDINATOR_MODULE_PROXY_UPSTREAM if set.")
+	moduleProxyOverride = flag.String("module_proxy", os.Getenv("COORDINATOR_MODULE_PROXY"), "If non-empty, the GOPROXY value given to builds that would otherwise use the internal module proxy on the GKE node or https://proxy.golang.org. Defaults to $COORDINATOR_MODULE_PROXY.")
+	moduleProxyBuilders = flag.String("module_proxy_builders", "", "Space-separated per-builder GOPROXY values, such as \"linux-amd64=https://proxy.example.com linux-arm64=https://proxy.example.com,direct\", to canary a module proxy on a few builders before switching all of them. They're used by all builds on those builders that may use the network.")
+)
+
+// builderModuleProxies maps builder names to the GOPROXY values that
+// builds on them use, from -module_proxy_builders.
+var builderModuleProxies map[string]string
+
+// parseBuilderModuleProxies parses the value of -module_proxy_builders.
+func parseBuilderModuleProxies(s string) (map[string]string, error) {
+	proxies := make(map[string]string)
+	for _, f := range strings.Fields(s) {
+		builder, proxy, ok := strings.Cut(f, "=")
+		if !ok || builder == "" || proxy == "" {
+			return nil, fmt.Errorf("%q isn't of the form builder=GOPROXY", f)
+		}
+		if _, ok := dashboard.ActiveBuilders()[builder]; !ok {
+			return nil, fmt.Errorf("unknown builder %q", builder)
+		}
+		if _, ok := proxies[builder]; ok {
+			return nil, fmt.Errorf("duplicate builder %q", builder)
+		}
+		proxies[builder] = proxy
+	}
+	return proxies, nil
+}
+
+// envOr returns the value of the environment variable key,
+// or def if it is unset or empty.
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
 func listenAndServeInternalModuleProxy() {
+	// Check the proxies in the background, so that a slow one doesn't
+	// delay serving.
+	if *moduleProxyOverride != "" {
+		go logModuleProxyCheck(*moduleProxyOverride)
+	}
 	if migration.StopInternalModuleProxy {
 		log.Println("not running internal module proxy")
 		return
 	}
+	upstream := strings.TrimSuffix(*moduleProxyUpstream, "/")
+	log.Printf("internal module proxy: forwarding to %s; builds use GOPROXY=%s", upstream, internalModuleProxy())
+	go logModuleProxyCheck(upstream)
 	err := http.ListenAndServe(":8123", http.HandlerFunc(proxyModuleCache))
 	log.Fatalf("error running internal module proxy: %v", err)
 }
 
-// proxyModuleCache proxies requests to https://proxy.golang.org/
+// proxyModuleCache proxies requests to the upstream module proxy,
+// https://proxy.golang.org/ unless overridden by the -module_proxy_upstream flag.
 func proxyModuleCache(w http.ResponseWriter, r *http.Request) {
-	proxyURL(w, r, "https://proxy.golang.org")
+	proxyURL(w, r, strings.TrimSuffix(*moduleProxyUpstream, "/"))
+}
+
+// logModuleProxyCheck checks the module proxy at baseURL with
+// checkModuleProxy and logs the result.
+func logModuleProxyCheck(baseURL string) {
+	if err := checkModuleProxy(context.Background(), baseURL); err != nil {
+		log.Printf("internal module proxy: %s failed connectivity check: %v", baseURL, err)
+		return
+	}
+	log.Printf("internal module proxy: %s passed connectivity check", baseURL)
+}
+
+// checkModuleProxy reports whether the module proxies in the GOPROXY
+// list proxies are reachable and able to serve a version list for a
+// well-known module. The "direct" and "off" entries aren't checked.
+func checkModuleProxy(ctx context.Context, proxies string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	var errs []error
+	for _, baseURL := range strings.FieldsFunc(proxies, func(r rune) bool { return r == ',' || r == '|' }) {
+		if baseURL == "direct" || baseURL == "off" {
+			continue
+		}
+		if err := checkOneModuleProxy(ctx, baseURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkOneModuleProxy(ctx context.Context, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(baseURL, "/")+"/golang.org/x/mod/@v/list", nil)
+	if err != nil {
+		return err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", req.URL, res.Status)
+	}
+	return nil
 }
 
 func proxyURL(w http.ResponseWriter, r *http.Request, baseURL string) {
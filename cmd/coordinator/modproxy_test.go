@@ -7,10 +7,14 @@
 package main
 
 import (
+	"context"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"golang.org/x/build/dashboard"
 )
 
 // TestProxyURL tests that the response served from proxyURL is not a
@@ -57,3 +61,66 @@ func TestProxyURL(t *testing.T) {
 		t.Errorf("header(%q) = %q; want %q", header, h, content)
 	}
 }
+
+func TestCheckModuleProxy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/golang.org/x/mod/@v/list" {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, "v0.1.0\n")
+	}))
+	defer ts.Close()
+
+	if err := checkModuleProxy(context.Background(), ts.URL); err != nil {
+		t.Errorf("checkModuleProxy(%q) = %v; want nil", ts.URL, err)
+	}
+	if err := checkModuleProxy(context.Background(), ts.URL+"/"); err != nil {
+		t.Errorf("checkModuleProxy(%q) = %v; want nil", ts.URL+"/", err)
+	}
+	if err := checkModuleProxy(context.Background(), ts.URL+"/sub"); err == nil {
+		t.Errorf("checkModuleProxy(%q) = nil; want error", ts.URL+"/sub")
+	}
+	for _, list := range []string{ts.URL + ",direct", ts.URL + "|" + ts.URL + "/,off", "direct"} {
+		if err := checkModuleProxy(context.Background(), list); err != nil {
+			t.Errorf("checkModuleProxy(%q) = %v; want nil", list, err)
+		}
+	}
+	if list := ts.URL + "," + ts.URL + "/sub,direct"; checkModuleProxy(context.Background(), list) == nil {
+		t.Errorf("checkModuleProxy(%q) = nil; want error", list)
+	}
+}
+
+func TestParseBuilderModuleProxies(t *testing.T) {
+	var builder string
+	for name := range dashboard.Builders {
+		builder = name
+		break
+	}
+	if builder == "" {
+		t.Skip("no builders")
+	}
+
+	got, err := parseBuilderModuleProxies(" " + builder + "=https://proxy.example.com,direct\t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{builder: "https://proxy.example.com,direct"}; !maps.Equal(got, want) {
+		t.Errorf("parseBuilderModuleProxies = %v, want %v", got, want)
+	}
+	if got, err := parseBuilderModuleProxies(""); err != nil || len(got) != 0 {
+		t.Errorf("parseBuilderModuleProxies(\"\") = %v, %v; want empty map, nil", got, err)
+	}
+
+	for _, s := range []string{
+		builder,
+		builder + "=",
+		"=https://proxy.example.com",
+		"no-such-builder=https://proxy.example.com",
+		builder + "=https://a.example.com " + builder + "=https://b.example.com",
+	} {
+		if got, err := parseBuilderModuleProxies(s); err == nil {
+			t.Errorf("parseBuilderModuleProxies(%q) = %v, want error", s, got)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	_ "embed"
+	"html/template"
+	"log"
+	"net/http"
+)
+
+//go:embed templates/pause-admin.html
+var pauseAdminTemplateStr string
+
+var pauseAdminTemplate = template.Must(baseTmpl.New("pause-admin.html").Parse(pauseAdminTemplateStr))
+
+// handlePauseAdmin serves a page showing whether the coordinator is
+// accepting new work, with a control to pause and resume it.
+// A POST with the form value "action" ("pause" or "resume") changes it.
+// While paused, no new post-submit builds or trybot runs are started,
+// and the ones in progress finish normally.
+//
+// It must only be served to operators.
+func handlePauseAdmin(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		switch action := r.FormValue("action"); action {
+		case "pause", "resume":
+			log.Printf("pause admin: %s (by %v)", action, r.Context().Value("email"))
+			ignoreAllNewWork.Store(action == "pause")
+		default:
+			http.Error(w, `action must be "pause" or "resume"`, http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+		return
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data := struct{ Paused bool }{ignoreAllNewWork.Load()}
+	if err := pauseAdminTemplate.Execute(w, data); err != nil {
+		log.Printf("handlePauseAdmin: %v", err)
+	}
+}
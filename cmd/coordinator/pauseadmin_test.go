@@ -0,0 +1,48 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPauseAdmin(t *testing.T) {
+	defer ignoreAllNewWork.Store(false)
+	post := func(action string) int {
+		req := httptest.NewRequest("POST", "/admin/pause", strings.NewReader(url.Values{"action": {action}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		handlePauseAdmin(w, req)
+		return w.Code
+	}
+
+	if code := post("pause"); code != http.StatusSeeOther {
+		t.Fatalf("pause: status %d, want %d", code, http.StatusSeeOther)
+	}
+	if !ignoreAllNewWork.Load() {
+		t.Errorf("after pause, ignoreAllNewWork = false, want true")
+	}
+	w := httptest.NewRecorder()
+	handlePauseAdmin(w, httptest.NewRequest("GET", "/admin/pause", nil))
+	if !strings.Contains(w.Body.String(), "Intake is paused") {
+		t.Errorf("page after pause doesn't say intake is paused:\n%s", w.Body.String())
+	}
+
+	if code := post("resume"); code != http.StatusSeeOther {
+		t.Fatalf("resume: status %d, want %d", code, http.StatusSeeOther)
+	}
+	if ignoreAllNewWork.Load() {
+		t.Errorf("after resume, ignoreAllNewWork = true, want false")
+	}
+	if code := post("bogus"); code != http.StatusBadRequest {
+		t.Errorf("bogus action: status %d, want %d", code, http.StatusBadRequest)
+	}
+}
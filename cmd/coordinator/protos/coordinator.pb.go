@@ -120,6 +120,141 @@ func (*ClearResultsResponse) Descriptor() ([]byte, []int) {
 	return file_cmd_coordinator_protos_coordinator_proto_rawDescGZIP(), []int{1}
 }
 
+// StreamBuildLogRequest specifies the build whose log is streamed.
+type StreamBuildLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// builder is the name of the builder.
+	Builder string `protobuf:"bytes,1,opt,name=builder,proto3" json:"builder,omitempty"`
+	// rev is the commit hash of the main Go repo.
+	Rev string `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	// sub_name is the name of the subrepo, if any.
+	SubName string `protobuf:"bytes,3,opt,name=sub_name,json=subName,proto3" json:"sub_name,omitempty"`
+	// sub_rev is the commit hash of the subrepo, if any.
+	SubRev string `protobuf:"bytes,4,opt,name=sub_rev,json=subRev,proto3" json:"sub_rev,omitempty"`
+	// build_id optionally selects a particular build of builder and rev,
+	// as identified by the "st" parameter of the coordinator's logs page.
+	BuildId string `protobuf:"bytes,5,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+}
+
+func (x *StreamBuildLogRequest) Reset() {
+	*x = StreamBuildLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_coordinator_protos_coordinator_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamBuildLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBuildLogRequest) ProtoMessage() {}
+
+func (x *StreamBuildLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_coordinator_protos_coordinator_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBuildLogRequest.ProtoReflect.Descriptor instead.
+func (*StreamBuildLogRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_coordinator_protos_coordinator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamBuildLogRequest) GetBuilder() string {
+	if x != nil {
+		return x.Builder
+	}
+	return ""
+}
+
+func (x *StreamBuildLogRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+func (x *StreamBuildLogRequest) GetSubName() string {
+	if x != nil {
+		return x.SubName
+	}
+	return ""
+}
+
+func (x *StreamBuildLogRequest) GetSubRev() string {
+	if x != nil {
+		return x.SubRev
+	}
+	return ""
+}
+
+func (x *StreamBuildLogRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+// StreamBuildLogResponse contains a chunk of a build's log output.
+type StreamBuildLogResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// output is the next chunk of the log.
+	Output []byte `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+}
+
+func (x *StreamBuildLogResponse) Reset() {
+	*x = StreamBuildLogResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_coordinator_protos_coordinator_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamBuildLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBuildLogResponse) ProtoMessage() {}
+
+func (x *StreamBuildLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_coordinator_protos_coordinator_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBuildLogResponse.ProtoReflect.Descriptor instead.
+func (*StreamBuildLogResponse) Descriptor() ([]byte, []int) {
+	return file_cmd_coordinator_protos_coordinator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamBuildLogResponse) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
 var File_cmd_coordinator_protos_coordinator_proto protoreflect.FileDescriptor
 
 var file_cmd_coordinator_protos_coordinator_proto_rawDesc = []byte{
@@ -131,16 +266,34 @@ var file_cmd_coordinator_protos_coordinator_proto_rawDesc = []byte{
 	0x6c, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c,
 	0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x16, 0x0a, 0x14, 0x43, 0x6c, 0x65, 0x61, 0x72,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
-	0x5a, 0x0a, 0x0b, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x4b,
-	0x0a, 0x0c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x1b,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67,
-	0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x78, 0x2f, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x2f, 0x63, 0x6d, 0x64, 0x2f, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f,
-	0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x92, 0x01, 0x0a, 0x15, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x72, 0x65, 0x76, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x5f, 0x72, 0x65, 0x76, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x75, 0x62, 0x52, 0x65, 0x76, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x49, 0x64, 0x22, 0x30, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x32, 0xaf, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x4b, 0x0a, 0x0c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x6f, 0x6c, 0x61,
+	0x6e, 0x67, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x78, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x63,
+	0x6d, 0x64, 0x2f, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -155,16 +308,20 @@ func file_cmd_coordinator_protos_coordinator_proto_rawDescGZIP() []byte {
 	return file_cmd_coordinator_protos_coordinator_proto_rawDescData
 }
 
-var file_cmd_coordinator_protos_coordinator_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_cmd_coordinator_protos_coordinator_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_cmd_coordinator_protos_coordinator_proto_goTypes = []interface{}{
-	(*ClearResultsRequest)(nil),  // 0: protos.ClearResultsRequest
-	(*ClearResultsResponse)(nil), // 1: protos.ClearResultsResponse
+	(*ClearResultsRequest)(nil),    // 0: protos.ClearResultsRequest
+	(*ClearResultsResponse)(nil),   // 1: protos.ClearResultsResponse
+	(*StreamBuildLogRequest)(nil),  // 2: protos.StreamBuildLogRequest
+	(*StreamBuildLogResponse)(nil), // 3: protos.StreamBuildLogResponse
 }
 var file_cmd_coordinator_protos_coordinator_proto_depIdxs = []int32{
 	0, // 0: protos.Coordinator.ClearResults:input_type -> protos.ClearResultsRequest
-	1, // 1: protos.Coordinator.ClearResults:output_type -> protos.ClearResultsResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+	2, // 1: protos.Coordinator.StreamBuildLog:input_type -> protos.StreamBuildLogRequest
+	1, // 2: protos.Coordinator.ClearResults:output_type -> protos.ClearResultsResponse
+	3, // 3: protos.Coordinator.StreamBuildLog:output_type -> protos.StreamBuildLogResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -200,6 +357,30 @@ func file_cmd_coordinator_protos_coordinator_proto_init() {
 				return nil
 			}
 		}
+		file_cmd_coordinator_protos_coordinator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamBuildLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cmd_coordinator_protos_coordinator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamBuildLogResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -207,7 +388,7 @@ func file_cmd_coordinator_protos_coordinator_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cmd_coordinator_protos_coordinator_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
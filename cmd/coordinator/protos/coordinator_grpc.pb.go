@@ -23,7 +23,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Coordinator_ClearResults_FullMethodName = "/protos.Coordinator/ClearResults"
+	Coordinator_ClearResults_FullMethodName   = "/protos.Coordinator/ClearResults"
+	Coordinator_StreamBuildLog_FullMethodName = "/protos.Coordinator/StreamBuildLog"
 )
 
 // CoordinatorClient is the client API for Coordinator service.
@@ -32,6 +33,9 @@ const (
 type CoordinatorClient interface {
 	// ClearResults clears build failures from the coordinator to force them to rebuild.
 	ClearResults(ctx context.Context, in *ClearResultsRequest, opts ...grpc.CallOption) (*ClearResultsResponse, error)
+	// StreamBuildLog streams the log output of a build as it is produced.
+	// The stream ends when the build is complete.
+	StreamBuildLog(ctx context.Context, in *StreamBuildLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamBuildLogResponse], error)
 }
 
 type coordinatorClient struct {
@@ -52,12 +56,34 @@ func (c *coordinatorClient) ClearResults(ctx context.Context, in *ClearResultsRe
 	return out, nil
 }
 
+func (c *coordinatorClient) StreamBuildLog(ctx context.Context, in *StreamBuildLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamBuildLogResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Coordinator_ServiceDesc.Streams[0], Coordinator_StreamBuildLog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamBuildLogRequest, StreamBuildLogResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Coordinator_StreamBuildLogClient = grpc.ServerStreamingClient[StreamBuildLogResponse]
+
 // CoordinatorServer is the server API for Coordinator service.
 // All implementations must embed UnimplementedCoordinatorServer
 // for forward compatibility.
 type CoordinatorServer interface {
 	// ClearResults clears build failures from the coordinator to force them to rebuild.
 	ClearResults(context.Context, *ClearResultsRequest) (*ClearResultsResponse, error)
+	// StreamBuildLog streams the log output of a build as it is produced.
+	// The stream ends when the build is complete.
+	StreamBuildLog(*StreamBuildLogRequest, grpc.ServerStreamingServer[StreamBuildLogResponse]) error
 	mustEmbedUnimplementedCoordinatorServer()
 }
 
@@ -71,6 +97,9 @@ type UnimplementedCoordinatorServer struct{}
 func (UnimplementedCoordinatorServer) ClearResults(context.Context, *ClearResultsRequest) (*ClearResultsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearResults not implemented")
 }
+func (UnimplementedCoordinatorServer) StreamBuildLog(*StreamBuildLogRequest, grpc.ServerStreamingServer[StreamBuildLogResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBuildLog not implemented")
+}
 func (UnimplementedCoordinatorServer) mustEmbedUnimplementedCoordinatorServer() {}
 func (UnimplementedCoordinatorServer) testEmbeddedByValue()                     {}
 
@@ -110,6 +139,17 @@ func _Coordinator_ClearResults_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Coordinator_StreamBuildLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBuildLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoordinatorServer).StreamBuildLog(m, &grpc.GenericServerStream[StreamBuildLogRequest, StreamBuildLogResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Coordinator_StreamBuildLogServer = grpc.ServerStreamingServer[StreamBuildLogResponse]
+
 // Coordinator_ServiceDesc is the grpc.ServiceDesc for Coordinator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -122,6 +162,12 @@ var Coordinator_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Coordinator_ClearResults_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBuildLog",
+			Handler:       _Coordinator_StreamBuildLog_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "cmd/coordinator/protos/coordinator.proto",
 }
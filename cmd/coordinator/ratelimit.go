@@ -0,0 +1,170 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"crypto/hmac"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	statusRateLimit  = flag.Float64("status_rate_limit", 5, "Maximum sustained rate, in requests per second, at which a single IP address may fetch the public status pages. Zero disables rate limiting.")
+	statusRateBurst  = flag.Int("status_rate_burst", 30, "Number of status page requests a single IP address may make in a burst, above -status_rate_limit.")
+	statusRateExempt = flag.String("status_rate_exempt", "", "Comma-separated CIDR ranges, in addition to loopback and private addresses, whose requests are not rate limited.")
+)
+
+// statusLimiter limits the rate at which each client may fetch the
+// public status pages. It's set up by initStatusRateLimit.
+var statusLimiter *ipRateLimiter
+
+// initStatusRateLimit configures rate limiting of the public status
+// pages from flags.
+func initStatusRateLimit() {
+	if *statusRateLimit <= 0 {
+		return
+	}
+	exempt, err := parseCIDRs(*statusRateExempt)
+	if err != nil {
+		log.Fatalf("invalid -status_rate_exempt: %v", err)
+	}
+	statusLimiter = newIPRateLimiter(rate.Limit(*statusRateLimit), *statusRateBurst, exempt)
+	log.Printf("rate limiting status pages to %v requests/s per IP, burst %d", *statusRateLimit, *statusRateBurst)
+}
+
+// rateLimited wraps the public status handler h, responding with
+// 429 Too Many Requests to clients that fetch pages too often.
+// Requests authenticated with a builder key aren't limited.
+func rateLimited(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if statusLimiter != nil && !hasBuilderKey(r) && !statusLimiter.allow(r.RemoteAddr, time.Now()) {
+			w.Header().Set("Retry-After", "10")
+			http.Error(w, "too many requests; please poll less often", http.StatusTooManyRequests)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// hasBuilderKey reports whether r carries "builder" and "key" query
+// parameters with a valid key for that builder, as used by the
+// dashboard and the builders themselves.
+func hasBuilderKey(r *http.Request) bool {
+	if len(masterKeyCache) == 0 {
+		return false
+	}
+	q := r.URL.Query()
+	builder, key := q.Get("builder"), q.Get("key")
+	if builder == "" || key == "" {
+		return false
+	}
+	return hmac.Equal([]byte(key), []byte(builderKey(builder)))
+}
+
+// parseCIDRs parses a comma-separated list of CIDR ranges.
+func parseCIDRs(s string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		_, n, err := net.ParseCIDR(f)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// ipRateLimiter is a set of token-bucket rate limiters, one per
+// client IP address. Requests from loopback and private addresses,
+// which come from within our own network, aren't limited; nor are
+// requests from the exempt ranges.
+type ipRateLimiter struct {
+	limit  rate.Limit
+	burst  int
+	exempt []*net.IPNet
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastPrune time.Time
+}
+
+type clientLimiter struct {
+	lim      *rate.Limiter
+	lastSeen time.Time
+}
+
+// ipLimiterIdle is how long a client's limiter is kept after its last
+// request. Clients idle for this long would have a full bucket anyway.
+const ipLimiterIdle = 10 * time.Minute
+
+func newIPRateLimiter(limit rate.Limit, burst int, exempt []*net.IPNet) *ipRateLimiter {
+	return &ipRateLimiter{
+		limit:   limit,
+		burst:   burst,
+		exempt:  exempt,
+		clients: make(map[string]*clientLimiter),
+	}
+}
+
+// allow reports whether a request from remoteAddr, an "IP:port"
+// address as found in http.Request.RemoteAddr, may proceed at now.
+func (l *ipRateLimiter) allow(remoteAddr string, now time.Time) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		// Not from a network client, for example in tests.
+		return true
+	}
+	if l.isExempt(ip) {
+		return true
+	}
+	key := ip.String()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastPrune) > ipLimiterIdle {
+		for k, c := range l.clients {
+			if now.Sub(c.lastSeen) > ipLimiterIdle {
+				delete(l.clients, k)
+			}
+		}
+		l.lastPrune = now
+	}
+	c, ok := l.clients[key]
+	if !ok {
+		c = &clientLimiter{lim: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[key] = c
+	}
+	c.lastSeen = now
+	return c.lim.AllowN(now, 1)
+}
+
+func (l *ipRateLimiter) isExempt(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() {
+		return true
+	}
+	for _, n := range l.exempt {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
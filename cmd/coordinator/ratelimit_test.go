@@ -0,0 +1,62 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIPRateLimiter(t *testing.T) {
+	_, exempt, _ := net.ParseCIDR("203.0.113.0/24")
+	l := newIPRateLimiter(1, 2, []*net.IPNet{exempt})
+	now := time.Now()
+
+	for i, want := range []bool{true, true, false} {
+		if got := l.allow("198.51.100.1:1234", now); got != want {
+			t.Errorf("request %d: allow = %v, want %v", i, got, want)
+		}
+	}
+	if !l.allow("198.51.100.2:1234", now) {
+		t.Errorf("another client was limited")
+	}
+	if !l.allow("198.51.100.1:1234", now.Add(time.Second)) {
+		t.Errorf("client still limited after its bucket refilled")
+	}
+	for _, addr := range []string{"127.0.0.1:80", "10.0.0.1:80", "[::1]:80", "203.0.113.9:80", "pipe"} {
+		for i := 0; i < 10; i++ {
+			if !l.allow(addr, now) {
+				t.Errorf("exempt address %s was limited", addr)
+				break
+			}
+		}
+	}
+
+	l.allow("198.51.100.3:1234", now.Add(2*ipLimiterIdle))
+	if len(l.clients) != 1 {
+		t.Errorf("after idle period, tracking %d clients, want 1", len(l.clients))
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	defer func(old *ipRateLimiter) { statusLimiter = old }(statusLimiter)
+	statusLimiter = newIPRateLimiter(0, 1, nil)
+	h := rateLimited(func(w http.ResponseWriter, r *http.Request) {})
+
+	for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req := httptest.NewRequest("GET", "/try.json", nil)
+		req.RemoteAddr = "198.51.100.1:1234"
+		w := httptest.NewRecorder()
+		h(w, req)
+		if w.Code != want {
+			t.Errorf("request %d: status = %d, want %d", i, w.Code, want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/coordinator/remote"
+)
+
+var remoteSessionsURL = flag.String("remote_sessions_url", "", "Optional gs://bucket/object in which to save the gomote sessions, so that they survive a coordinator restart. On startup, sessions whose buildlets are still running are reattached to, and the rest are pruned. Use with -startup_grace so their buildlets aren't deleted first.")
+
+// restoreRemoteSessions restores the gomote sessions saved by a previous
+// coordinator into sp, if -remote_sessions_url is set.
+func restoreRemoteSessions(sp *remote.SessionPool) {
+	if *remoteSessionsURL == "" {
+		return
+	}
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(*remoteSessionsURL, "gs://"), "/")
+	if !strings.HasPrefix(*remoteSessionsURL, "gs://") || !ok || bucket == "" || object == "" {
+		log.Fatalf("invalid -remote_sessions_url %q; want gs://bucket/object", *remoteSessionsURL)
+	}
+	store := remote.NewGCSSessionStore(mustStorageClient().Bucket(bucket).Object(object))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if err := sp.Restore(ctx, store, reattachRemoteBuildlet); err != nil {
+		log.Printf("unable to restore gomote sessions: %v", err)
+	}
+}
+
+// reattachRemoteBuildlet returns a client for the GCE buildlet of a gomote
+// session saved by a previous coordinator, if it's still running.
+// Sessions on other buildlets are pruned: EC2 buildlets use TLS keys that
+// aren't saved, and reverse buildlets reconnect to the new coordinator
+// on their own, as ordinary reverse buildlets.
+//
+// Once a reattached session ends, its VM is deleted by the periodic
+// cleanup of VMs that are no longer in use.
+func reattachRemoteBuildlet(ctx context.Context, rec remote.SessionRecord) (buildlet.Client, error) {
+	if rec.InstanceName == "" || rec.IPPort == "" {
+		return nil, errors.New("not a GCE buildlet")
+	}
+	bc := buildlet.NewClient(rec.IPPort, buildlet.NoKeyPair)
+	bc.SetName(rec.BuildletName)
+	bc.SetInstanceName(rec.InstanceName)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if _, err := bc.Status(ctx); err != nil {
+		bc.Close()
+		return nil, err
+	}
+	return bc, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// handleReplayAdmin starts a new build of the exact BuilderRev of a
+// finished post-submit build, such as one that failed because of an
+// infrastructure problem. findWork doesn't start builds that already
+// have a result on the dashboard, so this is the only way to re-run
+// one. A POST with the form value "buildID" names the build, which
+// must be one of the recently finished builds on the status page.
+//
+// It must only be served to operators.
+func handleReplayAdmin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	st, err := replayBuild(r.FormValue("buildID"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Printf("replay admin: replaying build %s of %v (by %v)", st.buildID, st.BuilderRev, r.Context().Value("email"))
+	fmt.Fprintf(w, "replaying %v\n", st.BuilderRev)
+}
+
+// replayBuild starts a new build of the BuilderRev of the finished
+// post-submit build with the given ID, returning the finished build.
+func replayBuild(buildID string) (*buildStatus, error) {
+	if buildID == "" {
+		return nil, errors.New("missing buildID")
+	}
+	st := getStatusByBuildID(buildID)
+	if st == nil {
+		return nil, fmt.Errorf("no recent build with ID %q", buildID)
+	}
+	if st.isTry() {
+		return nil, errors.New("can't replay trybot builds")
+	}
+	st.mu.Lock()
+	done := !st.done.IsZero()
+	st.mu.Unlock()
+	if !done {
+		return nil, errors.New("build hasn't finished")
+	}
+	if ignoreAllNewWork.Load() {
+		return nil, errors.New("not accepting new work")
+	}
+	if reason := cantBuildRevReason(st.BuilderRev); reason != "" {
+		return nil, fmt.Errorf("can't build %v: %s", st.BuilderRev, reason)
+	}
+	addWorkDetail(st.BuilderRev, st.commitDetail)
+	return st, nil
+}
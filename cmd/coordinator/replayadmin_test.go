@@ -0,0 +1,101 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildenv"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/internal/coordinator/pool"
+)
+
+func TestReplayAdmin(t *testing.T) {
+	var builder string
+	for name, conf := range dashboard.Builders {
+		if !conf.IsReverse() {
+			builder = name
+			break
+		}
+	}
+	if builder == "" {
+		t.Skip("no non-reverse builders")
+	}
+	gce := pool.NewGCEConfiguration()
+	defer gce.SetBuildEnv(gce.BuildEnv())
+	gce.SetBuildEnv(&buildenv.Environment{})
+	finished := &buildStatus{
+		BuilderRev:   buildgo.BuilderRev{Name: builder, Rev: "0123456789abcdef"},
+		commitDetail: commitDetail{RevCommitTime: time.Unix(1700000000, 0)},
+		buildID:      "Bfinished",
+		done:         time.Unix(1700000100, 0),
+	}
+	running := &buildStatus{
+		BuilderRev: buildgo.BuilderRev{Name: builder, Rev: "fedcba9876543210"},
+		buildID:    "Brunning",
+	}
+	try := &buildStatus{
+		BuilderRev: buildgo.BuilderRev{Name: builder, Rev: "0011223344556677"},
+		buildID:    "Btry",
+		trySet:     &trySet{},
+		done:       time.Unix(1700000100, 0),
+	}
+	statusMu.Lock()
+	statusDone = append(statusDone, finished, running, try)
+	statusMu.Unlock()
+	defer func() {
+		statusMu.Lock()
+		statusDone = statusDone[:len(statusDone)-3]
+		statusMu.Unlock()
+	}()
+
+	var added []buildgo.BuilderRev
+	var addedDetail commitDetail
+	addWorkTestHook = func(work buildgo.BuilderRev, d commitDetail) {
+		added = append(added, work)
+		addedDetail = d
+	}
+	defer func() { addWorkTestHook = nil }()
+
+	for _, tc := range []struct {
+		buildID    string
+		wantStatus int
+	}{
+		{"", http.StatusBadRequest},
+		{"Bunknown", http.StatusBadRequest},
+		{"Brunning", http.StatusBadRequest},
+		{"Btry", http.StatusBadRequest},
+		{"Bfinished", http.StatusOK},
+	} {
+		form := url.Values{"buildID": {tc.buildID}}
+		req := httptest.NewRequest("POST", "/admin/replay", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		handleReplayAdmin(rec, req)
+		if rec.Code != tc.wantStatus {
+			t.Errorf("replaying %q: got status %d, want %d; body: %s", tc.buildID, rec.Code, tc.wantStatus, rec.Body)
+		}
+	}
+	if len(added) != 1 || added[0] != finished.BuilderRev {
+		t.Fatalf("added work %v, want only %v", added, finished.BuilderRev)
+	}
+	if addedDetail != finished.commitDetail {
+		t.Errorf("added work with detail %+v, want %+v", addedDetail, finished.commitDetail)
+	}
+
+	rec := httptest.NewRecorder()
+	handleReplayAdmin(rec, httptest.NewRequest("GET", "/admin/replay", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: got status %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+// maxExecSteps is the maximum number of commands recorded per build.
+// Sharded test runs can issue many; the first ones are the interesting
+// part of a recipe anyway.
+const maxExecSteps = 1000
+
+// An execStep is a command that the coordinator ran on a buildlet
+// during a build.
+type execStep struct {
+	Buildlet    string    `json:"buildlet"` // name of the buildlet it ran on
+	Time        time.Time `json:"time"`
+	Cmd         string    `json:"cmd"`
+	Args        []string  `json:"args,omitempty"`
+	Dir         string    `json:"dir,omitempty"`
+	ExtraEnv    []string  `json:"extraEnv,omitempty"`
+	Path        []string  `json:"path,omitempty"`
+	SystemLevel bool      `json:"systemLevel,omitempty"`
+}
+
+// execRecorder is a buildlet.Client that records the commands
+// executed on it into its build's status.
+type execRecorder struct {
+	buildlet.Client
+	st *buildStatus
+}
+
+// recordExecs returns bc wrapped so that the commands executed on it
+// are recorded for st's reproduction recipe.
+func (st *buildStatus) recordExecs(bc buildlet.Client) buildlet.Client {
+	if _, ok := bc.(*execRecorder); ok {
+		return bc
+	}
+	return &execRecorder{Client: bc, st: st}
+}
+
+func (c *execRecorder) Exec(ctx context.Context, cmd string, opts buildlet.ExecOpts) (remoteErr, execErr error) {
+	c.st.mu.Lock()
+	if len(c.st.execs) < maxExecSteps {
+		c.st.execs = append(c.st.execs, execStep{
+			Buildlet:    c.Client.Name(),
+			Time:        time.Now(),
+			Cmd:         cmd,
+			Args:        opts.Args,
+			Dir:         opts.Dir,
+			ExtraEnv:    opts.ExtraEnv,
+			Path:        opts.Path,
+			SystemLevel: opts.SystemLevel,
+		})
+	}
+	c.st.mu.Unlock()
+	return c.Client.Exec(ctx, cmd, opts)
+}
+
+// buildRecipe is the JSON form of a build's reproduction recipe.
+type buildRecipe struct {
+	BuildID string     `json:"buildID"`
+	Builder string     `json:"builder"`
+	Rev     string     `json:"rev"`
+	SubName string     `json:"subName,omitempty"`
+	SubRev  string     `json:"subRev,omitempty"`
+	GOOS    string     `json:"goos"`
+	GOARCH  string     `json:"goarch"`
+	Env     []string   `json:"env,omitempty"` // the builder's environment, set for every command
+	Steps   []execStep `json:"steps"`
+}
+
+// handleRepro serves the commands the coordinator ran for a build,
+// as JSON by default or as a shell script with format=sh, so that
+// developers can approximate the build on a gomote or locally.
+func handleRepro(w http.ResponseWriter, r *http.Request) {
+	id := r.FormValue("buildID")
+	if id == "" {
+		http.Error(w, "missing buildID parameter", http.StatusBadRequest)
+		return
+	}
+	st := getStatusByBuildID(id)
+	if st == nil {
+		http.NotFound(w, r)
+		return
+	}
+	st.mu.Lock()
+	recipe := buildRecipe{
+		BuildID: st.buildID,
+		Builder: st.Name,
+		Rev:     st.Rev,
+		SubName: st.SubName,
+		SubRev:  st.SubRev,
+		GOOS:    st.conf.GOOS(),
+		GOARCH:  st.conf.GOARCH(),
+		Env:     st.conf.Env(),
+		Steps:   append([]execStep(nil), st.execs...),
+	}
+	st.mu.Unlock()
+
+	switch r.FormValue("format") {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		e := json.NewEncoder(w)
+		e.SetIndent("", "\t")
+		e.Encode(recipe)
+	case "sh":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		writeReproScript(w, recipe)
+	default:
+		http.Error(w, `format must be "json" or "sh"`, http.StatusBadRequest)
+	}
+}
+
+// writeReproScript writes recipe as a POSIX shell script to be run
+// from the root of a work directory laid out like the buildlet's.
+func writeReproScript(w io.Writer, recipe buildRecipe) {
+	fmt.Fprintf(w, "#!/bin/sh\n")
+	fmt.Fprintf(w, "# Commands run by the coordinator for build %s:\n", recipe.BuildID)
+	fmt.Fprintf(w, "#   builder: %s\n", recipe.Builder)
+	fmt.Fprintf(w, "#   rev: %s\n", recipe.Rev)
+	if recipe.SubName != "" {
+		fmt.Fprintf(w, "#   %s rev: %s\n", recipe.SubName, recipe.SubRev)
+	}
+	fmt.Fprintf(w, "# Run it from the root of a work directory laid out like the buildlet's,\n")
+	fmt.Fprintf(w, "# for example the one created by \"gomote create\" and \"gomote push\".\n")
+	fmt.Fprintf(w, "# Commands that ran on test helper buildlets are included in order.\n\n")
+	fmt.Fprintf(w, "set -e\nWORKDIR=$(pwd)\n")
+	fmt.Fprintf(w, "export GOOS=%s GOARCH=%s\n", shellQuote(recipe.GOOS), shellQuote(recipe.GOARCH))
+	for _, kv := range recipe.Env {
+		fmt.Fprintf(w, "export %s\n", shellQuoteEnv(kv))
+	}
+	if len(recipe.Steps) == 0 {
+		fmt.Fprintf(w, "\n# No commands have been run yet.\n")
+	}
+	for _, s := range recipe.Steps {
+		fmt.Fprintf(w, "\n# %s on %s\n", s.Time.UTC().Format(time.RFC3339), s.Buildlet)
+		var b strings.Builder
+		b.WriteString("(")
+		if s.Dir != "" {
+			fmt.Fprintf(&b, "cd %s && ", shellQuote(s.Dir))
+		}
+		if s.Path != nil {
+			elems := make([]string, len(s.Path))
+			for i, p := range s.Path {
+				p = strings.ReplaceAll(p, "$WORKDIR", "${WORKDIR}")
+				elems[i] = `"` + strings.ReplaceAll(p, `"`, `\"`) + `"`
+			}
+			fmt.Fprintf(&b, "PATH=%s ", strings.Join(elems, ":"))
+		}
+		for _, kv := range s.ExtraEnv {
+			b.WriteString(shellQuoteEnv(kv) + " ")
+		}
+		cmd := s.Cmd
+		if !s.SystemLevel && !strings.HasPrefix(cmd, "/") {
+			cmd = "$WORKDIR/" + cmd
+			b.WriteString(`"` + cmd + `"`)
+		} else {
+			b.WriteString(shellQuote(cmd))
+		}
+		for _, a := range s.Args {
+			b.WriteString(" " + shellQuote(a))
+		}
+		b.WriteString(")\n")
+		io.WriteString(w, b.String())
+	}
+}
+
+// shellQuote quotes s for use as a single word in a POSIX shell.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-+=/.,:@%") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellQuoteEnv quotes the value of the KEY=VALUE pair kv.
+func shellQuoteEnv(kv string) string {
+	k, v, _ := strings.Cut(kv, "=")
+	return k + "=" + shellQuote(v)
+}
+
+// getStatusByBuildID returns the status of the active, recently
+// finished, or trybot build with the given ID, or nil.
+func getStatusByBuildID(id string) *buildStatus {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	for _, st := range status {
+		if st.buildID == id {
+			return st
+		}
+	}
+	for _, st := range statusDone {
+		if st.buildID == id {
+			return st
+		}
+	}
+	for _, ts := range tries {
+		ts.mu.Lock()
+		for _, st := range ts.builds {
+			if st.buildID == id {
+				ts.mu.Unlock()
+				return st
+			}
+		}
+		ts.mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+func TestRecordExecs(t *testing.T) {
+	st := &buildStatus{}
+	fc := &buildlet.FakeClient{}
+	fc.SetName("buildlet-1")
+	bc := st.recordExecs(fc)
+	if st.recordExecs(bc) != bc {
+		t.Errorf("recordExecs wrapped an already recording client again")
+	}
+	bc.Exec(context.Background(), "go/src/make.bash", buildlet.ExecOpts{Dir: "go/src", ExtraEnv: []string{"GOROOT_FINAL=/tmp/go"}})
+	bc.Exec(context.Background(), "./go/bin/go", buildlet.ExecOpts{Args: []string{"tool", "dist", "test"}})
+
+	if len(st.execs) != 2 {
+		t.Fatalf("recorded %d commands, want 2", len(st.execs))
+	}
+	if s := st.execs[0]; s.Buildlet != "buildlet-1" || s.Cmd != "go/src/make.bash" || s.Dir != "go/src" {
+		t.Errorf("first recorded command = %+v", s)
+	}
+	if s := st.execs[1]; strings.Join(s.Args, " ") != "tool dist test" {
+		t.Errorf("second recorded command args = %q", s.Args)
+	}
+}
+
+func TestWriteReproScript(t *testing.T) {
+	var b strings.Builder
+	writeReproScript(&b, buildRecipe{
+		BuildID: "B0123456789",
+		Builder: "linux-amd64",
+		Rev:     "abcdef",
+		GOOS:    "linux",
+		GOARCH:  "amd64",
+		Env:     []string{"GO_TEST_TIMEOUT_SCALE=2", "GOFLAGS=-tags=a b"},
+		Steps: []execStep{{
+			Buildlet: "buildlet-1",
+			Time:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			Cmd:      "./go/bin/go",
+			Args:     []string{"tool", "dist", "test", "-run=^go_test:net$"},
+			Dir:      "go/src",
+			Path:     []string{"$WORKDIR/go/bin", "$PATH"},
+		}},
+	})
+	got := b.String()
+	for _, want := range []string{
+		"export GOOS=linux GOARCH=amd64\n",
+		"export GOFLAGS='-tags=a b'\n",
+		"# 2025-01-02T03:04:05Z on buildlet-1\n",
+		`(cd go/src && PATH="${WORKDIR}/go/bin":"$PATH" "$WORKDIR/./go/bin/go" tool dist test '-run=^go_test:net$')` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("script doesn't contain %q; got:\n%s", want, got)
+		}
+	}
+}
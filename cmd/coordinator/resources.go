@@ -0,0 +1,61 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+// buildletTimer is a buildlet.Client that adds the time from when it
+// was given to a build until it's closed to the build's machine time.
+type buildletTimer struct {
+	buildlet.Client
+	st    *buildStatus
+	start time.Time
+	once  sync.Once
+}
+
+// timeBuildlet returns bc wrapped so that the time it's held by st
+// counts towards st's machine time.
+func (st *buildStatus) timeBuildlet(bc buildlet.Client) buildlet.Client {
+	return &buildletTimer{Client: bc, st: st, start: time.Now()}
+}
+
+func (c *buildletTimer) Close() error {
+	c.once.Do(func() {
+		c.st.mu.Lock()
+		c.st.machineTime += time.Since(c.start)
+		c.st.mu.Unlock()
+	})
+	return c.Client.Close()
+}
+
+// resourcesUsed returns a line for a trybot run's final report that
+// sums up the time taken by its builds and the machine time of the
+// buildlets they used, so that the cost of a run, especially one with
+// many SlowBots, is visible.
+func resourcesUsed(builds []*buildStatus) string {
+	now := time.Now()
+	var wall, machine time.Duration
+	for _, st := range builds {
+		st.mu.Lock()
+		end := st.done
+		if end.IsZero() {
+			// Its final report is being written as it finishes.
+			end = now
+		}
+		wall += end.Sub(st.startTime)
+		machine += st.machineTime
+		st.mu.Unlock()
+	}
+	return fmt.Sprintf("Resources used: %v of build time across %d builds, and %.1f machine-hours.",
+		wall.Round(time.Second), len(builds), machine.Hours())
+}
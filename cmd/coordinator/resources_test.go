@@ -0,0 +1,38 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+func TestBuildletTimer(t *testing.T) {
+	st := &buildStatus{}
+	bc := st.timeBuildlet(&buildlet.FakeClient{})
+	bc.(*buildletTimer).start = time.Now().Add(-time.Hour)
+	bc.Close()
+	bc.Close() // counted once
+	if st.machineTime < time.Hour || st.machineTime > 2*time.Hour {
+		t.Errorf("machineTime = %v; want about 1h", st.machineTime)
+	}
+}
+
+func TestResourcesUsed(t *testing.T) {
+	now := time.Now()
+	builds := []*buildStatus{
+		{startTime: now.Add(-30 * time.Minute), done: now, machineTime: 90 * time.Minute},
+		{startTime: now.Add(-time.Hour), done: now, machineTime: time.Hour},
+	}
+	got := resourcesUsed(builds)
+	want := "Resources used: 1h30m0s of build time across 2 builds, and 2.5 machine-hours."
+	if got != want {
+		t.Errorf("resourcesUsed = %q; want %q", got, want)
+	}
+}
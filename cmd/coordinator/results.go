@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"golang.org/x/build/cmd/coordinator/protos"
+	"golang.org/x/build/internal/buildgo"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	grpcstatus "google.golang.org/grpc/status"
@@ -50,6 +51,46 @@ func (g *gRPCServer) ClearResults(ctx context.Context, req *protos.ClearResultsR
 	return &protos.ClearResultsResponse{}, nil
 }
 
+// StreamBuildLog implements the StreamBuildLog RPC call from the CoordinatorService.
+//
+// It streams the same output as the logs HTTP handler, without the status header,
+// until the build completes or the client goes away.
+func (g *gRPCServer) StreamBuildLog(req *protos.StreamBuildLogRequest, stream protos.Coordinator_StreamBuildLogServer) error {
+	if req.GetBuilder() == "" || req.GetRev() == "" {
+		return grpcstatus.Error(codes.InvalidArgument, "Builder and Rev must be provided")
+	}
+	br := buildgo.BuilderRev{
+		Name:    req.GetBuilder(),
+		Rev:     req.GetRev(),
+		SubName: req.GetSubName(),
+		SubRev:  req.GetSubRev(),
+	}
+	st := getStatus(br, req.GetBuildId())
+	if st == nil {
+		return grpcstatus.Error(codes.NotFound, "build not found")
+	}
+	output := st.output.Reader()
+	go func() {
+		<-stream.Context().Done()
+		output.Close()
+	}()
+	buf := make([]byte, 65536)
+	for {
+		n, err := output.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&protos.StreamBuildLogResponse{Output: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			// Either the build is done, or the client went away.
+			return stream.Context().Err()
+		} else if err != nil {
+			return grpcstatus.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
 // clearFromDashboard calls the dashboard API to remove a build.
 // TODO(golang.org/issue/34744) - Remove after switching to wiping in the Coordinator.
 func (g *gRPCServer) clearFromDashboard(ctx context.Context, builder, hash, key string) error {
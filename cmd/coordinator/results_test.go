@@ -13,6 +13,8 @@ import (
 	"testing"
 
 	"golang.org/x/build/cmd/coordinator/protos"
+	"golang.org/x/build/internal/buildgo"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	grpcstatus "google.golang.org/grpc/status"
@@ -163,3 +165,76 @@ func TestClearResultsErrors(t *testing.T) {
 		})
 	}
 }
+
+// fakeBuildLogStream implements protos.Coordinator_StreamBuildLogServer for testing.
+type fakeBuildLogStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	out []byte
+}
+
+func (s *fakeBuildLogStream) Context() context.Context { return s.ctx }
+
+func (s *fakeBuildLogStream) Send(resp *protos.StreamBuildLogResponse) error {
+	s.out = append(s.out, resp.GetOutput()...)
+	return nil
+}
+
+func TestStreamBuildLog(t *testing.T) {
+	st := &buildStatus{
+		BuilderRev: buildgo.BuilderRev{Name: "linux-amd64", Rev: "ABCDEF1234567890"},
+	}
+	st.output.Write([]byte("##### Building Go.\nALL TESTS PASSED\n"))
+	st.output.Close()
+	statusMu.Lock()
+	statusDone = append(statusDone, st)
+	statusMu.Unlock()
+	defer func() {
+		statusMu.Lock()
+		statusDone = statusDone[:len(statusDone)-1]
+		statusMu.Unlock()
+	}()
+
+	gs := &gRPCServer{}
+	stream := &fakeBuildLogStream{ctx: context.Background()}
+	req := &protos.StreamBuildLogRequest{Builder: "linux-amd64", Rev: "ABCDEF1234567890"}
+	if err := gs.StreamBuildLog(req, stream); err != nil {
+		t.Fatalf("StreamBuildLog(%v) = %v, wanted no error", req, err)
+	}
+	if got, want := string(stream.out), st.output.String(); got != want {
+		t.Errorf("StreamBuildLog(%v) streamed %q, wanted %q", req, got, want)
+	}
+}
+
+func TestStreamBuildLogErrors(t *testing.T) {
+	cases := []struct {
+		desc     string
+		req      *protos.StreamBuildLogRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "missing builder",
+			req:      &protos.StreamBuildLogRequest{Rev: "ABCDEF1234567890"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "missing rev",
+			req:      &protos.StreamBuildLogRequest{Builder: "linux-amd64"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "unknown build",
+			req:      &protos.StreamBuildLogRequest{Builder: "linux-amd64", Rev: "0000000000000000"},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			gs := &gRPCServer{}
+			err := gs.StreamBuildLog(c.req, &fakeBuildLogStream{ctx: context.Background()})
+			if grpcstatus.Code(err) != c.wantCode {
+				t.Errorf("StreamBuildLog(%v) = %v, wanted %v", c.req, err, c.wantCode)
+			}
+		})
+	}
+}
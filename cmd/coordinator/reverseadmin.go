@@ -0,0 +1,87 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	_ "embed"
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+
+	"golang.org/x/build/internal/coordinator/pool"
+)
+
+//go:embed templates/reverse-admin.html
+var reverseAdminTemplateStr string
+
+var reverseAdminTemplate = template.Must(baseTmpl.New("reverse-admin.html").Parse(reverseAdminTemplateStr))
+
+// reverseAdminHost is a row of the reverse buildlet admin page.
+type reverseAdminHost struct {
+	Name      string
+	HostType  string // empty if not connected
+	Connected bool
+	Disabled  bool
+}
+
+// handleReverseAdmin serves a page listing the reverse buildlets,
+// with controls to disable and re-enable them. A POST with the form
+// values "host" and "action" ("disable" or "enable") changes a host.
+//
+// It must only be served to operators.
+func handleReverseAdmin(w http.ResponseWriter, r *http.Request) {
+	p := pool.ReversePool()
+	switch r.Method {
+	case "GET":
+	case "POST":
+		host := r.FormValue("host")
+		if host == "" {
+			http.Error(w, "missing host", http.StatusBadRequest)
+			return
+		}
+		switch action := r.FormValue("action"); action {
+		case "disable", "enable":
+			log.Printf("reverse buildlet admin: %s host %q (by %v)", action, host, r.Context().Value("email"))
+			p.SetHostDisabled(host, action == "disable")
+		default:
+			http.Error(w, `action must be "disable" or "enable"`, http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+		return
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	hosts := make(map[string]*reverseAdminHost)
+	for _, hs := range p.ReverseStatus().HostTypes {
+		for name, m := range hs.Machines {
+			hosts[name] = &reverseAdminHost{Name: name, HostType: m.HostType, Connected: true, Disabled: m.Disabled}
+		}
+	}
+	for _, name := range p.DisabledHosts() {
+		if hosts[name] == nil {
+			hosts[name] = &reverseAdminHost{Name: name, Disabled: true}
+		}
+	}
+	var data struct{ Hosts []*reverseAdminHost }
+	for _, h := range hosts {
+		data.Hosts = append(data.Hosts, h)
+	}
+	sort.Slice(data.Hosts, func(i, j int) bool {
+		a, b := data.Hosts[i], data.Hosts[j]
+		if a.HostType != b.HostType {
+			return a.HostType < b.HostType
+		}
+		return a.Name < b.Name
+	})
+	if err := reverseAdminTemplate.Execute(w, data); err != nil {
+		log.Printf("handleReverseAdmin: %v", err)
+	}
+}
@@ -518,6 +518,7 @@ func handleStatus(w http.ResponseWriter, r *http.Request) {
 		NumFD:          fdCount(),
 		NumGoroutine:   runtime.NumGoroutine(),
 		HealthCheckers: healthCheckers,
+		Paused:         ignoreAllNewWork.Load(),
 	}
 	for _, st := range status {
 		if st.HasBuildlet() {
@@ -642,6 +643,7 @@ type statusData struct {
 	DiskFree          string
 	Version           string
 	HealthCheckers    []*healthChecker
+	Paused            bool // not accepting new work; see /admin/pause
 }
 
 //go:embed templates/base.html
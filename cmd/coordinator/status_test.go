@@ -131,6 +131,10 @@ func TestStatusSched(t *testing.T) {
 					Try:      schedule.SchedulerWaitingState{Count: 1},
 					Regular:  schedule.SchedulerWaitingState{Count: 3},
 				},
+				{
+					HostType: "draining",
+					Draining: true,
+				},
 			},
 		},
 	}
@@ -144,6 +148,7 @@ func TestStatusSched(t *testing.T) {
 		`<li>try: 1 \(oldest 5m0s, newest 2s\)</li>`,
 		`(?s)<li><b>gomote-and-try</b>: 6 waiting \(oldest 4m0s, newest 3s\)`, // checks for no ", progress"
 		`<li>gomote: 2 \(oldest 4m0s, newest 3s\)</li>`,
+		`(?s)<li><b>draining</b> \(draining\): 0 waiting\s+</li>`,
 	}
 	for _, rx := range wantMatch {
 		matched, err := regexp.Match(rx, buf.Bytes())
@@ -0,0 +1,114 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// A timelineEvent is a phase of a build, as served by /status/timeline.json.
+// Events logged by a finished span have an End time and a Duration.
+// The others are either single points in time or spans that are
+// still running.
+type timelineEvent struct {
+	Event    string        `json:"event"`            // "get_source", "make_and_test", "make", etc
+	Detail   string        `json:"detail,omitempty"` // optional detail text
+	Start    time.Time     `json:"start"`
+	End      *time.Time    `json:"end,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"` // in nanoseconds
+	// EndDetail is the detail text logged when the span finished,
+	// such as "after 2.5s; err=..." for failures.
+	EndDetail string `json:"endDetail,omitempty"`
+}
+
+// buildTimeline is the JSON form of a build's event timeline.
+type buildTimeline struct {
+	BuildID   string          `json:"buildID"`
+	Builder   string          `json:"builder"`
+	Rev       string          `json:"rev"`
+	StartTime time.Time       `json:"startTime"`
+	Done      bool            `json:"done"`
+	Events    []timelineEvent `json:"events"`
+}
+
+// timelineEvents turns the events logged by a build into a timeline,
+// pairing each "finish_X" event with an unfinished "X". Spans of the same
+// name can run at the same time, such as run_tests_multi on several
+// helpers, so it pairs them by detail text too: a span's finish event
+// ends with "; " and its detail (see schedule.Span.Done). Otherwise, it
+// pairs it with the most recent unfinished "X" without detail text.
+func timelineEvents(events []eventAndTime) []timelineEvent {
+	out := make([]timelineEvent, 0, len(events))
+	open := make(map[string][]int) // event name -> indexes into out, innermost last
+	for _, e := range events {
+		if name, ok := strings.CutPrefix(e.evt, "finish_"); ok {
+			if s := open[name]; len(s) > 0 {
+				i := matchingSpan(out, s, e.text)
+				te := &out[s[i]]
+				open[name] = append(s[:i:i], s[i+1:]...)
+				end := e.t
+				te.End = &end
+				te.Duration = e.t.Sub(te.Start)
+				te.EndDetail = e.text
+				continue
+			}
+		}
+		open[e.evt] = append(open[e.evt], len(out))
+		out = append(out, timelineEvent{Event: e.evt, Detail: e.text, Start: e.t})
+	}
+	return out
+}
+
+// matchingSpan returns the index in open, the indexes into out of the
+// unfinished spans of one name, of the span that the finish event with
+// the given text ends.
+func matchingSpan(out []timelineEvent, open []int, finishText string) int {
+	for i := len(open) - 1; i >= 0; i-- {
+		if d := out[open[i]].Detail; d != "" && strings.HasSuffix(finishText, "; "+d) {
+			return i
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		if out[open[i]].Detail == "" {
+			return i
+		}
+	}
+	return len(open) - 1
+}
+
+// handleTimelineJSON serves the event timeline of a single build,
+// identified by its buildID, so that tools can show where its time went.
+func handleTimelineJSON(w http.ResponseWriter, r *http.Request) {
+	id := r.FormValue("buildID")
+	if id == "" {
+		http.Error(w, "missing buildID parameter", http.StatusBadRequest)
+		return
+	}
+	st := getStatusByBuildID(id)
+	if st == nil {
+		http.NotFound(w, r)
+		return
+	}
+	st.mu.Lock()
+	tl := buildTimeline{
+		BuildID:   st.buildID,
+		Builder:   st.Name,
+		Rev:       st.Rev,
+		StartTime: st.startTime,
+		Done:      !st.done.IsZero(),
+		Events:    timelineEvents(st.events),
+	}
+	st.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	e := json.NewEncoder(w)
+	e.SetIndent("", "\t")
+	e.Encode(tl)
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimelineEvents(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return t0.Add(time.Duration(sec) * time.Second) }
+	events := []eventAndTime{
+		{t: at(0), evt: "get_buildlet"},
+		{t: at(5), evt: "finish_get_buildlet", text: "after 5s"},
+		{t: at(5), evt: "running_exec", text: "src/make.bash"},
+		{t: at(6), evt: "make"},
+		{t: at(7), evt: "make", text: "nested"},
+		{t: at(9), evt: "finish_make", text: "after 2s; nested"},
+		{t: at(20), evt: "finish_make", text: "after 14s; err=exit status 1"},
+		{t: at(21), evt: "run_tests"},
+		{t: at(22), evt: "finish_unknown"},
+		// Concurrent spans of the same name on different helpers.
+		{t: at(30), evt: "run_tests_multi", text: "helper-1: [a b]"},
+		{t: at(31), evt: "run_tests_multi", text: "helper-2: [c d]"},
+		{t: at(35), evt: "finish_run_tests_multi", text: "after 5s; helper-1: [a b]"},
+		{t: at(40), evt: "finish_run_tests_multi", text: "after 9s; err=exit status 1; helper-2: [c d]"},
+	}
+	got := timelineEvents(events)
+
+	type want struct {
+		event     string
+		detail    string
+		start     time.Time
+		dur       time.Duration
+		endDetail string
+	}
+	wants := []want{
+		{"get_buildlet", "", at(0), 5 * time.Second, "after 5s"},
+		{"running_exec", "src/make.bash", at(5), 0, ""},
+		{"make", "", at(6), 14 * time.Second, "after 14s; err=exit status 1"},
+		{"make", "nested", at(7), 2 * time.Second, "after 2s; nested"},
+		{"run_tests", "", at(21), 0, ""},
+		{"finish_unknown", "", at(22), 0, ""},
+		{"run_tests_multi", "helper-1: [a b]", at(30), 5 * time.Second, "after 5s; helper-1: [a b]"},
+		{"run_tests_multi", "helper-2: [c d]", at(31), 9 * time.Second, "after 9s; err=exit status 1; helper-2: [c d]"},
+	}
+	if len(got) != len(wants) {
+		t.Fatalf("timelineEvents returned %d events, want %d: %+v", len(got), len(wants), got)
+	}
+	for i, w := range wants {
+		g := got[i]
+		if g.Event != w.event || g.Detail != w.detail || !g.Start.Equal(w.start) || g.Duration != w.dur || g.EndDetail != w.endDetail {
+			t.Errorf("event %d = %+v, want %+v", i, g, w)
+		}
+		if (g.End != nil) != (w.dur != 0) {
+			t.Errorf("event %d: End = %v, want set only for finished spans", i, g.End)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/repos"
+)
+
+var (
+	tryDisabledProjects    = flag.String("try_disabled_projects", "", "Comma-separated Gerrit projects (e.g. \"net,tools\") for which trybots are disabled, on top of what the repos package allows.")
+	tryDisabledProjectsURL = flag.String("try_disabled_projects_url", "", "Optional gs://bucket/object listing more Gerrit projects for which trybots are disabled, one per line. It's reloaded every minute, so trybots can be disabled and re-enabled without a restart.")
+)
+
+// tryOverrides holds the Gerrit projects for which trybots are disabled
+// at runtime, even though repos.ByGerritProject allows them.
+var tryOverrides struct {
+	mu     sync.Mutex
+	flag   map[string]bool // from -try_disabled_projects
+	remote map[string]bool // from -try_disabled_projects_url
+}
+
+// tryDisabled reports whether trybots for the Gerrit project have been
+// disabled by -try_disabled_projects or -try_disabled_projects_url.
+func tryDisabled(project string) bool {
+	tryOverrides.mu.Lock()
+	defer tryOverrides.mu.Unlock()
+	return tryOverrides.flag[project] || tryOverrides.remote[project]
+}
+
+// initTryOverrides loads the trybot project overrides from flags, and
+// starts polling -try_disabled_projects_url if set.
+func initTryOverrides() {
+	disabled, err := parseTryDisabledProjects(*tryDisabledProjects)
+	if err != nil {
+		log.Fatalf("invalid -try_disabled_projects: %v", err)
+	}
+	if len(disabled) > 0 {
+		log.Printf("trybots disabled by -try_disabled_projects for: %s", strings.Join(sortedKeys(disabled), ", "))
+	}
+	tryOverrides.mu.Lock()
+	tryOverrides.flag = disabled
+	tryOverrides.mu.Unlock()
+
+	if *tryDisabledProjectsURL == "" {
+		return
+	}
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(*tryDisabledProjectsURL, "gs://"), "/")
+	if !strings.HasPrefix(*tryDisabledProjectsURL, "gs://") || !ok || bucket == "" || object == "" {
+		log.Fatalf("invalid -try_disabled_projects_url %q; want gs://bucket/object", *tryDisabledProjectsURL)
+	}
+	go func() {
+		for {
+			if err := reloadTryDisabledProjects(bucket, object); err != nil {
+				log.Printf("reloading trybot overrides from %s: %v", *tryDisabledProjectsURL, err)
+			}
+			time.Sleep(time.Minute)
+		}
+	}()
+}
+
+// reloadTryDisabledProjects reads the disabled projects from the given
+// GCS object and logs any change. On error, the previous set is kept.
+func reloadTryDisabledProjects(bucket, object string) error {
+	sc := pool.NewGCEConfiguration().StorageClient()
+	if sc == nil {
+		return errors.New("GCE configuration missing storage client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	r, err := sc.Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, 1<<20))
+	if err != nil {
+		return err
+	}
+	disabled, err := parseTryDisabledProjects(string(data))
+	if err != nil {
+		return err
+	}
+
+	tryOverrides.mu.Lock()
+	old := tryOverrides.remote
+	tryOverrides.remote = disabled
+	tryOverrides.mu.Unlock()
+
+	for _, p := range sortedKeys(disabled) {
+		if !old[p] {
+			log.Printf("trybots for %q disabled by %s", p, *tryDisabledProjectsURL)
+		}
+	}
+	for _, p := range sortedKeys(old) {
+		if !disabled[p] {
+			log.Printf("trybots for %q re-enabled by %s", p, *tryDisabledProjectsURL)
+		}
+	}
+	return nil
+}
+
+// parseTryDisabledProjects parses a list of Gerrit project names
+// separated by commas or whitespace. Text following a '#' on a line
+// is a comment. It's an error to name a project that the coordinator
+// doesn't build, since that's most likely a typo.
+func parseTryDisabledProjects(s string) (map[string]bool, error) {
+	disabled := make(map[string]bool)
+	for _, line := range strings.Split(s, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		for _, p := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\r' }) {
+			if r, ok := repos.ByGerritProject[p]; !ok || !r.CoordinatorCanBuild {
+				return nil, fmt.Errorf("unknown Gerrit project %q", p)
+			}
+			disabled[p] = true
+		}
+	}
+	return disabled, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTryDisabledProjects(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    map[string]bool
+		wantErr bool
+	}{
+		{in: "", want: map[string]bool{}},
+		{in: "net", want: map[string]bool{"net": true}},
+		{in: "net,tools", want: map[string]bool{"net": true, "tools": true}},
+		{in: "net, tools", want: map[string]bool{"net": true, "tools": true}},
+		{in: "# spurious failures, go.dev/issue/12345\nnet\ntools # flaky\n", want: map[string]bool{"net": true, "tools": true}},
+		{in: "net,nosuchrepo", wantErr: true},
+		{in: "gofrontend", wantErr: true}, // known to Gerrit, but not built by the coordinator
+	} {
+		got, err := parseTryDisabledProjects(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseTryDisabledProjects(%q) error = %v; want error: %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr {
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("parseTryDisabledProjects(%q) mismatch (-want +got):\n%s", tc.in, diff)
+		}
+	}
+}
+
+func TestTryDisabled(t *testing.T) {
+	defer func() {
+		tryOverrides.flag, tryOverrides.remote = nil, nil
+	}()
+	tryOverrides.flag = map[string]bool{"net": true}
+	tryOverrides.remote = map[string]bool{"tools": true}
+	for project, want := range map[string]bool{"net": true, "tools": true, "go": false, "text": false} {
+		if got := tryDisabled(project); got != want {
+			t.Errorf("tryDisabled(%q) = %v; want %v", project, got, want)
+		}
+	}
+}
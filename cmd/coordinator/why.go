@@ -0,0 +1,135 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/types"
+)
+
+// handleWhy serves /why?builder=&rev=&repo=, which replays findWork's
+// decision for a builder on a post-submit commit and reports why it
+// would or wouldn't be scheduled. repo defaults to "go".
+func handleWhy(w http.ResponseWriter, r *http.Request) {
+	builder, rev, repo := r.FormValue("builder"), r.FormValue("rev"), r.FormValue("repo")
+	if repo == "" {
+		repo = "go"
+	}
+	if builder == "" || rev == "" {
+		http.Error(w, "missing builder or rev parameter", http.StatusBadRequest)
+		return
+	}
+	var bs types.BuildStatus
+	if err := dash("GET", "", url.Values{
+		"mode":   {"json"},
+		"branch": {"mixed"},
+	}, nil, &bs); err != nil {
+		http.Error(w, fmt.Sprintf("fetching dashboard status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, line := range whyPostSubmit(&bs, builder, repo, rev) {
+		fmt.Fprintln(w, line)
+	}
+}
+
+// whyPostSubmit replays findWork over the dashboard status bs for
+// builder on the commits of repo whose hash starts with rev. It returns
+// one line for each such commit, and Go branch for subrepos, saying
+// whether findWork would schedule a build and if not, why.
+func whyPostSubmit(bs *types.BuildStatus, builder, repo, rev string) []string {
+	if reason := repoSkipReason(repo); reason != "" {
+		return []string{fmt.Sprintf("not scheduled: %s", reason)}
+	}
+	builderIndex := -1
+	for i, b := range bs.Builders {
+		if b == builder {
+			builderIndex = i
+			break
+		}
+	}
+
+	var lines []string
+	seenSubrepo := make(map[string]bool)
+	for _, br := range bs.Revisions {
+		if repoSkipReason(br.Repo) != "" {
+			continue
+		}
+		// Track which subrepo revisions await a snapshot the same
+		// way findWork does.
+		awaitSnapshot := br.Repo != "go" && !seenSubrepo[br.Repo]
+		if br.Repo != "go" {
+			seenSubrepo[br.Repo] = true
+		}
+		if br.Repo != repo || !strings.HasPrefix(br.Revision, rev) {
+			continue
+		}
+		what := br.Revision
+		if br.Repo != "go" {
+			what = fmt.Sprintf("%s with Go %s (%s)", br.Revision, br.GoRevision, br.GoBranch)
+		}
+
+		var work buildgo.BuilderRev
+		var skip string
+		switch {
+		case builderIndex >= 0 && builderIndex < len(br.Results):
+			work, skip = postSubmitWork(&br, builder, br.Results[builderIndex], awaitSnapshot)
+		case builderIndex >= 0:
+			skip = "bogus JSON response from dashboard"
+		default:
+			// The dashboard doesn't know about the builder, so
+			// findWork may bootstrap it on the main repo.
+			work, skip = bootstrapWork(&br, builder)
+		}
+		if skip == "" {
+			if ignoreAllNewWork.Load() {
+				skip = "the coordinator is ignoring all new work"
+			} else {
+				skip = cantBuildRevReason(work)
+			}
+		}
+		if skip != "" {
+			lines = append(lines, fmt.Sprintf("%s: not scheduled: %s", what, skip))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: would be scheduled", what))
+		}
+	}
+	if len(lines) == 0 {
+		return []string{fmt.Sprintf("not scheduled: no commit of repo %q matching %q is among the dashboard's recent commits", repo, rev)}
+	}
+	return lines
+}
+
+// bootstrapWork returns the work findWork adds to bootstrap builder,
+// which the dashboard doesn't know about yet, on the dashboard revision
+// br, or the reason it doesn't.
+func bootstrapWork(br *types.BuildRevision, builder string) (rev buildgo.BuilderRev, skip string) {
+	builderInfo, ok := dashboard.ActiveBuilders()[builder]
+	if !ok {
+		return rev, "the builder isn't managed by the coordinator"
+	}
+	if br.Repo != "go" {
+		return rev, "the dashboard doesn't know about the builder yet, and it's only bootstrapped on the main repo"
+	}
+	switch {
+	case builderInfo.BuildsRepoPostSubmit("go", "master", "master"):
+		if br.Branch == "master" {
+			return buildgo.BuilderRev{Name: builder, Rev: br.Revision}, ""
+		}
+	case builderInfo.BuildsRepoPostSubmit("go", "dev.typeparams", "dev.typeparams"):
+		if br.Branch == "dev.typeparams" {
+			return buildgo.BuilderRev{Name: builder, Rev: br.Revision}, ""
+		}
+	}
+	return rev, fmt.Sprintf("the dashboard doesn't know about the builder yet, and it isn't bootstrapped on branch %q", br.Branch)
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/build/types"
+)
+
+func TestWhyPostSubmit(t *testing.T) {
+	bs := &types.BuildStatus{
+		Builders: []string{"linux-amd64", "no-such-builder"},
+		Revisions: []types.BuildRevision{
+			{Repo: "go", Revision: "aaaa1111", Branch: "master", Results: []string{"ok", ""}},
+			{Repo: "go", Revision: "bbbb2222", Branch: "master", Results: []string{"https://example.com/log/1", ""}},
+		},
+	}
+	tests := []struct {
+		builder, repo, rev string
+		want               string
+	}{
+		{"linux-amd64", "go", "aaaa", "aaaa1111: not scheduled: the dashboard already has a result: ok"},
+		{"linux-amd64", "go", "bbbb", "bbbb2222: not scheduled: the dashboard already has a result: https://example.com/log/1"},
+		{"no-such-builder", "go", "aaaa", "aaaa1111: not scheduled: the builder isn't managed by the coordinator"},
+		{"unknown-to-dashboard", "go", "aaaa", "aaaa1111: not scheduled: the builder isn't managed by the coordinator"},
+		{"linux-amd64", "go", "cccc", `not scheduled: no commit of repo "go" matching "cccc"`},
+		{"linux-amd64", "no-such-repo", "aaaa", `not scheduled: repo "no-such-repo" isn't built by the coordinator`},
+	}
+	for _, tt := range tests {
+		got := whyPostSubmit(bs, tt.builder, tt.repo, tt.rev)
+		if len(got) != 1 || !strings.HasPrefix(got[0], tt.want) {
+			t.Errorf("whyPostSubmit(%q, %q, %q) = %q; want one line starting with %q", tt.builder, tt.repo, tt.rev, got, tt.want)
+		}
+	}
+}
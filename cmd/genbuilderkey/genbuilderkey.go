@@ -3,7 +3,8 @@
 // license that can be found in the LICENSE file.
 
 // The genbuilderkey binary generates a builder key or gomote user key
-// from the build system's master key.
+// from the build system's master key. With -token-ttl, it instead
+// generates a reverse buildlet registration token for a host type.
 package main
 
 import (
@@ -18,11 +19,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"golang.org/x/build/buildenv"
+	"golang.org/x/build/buildlet"
 	"golang.org/x/build/internal/secret"
 )
 
+var tokenTTL = flag.Duration("token-ttl", 0, "If non-zero, print a reverse buildlet registration token for the host type that's valid for this long, instead of its builder key. Reverse buildlets read it from $HOME/.gobuildtoken-<host type>.")
+
 func usage() {
 	fmt.Fprintln(os.Stderr, "Usage: genbuilderkey <Host Type>")
 	fmt.Fprintln(os.Stderr)
@@ -42,6 +47,10 @@ func main() {
 		flag.Usage()
 		os.Exit(2)
 	}
+	if *tokenTTL > 0 {
+		fmt.Println(buildlet.RegistrationToken(getMasterKey(), flag.Arg(0), time.Now().Add(*tokenTTL)))
+		return
+	}
 	fmt.Println(key(flag.Arg(0)))
 }
 
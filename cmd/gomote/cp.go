@@ -0,0 +1,100 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+
+	"golang.org/x/build/internal/gomote/protos"
+	"golang.org/x/sync/errgroup"
+)
+
+// cp copies a directory from one buildlet to others.
+func cp(args []string) error {
+	fs := flag.NewFlagSet("cp", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("cp usage: gomote cp [cp-opts] <src-instance> [dst-instance]")
+		log.Print("")
+		log.Print("Copies a directory from the source buildlet's work dir to the")
+		log.Print("destination buildlet's work dir, without downloading it locally.")
+		log.Print("")
+		log.Print("The destination instance is optional if a group is selected,")
+		log.Print("in which case the directory is copied to all the other")
+		log.Print("buildlets in the group.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var dir, destDir string
+	fs.StringVar(&dir, "dir", "", "relative directory from the source buildlet's work dir to copy")
+	fs.StringVar(&destDir, "dest-dir", "", "relative directory from the destination buildlet's work dir to extract into; defaults to -dir")
+	fs.Parse(args)
+	if destDir == "" {
+		destDir = dir
+	}
+
+	var src string
+	var dstSet []string
+	switch {
+	case fs.NArg() == 2:
+		src = fs.Arg(0)
+		dstSet = []string{fs.Arg(1)}
+	case fs.NArg() == 1 && activeGroup != nil:
+		src = fs.Arg(0)
+		for _, inst := range activeGroup.Instances {
+			if inst != src {
+				dstSet = append(dstSet, inst)
+			}
+		}
+		if len(dstSet) == 0 {
+			return fmt.Errorf("no other instances in group %q to copy to", activeGroup.Name)
+		}
+	default:
+		fs.Usage()
+	}
+
+	ctx := context.Background()
+	tarURL, err := doCopyFrom(ctx, src, dir)
+	if err != nil {
+		return err
+	}
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, inst := range dstSet {
+		inst := inst
+		eg.Go(func() error {
+			log.Printf("Copying %q from %q to %q...\n", dir, src, inst)
+			return doPutTarURL(ctx, inst, destDir, tarURL)
+		})
+	}
+	return eg.Wait()
+}
+
+// doCopyFrom has the gomote server store a tar.gz of dir on the src
+// instance, and returns a URL to it that the server accepts in
+// WriteTGZFromURL requests.
+func doCopyFrom(ctx context.Context, src, dir string) (string, error) {
+	client := gomoteServerClient(ctx)
+	resp, err := client.ReadTGZToURL(ctx, &protos.ReadTGZToURLRequest{
+		GomoteId:  src,
+		Directory: dir,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve tgz URL: %w", err)
+	}
+	// The returned URL is signed for download. Drop the signature, so
+	// the server recognizes the object as being in its own bucket and
+	// re-signs it for the destination instance.
+	u, err := url.Parse(resp.GetUrl())
+	if err != nil {
+		return "", fmt.Errorf("unable to parse tgz URL: %w", err)
+	}
+	u.RawQuery = ""
+	return u.String(), nil
+}
@@ -95,7 +95,12 @@ func create(args []string) error {
 	fs := flag.NewFlagSet("create", flag.ContinueOnError)
 	fs.Usage = func() {
 		log := usageLogger
-		log.Print("create usage: gomote create [create-opts] <type>")
+		log.Print("create usage: gomote create [create-opts] <type> [<type>...]")
+		log.Print()
+		log.Print("If more than one builder type is given, -count instances of")
+		log.Print("each type are created together, and added to a group. If no")
+		log.Print("group is specified, a new one is created with a generated name.")
+		log.Print("-setup isn't supported with more than one builder type.")
 		log.Print()
 		log.Print("If there's a valid group specified, new instances are")
 		log.Print("automatically added to the group. If the group in")
@@ -151,6 +156,8 @@ func create(args []string) error {
 	fs.BoolVar(&cfg.setup, "setup", false, "set up the instance by pushing GOROOT and building the Go toolchain")
 	fs.StringVar(&cfg.newGroup, "new-group", "", "also create a new group and add the new instances to it")
 	fs.BoolVar(&cfg.useGolangbuild, "use-golangbuild", true, "disable the installation of build dependencies installed by golangbuild")
+	fs.StringVar(&cfg.image, "image", "", "create the instance from this base image revision instead of the builder's current one; must be one the builder allows")
+	fs.BoolVar(&cfg.jsonOut, "json", false, "print the created instances as a JSON array instead of their names")
 
 	fs.Parse(args)
 	if listBuilders {
@@ -169,9 +176,13 @@ func create(args []string) error {
 		}
 		return nil
 	}
-	if fs.NArg() != 1 {
+	if fs.NArg() == 0 {
 		fs.Usage()
 	}
+	if fs.NArg() > 1 {
+		_, _, err := createMixedInstances(context.Background(), fs.Args(), &cfg)
+		return err
+	}
 	builderType := fs.Arg(0)
 	_, _, err := createInstances(context.Background(), builderType, &cfg)
 	return err
@@ -183,6 +194,8 @@ type createConfig struct {
 	setup          bool
 	newGroup       string
 	useGolangbuild bool
+	image          string
+	jsonOut        bool
 }
 
 func createInstances(ctx context.Context, builderType string, cfg *createConfig) ([]string, *groupData, error) {
@@ -204,6 +217,7 @@ func createInstances(ctx context.Context, builderType string, cfg *createConfig)
 
 	var instancesMu sync.Mutex
 	var instances []string
+	var infos []*protos.Instance // for -json
 	var tmpOutDir string
 	var tmpOutDirOnce sync.Once
 	eg, ctx := errgroup.WithContext(ctx)
@@ -216,11 +230,12 @@ func createInstances(ctx context.Context, builderType string, cfg *createConfig)
 			if !cfg.useGolangbuild {
 				exp = append(exp, "disable-golang-build")
 			}
-			stream, err := client.CreateInstance(ctx, &protos.CreateInstanceRequest{BuilderType: builderType, ExperimentOption: exp})
+			stream, err := client.CreateInstance(ctx, &protos.CreateInstanceRequest{BuilderType: builderType, ExperimentOption: exp, Image: cfg.image})
 			if err != nil {
 				return fmt.Errorf("failed to create buildlet: %w", err)
 			}
 			var inst string
+			var info *protos.Instance
 		updateLoop:
 			for {
 				update, err := stream.Recv()
@@ -232,13 +247,17 @@ func createInstances(ctx context.Context, builderType string, cfg *createConfig)
 				case update.GetStatus() != protos.CreateInstanceResponse_COMPLETE && cfg.printStatus:
 					log.Printf("still creating %s (%d) after %v; %d requests ahead of you\n", builderType, i+1, time.Since(start).Round(time.Second), update.GetWaitersAhead())
 				case update.GetStatus() == protos.CreateInstanceResponse_COMPLETE:
-					inst = update.GetInstance().GetGomoteId()
+					info = update.GetInstance()
+					inst = info.GetGomoteId()
 				}
 			}
-			fmt.Println(inst)
+			if !cfg.jsonOut {
+				fmt.Println(inst)
+			}
 
 			instancesMu.Lock()
 			instances = append(instances, inst)
+			infos = append(infos, info)
 			instancesMu.Unlock()
 
 			if group != nil {
@@ -260,7 +279,7 @@ func createInstances(ctx context.Context, builderType string, cfg *createConfig)
 			}
 
 			// Push GOROOT.
-			detailedProgress := cfg.count == 1
+			detailedProgress := cfg.count == 1 && !cfg.jsonOut
 			goroot, err := getGOROOT()
 			if err != nil {
 				return err
@@ -308,5 +327,123 @@ func createInstances(ctx context.Context, builderType string, cfg *createConfig)
 			return nil, nil, err
 		}
 	}
+	if cfg.jsonOut {
+		var groups []*groupData
+		if group != nil {
+			groups = append(groups, group)
+		}
+		out := make([]instanceInfo, len(infos))
+		for i, info := range infos {
+			out[i] = newInstanceInfo(info, groups)
+		}
+		if err := printJSON(out); err != nil {
+			return nil, nil, err
+		}
+	}
+	return instances, group, nil
+}
+
+// createMixedInstances creates cfg.count instances of each of the
+// builderTypes with a single CreateInstances call, and adds them to the
+// active group, or to a new group named after the one the server
+// generated if there is none. Instances that fail to be created are
+// reported, but don't prevent the others from being added to the group.
+func createMixedInstances(ctx context.Context, builderTypes []string, cfg *createConfig) ([]string, *groupData, error) {
+	if cfg.setup {
+		return nil, nil, fmt.Errorf("-setup isn't supported with more than one builder type")
+	}
+	group := activeGroup
+	var err error
+	if cfg.newGroup != "" {
+		group, err = doCreateGroup(cfg.newGroup)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if group == nil && os.Getenv("GOMOTE_GROUP") != "" {
+		group, err = doCreateGroup(os.Getenv("GOMOTE_GROUP"))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	req := &protos.CreateInstancesRequest{}
+	if group != nil {
+		req.GroupId = group.Name
+	}
+	var exp []string
+	if !cfg.useGolangbuild {
+		exp = append(exp, "disable-golang-build")
+	}
+	for _, bt := range builderTypes {
+		req.Builders = append(req.Builders, &protos.CreateInstancesRequest_Builder{
+			BuilderType:      bt,
+			Count:            int32(cfg.count),
+			ExperimentOption: exp,
+		})
+	}
+
+	start := time.Now()
+	client := gomoteServerClient(ctx)
+	stream, err := client.CreateInstances(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create buildlets: %w", err)
+	}
+	var instances []string
+	var infos []*protos.Instance // for -json
+	var failed int
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create buildlets: %w", err)
+		}
+		if group == nil {
+			group, err = doCreateGroup(update.GetGroupId())
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		switch {
+		case update.GetError() != "":
+			failed++
+			log.Printf("failed to create %s (%d): %s\n", update.GetBuilderType(), update.GetIndex()+1, update.GetError())
+		case update.GetUpdate().GetStatus() == protos.CreateInstanceResponse_COMPLETE:
+			info := update.GetUpdate().GetInstance()
+			if !cfg.jsonOut {
+				fmt.Println(info.GetGomoteId())
+			}
+			instances = append(instances, info.GetGomoteId())
+			infos = append(infos, info)
+			group.Instances = append(group.Instances, info.GetGomoteId())
+		case cfg.printStatus:
+			log.Printf("still creating %s (%d) after %v; %d requests ahead of you\n", update.GetBuilderType(), update.GetIndex()+1, time.Since(start).Round(time.Second), update.GetUpdate().GetWaitersAhead())
+		}
+	}
+	if group != nil {
+		if err := storeGroup(group); err != nil {
+			return nil, nil, err
+		}
+		if !cfg.jsonOut && group != activeGroup {
+			log.Printf("added %d instances to group %q\n", len(instances), group.Name)
+		}
+	}
+	if cfg.jsonOut {
+		var groups []*groupData
+		if group != nil {
+			groups = append(groups, group)
+		}
+		out := make([]instanceInfo, len(infos))
+		for i, info := range infos {
+			out[i] = newInstanceInfo(info, groups)
+		}
+		if err := printJSON(out); err != nil {
+			return nil, nil, err
+		}
+	}
+	if failed > 0 {
+		return instances, group, fmt.Errorf("failed to create %d of %d instances", failed, failed+len(instances))
+	}
 	return instances, group, nil
 }
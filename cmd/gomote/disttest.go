@@ -0,0 +1,206 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/build/buildenv"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/internal/buildstats"
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func distTest(args []string) error {
+	fs := flag.NewFlagSet("disttest", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("disttest usage: gomote disttest [disttest-opts] <instance>")
+		log.Print("")
+		log.Print("Runs the Go distribution's tests on the instance with")
+		log.Print("'go tool dist test', the way the coordinator would for the")
+		log.Print("instance's builder: only the tests its policy runs in")
+		log.Print("post-submit builds, in its environment, split into shards")
+		log.Print("balanced on its recorded test durations. The instance's go")
+		log.Print("directory must already be built, for example with")
+		log.Print("'gomote run <instance> go/src/make.bash'.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var builderEnv string
+	fs.StringVar(&builderEnv, "builderenv", "", "Optional alternate builder to act like, as with 'gomote run -builderenv'. Its configuration decides which tests run and how.")
+	var race bool
+	fs.BoolVar(&race, "race", false, "run the tests in race mode, as race builders do")
+	var compileOnly bool
+	fs.BoolVar(&compileOnly, "compile-only", false, "only compile the tests, as compile-only builders do")
+	var useStats bool
+	fs.BoolVar(&useStats, "stats", true, "balance shards on the builder's median test durations, read from BigQuery")
+	var keepGoing bool
+	fs.BoolVar(&keepGoing, "k", false, "keep going after a failed test within a shard")
+	var list bool
+	fs.BoolVar(&list, "list", false, "print the shards and the tests in them, but don't run them")
+	var shard int
+	fs.IntVar(&shard, "shard", 0, "if non-zero, only run the shard with this number, as printed by -list")
+
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+	}
+	inst := fs.Arg(0)
+
+	ctx := context.Background()
+	builder := builderEnv
+	if builder == "" {
+		resp, err := gomoteServerClient(ctx).GetBuilderEnvironment(ctx, &protos.GetBuilderEnvironmentRequest{
+			GomoteId: inst,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to get builder environment: %w", err)
+		}
+		builder = resp.GetBuilderType()
+	}
+	conf, ok := dashboard.Builders[builder]
+	if !ok {
+		return fmt.Errorf("unknown builder %q", builder)
+	}
+	race = race || conf.IsRace()
+	compileOnly = compileOnly || conf.CompileOnly
+
+	listArgs := []string{"tool", "dist", "test", "--no-rebuild", "--list"}
+	listArgs = append(listArgs, distTestModeArgs(race, compileOnly)...)
+	var buf strings.Builder
+	if err := doRun(ctx, inst, "go/bin/go", listArgs, distTestRunOpts(builderEnv, &buf)...); err != nil {
+		return fmt.Errorf("listing dist tests: %w\n%s", err, buf.String())
+	}
+	tests := distTestsToRun(conf, buildgo.Go120DistTestNames(strings.Fields(buf.String())))
+
+	var stats *buildstats.TestStats // nil means unknown durations
+	if useStats {
+		var err error
+		stats, err = buildstats.QueryTestStats(ctx, buildenv.Production)
+		if err != nil {
+			log.Printf("unable to read recorded test durations; assuming %v for each test: %v", stats.Duration(builder, ""), err)
+			stats = nil
+		}
+	}
+	shards := distTestShards(stats.Duration, builder, tests)
+	if shard < 0 || shard > len(shards) {
+		return fmt.Errorf("-shard must be between 1 and %d", len(shards))
+	}
+
+	if list {
+		for i, tests := range shards {
+			fmt.Printf("%d:", i+1)
+			for _, test := range tests {
+				fmt.Printf(" %s", test.Raw)
+			}
+			fmt.Println()
+		}
+		return nil
+	}
+
+	var failed []int
+	for i, tests := range shards {
+		if shard != 0 && shard != i+1 {
+			continue
+		}
+		testArgs := []string{"tool", "dist", "test", "--no-rebuild"}
+		testArgs = append(testArgs, distTestModeArgs(race, compileOnly)...)
+		if keepGoing {
+			testArgs = append(testArgs, "-k")
+		}
+		for _, test := range tests {
+			testArgs = append(testArgs, test.Raw)
+		}
+		fmt.Printf("# shard %d of %d (%d tests)\n", i+1, len(shards), len(tests))
+		err := doRun(ctx, inst, "go/bin/go", testArgs, distTestRunOpts(builderEnv, os.Stdout)...)
+		var ce *cmdFailedError
+		if errors.As(err, &ce) {
+			failed = append(failed, i+1)
+			continue
+		} else if err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed shards: %v", failed)
+	}
+	return nil
+}
+
+// distTestModeArgs returns the flags that select the mode 'go tool dist
+// test' is run in, both when listing and running tests.
+func distTestModeArgs(race, compileOnly bool) []string {
+	var args []string
+	if race {
+		args = append(args, "--race")
+	}
+	if compileOnly {
+		args = append(args, "--compile-only")
+	}
+	return args
+}
+
+// distTestRunOpts returns the options to run go/bin/go with on the
+// instance, matching how the coordinator runs dist tests. The gomote
+// server adds the environment of the instance's builder, or of
+// builderEnv if it's non-empty.
+func distTestRunOpts(builderEnv string, w io.Writer) []runOpt {
+	return []runOpt{
+		runBuilderEnv(builderEnv),
+		runDir("."),
+		runPath([]string{"$WORKDIR/go/bin", "$PATH"}),
+		runWriters(w),
+	}
+}
+
+// distTestsToRun returns the tests among tests that conf's dist test
+// policy runs in post-submit builds.
+func distTestsToRun(conf *dashboard.BuildConfig, tests []buildgo.DistTestName) []buildgo.DistTestName {
+	var run []buildgo.DistTestName
+	for _, test := range tests {
+		if conf.ShouldRunDistTest(test.Old, false) {
+			run = append(run, test)
+		}
+	}
+	return run
+}
+
+// distTestShards splits tests into the shards the coordinator would run
+// them in on builder: the go_test:* tests are grouped together, using
+// testDuration to estimate how long each takes on builder, and the rest
+// are each run by themselves.
+func distTestShards(testDuration func(builder, testName string) time.Duration, builder string, tests []buildgo.DistTestName) [][]buildgo.DistTestName {
+	names := make([]string, len(tests))
+	named := make(map[string]buildgo.DistTestName)
+	for i, test := range tests {
+		names[i] = test.Old
+		named[test.Old] = test
+	}
+
+	var shards [][]buildgo.DistTestName
+	for _, set := range buildgo.PartitionGoTests(testDuration, builder, names) {
+		shard := make([]buildgo.DistTestName, len(set))
+		for i, name := range set {
+			shard[i] = named[name]
+		}
+		shards = append(shards, shard)
+	}
+	for _, test := range tests {
+		if !strings.HasPrefix(test.Old, "go_test:") {
+			shards = append(shards, []buildgo.DistTestName{test})
+		}
+	}
+	return shards
+}
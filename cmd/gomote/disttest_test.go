@@ -0,0 +1,89 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/buildgo"
+)
+
+func TestDistTestShards(t *testing.T) {
+	in := "cmd/go cmd/api:check archive/tar archive/zip bufio bytes cmd/internal/testdir:0_2 cmd/internal/testdir:1_2"
+	for _, tc := range []struct {
+		name      string
+		durations map[string]time.Duration // on builder; 3s if missing
+		want      [][]string
+	}{
+		{
+			name: "unknown durations",
+			want: [][]string{
+				{"archive/tar", "archive/zip", "bufio"},
+				{"bytes", "cmd/go"},
+				{"cmd/api:check"},
+				{"cmd/internal/testdir:0_2"},
+				{"cmd/internal/testdir:1_2"},
+			},
+		},
+		{
+			name: "recorded durations",
+			durations: map[string]time.Duration{
+				"go_test:archive/tar": 8 * time.Second,
+				"go_test:archive/zip": 1 * time.Second,
+				"go_test:bufio":       1 * time.Second,
+				"go_test:bytes":       1 * time.Second,
+				"go_test:cmd/go":      30 * time.Second,
+			},
+			want: [][]string{
+				{"archive/tar", "archive/zip", "bufio"},
+				{"bytes"},
+				{"cmd/go"},
+				{"cmd/api:check"},
+				{"cmd/internal/testdir:0_2"},
+				{"cmd/internal/testdir:1_2"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testDuration := func(builder, testName string) time.Duration {
+				if builder != "linux-amd64" {
+					t.Errorf("testDuration called for builder %q, want linux-amd64", builder)
+				}
+				if d, ok := tc.durations[testName]; ok {
+					return d
+				}
+				return 3 * time.Second
+			}
+			var got [][]string
+			for _, shard := range distTestShards(testDuration, "linux-amd64", buildgo.Go120DistTestNames(strings.Fields(in))) {
+				var raw []string
+				for _, test := range shard {
+					raw = append(raw, test.Raw)
+				}
+				got = append(got, raw)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("distTestShards mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDistTestsToRun(t *testing.T) {
+	in := buildgo.Go120DistTestNames(strings.Fields("archive/tar cmd/api:check cmd/internal/testdir:0_2 cmd/internal/bootstrap_test"))
+	var got []string
+	for _, test := range distTestsToRun(dashboard.Builders["openbsd-amd64-72"], in) {
+		got = append(got, test.Raw)
+	}
+	// openbsd-amd64-72 skips the test directory and reboot tests.
+	want := []string{"archive/tar", "cmd/api:check"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("distTestsToRun mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+// env prints the environment and configuration of an instance's builder.
+func env(args []string) error {
+	fs := flag.NewFlagSet("env", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("env usage: gomote env [instance]")
+		log.Print("")
+		log.Print("Prints the environment variables and configuration of the builder")
+		log.Print("that the instance was created for, as used by its real builds.")
+		log.Print("Values that may be secret are redacted.")
+		log.Print("")
+		log.Print("Instance name is optional if a group is specified.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	fs.Parse(args)
+
+	var envSet []string
+	if fs.NArg() == 1 {
+		envSet = []string{fs.Arg(0)}
+	} else if fs.NArg() == 0 && activeGroup != nil {
+		envSet = append(envSet, activeGroup.Instances...)
+	} else {
+		fs.Usage()
+	}
+
+	ctx := context.Background()
+	for i, inst := range envSet {
+		if len(envSet) > 1 {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("# %s\n", inst)
+		}
+		if err := doEnv(ctx, inst, os.Stdout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func doEnv(ctx context.Context, name string, w io.Writer) error {
+	client := gomoteServerClient(ctx)
+	resp, err := client.GetBuilderEnvironment(ctx, &protos.GetBuilderEnvironmentRequest{
+		GomoteId: name,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get builder environment: %w", err)
+	}
+	writeBuilderEnvironment(w, resp)
+	return nil
+}
+
+// writeBuilderEnvironment writes resp in a form that can be used
+// as a shell script to set up the same environment locally.
+func writeBuilderEnvironment(w io.Writer, resp *protos.GetBuilderEnvironmentResponse) {
+	fmt.Fprintf(w, "# builder: %s\n", resp.GetBuilderType())
+	if goos := resp.GetGoos(); goos != "" {
+		fmt.Fprintf(w, "GOOS=%s\n", goos)
+	}
+	if goarch := resp.GetGoarch(); goarch != "" {
+		fmt.Fprintf(w, "GOARCH=%s\n", goarch)
+	}
+	for _, kv := range resp.GetEnvironment() {
+		fmt.Fprintln(w, kv)
+	}
+	if len(resp.GetConfig()) > 0 {
+		fmt.Fprintln(w, "# config:")
+		for _, c := range resp.GetConfig() {
+			fmt.Fprintf(w, "#   %s\n", c)
+		}
+	}
+}
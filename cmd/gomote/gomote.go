@@ -23,8 +23,11 @@ To list the subcommands, run "gomote" without arguments:
 
 	Commands:
 
+	  cp         copy a directory from one buildlet to another
 	  create     create a buildlet; with no args, list types of buildlets
 	  destroy    destroy a buildlet
+	  disttest   run the Go distribution's tests, sharded like the coordinator
+	  env        print the environment and config of a buildlet's builder
 	  gettar     extract a tar.gz from a buildlet
 	  list       list active buildlets
 	  login      create authentication credentials for the gomote services
@@ -38,6 +41,7 @@ To list the subcommands, run "gomote" without arguments:
 	  rdp        RDP (Remote Desktop Protocol) to a Windows buildlet
 	  repro      reproduce a build by LUCI build ID
 	  run        run a command on a buildlet
+	  snapshot   save a buildlet's go directory for later reuse
 	  ssh        ssh to a buildlet
 
 To list all the builder types available, run "create" with no arguments:
@@ -100,6 +104,8 @@ are several short-cuts that make this unnecessary in most cases:
     -new-group flag.
   - The create command will automatically create the group in GOMOTE_GROUP
     if it does not exist and no other group is explicitly specified.
+  - The create command, given more than one builder type, creates a
+    group for the new instances if no other group is specified.
   - The destroy command can destroy a group in addition to its instances
     with the -destroy-group flag.
 
@@ -133,6 +139,9 @@ commands.
   - The run command always streams output to a temporary file regardless
     of any additional flags to avoid losing output due to terminal
     scrollback. It always prints the location of the file.
+  - The run command accepts the -parallel flag for running a command on
+    a group at most N instances at a time, printing the output of each
+    instance to stdout with every line prefixed by the instance name.
 
 Using some of these tricks, it's straightforward to hammer at some test
 to reproduce a rare failure, like so:
@@ -213,8 +222,11 @@ func registerCommand(name, des string, run func([]string) error) {
 }
 
 func registerCommands() {
+	registerCommand("cp", "copy a directory from one buildlet to another", cp)
 	registerCommand("create", "create a buildlet; with no args, list types of buildlets", create)
 	registerCommand("destroy", "destroy a buildlet", destroy)
+	registerCommand("disttest", "run the Go distribution's tests, sharded like the coordinator", distTest)
+	registerCommand("env", "print the environment and config of a buildlet's builder", env)
 	registerCommand("gettar", "extract a tar.gz from a buildlet", getTar)
 	registerCommand("group", "manage groups of instances", group)
 	registerCommand("list", "list active buildlets", list)
@@ -229,7 +241,9 @@ func registerCommands() {
 	registerCommand("rdp", "Unimplimented: RDP (Remote Desktop Protocol) to a Windows buildlet", rdp)
 	registerCommand("rm", "delete files or directories", rm)
 	registerCommand("run", "run a command on a buildlet", run)
+	registerCommand("snapshot", "save a buildlet's go directory for later reuse", snapshot)
 	registerCommand("ssh", "ssh to a buildlet", ssh)
+	registerCommand("tail", "follow a file on a buildlet as it grows", tail)
 }
 
 var (
@@ -6,6 +6,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -18,10 +19,12 @@ import (
 func list(args []string) error {
 	fs := flag.NewFlagSet("list", flag.ContinueOnError)
 	fs.Usage = func() {
-		usageLogger.Print("list usage: gomote list")
+		usageLogger.Print("list usage: gomote list [list-opts]")
 		fs.PrintDefaults()
 		os.Exit(1)
 	}
+	var jsonOut bool
+	fs.BoolVar(&jsonOut, "json", false, "print the instances as a JSON array")
 	fs.Parse(args)
 	if fs.NArg() != 0 {
 		fs.Usage()
@@ -36,6 +39,13 @@ func list(args []string) error {
 	if err != nil {
 		return fmt.Errorf("unable to list instance: %w", err)
 	}
+	if jsonOut {
+		infos := []instanceInfo{}
+		for _, inst := range resp.GetInstances() {
+			infos = append(infos, newInstanceInfo(inst, groups))
+		}
+		return printJSON(infos)
+	}
 	for _, inst := range resp.GetInstances() {
 		var groupList strings.Builder
 		for _, g := range groups {
@@ -56,3 +66,42 @@ func list(args []string) error {
 	}
 	return nil
 }
+
+// instanceInfo describes an instance in the output of the list and
+// create commands' -json flag.
+type instanceInfo struct {
+	Name        string     `json:"name"`
+	BuilderType string     `json:"builderType"`
+	HostType    string     `json:"hostType"`
+	Created     *time.Time `json:"created,omitempty"` // nil if the server doesn't report it
+	Expires     time.Time  `json:"expires"`
+	WorkingDir  string     `json:"workingDir,omitempty"`
+	Groups      []string   `json:"groups,omitempty"`
+}
+
+func newInstanceInfo(inst *protos.Instance, groups []*groupData) instanceInfo {
+	info := instanceInfo{
+		Name:        inst.GetGomoteId(),
+		BuilderType: inst.GetBuilderType(),
+		HostType:    inst.GetHostType(),
+		Expires:     time.Unix(inst.GetExpires(), 0),
+		WorkingDir:  inst.GetWorkingDir(),
+	}
+	if c := inst.GetCreated(); c != 0 {
+		t := time.Unix(c, 0)
+		info.Created = &t
+	}
+	for _, g := range groups {
+		if g.has(inst.GetGomoteId()) {
+			info.Groups = append(info.Groups, g.Name)
+		}
+	}
+	return info
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	e := json.NewEncoder(os.Stdout)
+	e.SetIndent("", "\t")
+	return e.Encode(v)
+}
@@ -11,7 +11,9 @@ import (
 	"io"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/build/internal/gomote/protos"
 )
@@ -23,6 +25,10 @@ func ls(args []string) error {
 		log.Print("ls usage: gomote ls [ls-opts] [instance] [dir]")
 		log.Print("")
 		log.Print("Instance name is optional if a group is specified.")
+		log.Print("")
+		log.Print("With -l, each entry is printed with its mode, size, and")
+		log.Print("modification time. Sizes and times are only known for")
+		log.Print("regular files. With -R, names are relative to dir.")
 		fs.PrintDefaults()
 		os.Exit(1)
 	}
@@ -30,6 +36,8 @@ func ls(args []string) error {
 	fs.BoolVar(&recursive, "R", false, "recursive")
 	var digest bool
 	fs.BoolVar(&digest, "d", false, "get file digests")
+	var long bool
+	fs.BoolVar(&long, "l", false, "long format: print the mode, size, and modification time of each entry")
 	var skip string
 	fs.StringVar(&skip, "skip", "", "comma-separated list of relative directories to skip (use forward slashes)")
 	fs.Parse(args)
@@ -93,6 +101,10 @@ func ls(args []string) error {
 			if err != nil {
 				return fmt.Errorf("unable to ls: %w", err)
 			}
+			if long {
+				printLongEntries(os.Stdout, resp)
+				continue
+			}
 			for _, entry := range resp.GetEntries() {
 				fmt.Fprintf(os.Stdout, "%s\n", entry)
 			}
@@ -103,3 +115,30 @@ func ls(args []string) error {
 	}
 	return nil
 }
+
+// printLongEntries prints the entries of resp to w in the long format
+// of gomote ls -l, one per line.
+func printLongEntries(w io.Writer, resp *protos.ListDirectoryResponse) {
+	details := resp.GetDetails()
+	if len(details) != len(resp.GetEntries()) {
+		// The server predates structured entries.
+		for _, entry := range resp.GetEntries() {
+			fmt.Fprintf(w, "%s\n", entry)
+		}
+		return
+	}
+	for _, d := range details {
+		size, mtime := "-", "-"
+		if d.GetSize() >= 0 {
+			size = strconv.FormatInt(d.GetSize(), 10)
+		}
+		if d.GetModTime() != 0 {
+			mtime = time.Unix(d.GetModTime(), 0).Format("2006-01-02 15:04:05")
+		}
+		line := fmt.Sprintf("%-11s %12s %19s %s", d.GetMode(), size, mtime, d.GetName())
+		if d.GetDigest() != "" {
+			line += " " + d.GetDigest()
+		}
+		fmt.Fprintln(w, line)
+	}
+}
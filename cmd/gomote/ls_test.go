@@ -0,0 +1,37 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func TestPrintLongEntries(t *testing.T) {
+	mtime := time.Date(2025, 3, 4, 5, 6, 7, 0, time.Local)
+	var buf bytes.Buffer
+	printLongEntries(&buf, &protos.ListDirectoryResponse{
+		Entries: []string{"drwxr-xr-x\tsrc/", "-rw-r--r--\tsrc/go.mod\t42\t2025-03-04T05:06:07Z"},
+		Details: []*protos.DirectoryEntry{
+			{Name: "src/", Mode: "drwxr-xr-x", Size: -1},
+			{Name: "src/go.mod", Mode: "-rw-r--r--", Size: 42, ModTime: mtime.Unix(), Digest: "da39a3ee"},
+		},
+	})
+	want := "drwxr-xr-x             -                   - src/\n" +
+		"-rw-r--r--            42 2025-03-04 05:06:07 src/go.mod da39a3ee\n"
+	if got := buf.String(); got != want {
+		t.Errorf("printLongEntries wrote\n%s\nwant\n%s", got, want)
+	}
+
+	// Without details from the server, the entries are printed as is.
+	buf.Reset()
+	printLongEntries(&buf, &protos.ListDirectoryResponse{Entries: []string{"drwxr-xr-x\tsrc/"}})
+	if got, want := buf.String(), "drwxr-xr-x\tsrc/\n"; got != want {
+		t.Errorf("printLongEntries without details wrote %q; want %q", got, want)
+	}
+}
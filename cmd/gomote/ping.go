@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"golang.org/x/build/internal/gomote/protos"
 )
@@ -18,12 +19,18 @@ func ping(args []string) error {
 	fs := flag.NewFlagSet("ping", flag.ContinueOnError)
 	fs.Usage = func() {
 		log := usageLogger
-		log.Print("ping usage: gomote ping [instance]")
+		log.Print("ping usage: gomote ping [ping-opts] [instance]")
 		log.Print("")
 		log.Print("Instance name is optional if a group is specified.")
+		log.Print("")
+		log.Print("With -extend, the instance is kept alive until it has been")
+		log.Print("idle for the given duration, rather than the default of 30")
+		log.Print("minutes, and its new expiration time is printed.")
 		fs.PrintDefaults()
 		os.Exit(1)
 	}
+	var extend time.Duration
+	fs.DurationVar(&extend, "extend", 0, "extend the instance's idle timeout to this duration")
 	fs.Parse(args)
 
 	var pingSet []string
@@ -39,6 +46,14 @@ func ping(args []string) error {
 
 	ctx := context.Background()
 	for _, inst := range pingSet {
+		if extend != 0 {
+			if expires, err := doExtend(ctx, inst, extend); err != nil {
+				log.Printf("%s: %v\n", inst, err)
+			} else {
+				log.Printf("%s: alive, expires at %s if idle\n", inst, expires.Format(time.DateTime))
+			}
+			continue
+		}
 		if err := doPing(ctx, inst); err != nil {
 			log.Printf("%s: %v\n", inst, err)
 		} else {
@@ -48,6 +63,18 @@ func ping(args []string) error {
 	return nil
 }
 
+func doExtend(ctx context.Context, name string, d time.Duration) (time.Time, error) {
+	client := gomoteServerClient(ctx)
+	resp, err := client.ExtendInstanceLease(ctx, &protos.ExtendInstanceLeaseRequest{
+		GomoteId:           name,
+		IdleTimeoutSeconds: int64(d / time.Second),
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to extend instance lease: %w", err)
+	}
+	return time.Unix(resp.GetExpires(), 0), nil
+}
+
 func doPing(ctx context.Context, name string) error {
 	client := gomoteServerClient(ctx)
 	_, err := client.InstanceAlive(ctx, &protos.InstanceAliveRequest{
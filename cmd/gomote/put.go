@@ -17,10 +17,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/build/internal/gomote/protos"
 	"golang.org/x/build/tarutil"
@@ -263,10 +265,14 @@ func put(args []string) error {
 		log.Print("put usage: gomote put [put-opts] [instance] <source or '-' for stdin> [destination]")
 		fmt.Fprintln(os.Stderr)
 		log.Print("Instance name is optional if a group is specified.")
+		log.Print("If the source is an http or https URL, the gomote service downloads")
+		log.Print("it directly, resuming from where it stopped if an attempt fails.")
 		fs.PrintDefaults()
 		os.Exit(1)
 	}
 	modeStr := fs.String("mode", "", "Unix file mode (octal); default to source file mode")
+	attempts := fs.Int("attempts", 5, "maximum number of attempts the gomote service makes at reading the source")
+	backoff := fs.Duration("backoff", time.Second, "how long the gomote service waits before retrying a failed read of the source; doubles after each retry")
 	fs.Parse(args)
 
 	if fs.NArg() == 0 {
@@ -313,7 +319,15 @@ func put(args []string) error {
 		if src == "-" {
 			return errors.New("must specify destination file name when source is standard input")
 		}
-		dst = filepath.Base(src)
+		if isURL(src) {
+			u, err := url.Parse(src)
+			if err != nil {
+				return err
+			}
+			dst = path.Base(u.Path)
+		} else {
+			dst = filepath.Base(src)
+		}
 	}
 
 	var mode os.FileMode = 0666
@@ -329,7 +343,11 @@ func put(args []string) error {
 	}
 
 	var putFileFn func(context.Context, string) error
-	if src == "-" {
+	if isURL(src) {
+		putFileFn = func(ctx context.Context, inst string) error {
+			return doPutURL(ctx, inst, src, dst, mode, *attempts, *backoff)
+		}
+	} else if src == "-" {
 		var buf bytes.Buffer
 		_, err := io.Copy(&buf, os.Stdin)
 		if err != nil {
@@ -337,7 +355,7 @@ func put(args []string) error {
 		}
 		sharedFileBuf := buf.Bytes()
 		putFileFn = func(ctx context.Context, inst string) error {
-			return doPutFile(ctx, inst, bytes.NewReader(sharedFileBuf), dst, mode)
+			return doPutFile(ctx, inst, bytes.NewReader(sharedFileBuf), dst, mode, *attempts, *backoff)
 		}
 	} else {
 		putFileFn = func(ctx context.Context, inst string) error {
@@ -354,7 +372,7 @@ func put(args []string) error {
 				}
 				mode = fi.Mode()
 			}
-			return doPutFile(ctx, inst, f, dst, mode)
+			return doPutFile(ctx, inst, f, dst, mode, *attempts, *backoff)
 		}
 	}
 
@@ -368,7 +386,7 @@ func put(args []string) error {
 	return eg.Wait()
 }
 
-func doPutFile(ctx context.Context, inst string, r io.Reader, dst string, mode os.FileMode) error {
+func doPutFile(ctx context.Context, inst string, r io.Reader, dst string, mode os.FileMode, attempts int, backoff time.Duration) error {
 	client := gomoteServerClient(ctx)
 	resp, err := client.UploadFile(ctx, &protos.UploadFileRequest{})
 	if err != nil {
@@ -378,18 +396,36 @@ func doPutFile(ctx context.Context, inst string, r io.Reader, dst string, mode o
 	if err != nil {
 		return fmt.Errorf("unable to upload file to GCS: %w", err)
 	}
-	_, err = client.WriteFileFromURL(ctx, &protos.WriteFileFromURLRequest{
-		GomoteId: inst,
-		Url:      fmt.Sprintf("%s%s", resp.GetUrl(), resp.GetObjectName()),
-		Filename: dst,
-		Mode:     uint32(mode),
+	return doPutURL(ctx, inst, fmt.Sprintf("%s%s", resp.GetUrl(), resp.GetObjectName()), dst, mode, attempts, backoff)
+}
+
+// doPutURL has the gomote service download srcURL to dst on inst,
+// making up to attempts attempts and waiting backoff, doubled after
+// each retry, between them.
+func doPutURL(ctx context.Context, inst, srcURL, dst string, mode os.FileMode, attempts int, backoff time.Duration) error {
+	client := gomoteServerClient(ctx)
+	resp, err := client.WriteFileFromURL(ctx, &protos.WriteFileFromURLRequest{
+		GomoteId:         inst,
+		Url:              srcURL,
+		Filename:         dst,
+		Mode:             uint32(mode),
+		MaxAttempts:      int32(attempts),
+		InitialBackoffMs: backoff.Milliseconds(),
 	})
 	if err != nil {
 		return fmt.Errorf("unable to write the file from URL: %w", err)
 	}
+	if n := resp.GetAttempts(); n > 1 {
+		log.Printf("%s: downloading %s took %d attempts", inst, dst, n)
+	}
 	return nil
 }
 
+// isURL reports whether the put source src is an http or https URL.
+func isURL(src string) bool {
+	return strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "http://")
+}
+
 func uploadToGCS(ctx context.Context, fields map[string]string, file io.Reader, filename, url string) error {
 	buf := new(bytes.Buffer)
 	mw := multipart.NewWriter(buf)
@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 
@@ -69,10 +70,16 @@ func run(args []string) error {
 	var untilPattern string
 	fs.StringVar(&untilPattern, "until", "", "Run command repeatedly until the output matches the provided regexp.")
 
+	var parallel int
+	fs.IntVar(&parallel, "parallel", 0, "When running on a group, the maximum number of instances to run the command on at once, and also print the output of every instance to stdout, with each line prefixed by the instance name. Zero runs the command on all instances at once, printing only to the output files.")
+
 	fs.Parse(args)
 	if fs.NArg() == 0 {
 		fs.Usage()
 	}
+	if parallel < 0 {
+		return fmt.Errorf("-parallel must not be negative")
+	}
 
 	var until *regexp.Regexp
 	var err error
@@ -138,7 +145,11 @@ func run(args []string) error {
 
 	var cmdsFailedMu sync.Mutex
 	var cmdsFailed []*cmdFailedError
+	var stdoutMu sync.Mutex // guards writes to os.Stdout by prefixWriters
 	eg, ctx := errgroup.WithContext(context.Background())
+	if parallel > 0 {
+		eg.SetLimit(parallel)
+	}
 	for _, inst := range runSet {
 		inst := inst
 		if len(runSet) > 1 {
@@ -163,6 +174,10 @@ func run(args []string) error {
 			// backwards compatibility.
 			if len(runSet) == 1 {
 				outputs = append(outputs, os.Stdout)
+			} else if parallel > 0 {
+				pw := &prefixWriter{mu: &stdoutMu, w: os.Stdout, prefix: inst + ": "}
+				defer pw.Flush()
+				outputs = append(outputs, pw)
 			}
 			// Give ourselves the output too so that we can match against it.
 			var outBuf bytes.Buffer
@@ -242,9 +257,17 @@ func run(args []string) error {
 	// running. We still want to handle them, though, because we want to make sure
 	// we exit with a non-zero exit code to reflect the command failure.
 	for _, ce := range cmdsFailed {
-		log.Printf("Command %q failed on %q: %v\n", ce.cmd, ce.inst, err)
+		log.Printf("Command %q failed on %q: %v\n", ce.cmd, ce.inst, ce.err)
 	}
 	if len(cmdsFailed) > 0 {
+		if len(runSet) > 1 {
+			insts := make([]string, len(cmdsFailed))
+			for i, ce := range cmdsFailed {
+				insts[i] = ce.inst
+			}
+			slices.Sort(insts)
+			log.Printf("Command failed on %d of %d instances: %s\n", len(cmdsFailed), len(runSet), strings.Join(insts, ", "))
+		}
 		return errors.New("one or more commands failed")
 	}
 	return nil
@@ -290,6 +313,55 @@ func doRun(ctx context.Context, inst, cmd string, cmdArgs []string, opts ...runO
 	}
 }
 
+// prefixWriter is an io.Writer that writes each line written to it to w,
+// prefixed by prefix. Writers to the same w share mu, so that lines from
+// different instances aren't interleaved.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte // incomplete last line
+}
+
+func (pw *prefixWriter) Write(p []byte) (int, error) {
+	pw.buf = append(pw.buf, p...)
+	i := bytes.LastIndexByte(pw.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	lines := pw.buf[:i+1]
+	if err := pw.writeLines(lines); err != nil {
+		return 0, err
+	}
+	pw.buf = append(pw.buf[:0], pw.buf[i+1:]...)
+	return len(p), nil
+}
+
+// Flush writes out the incomplete last line, if any.
+func (pw *prefixWriter) Flush() error {
+	if len(pw.buf) == 0 {
+		return nil
+	}
+	err := pw.writeLines(append(pw.buf, '\n'))
+	pw.buf = pw.buf[:0]
+	return err
+}
+
+func (pw *prefixWriter) writeLines(lines []byte) error {
+	var b bytes.Buffer
+	for len(lines) > 0 {
+		line, rest, _ := bytes.Cut(lines, []byte("\n"))
+		b.WriteString(pw.prefix)
+		b.Write(line)
+		b.WriteByte('\n')
+		lines = rest
+	}
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	_, err := pw.w.Write(b.Bytes())
+	return err
+}
+
 type cmdFailedError struct {
 	inst, cmd string
 	err       error
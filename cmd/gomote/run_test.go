@@ -0,0 +1,40 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var mu sync.Mutex
+	var out strings.Builder
+	a := &prefixWriter{mu: &mu, w: &out, prefix: "a: "}
+	b := &prefixWriter{mu: &mu, w: &out, prefix: "b: "}
+	for _, w := range []struct {
+		pw *prefixWriter
+		s  string
+	}{
+		{a, "hello "},
+		{b, "one\ntwo\nthr"},
+		{a, "world\n"},
+		{b, "ee"},
+	} {
+		if n, err := w.pw.Write([]byte(w.s)); n != len(w.s) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v; want %d, nil", w.s, n, err, len(w.s))
+		}
+	}
+	for _, pw := range []*prefixWriter{a, b} {
+		if err := pw.Flush(); err != nil {
+			t.Fatalf("Flush: %v", err)
+		}
+	}
+	const want = "b: one\nb: two\na: hello world\nb: three\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+}
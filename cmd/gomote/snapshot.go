@@ -0,0 +1,47 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func snapshot(args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("snapshot usage: gomote snapshot <instance>")
+		log.Print("")
+		log.Print("Saves a snapshot of the instance's go directory and prints")
+		log.Print("its URL. The snapshot can be restored onto any of your")
+		log.Print("instances with:")
+		log.Print("")
+		log.Print("\tgomote puttar -dir=go <instance> <url>")
+		log.Print("")
+		log.Print("Snapshots can only be restored by the user who saved them.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+	}
+
+	ctx := context.Background()
+	client := gomoteServerClient(ctx)
+	resp, err := client.SnapshotInstance(ctx, &protos.SnapshotInstanceRequest{
+		GomoteId: fs.Arg(0),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to snapshot instance: %w", err)
+	}
+	fmt.Println(resp.GetUrl())
+	return nil
+}
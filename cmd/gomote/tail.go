@@ -0,0 +1,113 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/build/internal/gomote/protos"
+	"golang.org/x/sync/errgroup"
+)
+
+// tail follows a file on an instance, printing new output as it's appended.
+func tail(args []string) error {
+	fs := flag.NewFlagSet("tail", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("tail usage: gomote tail [tail-opts] [instance] <file>")
+		log.Print("")
+		log.Print("Prints the last lines of file, relative to the instance's work")
+		log.Print("directory, and then the data appended to it, like tail -f,")
+		log.Print("until interrupted.")
+		log.Print("")
+		log.Print("Instance name is optional if a group is specified, in which case")
+		log.Print("the file is followed on every instance in the group, with each")
+		log.Print("line prefixed by the instance name.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var lines int
+	fs.IntVar(&lines, "n", 10, "number of existing lines to print before following the file")
+	fs.Parse(args)
+
+	var tailSet []string
+	var file string
+	switch {
+	case fs.NArg() == 2:
+		tailSet = []string{fs.Arg(0)}
+		file = fs.Arg(1)
+	case fs.NArg() == 1 && activeGroup != nil:
+		tailSet = append(tailSet, activeGroup.Instances...)
+		file = fs.Arg(0)
+	default:
+		fs.Usage()
+	}
+	if lines < 0 {
+		return fmt.Errorf("-n must not be negative")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var stdoutMu sync.Mutex
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, inst := range tailSet {
+		inst := inst
+		eg.Go(func() error {
+			var w io.Writer = os.Stdout
+			if len(tailSet) > 1 {
+				pw := &prefixWriter{mu: &stdoutMu, w: os.Stdout, prefix: inst + ": "}
+				defer pw.Flush()
+				w = pw
+			}
+			return doTail(ctx, inst, file, lines, w)
+		})
+	}
+	err := eg.Wait()
+	if errors.Is(err, context.Canceled) {
+		// Interrupted, which is the normal way to stop following.
+		return nil
+	}
+	return err
+}
+
+// doTail follows file on the instance inst, writing its last lines and then
+// the data appended to it to w until ctx is done.
+// The following happens on the buildlet, so that only new data is sent.
+func doTail(ctx context.Context, inst, file string, lines int, w io.Writer) error {
+	client := gomoteServerClient(ctx)
+	resp, err := client.GetBuilderEnvironment(ctx, &protos.GetBuilderEnvironmentRequest{
+		GomoteId: inst,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get builder environment: %w", err)
+	}
+	cmd, cmdArgs := tailCommand(resp.GetGoos(), file, lines)
+	err = doRun(ctx, inst, cmd, cmdArgs, runSystem(true), runWriters(w))
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// tailCommand returns the command to run on a buildlet for goos,
+// in its work directory, to follow file.
+func tailCommand(goos, file string, lines int) (cmd string, args []string) {
+	if goos == "windows" {
+		// PowerShell joins the arguments after -Command into a script,
+		// so quote the file name as a string literal.
+		quoted := "'" + strings.ReplaceAll(file, "'", "''") + "'"
+		return "powershell", []string{"-NoProfile", "-Command", "Get-Content", "-Tail", strconv.Itoa(lines), "-Wait", "-LiteralPath", quoted}
+	}
+	return "tail", []string{"-n", strconv.Itoa(lines), "-f", file}
+}
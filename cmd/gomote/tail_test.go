@@ -0,0 +1,28 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTailCommand(t *testing.T) {
+	for _, tc := range []struct {
+		goos     string
+		file     string
+		wantCmd  string
+		wantArgs []string
+	}{
+		{"linux", "go/test.log", "tail", []string{"-n", "10", "-f", "go/test.log"}},
+		{"", "out.txt", "tail", []string{"-n", "10", "-f", "out.txt"}},
+		{"windows", `go\it's a log.txt`, "powershell", []string{"-NoProfile", "-Command", "Get-Content", "-Tail", "10", "-Wait", "-LiteralPath", `'go\it''s a log.txt'`}},
+	} {
+		cmd, args := tailCommand(tc.goos, tc.file, 10)
+		if cmd != tc.wantCmd || !slices.Equal(args, tc.wantArgs) {
+			t.Errorf("tailCommand(%q, %q, 10) = %q, %q; want %q, %q", tc.goos, tc.file, cmd, args, tc.wantCmd, tc.wantArgs)
+		}
+	}
+}
@@ -39,6 +39,9 @@ var (
 	sshAddr      = flag.String("ssh_addr", ":2222", "Address the gomote SSH server should listen on")
 	buildEnvName = flag.String("env", "", "The build environment configuration to use. Not required if running in dev mode locally or prod mode on GCE.")
 	mode         = flag.String("mode", "", "Valid modes are 'dev', 'prod', or '' for auto-detect. dev means localhost development, not be confused with staging on go-dashboard-dev, which is still the 'prod' mode.")
+
+	gomoteUserLimit  = flag.Int("gomote_user_limit", 0, "If positive, the maximum number of gomote instances a single user may have at once.")
+	gomoteUserLimits = flag.String("gomote_user_limits", "", "Comma-separated per-user overrides of -gomote_user_limit, such as \"gopher=20,relui-prod=0\". A limit of zero means no limit.")
 )
 
 var Version string // set by linker -X
@@ -62,6 +65,11 @@ func main() {
 	defer cancel()
 
 	sp := remote.NewSessionPool(context.Background())
+	userLimits, err := remote.ParseUserLimits(*gomoteUserLimits)
+	if err != nil {
+		log.Fatalf("invalid -gomote_user_limits: %v", err)
+	}
+	sp.SetUserLimits(*gomoteUserLimit, userLimits)
 	sshCA := mustRetrieveSSHCertificateAuthority()
 
 	var gomoteBucket string
@@ -88,6 +96,10 @@ func main() {
 		log.Fatalf("unable to create gomote server: %s", err)
 	}
 	gomotepb.RegisterGomoteServiceServer(grpcServer, gomoteServer)
+	if store := remoteSessionStore(); store != nil {
+		expectRemoteSessions(ctx, store, rdv)
+		go restoreRemoteSessions(sp, store, rdv)
+	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/reverse", rdv.HandleReverse)
@@ -0,0 +1,76 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/coordinator/remote"
+	"golang.org/x/build/internal/rendezvous"
+)
+
+var remoteSessionsURL = flag.String("remote_sessions_url", "", "Optional gs://bucket/object in which to save the gomote sessions, so that they survive a gomote server restart. On startup, sessions whose buildlets reconnect are restored, and the rest are pruned.")
+
+// reattachWait is how long the buildlet of a saved session has to
+// reconnect to a restarted gomote server. Buildlets on swarming bots
+// keep redialing the gomote server for a while after losing their
+// connection to it.
+const reattachWait = 5 * time.Minute
+
+// remoteSessionStore returns the store named by -remote_sessions_url,
+// or nil if it isn't set.
+func remoteSessionStore() remote.SessionStore {
+	if *remoteSessionsURL == "" {
+		return nil
+	}
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(*remoteSessionsURL, "gs://"), "/")
+	if !strings.HasPrefix(*remoteSessionsURL, "gs://") || !ok || bucket == "" || object == "" {
+		log.Fatalf("invalid -remote_sessions_url %q; want gs://bucket/object", *remoteSessionsURL)
+	}
+	return remote.NewGCSSessionStore(mustStorageClient().Bucket(bucket).Object(object))
+}
+
+// expectRemoteSessions registers the buildlets of the sessions saved in
+// store with rdv, so that they're accepted when they reconnect. It must
+// be called before the server starts serving, or the buildlets are
+// turned away and exit.
+func expectRemoteSessions(ctx context.Context, store remote.SessionStore, rdv *rendezvous.Rendezvous) {
+	lctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	recs, err := store.Load(lctx)
+	if err != nil {
+		log.Printf("unable to load gomote sessions: %v", err)
+		return
+	}
+	for _, rec := range recs {
+		if rec.BuildletName == "" || (!rec.Expires.IsZero() && rec.Expires.Before(time.Now())) {
+			continue
+		}
+		rdv.RegisterInstance(ctx, rec.BuildletName, reattachWait)
+	}
+}
+
+// restoreRemoteSessions restores the gomote sessions saved in store by a
+// previous gomote server into sp, reattaching to the buildlets expected
+// by expectRemoteSessions as they reconnect.
+func restoreRemoteSessions(sp *remote.SessionPool, store remote.SessionStore, rdv *rendezvous.Rendezvous) {
+	ctx, cancel := context.WithTimeout(context.Background(), reattachWait+time.Minute)
+	defer cancel()
+	reattach := func(ctx context.Context, rec remote.SessionRecord) (buildlet.Client, error) {
+		ctx, cancel := context.WithTimeout(ctx, reattachWait)
+		defer cancel()
+		return rdv.WaitForInstance(ctx, rec.BuildletName)
+	}
+	if err := sp.Restore(ctx, store, reattach); err != nil {
+		log.Printf("unable to restore gomote sessions: %v", err)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"golang.org/x/build/internal/gorebuild"
 )
 
 // BootstrapVersion returns the Go bootstrap version required
@@ -86,13 +88,13 @@ func (r *Report) BootstrapPrebuilt(b *Bootstrap, version string) error {
 	}
 
 	url := "https://go.dev/dl/" + version + "." + runtime.GOOS + "-" + runtime.GOARCH + ".tar.gz"
-	unpack := UnpackTarGz
+	unpack := gorebuild.UnpackTarGz
 	if runtime.GOOS == "windows" {
 		url = strings.TrimSuffix(url, ".tar.gz") + ".zip"
-		unpack = UnpackZip
+		unpack = gorebuild.UnpackZip
 	}
 
-	arch, err := Get(&b.Log, url)
+	arch, err := gorebuild.Get(&b.Log, url)
 	if err != nil {
 		return err
 	}
@@ -106,11 +108,11 @@ func (r *Report) BootstrapPrebuilt(b *Bootstrap, version string) error {
 // BootstrapBuild builds the named bootstrap toolchain and returns
 // the directory containing the GOROOT for the build.
 func (r *Report) BootstrapBuild(b *Bootstrap, version string) error {
-	tgz, err := GerritTarGz(&b.Log, "go", "refs/heads/release-branch."+version)
+	tgz, err := gorebuild.GerritTarGz(&b.Log, "go", "refs/heads/release-branch."+version)
 	if err != nil {
 		return err
 	}
-	if err := UnpackTarGz(b.Dir, tgz); err != nil {
+	if err := gorebuild.UnpackTarGz(b.Dir, tgz); err != nil {
 		return err
 	}
 	return r.Build(&b.Log, b.Dir, version, nil, nil)
@@ -122,7 +124,7 @@ func (r *Report) BootstrapBuild(b *Bootstrap, version string) error {
 // The returned error is not logged.
 // If an error happens during the build, the full output is logged to log,
 // but the returned error simply says "make.bash in <goroot> failed".
-func (r *Report) Build(log *Log, goroot, version string, env, args []string) error {
+func (r *Report) Build(log *gorebuild.Log, goroot, version string, env, args []string) error {
 	bver, err := BootstrapVersion(version)
 	if err != nil {
 		return err
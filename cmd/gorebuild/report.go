@@ -17,6 +17,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"golang.org/x/build/internal/gorebuild"
 )
 
 // A Report is the report about this reproduction attempt.
@@ -32,9 +34,9 @@ type Report struct {
 	Full       bool         // full bootstrap back to Go 1.4
 	Bootstraps []*Bootstrap // bootstrap toolchains used
 	Releases   []*Release   // releases reproduced
-	Log        Log
+	Log        gorebuild.Log
 
-	dl []*DLRelease // information from go.dev/dl
+	dl []*gorebuild.DLRelease // information from go.dev/dl
 }
 
 // A Bootstrap describes the result of building or obtaining a bootstrap toolchain.
@@ -42,14 +44,14 @@ type Bootstrap struct {
 	Version string
 	Dir     string
 	Err     error
-	Log     Log
+	Log     gorebuild.Log
 }
 
 // A Release describes results for files from a single release of Go.
 type Release struct {
 	Version string // Go version string "go1.21.3"
-	Log     Log
-	dl      *DLRelease
+	Log     gorebuild.Log
+	dl      *gorebuild.DLRelease
 
 	mu    sync.Mutex
 	Files []*File // Files reproduced
@@ -61,70 +63,14 @@ type File struct {
 	GOOS   string
 	GOARCH string
 	SHA256 string // SHA256 hex of file
-	Log    Log
-	dl     *DLFile
+	Log    gorebuild.Log
+	dl     *gorebuild.DLFile
 
 	cache bool
 	mu    sync.Mutex
 	data  []byte
 }
 
-// A Log contains timestamped log messages as well as an overall
-// result status derived from them.
-type Log struct {
-	Name string
-
-	// mu must be held when using the Log from multiple goroutines.
-	// It is OK not to hold mu when there is only a single goroutine accessing
-	// the data, such as during json.Marshal or json.Unmarshal.
-	mu       sync.Mutex
-	Messages []Message
-	Status   Status
-}
-
-// A Status reports the overall result of the report, version, or file:
-// FAIL, PASS, or SKIP.
-type Status string
-
-const (
-	FAIL Status = "FAIL"
-	PASS Status = "PASS"
-	SKIP Status = "SKIP"
-)
-
-// A Message is a single log message.
-type Message struct {
-	Time time.Time
-	Text string
-}
-
-// Printf adds a new message to the log.
-// If the message begins with FAIL:, PASS:, or SKIP:,
-// the status is updated accordingly.
-func (l *Log) Printf(format string, args ...any) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	text := fmt.Sprintf(format, args...)
-	text = strings.TrimRight(text, "\n")
-	now := time.Now()
-	l.Messages = append(l.Messages, Message{now, text})
-
-	if strings.HasPrefix(format, "FAIL:") {
-		l.Status = FAIL
-	} else if strings.HasPrefix(format, "PASS:") && l.Status != FAIL {
-		l.Status = PASS
-	} else if strings.HasPrefix(format, "SKIP:") && l.Status == "" {
-		l.Status = SKIP
-	}
-
-	prefix := ""
-	if l.Name != "" {
-		prefix = "[" + l.Name + "] "
-	}
-	fmt.Fprintf(os.Stderr, "%s %s%s\n", now.Format("15:04:05.000"), prefix, text)
-}
-
 // Run runs the rebuilds indicated by args and returns the resulting report.
 func Run(args []string) *Report {
 	r := &Report{
@@ -158,7 +104,7 @@ func Run(args []string) *Report {
 		return r
 	}
 
-	r.dl, err = DLReleases(&r.Log)
+	r.dl, err = gorebuild.DLReleases(&r.Log)
 	if err != nil {
 		return r
 	}
@@ -217,7 +163,7 @@ func Run(args []string) *Report {
 	for _, rel := range r.Releases {
 		rel := rel
 		// Download source code.
-		src, err := GerritTarGz(&rel.Log, "go", "refs/tags/"+rel.Version)
+		src, err := gorebuild.GerritTarGz(&rel.Log, "go", "refs/tags/"+rel.Version)
 		if err != nil {
 			rel.Log.Printf("FAIL: downloading source: %v", err)
 			continue
@@ -241,38 +187,38 @@ func Run(args []string) *Report {
 
 	// Collect results.
 	// Sort the list of work for nicer presentation.
-	if r.Log.Status != FAIL {
-		r.Log.Status = PASS
+	if r.Log.Status != gorebuild.FAIL {
+		r.Log.Status = gorebuild.PASS
 	}
 	sort.Slice(r.Releases, func(i, j int) bool { return goversion.Compare(r.Releases[i].Version, r.Releases[j].Version) > 0 })
 	for _, rel := range r.Releases {
-		if rel.Log.Status != FAIL {
-			rel.Log.Status = PASS
+		if rel.Log.Status != gorebuild.FAIL {
+			rel.Log.Status = gorebuild.PASS
 		}
 		sort.Slice(rel.Files, func(i, j int) bool { return rel.Files[i].Name < rel.Files[j].Name })
 		for _, f := range rel.Files {
 			if f.Log.Status == "" {
 				f.Log.Printf("FAIL: file not checked")
 			}
-			if f.Log.Status == FAIL {
+			if f.Log.Status == gorebuild.FAIL {
 				rel.Log.Printf("FAIL: %s did not verify", f.Name)
 			}
-			if f.Log.Status == SKIP && rel.Log.Status == PASS {
-				rel.Log.Status = SKIP // be clear not completely verified
+			if f.Log.Status == gorebuild.SKIP && rel.Log.Status == gorebuild.PASS {
+				rel.Log.Status = gorebuild.SKIP // be clear not completely verified
 			}
 		}
-		if rel.Log.Status == PASS {
+		if rel.Log.Status == gorebuild.PASS {
 			rel.Log.Printf("PASS")
 		}
-		if rel.Log.Status == FAIL {
+		if rel.Log.Status == gorebuild.FAIL {
 			r.Log.Printf("FAIL: %s did not verify", rel.Version)
-			r.Log.Status = FAIL
+			r.Log.Status = gorebuild.FAIL
 		}
-		if rel.Log.Status == SKIP && r.Log.Status == PASS {
-			r.Log.Status = SKIP // be clear not completely verified
+		if rel.Log.Status == gorebuild.SKIP && r.Log.Status == gorebuild.PASS {
+			r.Log.Status = gorebuild.SKIP // be clear not completely verified
 		}
 	}
-	if r.Log.Status == PASS {
+	if r.Log.Status == gorebuild.PASS {
 		r.Log.Printf("PASS")
 	}
 
@@ -281,7 +227,7 @@ func Run(args []string) *Report {
 
 // defaultVersions returns the list of default versions to rebuild.
 // (See the package documentation for details about which ones.)
-func defaultVersions(releases []*DLRelease) []string {
+func defaultVersions(releases []*gorebuild.DLRelease) []string {
 	var versions []string
 	seen := make(map[string]bool)
 	for _, r := range releases {
@@ -333,7 +279,7 @@ func (r *Report) ReproFile(rel *Release, file *File, src []byte) (err error) {
 	goroot := filepath.Join(r.Work, fmt.Sprintf("repro-%s-%s-%s", rel.Version, file.GOOS, file.GOARCH))
 	defer os.RemoveAll(goroot)
 
-	if err := UnpackTarGz(goroot, src); err != nil {
+	if err := gorebuild.UnpackTarGz(goroot, src); err != nil {
 		return err
 	}
 	env := []string{"GOOS=" + file.GOOS, "GOARCH=" + file.GOARCH}
@@ -374,20 +320,16 @@ func (r *Report) ReproFile(rel *Release, file *File, src []byte) (err error) {
 
 		match := bytes.Equal(data, pubData)
 		if !match && file.GOOS == "darwin" {
-			if strings.HasSuffix(bf.Name, ".tar.gz") && DiffTarGz(&bf.Log, data, pubData, StripDarwinSig) ||
-				strings.HasSuffix(bf.Name, ".zip") && DiffZip(&bf.Log, data, pubData, StripDarwinSig) {
-				bf.Log.Printf("verified match after stripping signatures from executables")
-				match = true
-			}
+			match = gorebuild.MatchDarwin(&bf.Log, bf.Name, data, pubData)
 		}
 		if !match {
 			if strings.HasSuffix(bf.Name, ".tar.gz") {
-				DiffTarGz(&bf.Log, data, pubData, nil)
+				gorebuild.DiffTarGz(&bf.Log, data, pubData, nil)
 			}
 			if strings.HasSuffix(bf.Name, ".zip") {
-				DiffZip(&bf.Log, data, pubData, nil)
+				gorebuild.DiffZip(&bf.Log, data, pubData, nil)
 			}
-			bf.Log.Printf("FAIL: rebuilt SHA256 %s does not match public download SHA256 %s", SHA256(data), SHA256(pubData))
+			bf.Log.Printf("FAIL: rebuilt SHA256 %s does not match public download SHA256 %s", gorebuild.SHA256(data), gorebuild.SHA256(pubData))
 			continue
 		}
 		bf.Log.Printf("PASS: rebuilt with %q", env)
@@ -413,7 +355,7 @@ func (r *Report) ReproWindowsMsi(rel *Release, file *File, zip []byte) {
 	if !ok {
 		return
 	}
-	ok, skip := DiffWindowsMsi(&mf.Log, zip, msi)
+	ok, skip := gorebuild.DiffWindowsMsi(&mf.Log, zip, msi)
 	if ok {
 		mf.Log.Printf("PASS: verified content against posted zip")
 	} else if skip {
@@ -431,7 +373,7 @@ func (r *Report) ReproDarwinPkg(rel *Release, file *File, tgz []byte) {
 	if !ok {
 		return
 	}
-	if DiffDarwinPkg(&pf.Log, tgz, pkg) {
+	if gorebuild.DiffDarwinPkg(&pf.Log, tgz, pkg) {
 		pf.Log.Printf("PASS: verified content against posted tgz")
 	}
 }
@@ -448,13 +390,13 @@ func (r *Report) Download(f *File) ([]byte, bool) {
 			return f.data, true
 		}
 	}
-	data, err := Get(&f.Log, url+f.Name)
+	data, err := gorebuild.Get(&f.Log, url+f.Name)
 	if err != nil {
 		f.Log.Printf("FAIL: cannot download public copy")
 		return nil, false
 	}
 
-	sum := SHA256(data)
+	sum := gorebuild.SHA256(data)
 	if f.dl != nil && f.dl.SHA256 != sum {
 		f.Log.Printf("FAIL: go.dev/dl-listed SHA256 %s does not match public download SHA256 %s", f.dl.SHA256, sum)
 		return nil, false
@@ -472,7 +414,7 @@ func (r *Report) Release(version string) *Release {
 		}
 	}
 
-	var dl *DLRelease
+	var dl *gorebuild.DLRelease
 	for _, dl = range r.dl {
 		if dl.Version == version {
 			rel := &Release{
@@ -5,7 +5,9 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"strings"
 
 	"golang.org/x/build/internal/macservice"
 )
@@ -20,12 +22,18 @@ type macServiceClient interface {
 
 // readOnlyMacServiceClient wraps a macServiceClient, logging instead of
 // performing mutating actions. Used for dry run mode.
+//
+// Skipped actions are also recorded in plan, which runOnce reports once all
+// checks are complete.
 type readOnlyMacServiceClient struct {
-	mc macServiceClient
+	mc   macServiceClient
+	plan *dryRunPlan
 }
 
 func (r readOnlyMacServiceClient) Lease(req macservice.LeaseRequest) (macservice.LeaseResponse, error) {
-	log.Printf("DRY RUN: Create lease with image %s", req.InstanceSpecification.DiskSelection.ImageHashes.BootSHA256)
+	image := req.InstanceSpecification.DiskSelection.ImageHashes.BootSHA256
+	log.Printf("DRY RUN: Create lease with image %s", image)
+	r.plan.add(fmt.Sprintf("create lease in %s with image %s", req.VMResourceNamespace.ProjectName, image))
 	return macservice.LeaseResponse{
 		PendingLease: macservice.Lease{LeaseID: "dry-run-lease"},
 	}, nil
@@ -33,14 +41,83 @@ func (r readOnlyMacServiceClient) Lease(req macservice.LeaseRequest) (macservice
 
 func (r readOnlyMacServiceClient) Renew(req macservice.RenewRequest) (macservice.RenewResponse, error) {
 	log.Printf("DRY RUN: Renew lease %s with duration %s", req.LeaseID, req.Duration)
+	r.plan.add(fmt.Sprintf("renew lease %s for %s", req.LeaseID, req.Duration))
 	return macservice.RenewResponse{}, nil // Perhaps fake RenewResponse.Expires?
 }
 
 func (r readOnlyMacServiceClient) Vacate(req macservice.VacateRequest) error {
+	return r.vacate(req, "")
+}
+
+// vacate is like Vacate, but records reason in the plan.
+func (r readOnlyMacServiceClient) vacate(req macservice.VacateRequest, reason string) error {
 	log.Printf("DRY RUN: Vacate lease %s", req.LeaseID)
+	action := "vacate lease " + req.LeaseID
+	if reason != "" {
+		action += ": " + reason
+	}
+	r.plan.add(action)
 	return nil
 }
 
 func (r readOnlyMacServiceClient) Find(req macservice.FindRequest) (macservice.FindResponse, error) {
 	return r.mc.Find(req)
 }
+
+// vacateLease vacates lease id. reason explains why, for use in the dry run
+// plan.
+func vacateLease(mc macServiceClient, id, reason string) error {
+	req := macservice.VacateRequest{LeaseID: id}
+	if r, ok := mc.(readOnlyMacServiceClient); ok {
+		return r.vacate(req, reason)
+	}
+	return mc.Vacate(req)
+}
+
+// dryRunPlan accumulates the actions that a dry run would have taken.
+//
+// A nil *dryRunPlan discards all actions.
+type dryRunPlan struct {
+	actions []string       // in the order first added
+	count   map[string]int // action -> number of times added
+}
+
+func (p *dryRunPlan) add(action string) {
+	if p == nil {
+		return
+	}
+	if p.count == nil {
+		p.count = make(map[string]int)
+	}
+	if p.count[action] == 0 {
+		p.actions = append(p.actions, action)
+	}
+	p.count[action]++
+}
+
+// String returns the plan with one action per line. Repeated actions (such as
+// creating several leases with the same image) are consolidated into a single
+// line with a count.
+func (p *dryRunPlan) String() string {
+	if p == nil || len(p.actions) == 0 {
+		return "no actions\n"
+	}
+	var b strings.Builder
+	for _, action := range p.actions {
+		if n := p.count[action]; n > 1 {
+			fmt.Fprintf(&b, "%s (x%d)\n", action, n)
+		} else {
+			fmt.Fprintf(&b, "%s\n", action)
+		}
+	}
+	return b.String()
+}
+
+// reset discards all accumulated actions.
+func (p *dryRunPlan) reset() {
+	if p == nil {
+		return
+	}
+	p.actions = nil
+	p.count = nil
+}
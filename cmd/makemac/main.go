@@ -79,7 +79,7 @@ func run() error {
 	var mc macServiceClient
 	mc = macservice.NewClient(*apiKey)
 	if *dryRun {
-		mc = readOnlyMacServiceClient{mc: mc}
+		mc = readOnlyMacServiceClient{mc: mc, plan: new(dryRunPlan)}
 	}
 
 	// Use service account / application default credentials for swarming
@@ -141,6 +141,11 @@ func runOnce(ctx context.Context, config map[*swarmingConfig][]imageConfig, mc m
 	renewLeases(mc, leases)
 	handleObsoleteLeases(mc, config, leases)
 	addNewLeases(mc, config, leases)
+
+	if r, ok := mc.(readOnlyMacServiceClient); ok {
+		log.Printf("DRY RUN: Plan:\n%s", r.plan)
+		r.plan.reset()
+	}
 }
 
 // leaseSwarmingHost returns the swarming host a managed lease belongs to.
@@ -320,7 +325,7 @@ func handleMissingBots(mc macServiceClient, bots map[string]*spb.BotInfo, leases
 
 		log.Printf("Lease %s missing from LUCI; failed initial boot?", id)
 		log.Printf("Vacating lease %s...", id)
-		if err := mc.Vacate(macservice.VacateRequest{LeaseID: id}); err != nil {
+		if err := vacateLease(mc, id, "missing from LUCI; failed initial boot?"); err != nil {
 			log.Printf("Error vacating lease %s: %v", id, err)
 			continue
 		}
@@ -363,7 +368,7 @@ func handleDeadBots(mc macServiceClient, bots map[string]*spb.BotInfo, leases ma
 
 		log.Printf("Lease %s is dead on LUCI, but still present on MacService; VM froze/crashed?", id)
 		log.Printf("Vacating lease %s...", id)
-		if err := mc.Vacate(macservice.VacateRequest{LeaseID: id}); err != nil {
+		if err := vacateLease(mc, id, "dead on LUCI, but still present on MacService; VM froze/crashed?"); err != nil {
 			log.Printf("Error vacating lease %s: %v", id, err)
 			continue
 		}
@@ -461,7 +466,7 @@ func handleObsoleteLeases(mc macServiceClient, config map[*swarmingConfig][]imag
 		// Config doesn't want instances with this image. Vacate.
 		log.Printf("Lease %s uses obsolete image %s", id, image)
 		log.Printf("Vacating lease %s...", id)
-		if err := mc.Vacate(macservice.VacateRequest{LeaseID: id}); err != nil {
+		if err := vacateLease(mc, id, "obsolete image "+image); err != nil {
 			log.Printf("Error vacating lease %s: %v", id, err)
 			continue
 		}
@@ -421,3 +421,51 @@ func TestAddNewLeases(t *testing.T) {
 		t.Errorf("Lease request mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestDryRunPlan(t *testing.T) {
+	const project = managedProjectPrefix + "/swarming.example.com"
+
+	var rec recordMacServiceClient
+	mc := readOnlyMacServiceClient{mc: &rec, plan: new(dryRunPlan)}
+
+	if err := vacateLease(mc, "obsolete", "obsolete image old-image"); err != nil {
+		t.Fatalf("vacateLease got err %v want nil", err)
+	}
+	if _, err := mc.Renew(macservice.RenewRequest{LeaseID: "active", Duration: renewExpirationDurationString}); err != nil {
+		t.Fatalf("Renew got err %v want nil", err)
+	}
+	req := macservice.LeaseRequest{
+		VMResourceNamespace: macservice.Namespace{ProjectName: project},
+		InstanceSpecification: macservice.InstanceSpecification{
+			DiskSelection: macservice.DiskSelection{
+				ImageHashes: macservice.ImageHashes{
+					BootSHA256: "new-image",
+				},
+			},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := mc.Lease(req); err != nil {
+			t.Fatalf("Lease got err %v want nil", err)
+		}
+	}
+
+	// Nothing should reach the underlying client.
+	if len(rec.lease) != 0 || len(rec.renew) != 0 || len(rec.vacate) != 0 {
+		t.Errorf("dry run performed mutating requests: %+v", rec)
+	}
+
+	got := mc.plan.String()
+	want := `vacate lease obsolete: obsolete image old-image
+renew lease active for ` + renewExpirationDurationString + `
+create lease in ` + project + ` with image new-image (x3)
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Plan mismatch (-want +got):\n%s", diff)
+	}
+
+	mc.plan.reset()
+	if got := mc.plan.String(); got != "no actions\n" {
+		t.Errorf("Plan after reset got %q want %q", got, "no actions\n")
+	}
+}
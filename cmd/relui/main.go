@@ -61,6 +61,7 @@ var (
 	scratchFilesBase = flag.String("scratch-files-base", "", "Storage for scratch files. gs://bucket/path or file:///path/to/scratch.")
 	signedFilesBase  = flag.String("signed-files-base", "", "Storage for signed files. gs://bucket/path or file:///path/to/signed.")
 	servingFilesBase = flag.String("serving-files-base", "", "Storage for serving files. gs://bucket/path or file:///path/to/serving.")
+	releaseFeedBase  = flag.String("release-feed-base", "", "Storage for release feed entries. gs://bucket/path or file:///path/to/feed. If empty, entries are only logged.")
 	edgeCacheURL     = flag.String("edge-cache-url", "", "URL release files appear at when published to the CDN, e.g. https://dl.google.com/go.")
 	websiteUploadURL = flag.String("website-upload-url", "", "URL to POST website file data to, e.g. https://go.dev/dl/upload.")
 
@@ -85,6 +86,15 @@ func main() {
 	addressVarFlag(&annMail.From, "announce-mail-from", "The From address to use for the (pre-)announcement mail.")
 	addressVarFlag(&annMail.To, "announce-mail-to", "The To address to use for the (pre-)announcement mail.")
 	addressListVarFlag(&annMail.BCC, "announce-mail-bcc", "The BCC address list to use for the (pre-)announcement mail.")
+	annSubjects := make(map[task.ReleaseKind]string)
+	flag.Func("announce-subject-template", "A kind=template pair, where kind is beta, rc, major, or minor, that overrides the subject of announcement mail for that kind of release with a text/template. Can be repeated.", func(s string) error {
+		kind, tmpl, err := relui.ParseSubjectTemplate(s)
+		if err != nil {
+			return err
+		}
+		annSubjects[kind] = tmpl
+		return nil
+	})
 	var schedMail task.MailHeader
 	addressVarFlag(&schedMail.From, "schedule-mail-from", "The From address to use for the scheduled workflow failure mail.")
 	addressVarFlag(&schedMail.To, "schedule-mail-to", "The To address to use for the scheduled workflow failure mail.")
@@ -149,25 +159,30 @@ func main() {
 			log.Fatalln("task.NewMastodonClient:", err)
 		}
 	}
+	gcsClient, err := storage.NewClient(ctx)
+	if err != nil {
+		log.Fatalf("Could not connect to GCS: %v", err)
+	}
 	commTasks := task.CommunicationTasks{
 		AnnounceMailTasks: task.AnnounceMailTasks{
 			SendMail:           mailFunc,
 			AnnounceMailHeader: annMail,
+			SubjectTemplates:   annSubjects,
 		},
 		SocialMediaTasks: task.SocialMediaTasks{
 			TwitterClient:  task.NewTwitterClient(twitterAPI),
 			MastodonClient: mastodonClient,
 		},
+		ReleaseFeedTasks: task.ReleaseFeedTasks{
+			GCSClient: gcsClient,
+			FeedURL:   *releaseFeedBase,
+		},
 	}
 	dh := relui.NewDefinitionHolder()
 	userPassAuth := buildlet.UserPass{
 		Username: "user-relui",
 		Password: key(*masterKey, "user-relui"),
 	}
-	gcsClient, err := storage.NewClient(ctx)
-	if err != nil {
-		log.Fatalf("Could not connect to GCS: %v", err)
-	}
 	cbClient, err := cloudbuild.NewClient(ctx)
 	if err != nil {
 		log.Fatalf("Could not connect to Cloud Build: %v", err)
@@ -277,6 +292,7 @@ func main() {
 		RepoOwner:     "golang",
 		RepoName:      "go",
 		ApproveAction: relui.ApproveActionDep(dbPool),
+		Gerrit:        gerritClient,
 	}
 	versionTasks := &task.VersionTasks{
 		Gerrit:     gerritClient,
@@ -0,0 +1,210 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	bbpb "go.chromium.org/luci/buildbucket/proto"
+	"golang.org/x/build/cmd/watchflakes/internal/cache"
+)
+
+// boardCacheTop is the number of most recent commits on a dashboard
+// whose results are never taken from the board cache, because their
+// builds may still be running or be retried.
+const boardCacheTop = 10
+
+// A boardCache keeps dashboards read from LUCI on disk, so that
+// repeated runs during development don't read everything again.
+type boardCache struct {
+	cache *cache.Cache
+	ttl   time.Duration // how long a cached dashboard can be used
+}
+
+// A cachedBoard is the cached form of a dashboard.
+type cachedBoard struct {
+	Since   time.Time
+	Fetched time.Time // when the dashboard was last read in full
+	Dash    *Dashboard
+}
+
+// A cachedBoardList is the cached form of the list of dashboards.
+type cachedBoardList struct {
+	Fetched  time.Time
+	Projects []Project
+}
+
+// ListBoards is like LUCIClient.ListBoards, but uses the list in the
+// cache if it's recent enough, and otherwise updates the cache.
+func (bc *boardCache) ListBoards(ctx context.Context, c *LUCIClient) ([]*Dashboard, error) {
+	const name = "boards.json"
+	data, _, err := bc.cache.Read(name)
+	if err != nil {
+		log.Printf("reading board cache: %v", err)
+	}
+	if data != nil {
+		var cached cachedBoardList
+		if err := json.Unmarshal(data, &cached); err != nil {
+			log.Printf("reading board cache %s: %v", name, err)
+		} else if time.Since(cached.Fetched) < bc.ttl {
+			boards := make([]*Dashboard, len(cached.Projects))
+			for i, p := range cached.Projects {
+				boards[i] = &Dashboard{Project: p}
+			}
+			return boards, nil
+		}
+	}
+	boards, err := c.ListBoards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := cachedBoardList{Fetched: time.Now()}
+	for _, dash := range boards {
+		list.Projects = append(list.Projects, dash.Project)
+	}
+	data, err = json.Marshal(list)
+	if err == nil {
+		err = bc.cache.Write(name, data)
+	}
+	if err != nil {
+		log.Printf("writing board cache: %v", err)
+	}
+	return boards, nil
+}
+
+// ReadBoards is like LUCIClient.ReadBoards, but uses the results in
+// the cache for all but the most recent commits if they're recent enough.
+// It updates the cache with what it reads.
+func (bc *boardCache) ReadBoards(ctx context.Context, c *LUCIClient, boards []*Dashboard, since time.Time) error {
+	for _, dash := range boards {
+		if err := bc.readBoard(ctx, c, dash, since); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bc *boardCache) readBoard(ctx context.Context, c *LUCIClient, dash *Dashboard, since time.Time) error {
+	// The key includes the length of the time window, rather than its
+	// start, which moves with every run.
+	name := fmt.Sprintf("board-%s-%s-%v.json", dash.Repo, dash.GoBranch, time.Since(since).Round(time.Hour))
+	data, _, err := bc.cache.Read(name)
+	if err != nil {
+		log.Printf("reading board cache: %v", err)
+	}
+	// The cache is rewritten by every run, so it expires by when the
+	// dashboard was last read in full, not by when it was written.
+	var cached cachedBoard
+	if data != nil {
+		if err := json.Unmarshal(data, &cached); err != nil {
+			log.Printf("reading board cache %s: %v", name, err)
+			cached.Dash = nil
+		} else if time.Since(cached.Fetched) >= bc.ttl {
+			cached.Dash = nil
+		}
+	}
+	if cached.Dash == nil || len(cached.Dash.Commits) <= boardCacheTop {
+		if err := c.ReadBoard(ctx, dash, since); err != nil {
+			return err
+		}
+		cached.Fetched = time.Now()
+	} else {
+		// Read everything since the oldest of the most recent commits,
+		// which covers all the builds that started after the cache
+		// was written, and use the cache for the rest.
+		cutoff := cached.Dash.Commits[0].Time
+		for _, cm := range cached.Dash.Commits[:boardCacheTop] {
+			if cm.Time.Before(cutoff) {
+				cutoff = cm.Time
+			}
+		}
+		if c.TraceSteps {
+			log.Println("ReadBoard: using cache before", dash.Repo, dash.GoBranch, cutoff)
+		}
+		fresh := &Dashboard{Project: dash.Project}
+		if err := c.ReadBoard(ctx, fresh, cutoff); err != nil {
+			return err
+		}
+		*dash = *mergeBoards(fresh, cached.Dash, since)
+	}
+
+	data, err = json.Marshal(cachedBoard{Since: since, Fetched: cached.Fetched, Dash: stableBoard(dash)})
+	if err == nil {
+		err = bc.cache.Write(name, data)
+	}
+	if err != nil {
+		log.Printf("writing board cache: %v", err)
+	}
+	return nil
+}
+
+// stableBoard returns a copy of dash without the results that may still
+// change: the ones for the most recent commits and unfinished builds.
+func stableBoard(dash *Dashboard) *Dashboard {
+	d := *dash
+	d.Results = make([][]*BuildResult, len(dash.Results))
+	for i, rs := range dash.Results {
+		d.Results[i] = make([]*BuildResult, len(rs))
+		for j, r := range rs {
+			if j < boardCacheTop || r == nil || r.Status&bbpb.Status_ENDED_MASK == 0 {
+				continue
+			}
+			d.Results[i][j] = r
+		}
+	}
+	return &d
+}
+
+// mergeBoards returns the dashboard made of fresh, which was read
+// recently, and older commits and results from cached.
+// Results in fresh take precedence. Commits before since are dropped.
+func mergeBoards(fresh, cached *Dashboard, since time.Time) *Dashboard {
+	d := &Dashboard{
+		Project:  fresh.Project,
+		Builders: fresh.Builders,
+		Commits:  fresh.Commits,
+	}
+	seen := make(map[string]bool)
+	for _, cm := range fresh.Commits {
+		seen[cm.Hash] = true
+	}
+	for _, cm := range cached.Commits {
+		if !seen[cm.Hash] && !cm.Time.Before(since) {
+			seen[cm.Hash] = true
+			d.Commits = append(d.Commits, cm)
+		}
+	}
+
+	// Index the results by builder name, then commit hash.
+	index := func(dash *Dashboard) map[string]map[string]*BuildResult {
+		m := make(map[string]map[string]*BuildResult)
+		for i, b := range dash.Builders {
+			m[b.Name] = make(map[string]*BuildResult)
+			for j, r := range dash.Results[i] {
+				if r != nil {
+					m[b.Name][dash.Commits[j].Hash] = r
+				}
+			}
+		}
+		return m
+	}
+	freshResults, cachedResults := index(fresh), index(cached)
+	d.Results = make([][]*BuildResult, len(d.Builders))
+	for i, b := range d.Builders {
+		d.Results[i] = make([]*BuildResult, len(d.Commits))
+		for j, cm := range d.Commits {
+			r := freshResults[b.Name][cm.Hash]
+			if r == nil {
+				r = cachedResults[b.Name][cm.Hash]
+			}
+			d.Results[i][j] = r
+		}
+	}
+	return d
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	bbpb "go.chromium.org/luci/buildbucket/proto"
+)
+
+func TestBoardCacheMerge(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	// A dashboard with 15 commits, one an hour, newest first,
+	// and a result for each on one builder.
+	var old Dashboard
+	old.Builders = []Builder{{Name: "linux-amd64"}, {Name: "gone"}}
+	old.Results = make([][]*BuildResult, 2)
+	for i := 0; i < 15; i++ {
+		hash := fmt.Sprintf("c%02d", i)
+		old.Commits = append(old.Commits, Commit{Hash: hash, Time: now.Add(-time.Duration(i+1) * time.Hour)})
+		old.Results[0] = append(old.Results[0], &BuildResult{Status: bbpb.Status_SUCCESS, Commit: hash})
+		old.Results[1] = append(old.Results[1], nil)
+	}
+	old.Results[0][12].Status = bbpb.Status_STARTED
+
+	cached := stableBoard(&old)
+	for j, r := range cached.Results[0] {
+		if want := j >= boardCacheTop && j != 12; (r != nil) != want {
+			t.Errorf("stableBoard kept result for commit %d = %v, want %v", j, r != nil, want)
+		}
+	}
+
+	// Since then, a new commit landed, and the second most recent
+	// commit was rebuilt with a different result. The oldest commit
+	// fell out of the time window.
+	fresh := &Dashboard{
+		Builders: []Builder{{Name: "linux-amd64"}},
+		Commits:  append([]Commit{{Hash: "new", Time: now}}, old.Commits[:boardCacheTop]...),
+	}
+	fresh.Results = [][]*BuildResult{make([]*BuildResult, len(fresh.Commits))}
+	fresh.Results[0][0] = &BuildResult{Status: bbpb.Status_FAILURE, Commit: "new"}
+	fresh.Results[0][2] = &BuildResult{Status: bbpb.Status_FAILURE, Commit: "c01"}
+
+	d := mergeBoards(fresh, cached, now.Add(-14*time.Hour-time.Minute))
+	if len(d.Commits) != 15 || d.Commits[0].Hash != "new" || d.Commits[14].Hash != "c13" {
+		t.Fatalf("merged commits = %v, want new, c00, ..., c13", d.Commits)
+	}
+	if len(d.Builders) != 1 || len(d.Results) != 1 {
+		t.Fatalf("merged board has %d builders and %d result rows, want 1", len(d.Builders), len(d.Results))
+	}
+	for j, r := range d.Results[0] {
+		var want bbpb.Status
+		switch {
+		case j == 0 || j == 2:
+			want = bbpb.Status_FAILURE
+		case j <= boardCacheTop || j == 13:
+			// Not built according to fresh; in flux in the cache.
+			continue
+		default:
+			want = bbpb.Status_SUCCESS
+		}
+		if r == nil || r.Status != want {
+			t.Errorf("merged result for %s = %v, want status %v", d.Commits[j].Hash, r, want)
+		}
+	}
+}
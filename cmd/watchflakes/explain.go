@@ -0,0 +1,119 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bbpb "go.chromium.org/luci/buildbucket/proto"
+)
+
+// explainBuild runs the build with the given ID through the same
+// triage steps as a regular run and prints the decision made at each
+// step: whether the broken commit or broken builder heuristics skipped
+// it, and for each failure, which issue's script skipped or matched it,
+// or whether it would have created a new issue.
+// It does not post anything to GitHub.
+//
+// If query is not nil, it is used instead of the issues in the
+// Test Flakes project.
+func explainBuild(ctx context.Context, c *LUCIClient, id int64, query *Issue) error {
+	r, err := c.GetBuild(ctx, id)
+	if err != nil {
+		return fmt.Errorf("GetBuild %d: %v", id, err)
+	}
+	if r == nil {
+		return fmt.Errorf("build %d: no result (unfinished build or repo mismatch)", id)
+	}
+	fmt.Printf("build %s: %s %s@%s %s\n", buildURL(id), r.Builder, r.Repo, shortHash(r.Commit), r.Status)
+
+	// Read the dashboard the build belongs to, so the broken commit and
+	// broken builder heuristics see the same neighboring results they
+	// would see in a regular run.
+	dash := &Dashboard{Project: Project{r.Repo, r.GoBranch}}
+	if err := c.ReadBoard(ctx, dash, time.Now().Add(-timeLimit)); err != nil {
+		return fmt.Errorf("ReadBoard: %v", err)
+	}
+	boards := []*Dashboard{dash}
+	skipBrokenCommits(boards)
+	skipBrokenBuilders(boards)
+
+	var onBoard *BuildResult
+	for _, rs := range dash.Results {
+		for _, br := range rs {
+			if br != nil && br.ID == id {
+				onBoard = br
+			}
+		}
+	}
+	switch {
+	case onBoard == nil:
+		fmt.Printf("not on the %s %s dashboard within the last %v (superseded by a later build?); broken commit/builder heuristics not applied\n", r.Repo, r.GoBranch, timeLimit)
+	case onBoard.Status == SKIP:
+		fmt.Printf("skipped: %s\n", onBoard.SkipReason)
+		return nil
+	default:
+		r = onBoard
+		if r.Top {
+			fmt.Printf("consistent failure at the top of the dashboard\n")
+		}
+	}
+	if r.Status != bbpb.Status_FAILURE {
+		fmt.Printf("not flagged: build status is %s\n", r.Status)
+		return nil
+	}
+
+	// Make a one-entry board, like -build does.
+	board := &Dashboard{
+		Builders: []Builder{{r.Builder, r.BuilderConfigProperties}},
+		Commits:  []Commit{{Hash: r.Commit}},
+		Results:  [][]*BuildResult{{r}},
+	}
+	failRes := c.FindFailures(ctx, []*Dashboard{board})
+	c.FetchLogs(failRes)
+
+	var issues []*Issue
+	if query != nil {
+		issues = []*Issue{query}
+	} else {
+		issues, err = readIssues(nil)
+		if err != nil {
+			return err
+		}
+		findScripts(issues)
+	}
+
+	for _, r := range failRes {
+		newIssue := 0
+		for _, f := range buildFailures(r) {
+			fp := NewFailurePost(r, f)
+			fmt.Printf("\nfailure: %s\n", fp)
+			action, targets := run(issues, fp.Record())
+			switch action {
+			case "skip":
+				fmt.Printf("\tskipped by %v\n", targets[0])
+			case "":
+				if newIssue > 0 {
+					fmt.Printf("\tno matching issue; not creating another new issue for the same build\n")
+					break
+				}
+				fmt.Printf("\tno matching issue; would create a new issue\n")
+				newIssue++
+			default:
+				for _, issue := range targets {
+					readComments(issue)
+					if issue.Mentions[fp.URL] {
+						fmt.Printf("\tmatched %v (%s), already mentioned\n", issue, action)
+					} else {
+						fmt.Printf("\tmatched %v (%s), would post\n", issue, action)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"golang.org/x/build/cmd/watchflakes/internal/script"
 	"rsc.io/github"
@@ -27,41 +28,30 @@ type Issue struct {
 	Comments []*github.IssueComment // all issue comments
 	NewBody  bool                   // issue body (containing script) is newer than last watchflakes comment
 	Mentions map[string]bool        // log URLs that have already been posted in watchflakes comments
+	Snippets []string               // normalized snippets that have already been posted in watchflakes comments
+	LastPost time.Time              // time of the latest watchflakes comment, or zero if none
 
 	// what to send back to the issue
 	Error string         // error message (markdown) to post back to issue
 	Post  []*FailurePost // failures to post back to issue
+	Route *issueRoute    // for a new issue, where to file it
 }
 
 func (i *Issue) String() string { return fmt.Sprintf("#%d", i.Number) }
 
 var (
 	gh         *github.Client
-	repo       *github.Repo
-	labels     map[string]*github.Label
 	testFlakes *github.Project
 )
 
 // readIssues reads the GitHub issues in the Test Flakes project.
-// It also sets up the repo, labels, and testFlakes variables for
+// It also sets up the githubRepos and testFlakes variables for
 // use by other functions below.
 func readIssues(old []*Issue) ([]*Issue, error) {
-	// Find repo.
-	r, err := gh.Repo("golang", "go")
-	if err != nil {
-		return nil, err
-	}
-	repo = r
-
-	// Find labels.
-	list, err := gh.SearchLabels("golang", "go", "")
-	if err != nil {
+	// Find repos and their labels.
+	if err := readGitHubRepos(); err != nil {
 		return nil, err
 	}
-	labels = make(map[string]*github.Label)
-	for _, label := range list {
-		labels[label.Name] = label
-	}
 
 	// Find Test Flakes project.
 	ps, err := gh.Projects("golang", "")
@@ -78,9 +68,10 @@ func readIssues(old []*Issue) ([]*Issue, error) {
 		return nil, fmt.Errorf("cannot find Test Flakes project")
 	}
 
-	cache := make(map[int]*Issue)
+	// The issues can be in different repos, so their numbers aren't unique.
+	cache := make(map[string]*Issue)
 	for _, issue := range old {
-		cache[issue.Number] = issue
+		cache[issue.ID] = issue
 	}
 	// Read all issues in Test Flakes.
 	var issues []*Issue
@@ -91,7 +82,7 @@ func readIssues(old []*Issue) ([]*Issue, error) {
 	for _, item := range items {
 		if item.Issue != nil {
 			issue := &Issue{Issue: item.Issue, NewBody: true, Stale: true}
-			if c := cache[item.Issue.Number]; c != nil {
+			if c := cache[item.Issue.ID]; c != nil {
 				// Carry conservative NewBody, Mentions data forward
 				// to avoid round trips about things we already know.
 				if c.Issue.LastEditedAt.Equal(item.Issue.LastEditedAt) {
@@ -226,42 +217,87 @@ func updateText(issue *Issue) string {
 	}
 
 	var b strings.Builder
-	fmt.Fprintf(&b, "Found new dashboard test flakes for:\n\n%s", indent(spaces[:4], issue.ScriptText))
-	for _, f := range issue.Post {
+	if issue.shouldReopen() {
+		fmt.Fprintf(&b, "Reopening: this issue was closed, but the failure recurred at a commit made after it was closed.\n\n")
+	}
+	if *digest != 0 && !issue.LastPost.IsZero() {
+		fmt.Fprintf(&b, "Found %d new dashboard test flakes since %s for:\n\n%s", len(issue.Post), issue.LastPost.UTC().Format("2006-01-02"), indent(spaces[:4], issue.ScriptText))
+	} else {
+		fmt.Fprintf(&b, "Found new dashboard test flakes for:\n\n%s", indent(spaces[:4], issue.ScriptText))
+	}
+	// Show the snippet of the first of each set of similar failures,
+	// and only link to the others.
+	var shown, earlier []*FailurePost
+	like := make(map[*FailurePost][]*FailurePost)
+Posts:
+	for _, fp := range issue.Post {
+		for _, s := range issue.Snippets {
+			if similarSnippets(fp.Normalized, s) {
+				earlier = append(earlier, fp)
+				continue Posts
+			}
+		}
+		for _, first := range shown {
+			if similarSnippets(fp.Normalized, first.Normalized) {
+				like[first] = append(like[first], fp)
+				continue Posts
+			}
+		}
+		shown = append(shown, fp)
+	}
+	for _, f := range shown {
 		b.WriteString("\n")
-		_ = f
 		b.WriteString(f.Markdown())
+		if len(like[f]) > 0 {
+			fmt.Fprintf(&b, "\n+%d more like this: %s\n", len(like[f]), logLinks(like[f]))
+		}
+	}
+	if len(earlier) > 0 {
+		fmt.Fprintf(&b, "\n+%d more like ones already posted: %s\n", len(earlier), logLinks(earlier))
 	}
 	return b.String()
 }
 
+// logLinks returns Markdown links to the logs of the failures.
+// The links are found by readComments as mentions.
+func logLinks(fps []*FailurePost) string {
+	var links []string
+	for _, fp := range fps {
+		links = append(links, fmt.Sprintf("[%s](%s)", fp.String(), fp.URL))
+	}
+	return strings.Join(links, ", ")
+}
+
 // prepareNew creates and returns a new issue for reporting the failure.
 // It doesn't post the issue to GitHub. If *post is true, one needs to
 // call postNew to post.
 func prepareNew(fp *FailurePost) (*Issue, error) {
-	var pattern, title string
+	var pattern, defaultTitle string
 	if fp.Pkg != "" {
 		pattern = fmt.Sprintf("pkg == %q && test == %q", fp.Pkg, fp.Test)
 		test := fp.Test
 		if test == "" {
 			test = "unrecognized"
 		}
-		title = shortPkg(fp.Pkg) + ": " + test + " failures"
+		defaultTitle = shortPkg(fp.Pkg) + ": " + test + " failures"
 	} else if fp.Test != "" {
 		pattern = fmt.Sprintf("repo == %q && pkg == %q && test == %q", fp.Repo, "", fp.Test)
-		title = "build: " + fp.Test + " failures"
+		defaultTitle = "build: " + fp.Test + " failures"
 	} else if fp.IsBuildFailure() {
 		pattern = fmt.Sprintf("builder == %q && repo == %q && mode == %q", fp.Builder, fp.Repo, "build")
-		title = "build: build failure on " + fp.Builder
+		defaultTitle = "build: build failure on " + fp.Builder
 	} else {
 		pattern = fmt.Sprintf("builder == %q && repo == %q && pkg == %q && test == %q", fp.Builder, fp.Repo, "", "")
-		title = "build: unrecognized failures on " + fp.Builder
+		defaultTitle = "build: unrecognized failures on " + fp.Builder
 	}
 
+	title, text, err := issueTitleAndBody(fp, defaultTitle, pattern)
+	if err != nil {
+		return nil, err
+	}
 	var msg strings.Builder
 	fmt.Fprintf(&msg, "```\n#!watchflakes\ndefault <- %s\n```\n\n", pattern)
-	fmt.Fprintf(&msg, "Issue created automatically to collect these failures.\n\n")
-	fmt.Fprintf(&msg, "Example ([log](%s)):\n\n%s", fp.URL, indent(spaces[:4], fp.Snippet))
+	msg.WriteString(text)
 
 	// TODO: for a single test failure, add a link to LUCI history page.
 
@@ -277,6 +313,7 @@ func prepareNew(fp *FailurePost) (*Issue, error) {
 		return nil, fmt.Errorf("cannot find script in generated issue:\nBody:\n%s\n\nError:\n%s", issue.Body, issue.Error)
 	}
 	issue.Post = append(issue.Post, fp)
+	issue.Route = routeIssue(fp)
 	return issue, nil
 }
 
@@ -291,8 +328,22 @@ const signature = "\n\n— [watchflakes](https://go.dev/wiki/Watchflakes)\n"
 // match them as well.
 var buildUrlRE = regexp.MustCompile(`[("']https://ci.chromium.org/(ui/)?b/[0-9]+['")]`)
 
+// postedSnippetRE matches the snippets in a watchflakes comment,
+// as formatted by FailurePost.Markdown.
+var postedSnippetRE = regexp.MustCompile(`(?s)</summary>\n\n(.*?)</details>`)
+
+// postedSnippets returns the normalized snippets in a watchflakes comment.
+func postedSnippets(body string) []string {
+	var snippets []string
+	for _, m := range postedSnippetRE.FindAllStringSubmatch(body, -1) {
+		snippet := strings.ReplaceAll("\n"+m[1], "\n"+spaces[:4], "\n")[1:]
+		snippets = append(snippets, normalizeSnippet(snippet))
+	}
+	return snippets
+}
+
 // readComments loads the comments for the given issue,
-// setting the Comments, NewBody, and Mentions fields.
+// setting the Comments, NewBody, Mentions, and Snippets fields.
 func readComments(issue *Issue) {
 	if issue.Number == 0 || !issue.Stale {
 		return
@@ -308,6 +359,7 @@ func readComments(issue *Issue) {
 		mtime = issue.CreatedAt
 	}
 	issue.Mentions = make(map[string]bool)
+	issue.Snippets = nil
 	issue.NewBody = true // until proven otherwise
 	for _, com := range comments {
 		// Only consider comments we signed.
@@ -317,6 +369,9 @@ func readComments(issue *Issue) {
 		if com.CreatedAt.After(issue.LastEditedAt) {
 			issue.NewBody = false
 		}
+		if com.CreatedAt.After(issue.LastPost) {
+			issue.LastPost = com.CreatedAt
+		}
 		for _, link := range buildUrlRE.FindAllString(com.Body, -1) {
 			l := strings.Trim(link, "()\"'")
 			issue.Mentions[l] = true
@@ -324,49 +379,66 @@ func readComments(issue *Issue) {
 			// match them as well.
 			issue.Mentions[strings.Replace(l, "ci.chromium.org/ui/b/", "ci.chromium.org/b/", 1)] = true
 		}
+		issue.Snippets = append(issue.Snippets, postedSnippets(com.Body)...)
 	}
 	issue.Stale = false
 }
 
-// postNew creates a new issue with the given title and body,
-// setting the NeedsInvestigation label and placing the issue int
-// the Test Flakes project.
+// postNew creates the new issue prepared by prepareNew
+// in the GitHub repo of its route, setting the NeedsInvestigation label
+// and the route's labels and placing the issue in the Test Flakes project.
 // It automatically adds signature to the body.
-func postNew(title, body string) *github.Issue {
+func postNew(issue *Issue) *github.Issue {
 	var args []any
-	if lab := labels["NeedsInvestigation"]; lab != nil {
+	for _, lab := range newIssueLabels(issue.Route) {
 		args = append(args, lab)
 	}
 	args = append(args, testFlakes)
 
-	issue, err := gh.CreateIssue(repo, title, body+signature, args...)
+	// readGitHubRepos read the repos of all routes.
+	r := githubRepos[issue.Route.GitHub]
+	created, err := gh.CreateIssue(r.Repo, issue.Title, issue.Body+signature, args...)
 	if err != nil {
 		log.Fatal(err)
 	}
-	return issue
+	return created
+}
+
+// shouldReopen reports whether the issue is closed but has new failures to
+// post that happened at commits made after it was closed.
+// Such a failure means the flake recurred despite whatever fix closed
+// the issue, and a comment on a closed issue is unlikely to be seen.
+// Failures at older commits are still posted but don't reopen the issue,
+// since they may predate the fix.
+func (i *Issue) shouldReopen() bool {
+	if i.Issue == nil || !i.Issue.Closed {
+		return false
+	}
+	for _, fp := range i.Post {
+		if fp.Time.After(i.ClosedAt) {
+			return true
+		}
+	}
+	return false
 }
 
-// postComment posts a new comment on the issue.
+// postComment posts a new comment on the issue,
+// first reopening the issue if shouldReopen says to.
 // It automatically adds signature to the comment.
 func postComment(issue *Issue, body string) error {
+	if issue.shouldReopen() {
+		if err := gh.ReopenIssue(issue.Issue); err != nil {
+			return err
+		}
+	}
+	return gh.AddIssueComment(issue.Issue, commentBody(body))
+}
+
+// commentBody returns the final text of a comment with the given body.
+func commentBody(body string) string {
 	if len(body) > 50000 {
 		// Apparently GitHub GraphQL API limits comment length to 65536.
 		body = body[:50000] + "\n</details>\n(... long comment truncated ...)\n"
 	}
-	if issue.Issue.Closed {
-		reopen := false
-		for _, p := range issue.Post {
-			_ = p
-			if p.Time.After(issue.ClosedAt) {
-				reopen = true
-				break
-			}
-		}
-		if reopen {
-			if err := gh.ReopenIssue(issue.Issue); err != nil {
-				return err
-			}
-		}
-	}
-	return gh.AddIssueComment(issue.Issue, body+signature)
+	return body + signature
 }
@@ -0,0 +1,180 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"rsc.io/github"
+)
+
+func TestShouldReopen(t *testing.T) {
+	closedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	post := func(commitTime time.Time) *FailurePost {
+		r := &BuildResult{Time: commitTime, BuilderConfigProperties: &BuilderConfigProperties{}}
+		return &FailurePost{BuildResult: r, Failure: &Failure{}}
+	}
+	before := post(closedAt.Add(-time.Hour))
+	after := post(closedAt.Add(time.Hour))
+
+	tests := []struct {
+		name   string
+		closed bool
+		posts  []*FailurePost
+		want   bool
+	}{
+		{"open", false, []*FailurePost{after}, false},
+		{"closed_no_posts", true, nil, false},
+		{"closed_old_failure", true, []*FailurePost{before}, false},
+		{"closed_recurred", true, []*FailurePost{before, after}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &Issue{
+				Issue: &github.Issue{Number: 1, Closed: tt.closed, ClosedAt: closedAt},
+				Post:  tt.posts,
+			}
+			if got := issue.shouldReopen(); got != tt.want {
+				t.Errorf("shouldReopen = %v, want %v", got, tt.want)
+			}
+			if got := strings.HasPrefix(updateText(issue), "Reopening:"); got != tt.want && len(tt.posts) > 0 {
+				t.Errorf("updateText mentions reopening = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssueTemplates(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "templates.json")
+	config := `{"tools": {"title": "x/tools: {{.DefaultTitle}}", "body": "Ping the tools team.\n\n{{indent .Snippet}}"}}`
+	if err := os.WriteFile(file, []byte(config), 0666); err != nil {
+		t.Fatal(err)
+	}
+	templates, err := loadIssueTemplates(file)
+	if err != nil {
+		t.Fatalf("loadIssueTemplates: %v", err)
+	}
+	defer func(old map[string]*issueTemplate) { issueTemplates = old }(issueTemplates)
+	issueTemplates = templates
+
+	post := func(repo string) *FailurePost {
+		r := &BuildResult{Builder: "gotip-linux-amd64", BuilderConfigProperties: &BuilderConfigProperties{Repo: repo}}
+		return &FailurePost{BuildResult: r, Failure: &Failure{}, URL: "https://ci.chromium.org/b/1", Pkg: "golang.org/x/tools/gopls", Test: "TestFoo", Snippet: "--- FAIL: TestFoo"}
+	}
+	for _, tt := range []struct {
+		repo      string
+		wantTitle string
+		wantText  string
+	}{
+		{"tools", "x/tools: x/tools/gopls: TestFoo failures", "Ping the tools team.\n\n    --- FAIL: TestFoo"},
+		{"go", "x/tools/gopls: TestFoo failures", "Issue created automatically to collect these failures.\n\nExample ([log](https://ci.chromium.org/b/1)):\n\n    --- FAIL: TestFoo"},
+	} {
+		t.Run(tt.repo, func(t *testing.T) {
+			issue, err := prepareNew(post(tt.repo))
+			if err != nil {
+				t.Fatalf("prepareNew: %v", err)
+			}
+			if issue.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", issue.Title, tt.wantTitle)
+			}
+			if !strings.HasPrefix(issue.Body, "```\n#!watchflakes\n") {
+				t.Errorf("body doesn't start with the watchflakes script:\n%s", issue.Body)
+			}
+			if !strings.Contains(issue.Body, tt.wantText) {
+				t.Errorf("body doesn't contain %q:\n%s", tt.wantText, issue.Body)
+			}
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	defer func(old *json.Encoder) { preview = old }(preview)
+	var buf bytes.Buffer
+	preview = json.NewEncoder(&buf)
+
+	defer func(old map[string]*githubRepo) { githubRepos = old }(githubRepos)
+	githubRepos = map[string]*githubRepo{
+		"golang/go": {labels: map[string]*github.Label{"NeedsInvestigation": {Name: "NeedsInvestigation"}, "gopls": {Name: "gopls"}}},
+	}
+	route := &issueRoute{GitHub: "golang/go", Labels: []string{"gopls", "missing"}}
+	previewNew(&Issue{Issue: &github.Issue{Title: "net/http: TestFoo failures", Body: "new issue body"}, Route: route})
+	issue := &Issue{Issue: &github.Issue{Number: 42, Title: "net/http: TestBar failures"}}
+	previewComment(issue, strings.Repeat("x", 60000))
+
+	dec := json.NewDecoder(&buf)
+	var entries []previewEntry
+	for dec.More() {
+		var e previewEntry
+		if err := dec.Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d preview entries, want 2", len(entries))
+	}
+	if e := entries[0]; e.Action != "new-issue" || e.Repo != "golang/go" || e.Title != "net/http: TestFoo failures" || e.Body != "new issue body"+signature ||
+		!slices.Equal(e.Labels, []string{"NeedsInvestigation", "gopls"}) {
+		t.Errorf("new issue preview = %+v", e)
+	}
+	if e := entries[1]; e.Action != "comment" || e.Issue != 42 || e.Reopen || !strings.HasSuffix(e.Body, "(... long comment truncated ...)\n"+signature) {
+		t.Errorf("comment preview = %+v", e)
+	}
+}
+
+func TestUpdateTextSimilarSnippets(t *testing.T) {
+	post := func(id int, snippet string) *FailurePost {
+		r := &BuildResult{ID: int64(id), Commit: "0123456789", BuilderConfigProperties: &BuilderConfigProperties{}}
+		return &FailurePost{
+			BuildResult: r,
+			Failure:     &Failure{TestID: "net.TestDial"},
+			URL:         buildURL(r.ID),
+			Snippet:     snippet,
+			Normalized:  normalizeSnippet(snippet),
+		}
+	}
+	const timeout = "--- FAIL: TestDial (%s)\n    dial_test.go:42: dial tcp: i/o timeout\nFAIL\nFAIL\tnet\t%s\n"
+	const refused = "--- FAIL: TestDial (0.01s)\n    dial_test.go:57: connection refused\n    dial_test.go:58: retrying\n    dial_test.go:59: giving up\nFAIL\n"
+	issue := &Issue{
+		Issue: &github.Issue{Number: 1},
+		Post: []*FailurePost{
+			post(1, fmt.Sprintf(timeout, "3.01s", "3.2s")),
+			post(2, refused),
+			post(3, fmt.Sprintf(timeout, "5.5s", "6.1s")),
+			post(4, fmt.Sprintf(timeout, "1.2s", "1.5s")),
+		},
+	}
+	text := updateText(issue)
+	if n := strings.Count(text, "<details>"); n != 2 {
+		t.Errorf("updateText shows %d snippets, want 2:\n%s", n, text)
+	}
+	if !strings.Contains(text, "+2 more like this: ") {
+		t.Errorf("updateText doesn't collapse similar failures:\n%s", text)
+	}
+	for _, fp := range issue.Post {
+		if !strings.Contains(text, "("+fp.URL+")") && !strings.Contains(text, `"`+fp.URL+`"`) {
+			t.Errorf("updateText doesn't link to %s:\n%s", fp.URL, text)
+		}
+	}
+
+	// Once posted, similar failures are only linked.
+	issue.Snippets = postedSnippets(text)
+	if len(issue.Snippets) != 2 {
+		t.Fatalf("postedSnippets found %d snippets, want 2", len(issue.Snippets))
+	}
+	issue.Post = []*FailurePost{post(5, refused)}
+	text = updateText(issue)
+	if strings.Contains(text, "<details>") || !strings.Contains(text, "+1 more like ones already posted: ") {
+		t.Errorf("updateText for a failure already posted:\n%s", text)
+	}
+}
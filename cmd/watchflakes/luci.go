@@ -108,7 +108,8 @@ type BuildResult struct {
 	StepLogURL   string // textual log of the (last) failed step, if any
 	StepLogText  string
 	Failures     []*Failure
-	Top          bool // whether this is a consistent failure at the top (tip)
+	Top          bool   // whether this is a consistent failure at the top (tip)
+	SkipReason   string // why the result was changed to SKIP, if it was
 }
 
 type Commit struct {
@@ -572,6 +573,8 @@ func shortHash(s string) string {
 // The result is sorted by commit date, then repo, then builder.
 // Pupulate the failure contents (the .Failures fields) for the
 // failures.
+// Only builds with status FAILURE are failures: infrastructure
+// failures and canceled builds never count as test flakes.
 func (c *LUCIClient) FindFailures(ctx context.Context, boards []*Dashboard) []*BuildResult {
 	var res []*BuildResult
 	var wg sync.WaitGroup
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -20,18 +21,16 @@ import (
 	bbpb "go.chromium.org/luci/buildbucket/proto"
 	rdbpb "go.chromium.org/luci/resultdb/proto/v1"
 	"golang.org/x/build/buildenv"
+	"golang.org/x/build/cmd/watchflakes/internal/cache"
 	"golang.org/x/build/cmd/watchflakes/internal/script"
 	"golang.org/x/build/internal/secret"
 	"rsc.io/github"
 )
 
-// TODO:
-// - subrepos by go commit
-// - handle INFRA_FAILURE and CANCELED
-
 var _ = fmt.Print
 
 // Query failures within most recent timeLimit.
+// It's also the most that -since can go back.
 const timeLimit = 45 * 24 * time.Hour
 
 const maxFailPerBuild = 3
@@ -40,11 +39,28 @@ const tooManyToBeFlakes = 4
 
 var (
 	build   = flag.String("build", "", "a particular build ID or URL to analyze (mainly for debugging)")
+	explain = flag.String("explain", "", "explain the triage decisions for a particular build ID or URL, without posting")
 	md      = flag.Bool("md", false, "print Markdown output suitable for GitHub issues")
 	post    = flag.Bool("post", false, "post updates to GitHub issues")
+	postTo  = flag.String("post-to-file", "", "instead of posting updates to GitHub issues, write the new issues and comments that -post would post to `file` (- for standard output), as JSON objects")
 	repeat  = flag.Duration("repeat", 0, "keep running with specified `period`; zero means to run once and exit")
 	verbose = flag.Bool("v", false, "print verbose posting decisions")
 
+	digest             = flag.Duration("digest", 0, "post to an existing issue at most once per `period`, listing all the failures seen since the last post; zero means post on every run")
+	consistentFailures = flag.Int("consistent-failures", tooManyToBeFlakes, "tag a new issue's title with [consistent failure] when at least `n` of its failures are at the top of a dashboard; "+
+		"independent of the "+strconv.Itoa(tooManyToBeFlakes)+" failures in a row that mark a builder as broken, but since only such runs are kept at the top, smaller values behave like "+strconv.Itoa(tooManyToBeFlakes))
+	minOccurrences = flag.Int("min-occurrences", 1, "create a new issue only for failures seen at least `n` times in the analyzed window")
+
+	since = flag.String("since", "", "analyze only builds of commits made after `time`, either in RFC 3339 format or relative to now like 72h; "+
+		"the default and the earliest allowed is "+timeLimit.String()+" ago; ignored with -build")
+
+	metrics = flag.String("metrics", "", "after each run, append its counts of failures, issues, and posts and its duration to `file` (- for standard output) as a line of JSON")
+
+	boardCacheTTL = flag.Duration("board-cache", 0, "reuse the dashboards and their results read from LUCI by earlier runs within `period`, from an on-disk cache, for faster development; the most recent commits are always read again; zero means no cache")
+
+	templates = flag.String("issue-templates", "", "read per-repo templates for the title and body of new issues from the JSON `file`")
+	routes    = flag.String("issue-routes", "", "read the GitHub repos and labels of new issues, by the failures' repo and package, from the JSON `file`; the default is golang/go")
+
 	useSecretManager = flag.Bool("use-secret-manager", false, "fetch GitHub token from Secret Manager instead of $HOME/.netrc")
 )
 
@@ -58,11 +74,53 @@ func main() {
 	log.SetPrefix("watchflakes: ")
 	flag.Usage = usage
 	buildenv.RegisterStagingFlag()
+	flag.Func("snippet-marker", "also treat output lines containing `text` as important, so that they're included in snippets of long logs; can be repeated", func(s string) error {
+		if s == "" {
+			return fmt.Errorf("empty marker")
+		}
+		snippetMarkers = append(snippetMarkers, snippetMarker{text: s})
+		return nil
+	})
 	flag.Parse()
 	if flag.NArg() > 1 {
 		usage()
 	}
 
+	if *post && *postTo != "" {
+		log.Fatalf("-post and -post-to-file are mutually exclusive")
+	}
+	if *postTo != "" {
+		closePreview, err := openPreview(*postTo)
+		if err != nil {
+			log.Fatalf("opening preview file: %v", err)
+		}
+		defer func() {
+			if err := closePreview(); err != nil {
+				log.Fatalf("writing preview file: %v", err)
+			}
+		}()
+	}
+
+	if *templates != "" {
+		var err error
+		issueTemplates, err = loadIssueTemplates(*templates)
+		if err != nil {
+			log.Fatalf("loading issue templates: %v", err)
+		}
+	}
+
+	if _, err := parseSince(*since, time.Now()); err != nil {
+		log.Fatal(err)
+	}
+
+	if *routes != "" {
+		var err error
+		issueRoutes, err = loadIssueRoutes(*routes)
+		if err != nil {
+			log.Fatalf("loading issue routes: %v", err)
+		}
+	}
+
 	var query *Issue
 	if flag.NArg() == 1 {
 		s, err := script.Parse("script", flag.Arg(0), fields)
@@ -97,6 +155,34 @@ func main() {
 	c := NewLUCIClient(runtime.GOMAXPROCS(0) * 4)
 	c.TraceSteps = true
 
+	if *explain != "" {
+		id, err := parseBuildID(*explain)
+		if err != nil {
+			log.Fatalf("invalid build ID for -explain flag: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		defer cancel()
+		if err := explainBuild(ctx, c, id, query); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	listBoards, readBoards := c.ListBoards, c.ReadBoards
+	if *boardCacheTTL > 0 {
+		dc, err := cache.Create("watchflakes")
+		if err != nil {
+			log.Fatalf("creating board cache: %v", err)
+		}
+		bc := &boardCache{cache: dc, ttl: *boardCacheTTL}
+		listBoards = func(ctx context.Context) ([]*Dashboard, error) {
+			return bc.ListBoards(ctx, c)
+		}
+		readBoards = func(ctx context.Context, boards []*Dashboard, since time.Time) error {
+			return bc.ReadBoards(ctx, c, boards, since)
+		}
+	}
+
 	var ticker *time.Ticker
 	timeout := 30 * time.Minute // default timeout for one-off run
 	if *repeat != 0 {
@@ -107,23 +193,27 @@ Repeat:
 	startTime := time.Now()
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	var boards []*Dashboard
+	m := &runMetrics{Start: startTime}
 	if *build == "" {
 		// fetch the dashboard
 		var err error
-		boards, err = c.ListBoards(ctx)
+		boards, err = listBoards(ctx)
 		if err != nil {
 			log.Fatalln("ListBoards:", err)
 		}
-		err = c.ReadBoards(ctx, boards, startTime.Add(-timeLimit))
+		// parseSince succeeded above, and a relative -since
+		// moves with startTime.
+		sinceTime, _ := parseSince(*since, startTime)
+		err = readBoards(ctx, boards, sinceTime)
 		if err != nil {
 			log.Fatalln("ReadBoards:", err)
 		}
-		skipBrokenCommits(boards)
-		skipBrokenBuilders(boards)
+		m.BrokenCommits = skipBrokenCommits(boards)
+		m.BrokenBuilders = skipBrokenBuilders(boards)
 	} else {
-		id, err := strconv.ParseInt(strings.TrimPrefix(*build, "https://ci.chromium.org/b/"), 10, 64)
+		id, err := parseBuildID(*build)
 		if err != nil {
-			log.Fatalf("invalid build ID for -build flag: %q\n\texpect a build ID or https://ci.chromium.org/b/ URL", *build)
+			log.Fatalf("invalid build ID for -build flag: %v", err)
 		}
 		r, err := c.GetBuild(ctx, id)
 		if err != nil {
@@ -163,20 +253,20 @@ Repeat:
 
 	for _, r := range failRes {
 		newIssue := 0
-		fs := r.Failures
-		fs = coalesceFailures(fs)
-		if len(fs) == 0 {
-			// No test failure, Probably a build failure.
-			// E.g. https://ci.chromium.org/ui/b/8759448820419452721
-			// Make a dummy failure.
-			f := &Failure{
-				Status:  rdbpb.TestStatus_FAIL,
-				LogText: r.StepLogText,
-			}
-			fs = []*Failure{f}
-		}
-		for _, f := range fs {
+		seen := make(map[string]bool)
+		for _, f := range buildFailures(r) {
 			fp := NewFailurePost(r, f)
+			// Failures of a build that differ only in volatile details,
+			// like a test that failed again when retried, are the same
+			// failure: match and post only the first of them.
+			key := f.TestID + "\x00" + fp.Normalized
+			if seen[key] {
+				if *verbose {
+					fmt.Printf("%s: skipped duplicate failure of %s\n", fp.URL, f.TestID)
+				}
+				continue
+			}
+			seen[key] = true
 			record := fp.Record()
 			action, targets := run(issues, record)
 			if *verbose {
@@ -186,6 +276,7 @@ Repeat:
 			switch action {
 			case "skip":
 				// do nothing
+				m.Skipped++
 				if *verbose {
 					fmt.Printf("%s: skipped by #%d\n", fp.URL, targets[0].Number)
 				}
@@ -230,20 +321,15 @@ Repeat:
 			}
 		}
 	}
-	for _, issue := range issues {
-		if issue.Number == 0 && len(issue.Post) >= tooManyToBeFlakes && issue.Post[0].Top {
-			// New issue. Check if it is failing consistently at top.
-			top := 0
-			for _, fp := range issue.Post {
-				if fp.Top {
-					top++
-				}
-			}
-			if top >= tooManyToBeFlakes {
-				issue.Title += " [consistent failure]"
-			}
+	if query == nil {
+		pending := pendingPosts(issues)
+		issues = dropRareNewIssues(issues, *minOccurrences)
+		if *digest != 0 {
+			deferDigests(issues, *digest, time.Now())
 		}
+		m.Suppressed = pending - pendingPosts(issues)
 	}
+	markConsistentFailures(issues, *consistentFailures)
 
 	if query != nil {
 		format := (*FailurePost).Text
@@ -265,10 +351,22 @@ Repeat:
 	posts := 0
 	for _, issue := range issues {
 		if len(issue.Post) > 0 {
+			if issue.Number == 0 {
+				m.NewIssues++
+			}
 			if *post && issue.Number == 0 {
-				issue.Issue = postNew(issue.Title, issue.Body)
+				issue.Issue = postNew(issue)
+			} else if preview != nil && issue.Number == 0 {
+				previewNew(issue)
 			}
 			fmt.Printf(" - new for #%d %s\n", issue.Number, issue.Title)
+			if issue.shouldReopen() {
+				if *post {
+					fmt.Printf("   reopening #%d\n", issue.Number)
+				} else {
+					fmt.Printf("   would reopen #%d (use -post)\n", issue.Number)
+				}
+			}
 			for _, fp := range issue.Post {
 				fmt.Printf("    - %s\n      %s\n", fp, fp.URL)
 			}
@@ -279,6 +377,7 @@ Repeat:
 			if *post {
 				if err := postComment(issue, msg); err != nil {
 					log.Print(err)
+					m.PostErrors++
 					continue
 				}
 				if issue.Mentions == nil {
@@ -287,6 +386,10 @@ Repeat:
 				for _, fp := range issue.Post {
 					issue.Mentions[fp.URL] = true
 				}
+				issue.Snippets = append(issue.Snippets, postedSnippets(msg)...)
+				issue.LastPost = time.Now()
+			} else if preview != nil {
+				previewComment(issue, msg)
 			}
 			posts++
 		}
@@ -295,6 +398,13 @@ Repeat:
 	cancel()
 
 	log.Printf("Done. %d boards, %d failures, %d issues, %d posts, in %v\n", len(boards), len(failRes), len(issues), posts, time.Since(startTime))
+	if *metrics != "" {
+		m.Duration = time.Since(startTime).Seconds()
+		m.Boards, m.Failures, m.Issues, m.Posts = len(boards), len(failRes), len(issues), posts
+		if err := writeMetrics(*metrics, m); err != nil {
+			log.Printf("writing metrics: %v", err)
+		}
+	}
 
 	if *repeat != 0 {
 		<-ticker.C
@@ -302,17 +412,104 @@ Repeat:
 	}
 }
 
+// dropRareNewIssues returns issues without the new ones that have
+// fewer than min failures to post. Their failures are seen again in
+// later runs, until either there are enough of them or they fall out
+// of the analyzed time window.
+func dropRareNewIssues(issues []*Issue, min int) []*Issue {
+	kept := issues[:0]
+	for _, issue := range issues {
+		if issue.Number == 0 && len(issue.Post) < min {
+			fmt.Printf("# deferring new issue %q: %d failures, need %d (-min-occurrences)\n", issue.Title, len(issue.Post), min)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
+
+// deferDigests holds back the failures to post to existing issues
+// that watchflakes last commented on less than period before now.
+// The failures aren't marked as mentioned, so they're found again in
+// later runs and posted together once the period has passed.
+// Failures that reopen an issue are always posted right away.
+func deferDigests(issues []*Issue, period time.Duration, now time.Time) {
+	for _, issue := range issues {
+		if issue.Number == 0 || len(issue.Post) == 0 || issue.shouldReopen() {
+			continue
+		}
+		readComments(issue)
+		if issue.LastPost.IsZero() {
+			continue
+		}
+		if due := issue.LastPost.Add(period); now.Before(due) {
+			fmt.Printf(" - deferring %d failures for #%d until digest at %v (-digest)\n", len(issue.Post), issue.Number, due.UTC().Format(time.RFC3339))
+			issue.Post = nil
+		}
+	}
+}
+
+// parseBuildID parses a build ID or https://ci.chromium.org/b/ URL.
+func parseBuildID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(s, "https://ci.chromium.org/b/"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q: expect a build ID or https://ci.chromium.org/b/ URL", s)
+	}
+	return id, nil
+}
+
+// buildFailures returns the coalesced failures of the failed build r,
+// to be matched against issue scripts.
+func buildFailures(r *BuildResult) []*Failure {
+	fs := coalesceFailures(r.Failures)
+	if len(fs) == 0 {
+		// No test failure, Probably a build failure.
+		// E.g. https://ci.chromium.org/ui/b/8759448820419452721
+		// Make a dummy failure.
+		f := &Failure{
+			Status:  rdbpb.TestStatus_FAIL,
+			LogText: r.StepLogText,
+		}
+		fs = []*Failure{f}
+	}
+	return fs
+}
+
 const SKIP = bbpb.Status_STATUS_UNSPECIFIED // for smashing the status to skip a non-flake failure
 
+// markConsistentFailures adds " [consistent failure]" to the titles of
+// new issues with at least n failures at the top of their dashboards,
+// which is where failures that haven't been fixed yet are.
+func markConsistentFailures(issues []*Issue, n int) {
+	for _, issue := range issues {
+		if issue.Number == 0 && len(issue.Post) >= n && len(issue.Post) > 0 && issue.Post[0].Top {
+			// New issue. Check if it is failing consistently at top.
+			top := 0
+			for _, fp := range issue.Post {
+				if fp.Top {
+					top++
+				}
+			}
+			if top >= n {
+				issue.Title += " [consistent failure]"
+			}
+		}
+	}
+}
+
 // skipBrokenCommits identifies broken commits,
 // which are the ones that failed on at least 1/4 of builders,
 // and then changes all results for those commits to SKIP.
-func skipBrokenCommits(boards []*Dashboard) {
+// Infrastructure failures say nothing about the commit,
+// so they count as neither good nor bad.
+// It returns the number of broken commits.
+func skipBrokenCommits(boards []*Dashboard) (broken int) {
 	for _, dash := range boards {
 		builderThreshold := len(dash.Builders) / 4
 		for i := 0; i < len(dash.Commits); i++ {
 			bad := 0
 			good := 0
+			infra := 0
 			for _, rs := range dash.Results {
 				if rs[i] == nil {
 					continue
@@ -322,28 +519,38 @@ func skipBrokenCommits(boards []*Dashboard) {
 					good++
 				case bbpb.Status_FAILURE:
 					bad++
+				case bbpb.Status_INFRA_FAILURE:
+					infra++
+				default:
 					// ignore other status
 				}
 			}
 			if bad > builderThreshold || good < builderThreshold {
-				fmt.Printf("skip: commit %s (%s %s) is broken (good=%d bad=%d)\n", shortHash(dash.Commits[i].Hash), dash.Repo, dash.GoBranch, good, bad)
+				reason := fmt.Sprintf("commit %s (%s %s) is broken (good=%d bad=%d infra=%d)", shortHash(dash.Commits[i].Hash), dash.Repo, dash.GoBranch, good, bad, infra)
+				fmt.Printf("skip: %s\n", reason)
+				broken++
 				for _, rs := range dash.Results {
 					if rs[i] != nil {
 						rs[i].Status = SKIP
+						rs[i].SkipReason = reason
 					}
 				}
 			}
 		}
 	}
+	return broken
 }
 
 // skipBrokenBuilders identifies builders that were consistently broken
 // (at least tooManyToBeFlakes failures in a row) and then turned ok.
 // It changes those consistent failures to SKIP.
+// Infrastructure failures count toward a run of failures.
 //
 // It does not skip consistent failures at the top (latest few commits).
 // Instead, it sets Top to true on them.
-func skipBrokenBuilders(boards []*Dashboard) {
+//
+// It returns the number of runs of consistent failures it skipped.
+func skipBrokenBuilders(boards []*Dashboard) (broken int) {
 	for _, dash := range boards {
 		for _, rs := range dash.Results {
 			bad := 0
@@ -351,8 +558,10 @@ func skipBrokenBuilders(boards []*Dashboard) {
 			top := true
 			skip := func(i int) { // skip the i-th result
 				if rs[i] != nil {
-					fmt.Printf("skip: builder %s was broken at %s (%s %s)\n", rs[i].Builder, shortHash(rs[i].Commit), dash.Repo, dash.GoBranch)
+					reason := fmt.Sprintf("builder %s was broken at %s (%s %s)", rs[i].Builder, shortHash(rs[i].Commit), dash.Repo, dash.GoBranch)
+					fmt.Printf("skip: %s\n", reason)
 					rs[i].Status = SKIP
+					rs[i].SkipReason = reason
 				}
 			}
 			for i, r := range rs {
@@ -372,7 +581,7 @@ func skipBrokenBuilders(boards []*Dashboard) {
 					top = false
 					bad = 0
 					continue
-				case bbpb.Status_FAILURE:
+				case bbpb.Status_FAILURE, bbpb.Status_INFRA_FAILURE:
 					bad++
 					if top {
 						// Set Top to true, but don't skip.
@@ -387,6 +596,7 @@ func skipBrokenBuilders(boards []*Dashboard) {
 					badStart = i
 				case tooManyToBeFlakes:
 					// Skip the run so far.
+					broken++
 					for j := badStart; j < i; j++ {
 						skip(j)
 					}
@@ -407,6 +617,7 @@ func skipBrokenBuilders(boards []*Dashboard) {
 			}
 		}
 	}
+	return broken
 }
 
 // run runs the scripts in issues on record.
@@ -447,6 +658,11 @@ type FailurePost struct {
 	Pkg     string
 	Test    string
 	Snippet string
+
+	// Normalized is Snippet with volatile details like addresses and
+	// timestamps canonicalized, for detecting duplicate failures.
+	// Snippet is what gets displayed.
+	Normalized string
 }
 
 func NewFailurePost(r *BuildResult, f *Failure) *FailurePost {
@@ -462,6 +678,7 @@ func NewFailurePost(r *BuildResult, f *Failure) *FailurePost {
 		Pkg:         pkg,
 		Test:        test,
 		Snippet:     snip,
+		Normalized:  normalizeSnippet(snip),
 	}
 	return fp
 }
@@ -483,6 +700,8 @@ var fields = []string{
 	"goarch",
 	"log",
 	"status",
+	"gocommit",
+	"gobranch",
 }
 
 func (fp *FailurePost) Record() script.Record {
@@ -499,6 +718,13 @@ func (fp *FailurePost) Record() script.Record {
 		"goarch":  fp.Target.GOARCH,
 		"log":     fp.BuildResult.LogText,
 		"status":  fp.Failure.Status.String(),
+		// The Go commit and branch that were tested,
+		// which for subrepos differ from the commit being tested.
+		"gocommit": fp.GoCommit,
+		"gobranch": fp.GoBranch,
+	}
+	if fp.Repo == "go" {
+		m["gocommit"] = fp.Commit
 	}
 	m[""] = m["output"] // default field for `regexp` search (as opposed to field ~ `regexp`)
 	if fp.IsBuildFailure() {
@@ -587,6 +813,36 @@ func shortPkg(pkg string) string {
 	return pkg
 }
 
+// A snippetMarker identifies an important-looking line of output,
+// which snippet includes in the snippet even if it's in the middle.
+type snippetMarker struct {
+	text   string
+	prefix bool // text must start the line, rather than appear anywhere in it
+}
+
+// importantLine reports whether line matches one of snippetMarkers.
+func importantLine(line string) bool {
+	for _, m := range snippetMarkers {
+		if m.prefix && strings.HasPrefix(line, m.text) || !m.prefix && strings.Contains(line, m.text) {
+			return true
+		}
+	}
+	return false
+}
+
+// snippetMarkers are the important-looking lines, in addition to which
+// -snippet-marker adds more.
+var snippetMarkers = []snippetMarker{
+	{text: "panic:", prefix: true},
+	{text: "fatal error:", prefix: true},
+	{text: "--- FAIL:", prefix: true},
+	{text: ": internal compiler error:"},
+	{text: "DATA RACE"},               // WARNING: DATA RACE
+	{text: "signal SIGSEGV"},          // [signal SIGSEGV: segmentation violation ...]
+	{text: "test timed out"},          // panic: test timed out after 10m0s
+	{text: "goroutine stack exceeds"}, // runtime: goroutine stack exceeds 1000000000-byte limit
+}
+
 // shorten the output lines to form a snippet
 func snippet(log string) string {
 	lines := strings.SplitAfter(log, "\n")
@@ -602,15 +858,14 @@ func snippet(log string) string {
 	// If we have more than 30 lines, make the snippet by taking the first 10,
 	// the last 10, and possibly a middle 10. The middle 10 is included when
 	// the interior lines (between the first and last 10) contain an important-looking
-	// message like "panic:" or "--- FAIL:". The middle 10 start at the important-looking line.
-	// such as
+	// message like "panic:" or "--- FAIL:", as listed in snippetMarkers.
+	// The middle 10 start at the first important-looking line.
 	if len(lines) > 30 {
 		var keep []string
 		keep = append(keep, lines[:10]...)
 		dots := true
 		for i := 10; i < len(lines)-10; i++ {
-			s := strings.TrimSpace(lines[i])
-			if strings.HasPrefix(s, "panic:") || strings.HasPrefix(s, "fatal error:") || strings.HasPrefix(s, "--- FAIL:") || strings.Contains(s, ": internal compiler error:") {
+			if importantLine(strings.TrimSpace(lines[i])) {
 				if i > 10 {
 					keep = append(keep, "...\n")
 				}
@@ -633,6 +888,102 @@ func snippet(log string) string {
 	return strings.Join(lines, "")
 }
 
+// volatileTokens are the patterns of snippet text that differ between
+// occurrences of the same failure, with their canonical replacements,
+// in the order they're applied.
+var volatileTokens = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// Timestamps, like 2025-06-10T12:00:00.123Z or 12:00:00.123456.
+	{regexp.MustCompile(`\b\d{4}[-/]\d{2}[-/]\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "TIME"},
+	{regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}(\.\d+)?\b`), "TIME"},
+	// Addresses and offsets, like 0xc000123456 or +0x1f.
+	{regexp.MustCompile(`\b0x[0-9a-fA-F]+\b`), "0x?"},
+	// Goroutine numbers and wait times, like "goroutine 42 [chan receive, 3 minutes]".
+	{regexp.MustCompile(`\bgoroutine \d+\b`), "goroutine N"},
+	{regexp.MustCompile(`, \d+ minutes\]`), ", N minutes]"},
+	// Durations, like 1.23s, 250ms or 1m30.5s.
+	{regexp.MustCompile(`\b(\d+(\.\d+)?(ns|us|µs|ms|s|m|h))+\b`), "DURATION"},
+}
+
+// normalizeSnippet returns snippet with the tokens that differ between
+// occurrences of the same failure, like goroutine addresses, durations
+// and timestamps, replaced by placeholders, so that the results for
+// occurrences of the same failure are equal.
+func normalizeSnippet(snippet string) string {
+	for _, t := range volatileTokens {
+		snippet = t.re.ReplaceAllString(snippet, t.repl)
+	}
+	return snippet
+}
+
+// maxSnippetDistance is the fraction of lines that may differ between
+// the normalized snippets of two failures for them to be similar enough
+// that only one of them is shown when posting.
+const maxSnippetDistance = 0.2
+
+// similarSnippets reports whether the normalized snippets a and b are
+// similar: whether the line edit distance between them is at most
+// maxSnippetDistance of the length of the longer one.
+func similarSnippets(a, b string) bool {
+	if a == b {
+		return true
+	}
+	x := strings.Split(strings.TrimRight(a, "\n"), "\n")
+	y := strings.Split(strings.TrimRight(b, "\n"), "\n")
+	n := max(len(x), len(y))
+	return float64(lineDistance(x, y)) <= maxSnippetDistance*float64(n)
+}
+
+// lineDistance returns the Levenshtein distance between x and y,
+// counting inserted, deleted, and changed lines.
+func lineDistance(x, y []string) int {
+	prev := make([]int, len(y)+1)
+	cur := make([]int, len(y)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		cur[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(y)]
+}
+
+// parseSince returns the time that the -since flag value s refers to,
+// relative to now. s is either an RFC 3339 time or a positive duration
+// before now. Times earlier than timeLimit before now are clamped to it,
+// since older results aren't kept. An empty s means timeLimit before now.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	limit := now.Add(-timeLimit)
+	if s == "" {
+		return limit, nil
+	}
+	var t time.Time
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid -since %q: duration must be positive", s)
+		}
+		t = now.Add(-d)
+	} else if t, err = time.Parse(time.RFC3339, s); err != nil {
+		return time.Time{}, fmt.Errorf("invalid -since %q: want an RFC 3339 time or a duration like 72h", s)
+	} else if t.After(now) {
+		return time.Time{}, fmt.Errorf("invalid -since %q: time is in the future", s)
+	}
+	if t.Before(limit) {
+		t = limit
+	}
+	return t, nil
+}
+
 // If a build that has too many failures, the build is probably broken
 // (e.g. timeout, crash). Coalesce the failures and report maxFailPerBuild
 // of them.
@@ -0,0 +1,323 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	bbpb "go.chromium.org/luci/buildbucket/proto"
+	"rsc.io/github"
+)
+
+func TestDropRareNewIssues(t *testing.T) {
+	issue := func(number, posts int) *Issue {
+		return &Issue{
+			Issue: &github.Issue{Number: number, Title: "x: TestX failures"},
+			Post:  make([]*FailurePost, posts),
+		}
+	}
+	existing := issue(1, 1)
+	rare := issue(0, 1)
+	frequent := issue(0, 3)
+
+	for _, tt := range []struct {
+		min  int
+		want []*Issue
+	}{
+		{1, []*Issue{existing, rare, frequent}},
+		{2, []*Issue{existing, frequent}},
+		{4, []*Issue{existing}},
+	} {
+		got := dropRareNewIssues([]*Issue{existing, rare, frequent}, tt.min)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("dropRareNewIssues(min=%d) kept %d issues, want %d", tt.min, len(got), len(tt.want))
+		}
+	}
+}
+
+func TestDeferDigests(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	issue := func(number int, lastPost time.Time) *Issue {
+		return &Issue{
+			Issue:    &github.Issue{Number: number},
+			LastPost: lastPost,
+			Post:     []*FailurePost{{BuildResult: &BuildResult{Time: now.Add(-time.Hour)}}},
+		}
+	}
+	recent := issue(1, now.Add(-2*24*time.Hour))
+	due := issue(2, now.Add(-8*24*time.Hour))
+	never := issue(3, time.Time{})
+	newIssue := issue(0, time.Time{})
+	reopen := issue(4, now.Add(-time.Hour))
+	reopen.Closed = true
+	reopen.ClosedAt = now.Add(-2 * time.Hour)
+
+	deferDigests([]*Issue{recent, due, never, newIssue, reopen}, 7*24*time.Hour, now)
+	for _, tt := range []struct {
+		issue *Issue
+		posts int
+	}{
+		{recent, 0},
+		{due, 1},
+		{never, 1},
+		{newIssue, 1},
+		{reopen, 1},
+	} {
+		if len(tt.issue.Post) != tt.posts {
+			t.Errorf("after deferDigests, issue #%d has %d posts, want %d", tt.issue.Number, len(tt.issue.Post), tt.posts)
+		}
+	}
+}
+
+func TestNormalizeSnippet(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+	}{
+		{
+			"panic: runtime error: invalid memory address [signal SIGSEGV addr=0x0 pc=0x4a3b2c]\n\ngoroutine 42 [running]:\nmain.f(0xc000012345)\n\t/x/main.go:10 +0x1f\n",
+			"panic: runtime error: invalid memory address [signal SIGSEGV addr=0x0 pc=0x4a3f00]\n\ngoroutine 7 [running]:\nmain.f(0xc0000abcde)\n\t/x/main.go:10 +0x1f\n",
+		},
+		{
+			"--- FAIL: TestX (1.23s)\n    x_test.go:5: timed out after 250ms\n",
+			"--- FAIL: TestX (10.5s)\n    x_test.go:5: timed out after 1m30.5s\n",
+		},
+		{
+			"2025-06-10T12:00:00.123Z server started\n12:00:01.5 request failed\n",
+			"2025-06-11T08:30:45Z server started\n08:30:46.123456 request failed\n",
+		},
+		{
+			"goroutine 1 [chan receive, 3 minutes]:\n",
+			"goroutine 18 [chan receive, 12 minutes]:\n",
+		},
+	} {
+		if na, nb := normalizeSnippet(tt.a), normalizeSnippet(tt.b); na != nb {
+			t.Errorf("normalizeSnippet differs:\n%s\nvs\n%s", na, nb)
+		}
+	}
+
+	for _, tt := range []struct {
+		a, b string
+	}{
+		{"--- FAIL: TestX (1.23s)\n", "--- FAIL: TestY (1.23s)\n"},
+		{"x_test.go:5: got 1, want 2\n", "x_test.go:5: got 3, want 2\n"},
+		{"main.go:10: unexpected EOF\n", "main.go:11: unexpected EOF\n"},
+	} {
+		if na, nb := normalizeSnippet(tt.a), normalizeSnippet(tt.b); na == nb {
+			t.Errorf("normalizeSnippet(%q) == normalizeSnippet(%q) = %q, want different", tt.a, tt.b, na)
+		}
+	}
+}
+
+func TestInfraFailures(t *testing.T) {
+	const (
+		ok     = bbpb.Status_SUCCESS
+		fail   = bbpb.Status_FAILURE
+		infra  = bbpb.Status_INFRA_FAILURE
+		cancel = bbpb.Status_CANCELED
+	)
+	statuses := []bbpb.Status{ok, infra, infra, fail, cancel, infra, ok, infra, ok}
+	var rs []*BuildResult
+	var commits []Commit
+	for i, s := range statuses {
+		hash := string(rune('a' + i))
+		rs = append(rs, &BuildResult{Status: s, Builder: "linux-amd64", Commit: hash})
+		commits = append(commits, Commit{Hash: hash})
+	}
+	board := &Dashboard{
+		Project:  Project{Repo: "go", GoBranch: "master"},
+		Builders: []Builder{{Name: "linux-amd64"}},
+		Commits:  commits,
+		Results:  [][]*BuildResult{rs},
+	}
+
+	// The failure in a run of four failures that are mostly
+	// infrastructure failures is part of a broken builder, not a flake.
+	if n := skipBrokenBuilders([]*Dashboard{board}); n != 1 {
+		t.Errorf("skipBrokenBuilders found %d broken runs, want 1", n)
+	}
+	for i, want := range []bbpb.Status{ok, SKIP, SKIP, SKIP, SKIP, SKIP, ok, infra, ok} {
+		if rs[i].Status != want {
+			t.Errorf("after skipBrokenBuilders, result %d has status %v, want %v", i, rs[i].Status, want)
+		}
+	}
+
+	// Infrastructure failures and canceled builds are never
+	// reported as test failures.
+	board.Results = [][]*BuildResult{{
+		{Status: infra, Builder: "linux-amd64", Commit: "a"},
+		{Status: cancel, Builder: "linux-amd64", Commit: "b"},
+	}}
+	c := &LUCIClient{nProc: 1}
+	if res := c.FindFailures(context.Background(), []*Dashboard{board}); len(res) != 0 {
+		t.Errorf("FindFailures found %d failures, want 0", len(res))
+	}
+}
+
+func TestMarkConsistentFailures(t *testing.T) {
+	issue := func(number, top, other int) *Issue {
+		is := &Issue{Issue: &github.Issue{Number: number, Title: "x: TestX failures"}}
+		for i := 0; i < top; i++ {
+			is.Post = append(is.Post, &FailurePost{BuildResult: &BuildResult{Top: true}})
+		}
+		for i := 0; i < other; i++ {
+			is.Post = append(is.Post, &FailurePost{BuildResult: &BuildResult{}})
+		}
+		return is
+	}
+	for _, tt := range []struct {
+		issue *Issue
+		n     int
+		want  bool
+	}{
+		{issue(0, 4, 0), 4, true},
+		{issue(0, 4, 2), 4, true},
+		{issue(0, 4, 0), 6, false},
+		{issue(0, 6, 0), 6, true},
+		{issue(0, 0, 6), 2, false},
+		{issue(0, 0, 0), 0, false},
+		{issue(1, 6, 0), 4, false}, // existing issue
+	} {
+		markConsistentFailures([]*Issue{tt.issue}, tt.n)
+		if got := strings.HasSuffix(tt.issue.Title, " [consistent failure]"); got != tt.want {
+			t.Errorf("with %d posts and n=%d, title %q; want tagged=%v", len(tt.issue.Post), tt.n, tt.issue.Title, tt.want)
+		}
+	}
+}
+
+func TestSimilarSnippets(t *testing.T) {
+	lines := func(n int, change ...int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			if slices.Contains(change, i) {
+				b.WriteString("changed\n")
+			} else {
+				fmt.Fprintf(&b, "line %d\n", i)
+			}
+		}
+		return b.String()
+	}
+	for _, tt := range []struct {
+		a, b string
+		want bool
+	}{
+		{lines(10), lines(10), true},
+		{lines(10), lines(10, 3), true},
+		{lines(10), lines(10, 3, 7), true},
+		{lines(10), lines(10, 3, 5, 7), false},
+		{lines(10), lines(8), true},
+		{lines(10), lines(5), false},
+		{"a\n", "b\n", false},
+	} {
+		if got := similarSnippets(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarSnippets(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		in   string
+		want time.Time // zero for an error
+	}{
+		{"", now.Add(-timeLimit)},
+		{"72h", now.Add(-72 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"2025-06-01T00:00:00Z", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"2025-06-01T00:00:00-04:00", time.Date(2025, 6, 1, 4, 0, 0, 0, time.UTC)},
+		{"10000h", now.Add(-timeLimit)},
+		{"2024-01-01T00:00:00Z", now.Add(-timeLimit)},
+		{"0s", time.Time{}},
+		{"-1h", time.Time{}},
+		{"2025-07-01T00:00:00Z", time.Time{}},
+		{"yesterday", time.Time{}},
+		{"2025-06-01", time.Time{}},
+	} {
+		got, err := parseSince(tt.in, now)
+		if tt.want.IsZero() {
+			if err == nil {
+				t.Errorf("parseSince(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestSnippetMarkers(t *testing.T) {
+	// filler returns n lines of uninteresting output.
+	filler := func(name string, n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			fmt.Fprintf(&b, "=== RUN   %s/%d\n", name, i)
+		}
+		return b.String()
+	}
+	for _, tt := range []struct {
+		name   string
+		middle string // the interesting part of the log
+		first  string // the line that must start the middle of the snippet
+	}{
+		{"race", `==================
+WARNING: DATA RACE
+Write at 0x00c0001a2b38 by goroutine 42:
+  net/http.(*Transport).dialConn()
+      /workdir/go/src/net/http/transport.go:1760 +0x1f4
+
+Previous read at 0x00c0001a2b38 by goroutine 41:
+  net/http.(*Transport).getConn()
+      /workdir/go/src/net/http/transport.go:1401 +0x8b8
+==================
+--- FAIL: TestTransportDial (0.02s)
+    testing.go:1490: race detected during execution of test
+`, "WARNING: DATA RACE"},
+		{"sigsegv", `[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4a5b2c]
+
+goroutine 7 [running]:
+os/exec.(*Cmd).Wait(0x0)
+	/workdir/go/src/os/exec/exec.go:890 +0x2c
+`, "[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4a5b2c]"},
+		{"timeout", `    test timed out after 3m0s, running tests:
+        TestScript (3m0s)
+        TestScript/build_cache (2m59s)
+
+goroutine 1 [chan receive, 3 minutes]:
+testing.(*T).Run(0xc000003a00)
+`, "    test timed out after 3m0s, running tests:"},
+		{"stack", `runtime: goroutine stack exceeds 1000000000-byte limit
+runtime: sp=0xc020160398 stack=[0xc020160000, 0xc040160000]
+fatal error: stack overflow
+
+runtime stack:
+runtime.throw({0x7c1b2e, 0xe})
+`, "runtime: goroutine stack exceeds 1000000000-byte limit"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			log := filler("TestHead", 15) + tt.middle + filler("TestTail", 15)
+			got := snippet(log)
+			if !strings.Contains(got, "...\n"+tt.first+"\n") {
+				t.Errorf("snippet doesn't start its middle lines at %q:\n%s", tt.first, got)
+			}
+		})
+	}
+
+	// Markers can be added to the list.
+	defer func(old []snippetMarker) { snippetMarkers = old }(snippetMarkers)
+	log := filler("TestHead", 15) + "ERROR: AddressSanitizer: heap-use-after-free\n" + filler("TestTail", 15)
+	if got := snippet(log); strings.Contains(got, "AddressSanitizer") {
+		t.Errorf("snippet includes an unimportant line:\n%s", got)
+	}
+	snippetMarkers = append(snippetMarkers, snippetMarker{text: "AddressSanitizer"})
+	if got := snippet(log); !strings.Contains(got, "...\nERROR: AddressSanitizer") {
+		t.Errorf("snippet doesn't include an added marker:\n%s", got)
+	}
+}
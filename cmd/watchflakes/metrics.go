@@ -0,0 +1,63 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// runMetrics are the counters of one watchflakes run, as written by -metrics
+// for monitoring, such as alerting on a spike of new issues.
+type runMetrics struct {
+	Start    time.Time `json:"start"`    // when the run started
+	Duration float64   `json:"duration"` // in seconds
+
+	Boards   int `json:"boards"`   // dashboards read
+	Failures int `json:"failures"` // failed builds found
+	Issues   int `json:"issues"`   // issues the failures were matched against
+
+	NewIssues  int `json:"newIssues"`  // new issues created, or that would be without -post
+	Posts      int `json:"posts"`      // issues created or commented on
+	PostErrors int `json:"postErrors"` // comments that failed to post
+	Skipped    int `json:"skipped"`    // failures matched by a skip in an issue script
+	Suppressed int `json:"suppressed"` // failures held back by -min-occurrences or -digest
+
+	BrokenBuilders int `json:"brokenBuilders"` // runs of consistent failures on a builder, skipped as broken
+	BrokenCommits  int `json:"brokenCommits"`  // commits skipped as broken
+}
+
+// writeMetrics appends m as a single line of JSON to file.
+// The file "-" means standard output.
+func writeMetrics(file string, m *runMetrics) error {
+	js, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	js = append(js, '\n')
+	if file == "-" {
+		_, err := os.Stdout.Write(js)
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(js); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// pendingPosts returns the number of failures waiting to be posted to issues.
+func pendingPosts(issues []*Issue) int {
+	n := 0
+	for _, issue := range issues {
+		n += len(issue.Post)
+	}
+	return n
+}
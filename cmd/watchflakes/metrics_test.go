@@ -0,0 +1,51 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "metrics.jsonl")
+	start := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	runs := []*runMetrics{
+		{Start: start, Duration: 62.5, Boards: 3, Failures: 10, Issues: 40, NewIssues: 1, Posts: 4, Skipped: 2, BrokenBuilders: 1},
+		{Start: start.Add(time.Hour), Duration: 30, Boards: 3, Failures: 12, Issues: 41, Posts: 2, Suppressed: 5, BrokenCommits: 1},
+	}
+	for _, m := range runs {
+		if err := writeMetrics(file, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []*runMetrics
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		m := new(runMetrics)
+		if err := json.Unmarshal(s.Bytes(), m); err != nil {
+			t.Fatalf("line %d: %v", len(got)+1, err)
+		}
+		got = append(got, m)
+	}
+	if len(got) != len(runs) {
+		t.Fatalf("read %d lines of metrics, want %d", len(got), len(runs))
+	}
+	for i := range runs {
+		if *got[i] != *runs[i] {
+			t.Errorf("line %d = %+v, want %+v", i+1, *got[i], *runs[i])
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+)
+
+// A previewEntry is a GitHub update that -post would make, in its final
+// rendered form, as written by -post-to-file.
+type previewEntry struct {
+	Action string   `json:"action"`           // "new-issue" or "comment"
+	Repo   string   `json:"repo,omitempty"`   // GitHub repo of a new issue, as "owner/name"
+	Issue  int      `json:"issue,omitempty"`  // number of the issue commented on; 0 for a new issue
+	Title  string   `json:"title"`            // title of the issue
+	Labels []string `json:"labels,omitempty"` // labels of a new issue
+	Reopen bool     `json:"reopen,omitempty"` // whether the issue is reopened before commenting
+	Body   string   `json:"body"`             // issue body or comment text
+}
+
+// preview, if non-nil, receives the updates that -post would make.
+// It's set up by openPreview.
+var preview *json.Encoder
+
+// openPreview arranges for the updates that -post would make to be
+// written to file as a stream of JSON previewEntry objects, one per line.
+// The file "-" means standard output.
+// The caller must call close once all entries are written.
+func openPreview(file string) (close func() error, err error) {
+	if file == "-" {
+		preview = json.NewEncoder(os.Stdout)
+		return func() error { return nil }, nil
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	preview = json.NewEncoder(f)
+	return f.Close, nil
+}
+
+func writePreview(e *previewEntry) {
+	if err := preview.Encode(e); err != nil {
+		log.Fatalf("writing preview: %v", err)
+	}
+}
+
+// previewNew writes the preview of the new issue that postNew would create.
+func previewNew(issue *Issue) {
+	e := &previewEntry{Action: "new-issue", Repo: issue.Route.GitHub, Title: issue.Title, Body: issue.Body + signature}
+	for _, lab := range newIssueLabels(issue.Route) {
+		e.Labels = append(e.Labels, lab.Name)
+	}
+	writePreview(e)
+}
+
+// previewComment writes the preview of the comment that postComment would
+// post on issue.
+func previewComment(issue *Issue, body string) {
+	writePreview(&previewEntry{
+		Action: "comment",
+		Issue:  issue.Number,
+		Title:  issue.Title,
+		Reopen: issue.shouldReopen(),
+		Body:   commentBody(body),
+	})
+}
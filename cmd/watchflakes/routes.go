@@ -0,0 +1,130 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"rsc.io/github"
+)
+
+// An issueRoute says where to file new issues for the failures it matches.
+type issueRoute struct {
+	Repo   string   `json:"repo"`   // repo of the failures (as in FailurePost.Repo); empty matches all
+	Pkg    string   `json:"pkg"`    // package of the failures, or a parent of it; empty matches all
+	GitHub string   `json:"github"` // GitHub repo to file the issues in, as "owner/name"
+	Labels []string `json:"labels"` // labels to add to the issues, besides NeedsInvestigation
+}
+
+// defaultRoute is where new issues are filed when no issueRoutes entry
+// matches the failure.
+var defaultRoute = &issueRoute{GitHub: "golang/go"}
+
+// issueRoutes are the routes for new issues, in order of preference.
+var issueRoutes []*issueRoute
+
+// loadIssueRoutes reads the issue routing configuration in file,
+// a JSON array of routes, such as:
+//
+//	[{"repo": "tools", "pkg": "golang.org/x/tools/gopls", "github": "golang/go", "labels": ["gopls"]},
+//	 {"repo": "vscode-go", "github": "golang/vscode-go"}]
+//
+// The issues must be in the golang organization, since watchflakes only
+// reads the issues in its Test Flakes project.
+func loadIssueRoutes(file string) ([]*issueRoute, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var routes []*issueRoute
+	if err := json.Unmarshal(data, &routes); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", file, err)
+	}
+	for i, r := range routes {
+		owner, name, ok := strings.Cut(r.GitHub, "/")
+		if !ok || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("%s: route %d: invalid GitHub repo %q, want owner/name", file, i, r.GitHub)
+		}
+		if owner != "golang" {
+			return nil, fmt.Errorf("%s: route %d: GitHub repo %q is not in the golang organization", file, i, r.GitHub)
+		}
+	}
+	return routes, nil
+}
+
+// routeIssue returns the route for a new issue for fp:
+// the first of issueRoutes that matches it, or else defaultRoute.
+func routeIssue(fp *FailurePost) *issueRoute {
+	for _, r := range issueRoutes {
+		if r.Repo != "" && r.Repo != fp.Repo {
+			continue
+		}
+		if r.Pkg != "" && fp.Pkg != r.Pkg && !strings.HasPrefix(fp.Pkg, r.Pkg+"/") {
+			continue
+		}
+		return r
+	}
+	return defaultRoute
+}
+
+// A githubRepo is a GitHub repo that new issues can be filed in.
+type githubRepo struct {
+	*github.Repo
+	labels map[string]*github.Label
+}
+
+// githubRepos holds the GitHub repos of defaultRoute and issueRoutes,
+// keyed by "owner/name". It's set up by readGitHubRepos.
+var githubRepos map[string]*githubRepo
+
+// readGitHubRepos reads the GitHub repos that new issues are filed in,
+// and their labels.
+func readGitHubRepos() error {
+	githubRepos = make(map[string]*githubRepo)
+	for _, route := range append([]*issueRoute{defaultRoute}, issueRoutes...) {
+		r := githubRepos[route.GitHub]
+		if r == nil {
+			owner, name, _ := strings.Cut(route.GitHub, "/")
+			repo, err := gh.Repo(owner, name)
+			if err != nil {
+				return err
+			}
+			list, err := gh.SearchLabels(owner, name, "")
+			if err != nil {
+				return err
+			}
+			r = &githubRepo{Repo: repo, labels: make(map[string]*github.Label)}
+			for _, label := range list {
+				r.labels[label.Name] = label
+			}
+			githubRepos[route.GitHub] = r
+		}
+		for _, name := range route.Labels {
+			if r.labels[name] == nil {
+				log.Printf("label %q not found in %s; not adding it to new issues", name, route.GitHub)
+			}
+		}
+	}
+	return nil
+}
+
+// newIssueLabels returns the labels to add to a new issue filed by route.
+func newIssueLabels(route *issueRoute) []*github.Label {
+	r := githubRepos[route.GitHub]
+	if r == nil {
+		return nil
+	}
+	var labels []*github.Label
+	for _, name := range append([]string{"NeedsInvestigation"}, route.Labels...) {
+		if lab := r.labels[name]; lab != nil {
+			labels = append(labels, lab)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRouteIssue(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "routes.json")
+	err := os.WriteFile(file, []byte(`[
+		{"repo": "tools", "pkg": "golang.org/x/tools/gopls", "github": "golang/go", "labels": ["gopls"]},
+		{"repo": "vscode-go", "github": "golang/vscode-go"},
+		{"pkg": "golang.org/x/telemetry", "github": "golang/go", "labels": ["telemetry"]}
+	]`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes, err := loadIssueRoutes(file)
+	if err != nil {
+		t.Fatalf("loadIssueRoutes: %v", err)
+	}
+	defer func(old []*issueRoute) { issueRoutes = old }(issueRoutes)
+	issueRoutes = routes
+
+	for _, tt := range []struct {
+		repo, pkg string
+		want      *issueRoute
+	}{
+		{"tools", "golang.org/x/tools/gopls", routes[0]},
+		{"tools", "golang.org/x/tools/gopls/internal/server", routes[0]},
+		{"tools", "golang.org/x/tools/goplsfoo", defaultRoute},
+		{"tools", "golang.org/x/tools/go/packages", defaultRoute},
+		{"go", "golang.org/x/tools/gopls", defaultRoute},
+		{"vscode-go", "", routes[1]},
+		{"vscode-go", "github.com/golang/vscode-go/extension", routes[1]},
+		{"telemetry", "golang.org/x/telemetry/upload", routes[2]},
+		{"go", "net/http", defaultRoute},
+	} {
+		r := &BuildResult{BuilderConfigProperties: &BuilderConfigProperties{Repo: tt.repo}}
+		fp := &FailurePost{BuildResult: r, Failure: &Failure{}, Pkg: tt.pkg}
+		if got := routeIssue(fp); got != tt.want {
+			t.Errorf("routeIssue(repo=%q, pkg=%q) = %+v, want %+v", tt.repo, tt.pkg, got, tt.want)
+		}
+	}
+}
+
+func TestLoadIssueRoutesErrors(t *testing.T) {
+	for _, config := range []string{
+		`{"repo": "tools"}`,
+		`[{"repo": "tools"}]`,
+		`[{"repo": "tools", "github": "golang"}]`,
+		`[{"repo": "tools", "github": "golang/tools/gopls"}]`,
+		`[{"repo": "tools", "github": "example/tools"}]`,
+	} {
+		file := filepath.Join(t.TempDir(), "routes.json")
+		if err := os.WriteFile(file, []byte(config), 0666); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadIssueRoutes(file); err == nil {
+			t.Errorf("loadIssueRoutes(%s) succeeded, want error", config)
+		}
+	}
+}
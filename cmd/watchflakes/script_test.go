@@ -108,6 +108,17 @@ var scriptTests = [...]struct {
 		}},
 		"",
 	},
+	{
+		`post <- repo == "tools" && gobranch == "release-branch.go1.22"`,
+		[]*script.Rule{{
+			Action: "post",
+			Pattern: &script.AndExpr{
+				X: &script.CmpExpr{Field: "repo", Op: "==", Literal: "tools"},
+				Y: &script.CmpExpr{Field: "gobranch", Op: "==", Literal: "release-branch.go1.22"},
+			},
+		}},
+		"",
+	},
 	{
 		`post <- pkg ~ "^cmd/go"`,
 		nil,
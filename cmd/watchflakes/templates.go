@@ -0,0 +1,110 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// An issueTemplate holds the templates for the title and body of new
+// issues filed for failures in a repo. The templates are executed with
+// an issueTemplateData.
+//
+// The body template only produces the human-readable part of the body:
+// the watchflakes script that matches the failures always comes first,
+// and the signature always comes last, since watchflakes relies on both
+// to recognize the issues it manages.
+type issueTemplate struct {
+	Title *template.Template
+	Body  *template.Template
+}
+
+// issueTemplateData is the data issue templates are executed with.
+type issueTemplateData struct {
+	*FailurePost
+	DefaultTitle string // title of the issue when no title template is configured
+	Pattern      string // watchflakes pattern matching the failure
+}
+
+var templateFuncs = template.FuncMap{
+	"indent": func(s string) string { return indent(spaces[:4], s) },
+}
+
+var defaultIssueTemplate = &issueTemplate{
+	Title: template.Must(template.New("title").Parse(`{{.DefaultTitle}}`)),
+	Body: template.Must(template.New("body").Funcs(templateFuncs).Parse(
+		"Issue created automatically to collect these failures.\n\n" +
+			"Example ([log]({{.URL}})):\n\n{{indent .Snippet}}")),
+}
+
+// issueTemplates maps repos (as in FailurePost.Repo) to the templates
+// for their new issues. Repos not in the map use defaultIssueTemplate.
+var issueTemplates = map[string]*issueTemplate{}
+
+// loadIssueTemplates reads the issue templates configuration in file,
+// a JSON object mapping repo names to objects with optional "title" and
+// "body" templates, such as:
+//
+//	{"tools": {"body": "Please triage per go.dev/wiki/...\n\n{{indent .Snippet}}"}}
+//
+// Templates that aren't set fall back to the defaults.
+func loadIssueTemplates(file string) (map[string]*issueTemplate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", file, err)
+	}
+	m := make(map[string]*issueTemplate)
+	for repo, c := range config {
+		t := &issueTemplate{Title: defaultIssueTemplate.Title, Body: defaultIssueTemplate.Body}
+		if c.Title != "" {
+			t.Title, err = template.New(repo + " title").Funcs(templateFuncs).Parse(c.Title)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", file, err)
+			}
+		}
+		if c.Body != "" {
+			t.Body, err = template.New(repo + " body").Funcs(templateFuncs).Parse(c.Body)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", file, err)
+			}
+		}
+		m[repo] = t
+	}
+	return m, nil
+}
+
+// issueTitleAndBody returns the title and the human-readable part of
+// the body of a new issue for fp, using the templates for fp's repo.
+func issueTitleAndBody(fp *FailurePost, defaultTitle, pattern string) (title, body string, err error) {
+	t := issueTemplates[fp.Repo]
+	if t == nil {
+		t = defaultIssueTemplate
+	}
+	data := &issueTemplateData{FailurePost: fp, DefaultTitle: defaultTitle, Pattern: pattern}
+	var b strings.Builder
+	if err := t.Title.Execute(&b, data); err != nil {
+		return "", "", fmt.Errorf("executing title template for repo %q: %v", fp.Repo, err)
+	}
+	title = strings.TrimSpace(b.String())
+	if title == "" || strings.Contains(title, "\n") {
+		return "", "", fmt.Errorf("title template for repo %q produced invalid title %q", fp.Repo, title)
+	}
+	b.Reset()
+	if err := t.Body.Execute(&b, data); err != nil {
+		return "", "", fmt.Errorf("executing body template for repo %q: %v", fp.Repo, err)
+	}
+	return title, b.String(), nil
+}
@@ -9,9 +9,11 @@ package dashboard
 import (
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/build/buildenv"
@@ -120,6 +122,32 @@ var slowBotAliases = map[string]string{
 // Initialization happens below, via calls to addBuilder.
 var Builders = map[string]*BuildConfig{}
 
+// activeBuilders is the builder configuration set by SetActiveBuilders.
+var activeBuilders atomic.Pointer[map[string]*BuildConfig]
+
+// ActiveBuilders returns the builder configuration in use, keyed by
+// builder name: Builders, unless a program such as the coordinator
+// replaced it at runtime with SetActiveBuilders. Lookups of builders
+// to run should go through it, so that they all see the same
+// configuration. The returned map must not be modified.
+func ActiveBuilders() map[string]*BuildConfig {
+	if m := activeBuilders.Load(); m != nil {
+		return *m
+	}
+	return Builders
+}
+
+// SetActiveBuilders makes m the builder configuration returned by
+// ActiveBuilders. m must not be modified afterwards.
+// A nil m goes back to Builders.
+func SetActiveBuilders(m map[string]*BuildConfig) {
+	if m == nil {
+		activeBuilders.Store(nil)
+		return
+	}
+	activeBuilders.Store(&m)
+}
+
 // GoBootstrap is the bootstrap Go version.
 //
 // For bootstrap versions Go 1.21.0 and newer,
@@ -678,6 +706,12 @@ type HostConfig struct {
 	ContainerImage string // e.g. "linux-buildlet-std:latest" (suffix after "gcr.io/<PROJ>/")
 	IsReverse      bool   // if true, only use the reverse buildlet pool
 
+	// AllowedImages are older revisions of VMImage or ContainerImage
+	// (for container hosts) that gomote users may explicitly request
+	// with 'gomote create -image' to reproduce image-specific failures.
+	// The current image is always allowed.
+	AllowedImages []string
+
 	// GCE options, if VMImage != "" || ContainerImage != ""
 	machineType     string  // optional GCE instance type ("n2-standard-4") or instance class ("n2")
 	RegularDisk     bool    // if true, use spinning disk instead of SSD
@@ -892,6 +926,14 @@ func (c *BuildConfig) Env() []string {
 	return append(env, c.env...)
 }
 
+// WithEnv returns a copy of c with the extra environment ("key=value")
+// pairs env, which override any earlier values of the same keys.
+func (c *BuildConfig) WithEnv(env ...string) *BuildConfig {
+	nc := *c
+	nc.env = append(slices.Clip(c.env), env...)
+	return &nc
+}
+
 func (c *BuildConfig) IsReverse() bool { return c.HostConfig().IsReverse }
 
 func (c *BuildConfig) IsGCE() bool { return !c.HostConfig().IsReverse && !c.HostConfig().IsEC2 }
@@ -901,6 +943,20 @@ func (c *HostConfig) IsContainer() bool  { return c.ContainerImage != "" }
 
 func (c *BuildConfig) IsVM() bool { return c.HostConfig().IsVM() }
 
+// IsAllowedImage reports whether image may be requested as the base
+// image of a GCE instance of this host type: the current VMImage or
+// ContainerImage, or one of the AllowedImages.
+func (c *HostConfig) IsAllowedImage(image string) bool {
+	if image == "" || c.IsReverse || c.IsEC2 {
+		return false
+	}
+	current := c.VMImage
+	if c.IsContainer() {
+		current = c.ContainerImage
+	}
+	return image == current || slices.Contains(c.AllowedImages, image)
+}
+
 // IsVM reports whether the instance running the job is ultimately a VM. Hosts where
 // a VM is used only to initiate a container are considered a container, not a VM.
 // EC2 instances may be configured to run in containers that are running
@@ -2984,11 +3040,11 @@ func TryBuildersForProject(proj, branch, goBranch string) []*BuildConfig {
 type isBuilderFunc func(conf *BuildConfig, proj, branch, goBranch string) bool
 
 // buildersForProject returns the builders that should be run for the given project,
-// using isBuilder to test each builder.
+// using isBuilder to test each of ActiveBuilders.
 // See TryBuildersForProject for the valid forms of proj, branch and goBranch.
 func buildersForProject(proj, branch, goBranch string, isBuilder isBuilderFunc) []*BuildConfig {
 	var confs []*BuildConfig
-	for _, conf := range Builders {
+	for _, conf := range ActiveBuilders() {
 		if isBuilder(conf, proj, branch, goBranch) {
 			confs = append(confs, conf)
 		}
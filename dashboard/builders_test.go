@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -1176,3 +1177,45 @@ func listPorts() ([]string, error) {
 	}
 	return strings.Fields(string(out)), nil
 }
+
+func TestHostConfigIsAllowedImage(t *testing.T) {
+	vm := &HostConfig{VMImage: "openbsd-amd64-72", AllowedImages: []string{"openbsd-amd64-71"}}
+	container := &HostConfig{ContainerImage: "linux-x86-bullseye:latest", AllowedImages: []string{"linux-x86-bullseye:abc123"}}
+	reverse := &HostConfig{IsReverse: true}
+	tests := []struct {
+		hc    *HostConfig
+		image string
+		want  bool
+	}{
+		{vm, "openbsd-amd64-72", true},
+		{vm, "openbsd-amd64-71", true},
+		{vm, "openbsd-amd64-70", false},
+		{vm, "", false},
+		{container, "linux-x86-bullseye:latest", true},
+		{container, "linux-x86-bullseye:abc123", true},
+		{container, "linux-x86-bookworm:latest", false},
+		{reverse, "", false},
+		{reverse, "anything", false},
+	}
+	for _, tt := range tests {
+		if got := tt.hc.IsAllowedImage(tt.image); got != tt.want {
+			t.Errorf("HostConfig{VMImage: %q, ContainerImage: %q}.IsAllowedImage(%q) = %v; want %v", tt.hc.VMImage, tt.hc.ContainerImage, tt.image, got, tt.want)
+		}
+	}
+}
+
+func TestBuildConfigWithEnv(t *testing.T) {
+	c := &BuildConfig{Name: "linux-amd64-test", HostType: "host-linux-amd64-bullseye", env: make([]string, 1, 2)}
+	c.env[0] = "GOAMD64=v2"
+	nc := c.WithEnv("GOAMD64=v3")
+	if got, want := nc.Env(), "GOAMD64=v3"; got[len(got)-1] != want {
+		t.Errorf("WithEnv(%q).Env() = %q; want it to end with %q", want, got, want)
+	}
+	nc2 := c.WithEnv("GODEBUG=x=1")
+	if slices.Contains(nc.Env(), "GODEBUG=x=1") || slices.Contains(c.Env(), "GODEBUG=x=1") {
+		t.Errorf("WithEnv modified the environment of other BuildConfigs")
+	}
+	if !slices.Contains(nc2.Env(), "GOAMD64=v2") {
+		t.Errorf("WithEnv(%q).Env() = %q; want it to keep GOAMD64=v2", "GODEBUG=x=1", nc2.Env())
+	}
+}
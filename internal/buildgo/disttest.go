@@ -0,0 +1,122 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildgo
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DistTestName is the name of a dist test as discovered from 'go tool dist test -list'.
+type DistTestName struct {
+	Old string // Old is dist test name converted to Go 1.20 format, like "go_test:sort" or "reboot".
+	Raw string // Raw is unmodified name from dist, suitable as an argument back to 'go tool dist test'.
+}
+
+// Go120DistTestNames converts a list of dist test names from
+// an arbitrary Go distribution to the format used in Go 1.20
+// and prior versions. (Go 1.21 introduces a simpler format.)
+//
+// This exists only to avoid rewriting current dist adjust policies.
+// We wish to avoid new dist adjust policies, but if they're truly needed,
+// they can choose to start using new dist test names instead.
+func Go120DistTestNames(names []string) []DistTestName {
+	if len(names) == 0 {
+		// Only happens if there's a problem, but no need to panic.
+		return nil
+	} else if strings.HasPrefix(names[0], "go_test:") {
+		// In Go 1.21 and newer no dist tests have a "go_test:" prefix.
+		// In Go 1.20 and older, go tool dist test -list always returns
+		// at least one "go_test:*" test first.
+		// So if we see it, the list is already in Go 1.20 format.
+		var s []DistTestName
+		for _, old := range names {
+			s = append(s, DistTestName{old, old})
+		}
+		return s
+	}
+	// Remap the new Go 1.21+ dist test names to old ones.
+	var s []DistTestName
+	for _, new := range names {
+		var old string
+		switch pkg, variant, _ := strings.Cut(new, ":"); {
+		// Special cases. Enough to cover what's used by old dist
+		// adjust policies. Not much use in going far beyond that.
+		case variant == "nolibgcc":
+			old = "nolibgcc:" + pkg
+		case variant == "race":
+			old = "race"
+		case variant == "moved_goroot":
+			old = "moved_goroot"
+		case pkg == "cmd/internal/testdir":
+			if variant == "" {
+				// Handle this too for when we stop doing special-case sharding only for testdir inside dist.
+				variant = "0_1"
+			}
+			old = "test:" + variant
+		case pkg == "cmd/api" && variant == "check":
+			old = "api"
+		case pkg == "cmd/internal/bootstrap_test":
+			old = "reboot"
+
+		// Easy regular cases.
+		case variant == "":
+			old = "go_test:" + pkg
+		case variant == "racebench":
+			old = "go_test_bench:" + pkg
+
+		// Neither a known special case nor a regular case.
+		default:
+			old = new // Less bad than leaving it empty.
+		}
+		s = append(s, DistTestName{Old: old, Raw: new})
+	}
+	return s
+}
+
+// PartitionGoTests groups the "go_test:*" tests among the Go 1.20 format
+// test names in tests into sets that can be run together by a single
+// 'go tool dist test' invocation, using testDuration to estimate how long
+// each test takes on the named builder. Other tests are not returned;
+// they're expected to be run by themselves.
+func PartitionGoTests(testDuration func(string, string) time.Duration, builderName string, tests []string) (sets [][]string) {
+	var srcTests []string
+	var cmdTests []string
+	for _, name := range tests {
+		if strings.HasPrefix(name, "go_test:cmd/") {
+			cmdTests = append(cmdTests, name)
+		} else if strings.HasPrefix(name, "go_test:") {
+			srcTests = append(srcTests, name)
+		}
+	}
+	sort.Strings(srcTests)
+	sort.Strings(cmdTests)
+	goTests := append(srcTests, cmdTests...)
+
+	const sizeThres = 10 * time.Second
+
+	var curSet []string
+	var curDur time.Duration
+
+	flush := func() {
+		if len(curSet) > 0 {
+			sets = append(sets, curSet)
+			curSet = nil
+			curDur = 0
+		}
+	}
+	for _, testName := range goTests {
+		d := testDuration(builderName, testName)
+		if curDur+d > sizeThres {
+			flush() // no-op if empty
+		}
+		curSet = append(curSet, testName)
+		curDur += d
+	}
+
+	flush()
+	return
+}
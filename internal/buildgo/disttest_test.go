@@ -0,0 +1,108 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildgo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type Seconds float64
+
+func (s Seconds) Duration() time.Duration {
+	return time.Duration(float64(s) * float64(time.Second))
+}
+
+var fixedTestDuration = map[string]Seconds{
+	"go_test:a": 1,
+	"go_test:b": 1.5,
+	"go_test:c": 2,
+	"go_test:d": 2.50,
+	"go_test:e": 3,
+	"go_test:f": 3.5,
+	"go_test:g": 4,
+	"go_test:h": 4.5,
+	"go_test:i": 5,
+	"go_test:j": 5.5,
+	"go_test:k": 6.5,
+}
+
+func TestPartitionGoTests(t *testing.T) {
+	var in []string
+	for name := range fixedTestDuration {
+		in = append(in, name)
+	}
+	testDuration := func(builder, testName string) time.Duration {
+		if s, ok := fixedTestDuration[testName]; ok {
+			return s.Duration()
+		}
+		return 3 * time.Second
+	}
+	sets := PartitionGoTests(testDuration, "", in)
+	want := [][]string{
+		{"go_test:a", "go_test:b", "go_test:c", "go_test:d", "go_test:e"},
+		{"go_test:f", "go_test:g"},
+		{"go_test:h", "go_test:i"},
+		{"go_test:j"},
+		{"go_test:k"},
+	}
+	if !reflect.DeepEqual(sets, want) {
+		t.Errorf(" got: %v\nwant: %v", sets, want)
+	}
+}
+
+// Test that Go120DistTestNames remaps both 1.20 (old) and 1.21 (new)
+// dist test names to old, and doesn't forget the original name (raw).
+func TestGo120DistTestNames(t *testing.T) {
+	for _, tc := range [...]struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "empty",
+			in:   "",
+			want: "",
+		},
+		{
+			name: "old to old",
+			in:   "go_test:archive/tar go_test:cmd/go api reboot test:0_2 test:1_2",
+			want: "go_test:archive/tar go_test:cmd/go api reboot test:0_2 test:1_2",
+		},
+		{
+			name: "new to old",
+			in:   "        archive/tar         cmd/go cmd/api:check cmd/internal/bootstrap_test cmd/internal/testdir:0_2 cmd/internal/testdir:1_2",
+			want: "go_test:archive/tar go_test:cmd/go     api                  reboot                        test:0_2                 test:1_2",
+		},
+		{
+			name: "more special cases",
+			in:   "crypto/x509:nolibgcc fmt:moved_goroot flag:race net:race os:race",
+			want: "nolibgcc:crypto/x509     moved_goroot      race     race    race",
+		},
+		{
+			name: "unhandled special case",
+			in:   "something:something",
+			want: "something:something",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Go120DistTestNames(strings.Fields(tc.in))
+			var want []DistTestName
+			for _, old := range strings.Fields(tc.want) {
+				want = append(want, DistTestName{Old: old})
+			}
+			for i, raw := range strings.Fields(tc.in) {
+				want[i].Raw = raw
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Go120DistTestNames mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -20,6 +20,50 @@ import (
 	"google.golang.org/api/iterator"
 )
 
+// missingFields returns the top-level fields of want that aren't in have,
+// as nullable columns that can be added to an existing table.
+func missingFields(have, want bigquery.Schema) bigquery.Schema {
+	names := make(map[string]bool)
+	for _, fs := range have {
+		names[fs.Name] = true
+	}
+	var missing bigquery.Schema
+	for _, fs := range want {
+		if !names[fs.Name] {
+			fs := *fs
+			fs.Required = false
+			missing = append(missing, &fs)
+		}
+	}
+	return missing
+}
+
+// EnsureTable creates the BigQuery table t with the schema inferred
+// from the struct st if it doesn't exist, and otherwise adds columns
+// for the fields of st that it's missing.
+func EnsureTable(ctx context.Context, t *bigquery.Table, st interface{}) error {
+	schema, err := bigquery.InferSchema(st)
+	if err != nil {
+		return fmt.Errorf("InferSchema: %v", err)
+	}
+	meta, err := t.Metadata(ctx)
+	if ae, ok := err.(*googleapi.Error); ok && ae.Code == 404 {
+		log.Printf("buildstats: creating table %s", t.TableID)
+		return t.Create(ctx, &bigquery.TableMetadata{Schema: schema})
+	}
+	if err != nil {
+		return fmt.Errorf("getting %s table metadata: %v", t.TableID, err)
+	}
+	if added := missingFields(meta.Schema, schema); len(added) > 0 {
+		log.Printf("buildstats: adding %d columns to %s", len(added), t.TableID)
+		newSchema := append(meta.Schema[:len(meta.Schema):len(meta.Schema)], added...)
+		if _, err := t.Update(ctx, bigquery.TableMetadataToUpdate{Schema: newSchema}, meta.ETag); err != nil {
+			return fmt.Errorf("updating %s table schema: %v", t.TableID, err)
+		}
+	}
+	return nil
+}
+
 // SyncBuilds syncs the datastore "Build" entities to the BigQuery "Builds" table.
 // This stores information on each build as a whole, without details.
 func SyncBuilds(ctx context.Context, env *buildenv.Environment) error {
@@ -30,36 +74,15 @@ func SyncBuilds(ctx context.Context, env *buildenv.Environment) error {
 	defer bq.Close()
 
 	buildsTable := bq.Dataset("builds").Table("Builds")
-	meta, err := buildsTable.Metadata(ctx)
-	if ae, ok := err.(*googleapi.Error); ok && ae.Code == 404 {
-		if Verbose {
-			log.Printf("Creating table Builds...")
-		}
-		err = buildsTable.Create(ctx, nil)
-		if err == nil {
-			meta, err = buildsTable.Metadata(ctx)
-		}
+	// Create the table, or add columns for any fields added to
+	// BuildRecord since it was created, so that they're synced too.
+	if err := EnsureTable(ctx, buildsTable, types.BuildRecord{}); err != nil {
+		return err
 	}
+	meta, err := buildsTable.Metadata(ctx)
 	if err != nil {
 		return fmt.Errorf("getting Builds table metadata: %v", err)
 	}
-	if Verbose {
-		log.Printf("buildstats: Builds metadata: %#v", meta)
-	}
-	if len(meta.Schema) == 0 {
-		if Verbose {
-			log.Printf("buildstats: builds table has empty schema")
-		}
-		schema, err := bigquery.InferSchema(types.BuildRecord{})
-		if err != nil {
-			return fmt.Errorf("InferSchema: %v", err)
-		}
-		blindWrite := ""
-		meta, err = buildsTable.Update(ctx, bigquery.TableMetadataToUpdate{Schema: schema}, blindWrite)
-		if err != nil {
-			return fmt.Errorf("table.Update schema: %v", err)
-		}
-	}
 	if Verbose {
 		for i, fs := range meta.Schema {
 			log.Printf("  schema[%v]: %+v", i, fs)
@@ -0,0 +1,241 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package pool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"golang.org/x/build/internal/buildstats"
+	"golang.org/x/build/types"
+)
+
+// Tuning knobs for the BigQuery exporter.
+const (
+	bqBatchSize     = 500              // maximum rows per insert
+	bqFlushInterval = 10 * time.Second // how long rows may wait for a batch to fill
+	bqMaxPending    = 20000            // rows buffered per table before new ones are dropped
+	bqMaxAttempts   = 4                // inserts attempted before a batch is spilled
+)
+
+// rowPutter inserts rows into a table. It's implemented by
+// *bigquery.Inserter.
+type rowPutter interface {
+	Put(ctx context.Context, src interface{}) error
+}
+
+// A bqTable is the buffer of rows waiting to be inserted into one
+// BigQuery table.
+type bqTable struct {
+	name    string // for logging and spill object names
+	putter  rowPutter
+	pending []*bigquery.StructSaver
+	dropped int // rows dropped because pending was full, since last logged
+}
+
+// A RecordExporter streams build and span records to BigQuery in
+// batches, in addition to the datastore writes done by PutBuildRecord
+// and PutSpanRecord.
+//
+// Adding a record never blocks. Failed inserts are retried with
+// backoff, and batches that still fail are spilled to GCS as
+// newline-delimited JSON, so they can be loaded later with bq load.
+// If records arrive faster than they can be exported or spilled,
+// the newest ones are dropped once bqMaxPending rows are buffered.
+type RecordExporter struct {
+	// spill writes data to the named object for later loading.
+	spill func(ctx context.Context, name string, data []byte) error
+	// sleep is time.Sleep, replaced in tests.
+	sleep func(time.Duration)
+
+	kick chan struct{} // non-blocking signal that a batch is full
+
+	mu     sync.Mutex
+	builds bqTable
+	spans  bqTable
+}
+
+// The BigQuery tables in the "builds" dataset that a RecordExporter
+// streams to. They're separate from the Builds and Spans tables that
+// buildstats.SyncBuilds and SyncSpans fill from datastore, which decide
+// what to copy by the latest EndTime in the table, so that the two
+// don't skip or duplicate each other's rows.
+const (
+	StreamedBuildsTable = "StreamedBuilds"
+	StreamedSpansTable  = "StreamedSpans"
+)
+
+// NewRecordExporter returns a RecordExporter that writes to the
+// StreamedBuildsTable and StreamedSpansTable tables in the "builds"
+// dataset, creating them or adding columns to them as needed.
+// Batches that can't be inserted are written under spillPrefix in the
+// build environment's log bucket.
+// The caller must call Run to start exporting.
+func NewRecordExporter(ctx context.Context, bq *bigquery.Client, spillPrefix string) (*RecordExporter, error) {
+	ds := bq.Dataset("builds")
+	builds, spans := ds.Table(StreamedBuildsTable), ds.Table(StreamedSpansTable)
+	if err := buildstats.EnsureTable(ctx, builds, types.BuildRecord{}); err != nil {
+		return nil, err
+	}
+	if err := buildstats.EnsureTable(ctx, spans, types.SpanRecord{}); err != nil {
+		return nil, err
+	}
+	return newRecordExporter(builds.Inserter(), spans.Inserter(), func(ctx context.Context, name string, data []byte) error {
+		if storageClient == nil || buildEnv == nil || buildEnv.LogBucket == "" {
+			return fmt.Errorf("no log bucket to spill to")
+		}
+		w := storageClient.Bucket(buildEnv.LogBucket).Object(spillPrefix + name).NewWriter(ctx)
+		w.ContentType = "application/json"
+		if _, err := w.Write(data); err != nil {
+			w.Close()
+			return err
+		}
+		return w.Close()
+	}), nil
+}
+
+func newRecordExporter(builds, spans rowPutter, spill func(context.Context, string, []byte) error) *RecordExporter {
+	return &RecordExporter{
+		spill:  spill,
+		sleep:  time.Sleep,
+		kick:   make(chan struct{}, 1),
+		builds: bqTable{name: "builds", putter: builds},
+		spans:  bqTable{name: "spans", putter: spans},
+	}
+}
+
+// SetRecordExporter makes p send the build and span records it
+// stores to e as well.
+func (p *Process) SetRecordExporter(e *RecordExporter) {
+	p.exporter = e
+}
+
+// AddBuildRecord queues br for export if it's the final record of the
+// build, with EndTime set. The record put when the build starts isn't
+// exported, so that there's one row per build.
+func (e *RecordExporter) AddBuildRecord(br *types.BuildRecord) {
+	if br.EndTime.IsZero() {
+		return
+	}
+	e.add(&e.builds, &bigquery.StructSaver{Struct: br, InsertID: br.ID})
+}
+
+// AddSpanRecord queues sr for export.
+func (e *RecordExporter) AddSpanRecord(sr *types.SpanRecord) {
+	e.add(&e.spans, &bigquery.StructSaver{Struct: sr, InsertID: spanKey(sr)})
+}
+
+func (e *RecordExporter) add(t *bqTable, row *bigquery.StructSaver) {
+	e.mu.Lock()
+	if len(t.pending) >= bqMaxPending {
+		t.dropped++
+		e.mu.Unlock()
+		return
+	}
+	t.pending = append(t.pending, row)
+	full := len(t.pending) >= bqBatchSize
+	e.mu.Unlock()
+	if full {
+		select {
+		case e.kick <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run exports queued records until ctx is done.
+func (e *RecordExporter) Run(ctx context.Context) {
+	t := time.NewTicker(bqFlushInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		case <-e.kick:
+		}
+		e.flush(ctx, &e.builds)
+		e.flush(ctx, &e.spans)
+	}
+}
+
+// flush exports all of t's pending rows, a batch at a time.
+func (e *RecordExporter) flush(ctx context.Context, t *bqTable) {
+	for ctx.Err() == nil {
+		e.mu.Lock()
+		if t.dropped > 0 {
+			log.Printf("bigquery export: dropped %d %s records because the export buffer was full", t.dropped, t.name)
+			t.dropped = 0
+		}
+		n := min(len(t.pending), bqBatchSize)
+		batch := t.pending[:n:n]
+		t.pending = t.pending[n:]
+		e.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		e.export(ctx, t, batch)
+	}
+}
+
+// export inserts batch into t's table, retrying with backoff, and
+// spills it if it can't be inserted.
+func (e *RecordExporter) export(ctx context.Context, t *bqTable, batch []*bigquery.StructSaver) {
+	var err error
+	backoff := time.Second
+	for attempt := 1; attempt <= bqMaxAttempts; attempt++ {
+		if attempt > 1 {
+			e.sleep(backoff)
+			backoff *= 2
+		}
+		putCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		err = t.putter.Put(putCtx, batch)
+		cancel()
+		if err == nil {
+			return
+		}
+		if multi, ok := err.(bigquery.PutMultiError); ok {
+			// Retry only the rows that failed.
+			batch = failedRows(batch, multi)
+		}
+	}
+	log.Printf("bigquery export: inserting %d %s records failed after %d attempts, spilling them: %v", len(batch), t.name, bqMaxAttempts, err)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, row := range batch {
+		if err := enc.Encode(row.Struct); err != nil {
+			log.Printf("bigquery export: encoding %s record %s: %v", t.name, row.InsertID, err)
+		}
+	}
+	name := fmt.Sprintf("%s/%s.json", t.name, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := e.spill(ctx, name, buf.Bytes()); err != nil {
+		log.Printf("bigquery export: spilling %d %s records failed, dropping them: %v", len(batch), t.name, err)
+	}
+}
+
+// failedRows returns the rows of batch reported as failed by err.
+func failedRows(batch []*bigquery.StructSaver, err bigquery.PutMultiError) []*bigquery.StructSaver {
+	var failed []*bigquery.StructSaver
+	for _, re := range err {
+		if re.RowIndex >= 0 && re.RowIndex < len(batch) {
+			failed = append(failed, batch[re.RowIndex])
+		}
+	}
+	return failed
+}
+
+// spanKey returns the datastore key name and BigQuery insert ID of sr.
+func spanKey(sr *types.SpanRecord) string {
+	return fmt.Sprintf("%s-%v-%v", sr.BuildID, sr.StartTime.UnixNano(), sr.Event)
+}
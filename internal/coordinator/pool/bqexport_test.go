@@ -0,0 +1,119 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"golang.org/x/build/types"
+)
+
+// fakePutter records inserted rows. Its first failures calls to Put fail.
+type fakePutter struct {
+	failures int
+	puts     int
+	rows     []string // insert IDs
+}
+
+func (p *fakePutter) Put(ctx context.Context, src interface{}) error {
+	p.puts++
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("bigquery is down")
+	}
+	for _, row := range src.([]*bigquery.StructSaver) {
+		p.rows = append(p.rows, row.InsertID)
+	}
+	return nil
+}
+
+func newTestExporter(builds, spans rowPutter) (*RecordExporter, map[string]string) {
+	spilled := make(map[string]string)
+	e := newRecordExporter(builds, spans, func(_ context.Context, name string, data []byte) error {
+		spilled[name] = string(data)
+		return nil
+	})
+	e.sleep = func(time.Duration) {}
+	return e, spilled
+}
+
+func TestRecordExporterBatches(t *testing.T) {
+	builds, spans := new(fakePutter), new(fakePutter)
+	e, spilled := newTestExporter(builds, spans)
+	for i := 0; i < bqBatchSize+10; i++ {
+		e.AddBuildRecord(&types.BuildRecord{ID: fmt.Sprintf("B%d", i), EndTime: time.Now()})
+	}
+	e.AddSpanRecord(&types.SpanRecord{BuildID: "B0", Event: "make"})
+
+	e.flush(context.Background(), &e.builds)
+	e.flush(context.Background(), &e.spans)
+
+	if builds.puts != 2 || len(builds.rows) != bqBatchSize+10 {
+		t.Errorf("builds: got %d rows in %d puts, want %d rows in 2 puts", len(builds.rows), builds.puts, bqBatchSize+10)
+	}
+	if len(spans.rows) != 1 || !strings.HasPrefix(spans.rows[0], "B0-") {
+		t.Errorf("spans: got rows %q, want one with ID B0-...", spans.rows)
+	}
+	if len(spilled) != 0 {
+		t.Errorf("spilled %d objects, want none", len(spilled))
+	}
+}
+
+func TestRecordExporterFinalBuildRecords(t *testing.T) {
+	builds := new(fakePutter)
+	e, _ := newTestExporter(builds, new(fakePutter))
+	br := &types.BuildRecord{ID: "B1", StartTime: time.Now()}
+	e.AddBuildRecord(br) // as put when the build starts
+	br2 := *br
+	br2.EndTime = br.StartTime.Add(time.Minute)
+	e.AddBuildRecord(&br2) // as put when it finishes
+	e.flush(context.Background(), &e.builds)
+	if len(builds.rows) != 1 {
+		t.Errorf("exported %d build rows, want only the final one", len(builds.rows))
+	}
+}
+
+func TestRecordExporterRetryAndSpill(t *testing.T) {
+	builds := &fakePutter{failures: bqMaxAttempts - 1}
+	e, spilled := newTestExporter(builds, new(fakePutter))
+	e.AddBuildRecord(&types.BuildRecord{ID: "B1", EndTime: time.Now()})
+	e.flush(context.Background(), &e.builds)
+	if len(builds.rows) != 1 || len(spilled) != 0 {
+		t.Fatalf("after transient failures: got %d rows, %d spilled objects; want 1 row, 0 spilled", len(builds.rows), len(spilled))
+	}
+
+	builds.failures = bqMaxAttempts
+	e.AddBuildRecord(&types.BuildRecord{ID: "B2", EndTime: time.Now()})
+	e.flush(context.Background(), &e.builds)
+	if len(builds.rows) != 1 || len(spilled) != 1 {
+		t.Fatalf("after persistent failures: got %d rows, %d spilled objects; want 1 row, 1 spilled", len(builds.rows), len(spilled))
+	}
+	for name, data := range spilled {
+		if !strings.HasPrefix(name, "builds/") || !strings.Contains(data, `"ID":"B2"`) {
+			t.Errorf("spilled %s = %q, want builds/... containing B2", name, data)
+		}
+	}
+}
+
+func TestRecordExporterDropsWhenFull(t *testing.T) {
+	e, _ := newTestExporter(new(fakePutter), new(fakePutter))
+	for i := 0; i < bqMaxPending+5; i++ {
+		e.AddSpanRecord(&types.SpanRecord{BuildID: "B", Event: fmt.Sprint(i)})
+	}
+	if got := len(e.spans.pending); got != bqMaxPending {
+		t.Errorf("pending = %d, want %d", got, bqMaxPending)
+	}
+	if e.spans.dropped != 5 {
+		t.Errorf("dropped = %d, want 5", e.spans.dropped)
+	}
+}
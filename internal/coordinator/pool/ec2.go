@@ -13,6 +13,7 @@ import (
 	"html"
 	"io"
 	"log"
+	"slices"
 	"sync"
 	"time"
 
@@ -320,6 +321,7 @@ func (eb *EC2Buildlet) destroyUntrackedInstances(ctx context.Context) {
 		return
 	}
 	deleteInsts := make([]string, 0, len(insts))
+	instNames := make(map[string]string) // instance ID to name
 	for _, inst := range insts {
 		if !isBuildlet(inst.Name) {
 			// Non-buildlets have not been created by the EC2 buildlet pool. Their lifecycle
@@ -335,12 +337,30 @@ func (eb *EC2Buildlet) destroyUntrackedInstances(ctx context.Context) {
 		if id := eb.ledger.InstanceID(inst.Name); id != "" {
 			continue
 		}
+		if inStartupGrace(time.Now()) {
+			log.Printf("destroyUntrackedInstances: not yet deleting %q during startup grace period", inst.Name)
+			continue
+		}
 		deleteInsts = append(deleteInsts, inst.ID)
+		instNames[inst.ID] = inst.Name
 		log.Printf("queued for deleting untracked EC2 VM %q with id %q", inst.Name, inst.ID)
 	}
 	if len(deleteInsts) == 0 {
 		return
 	}
+	// Check again right before deleting, in case any came back into use
+	// since we listed them.
+	deleteInsts = slices.DeleteFunc(deleteInsts, func(id string) bool {
+		name := instNames[id]
+		if eb.ledger.InstanceID(name) != "" || eb.isRemoteBuildlet(name) {
+			log.Printf("destroyUntrackedInstances: keeping %q; it's now in use", name)
+			return true
+		}
+		return false
+	})
+	if len(deleteInsts) == 0 {
+		return
+	}
 	if err := eb.awsClient.DestroyInstances(ctx, deleteInsts...); err != nil {
 		log.Printf("failed cleaning EC2 VMs: %s", err)
 	}
@@ -518,6 +518,44 @@ func TestEC2BuildeletDestroyUntrackedInstances(t *testing.T) {
 	}
 }
 
+func TestEC2BuildletDestroyUntrackedInstancesStartupGrace(t *testing.T) {
+	defer SetStartupGrace(0)
+	awsC := cloud.NewFakeAWSClient()
+	for it := 0; it < 3; it++ {
+		_, err := awsC.CreateInstance(context.Background(), &cloud.EC2VMConfiguration{
+			Description: "test instance",
+			ImageID:     "image-x",
+			Name:        instanceName("host-test-type", 10),
+			SSHKeyID:    "key-14",
+			Tags:        map[string]string{},
+			Type:        "type-x",
+			Zone:        "zone-1",
+		})
+		if err != nil {
+			t.Fatalf("unable to create instance: %s", err)
+		}
+	}
+	pool := &EC2Buildlet{
+		awsClient:        awsC,
+		isRemoteBuildlet: func(string) bool { return false },
+		ledger:           newLedger(),
+	}
+
+	SetStartupGrace(time.Hour)
+	pool.destroyUntrackedInstances(context.Background())
+	gotInsts, err := awsC.RunningInstances(context.Background())
+	if err != nil || len(gotInsts) != 3 {
+		t.Errorf("during startup grace: awsClient.RunningInstances(ctx) = %d instances, %v; want 3 instances and no error", len(gotInsts), err)
+	}
+
+	SetStartupGrace(0)
+	pool.destroyUntrackedInstances(context.Background())
+	gotInsts, err = awsC.RunningInstances(context.Background())
+	if err != nil || len(gotInsts) != 0 {
+		t.Errorf("after startup grace: awsClient.RunningInstances(ctx) = %d instances, %v; want 0 instances and no error", len(gotInsts), err)
+	}
+}
+
 // fakeEC2BuildletClient is the client used to create buildlets on EC2.
 type fakeEC2BuildletClient struct {
 	createVMRequestSuccess bool
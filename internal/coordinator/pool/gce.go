@@ -94,6 +94,19 @@ var (
 	isRemoteBuildlet IsRemoteBuildletFunc
 )
 
+// snapBucketOverride, if non-empty, replaces the build environment's
+// SnapBucket. It's set by SetSnapBucket.
+var snapBucketOverride string
+
+// SetSnapBucket overrides the GCS bucket that build snapshots are read
+// from and written to, instead of the build environment's SnapBucket.
+// It's meant for bucket migrations and testing.
+//
+// It must be called before InitGCE.
+func SetSnapBucket(bucket string) {
+	snapBucketOverride = bucket
+}
+
 // InitGCE initializes the GCE buildlet pool.
 func InitGCE(sc *secret.Client, basePin *atomic.Value, fn IsRemoteBuildletFunc, buildEnvName, mode string) error {
 	gceMode = mode
@@ -119,6 +132,9 @@ func InitGCE(sc *secret.Client, basePin *atomic.Value, fn IsRemoteBuildletFunc,
 
 	buildEnv = buildenv.ByProjectID(buildEnvName)
 	inStaging = buildEnv == buildenv.Staging
+	if snapBucketOverride != "" {
+		buildEnv.SnapBucket = snapBucketOverride
+	}
 
 	// If running on GCE, override the zone and static IP, and check service account permissions.
 	if metadata.OnGCE() {
@@ -162,6 +178,12 @@ func InitGCE(sc *secret.Client, basePin *atomic.Value, fn IsRemoteBuildletFunc,
 		if err != nil {
 			log.Fatalf("storage.NewClient: %v", err)
 		}
+		if err := checkSnapBucket(ctx); err != nil {
+			if snapBucketOverride != "" {
+				log.Fatalf("Overridden snapshot bucket is unusable: %v", err)
+			}
+			log.Printf("Snapshot bucket is unusable: %v", err)
+		}
 	}
 
 	dsClient, err = datastore.NewClient(ctx, buildEnv.ProjectName)
@@ -302,6 +324,37 @@ func (c *GCEConfiguration) GCPCredentials() *google.Credentials {
 	return gcpCreds
 }
 
+// checkSnapBucket checks that a test object can be written to and read
+// back from the snapshot bucket, and logs which bucket is in use.
+func checkSnapBucket(ctx context.Context) error {
+	bucket := buildEnv.SnapBucket
+	if bucket == "" {
+		log.Printf("Snapshots disabled: no snapshot bucket configured.")
+		return nil
+	}
+	log.Printf("Using snapshot bucket %q.", bucket)
+	want := fmt.Sprintf("Hello, world! Coordinator start-up at %v", time.Now())
+	obj := storageClient.Bucket(bucket).Object("hello.txt")
+	wr := obj.NewWriter(ctx)
+	fmt.Fprint(wr, want)
+	if err := wr.Close(); err != nil {
+		return fmt.Errorf("test write of a GCS object to bucket %q failed: %v", bucket, err)
+	}
+	rd, err := obj.NewReader(ctx)
+	if err != nil {
+		return fmt.Errorf("test read of a GCS object from bucket %q failed: %v", bucket, err)
+	}
+	defer rd.Close()
+	got, err := io.ReadAll(rd)
+	if err != nil {
+		return fmt.Errorf("test read of a GCS object from bucket %q failed: %v", bucket, err)
+	}
+	if string(got) != want {
+		return fmt.Errorf("test read of a GCS object from bucket %q returned %q, want %q", bucket, got, want)
+	}
+	return nil
+}
+
 func checkTryBuildDeps(ctx context.Context, sc *secret.Client) error {
 	if !hasStorageScope() {
 		return errors.New("coordinator's GCE instance lacks the storage service scope")
@@ -510,6 +563,7 @@ func (p *GCEBuildlet) GetBuildlet(ctx context.Context, hostType string, lg Logge
 			},
 			Zone:       zone,
 			DiskSizeGB: hconf.RootDriveSizeGB,
+			Image:      si.Image,
 		})
 		if errors.Is(err, buildlet.ErrQuotaExceeded) && ctx.Err() == nil {
 			log.Printf("Failed to create VM because quota exceeded. Retrying after 10 second (attempt: %d).", attempts)
@@ -710,11 +764,13 @@ func (p *GCEBuildlet) cleanZoneVMs(zone string) error {
 				}
 			}
 			isBuildlet := isBuildlet(inst.Name)
+			var unrecognized bool // deleting only because it's not ours
 
 			if isBuildlet && !sawDeleteAt && !p.instanceUsed(inst.Name) {
 				createdAt, _ := time.Parse(time.RFC3339Nano, inst.CreationTimestamp)
 				if createdAt.Before(time.Now().Add(-3 * time.Hour)) {
 					deleteReason = fmt.Sprintf("no delete-at, created at %s", inst.CreationTimestamp)
+					unrecognized = true
 				}
 			}
 
@@ -724,9 +780,19 @@ func (p *GCEBuildlet) cleanZoneVMs(zone string) error {
 			if deleteReason == "" && sawDeleteAt && isBuildlet && !p.instanceUsed(inst.Name) {
 				if _, ok := deletedVMCache.Get(inst.Name); !ok {
 					deleteReason = "from earlier coordinator generation"
+					unrecognized = true
 				}
 			}
 
+			if unrecognized && inst.Status == "RUNNING" && inStartupGrace(time.Now()) {
+				log.Printf("cleanZoneVMs: not yet deleting VM %q (%s) during startup grace period", inst.Name, deleteReason)
+				continue
+			}
+			if unrecognized && (p.instanceUsed(inst.Name) || isRemoteBuildlet(inst.Name)) {
+				// Back in use since we listed it, while paging or during the grace period.
+				log.Printf("cleanZoneVMs: keeping VM %q; it's now in use", inst.Name)
+				continue
+			}
 			if deleteReason != "" {
 				log.Printf("deleting VM %q in zone %q; %s ...", inst.Name, zone, deleteReason)
 				deleteVM(zone, inst.Name)
@@ -8,7 +8,6 @@ package pool
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"sync"
 	"time"
@@ -36,6 +35,7 @@ type Logger interface {
 type Process struct {
 	processID        string
 	processStartTime time.Time
+	exporter         *RecordExporter // or nil; see SetRecordExporter
 }
 
 var (
@@ -94,6 +94,9 @@ func (p *Process) UpdateInstanceRecord() {
 }
 
 func (p *Process) PutBuildRecord(br *types.BuildRecord) {
+	if p.exporter != nil {
+		p.exporter.AddBuildRecord(br)
+	}
 	dsClient := NewGCEConfiguration().DSClient()
 	if dsClient == nil {
 		return
@@ -106,12 +109,15 @@ func (p *Process) PutBuildRecord(br *types.BuildRecord) {
 }
 
 func (p *Process) PutSpanRecord(sr *types.SpanRecord) {
+	if p.exporter != nil {
+		p.exporter.AddSpanRecord(sr)
+	}
 	dsClient := NewGCEConfiguration().DSClient()
 	if dsClient == nil {
 		return
 	}
 	ctx := context.Background()
-	key := datastore.NameKey("Span", fmt.Sprintf("%s-%v-%v", sr.BuildID, sr.StartTime.UnixNano(), sr.Event), nil)
+	key := datastore.NameKey("Span", spanKey(sr), nil)
 	if _, err := dsClient.Put(ctx, key, sr); err != nil {
 		log.Printf("datastore Span Put: %v", err)
 	}
@@ -41,6 +41,29 @@ type Buildlet interface {
 // functions are moved into a package.
 type IsRemoteBuildletFunc func(instanceName string) bool
 
+var (
+	processStart = time.Now()
+	startupGrace time.Duration // set by SetStartupGrace
+)
+
+// SetStartupGrace sets how long after the coordinator starts that the
+// GCE and EC2 pools delay deleting running buildlets they don't
+// recognize as leftovers from a previous coordinator. The pools don't
+// take them over; any that come back into use in the meantime, such as
+// reattached gomote sessions, are kept, and the rest are deleted once
+// the grace period ends. A zero grace period, the default, deletes them
+// right away.
+//
+// It must be called before the pools are started.
+func SetStartupGrace(d time.Duration) {
+	startupGrace = d
+}
+
+// inStartupGrace reports whether now is within the startup grace period.
+func inStartupGrace(now time.Time) bool {
+	return now.Sub(processStart) < startupGrace
+}
+
 // randHex generates a random hex string.
 func randHex(n int) string {
 	buf := make([]byte, n/2+1)
@@ -45,6 +45,10 @@ type SchedItem struct {
 	CommitTime  time.Time
 	RequestTime time.Time
 	User        string
+
+	// Image optionally overrides the base image of the host type.
+	// It's only set for gomote instances; see buildlet.VMOpts.Image.
+	Image string
 }
 
 // Priority returns the BuildletPriority for a SchedItem.
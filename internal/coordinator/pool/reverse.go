@@ -34,6 +34,7 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/md5"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -42,6 +43,8 @@ import (
 	"net"
 	"net/http"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -49,6 +52,7 @@ import (
 	"golang.org/x/build/dashboard"
 	"golang.org/x/build/internal/coordinator/pool/queue"
 	"golang.org/x/build/revdial/v2"
+	"golang.org/x/build/types"
 )
 
 const minBuildletVersion = 23
@@ -57,6 +61,7 @@ var (
 	reversePool = &ReverseBuildletPool{
 		hostLastGood: make(map[string]time.Time),
 		hostQueue:    make(map[string]*queue.Quota),
+		disabled:     make(map[string]bool),
 	}
 
 	builderMasterKey []byte
@@ -75,7 +80,7 @@ func ReversePool() *ReverseBuildletPool {
 
 // ReverseBuildletPool manages the pool of reverse buildlet pools.
 type ReverseBuildletPool struct {
-	// mu guards all 5 fields below and also fields of
+	// mu guards all the fields below and also fields of
 	// *reverseBuildlet in buildlets
 	mu sync.Mutex
 
@@ -102,6 +107,151 @@ type ReverseBuildletPool struct {
 	// machines as both POWER8 and POWER9 host types, but with the
 	// same names).
 	hostLastGood map[string]time.Time
+
+	// disabled is the set of host names that operators have
+	// disabled. Disabled buildlets stay connected and health
+	// checked, but aren't given work. It's keyed by host name
+	// rather than being a field on reverseBuildlet so that a
+	// misbehaving machine stays disabled when it reconnects.
+	disabled map[string]bool
+
+	// maxPerHostType, if positive, is the maximum number of
+	// buildlets of a host type that may be connected at once,
+	// unless maxByHostType overrides it for that host type.
+	// Registrations beyond it are rejected.
+	maxPerHostType int
+	maxByHostType  map[string]int
+
+	// requireToken is whether registrations must present a valid
+	// registration token in addition to the builder key.
+	requireToken bool
+}
+
+// SetMaxPerHostType sets the maximum number of reverse buildlets of
+// each host type that may be connected at once, protecting the pool
+// from runaway registrations. Buildlets that register beyond it are
+// rejected. The limit for a host type is its entry in byHostType if
+// present, and def otherwise. Zero, the default, means no limit.
+func (p *ReverseBuildletPool) SetMaxPerHostType(def int, byHostType map[string]int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maxPerHostType = def
+	p.maxByHostType = byHostType
+}
+
+// ParseMaxPerHostType parses a comma-separated list of hostType=N
+// pairs, optionally including a bare N that applies to all other host
+// types, into arguments for SetMaxPerHostType.
+func ParseMaxPerHostType(s string) (def int, byHostType map[string]int, err error) {
+	if s == "" {
+		return 0, nil, nil
+	}
+	haveDef := false
+	for _, f := range strings.Split(s, ",") {
+		hostType, num, ok := strings.Cut(f, "=")
+		if !ok {
+			hostType, num = "", f
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil || n < 0 {
+			return 0, nil, fmt.Errorf("invalid limit %q; want a non-negative integer", f)
+		}
+		switch {
+		case !ok && haveDef:
+			return 0, nil, fmt.Errorf("more than one default limit in %q", s)
+		case !ok:
+			def, haveDef = n, true
+		case hostType == "":
+			return 0, nil, fmt.Errorf("missing host type in %q", f)
+		default:
+			if _, dup := byHostType[hostType]; dup {
+				return 0, nil, fmt.Errorf("duplicate limit for host type %s", hostType)
+			}
+			if byHostType == nil {
+				byHostType = make(map[string]int)
+			}
+			byHostType[hostType] = n
+		}
+	}
+	return def, byHostType, nil
+}
+
+// maxLocked returns the maximum number of buildlets of hostType that
+// may be connected at once, or 0 for no limit. p.mu must be held.
+func (p *ReverseBuildletPool) maxLocked(hostType string) int {
+	if n, ok := p.maxByHostType[hostType]; ok {
+		return n
+	}
+	return p.maxPerHostType
+}
+
+// SetRequireRegistrationToken sets whether reverse buildlets must
+// present a registration token signed with the builder master key for
+// their host type, as generated by cmd/genbuilderkey, to register.
+// Registrations without a valid, unexpired token are rejected.
+func (p *ReverseBuildletPool) SetRequireRegistrationToken(require bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requireToken = require
+}
+
+// requiresToken reports whether registrations must present a valid
+// registration token.
+func (p *ReverseBuildletPool) requiresToken() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requireToken
+}
+
+// atCapLocked reports whether no more buildlets of hostType may be
+// connected. p.mu must be held.
+func (p *ReverseBuildletPool) atCapLocked(hostType string) bool {
+	max := p.maxLocked(hostType)
+	if max <= 0 {
+		return false
+	}
+	n := 0
+	for _, b := range p.buildlets {
+		if b.hostType == hostType {
+			n++
+		}
+	}
+	return n >= max
+}
+
+// atCap reports whether no more buildlets of hostType may be connected.
+func (p *ReverseBuildletPool) atCap(hostType string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.atCapLocked(hostType)
+}
+
+// SetHostDisabled disables or re-enables the reverse buildlet with
+// the given host name. While disabled, it's not assigned builds and
+// doesn't count towards its host type in CanBuild. Builds already
+// running on it aren't affected.
+func (p *ReverseBuildletPool) SetHostDisabled(hostname string, disabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if disabled {
+		p.disabled[hostname] = true
+	} else {
+		delete(p.disabled, hostname)
+	}
+	p.updateQuotasLocked()
+}
+
+// DisabledHosts returns the sorted host names of the disabled reverse
+// buildlets, including ones that aren't currently connected.
+func (p *ReverseBuildletPool) DisabledHosts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var hosts []string
+	for h := range p.disabled {
+		hosts = append(hosts, h)
+	}
+	sort.Strings(hosts)
+	return hosts
 }
 
 // BuildletLastSeen gives the last time a buildlet was connected to the pool. If
@@ -123,7 +273,7 @@ func (p *ReverseBuildletPool) tryToGrab(hostType string) (bc buildlet.Client, bu
 	defer p.mu.Unlock()
 	defer p.updateQuotasLocked()
 	for _, b := range p.buildlets {
-		if b.hostType != hostType {
+		if b.hostType != hostType || p.disabled[b.hostname] {
 			continue
 		}
 		if b.inUse {
@@ -303,6 +453,9 @@ func (p *ReverseBuildletPool) WriteHTMLStatus(w io.Writer) {
 			machStatus = "working"
 			numInUse++
 		}
+		if p.disabled[b.hostname] {
+			machStatus = "<b>disabled</b>, " + machStatus
+		}
 		fmt.Fprintf(&buf, "<li>%s (%s) version %s, %s: connected %s, %s for %s</li>\n",
 			b.hostname,
 			b.conn.RemoteAddr(),
@@ -348,6 +501,67 @@ func (p *ReverseBuildletPool) WriteHTMLStatus(w io.Writer) {
 	fmt.Fprintf(w, "<b>Reverse pool machine detail</b><ul>%s</ul>", buf.Bytes())
 }
 
+// ReverseStatus returns the status of the connected reverse buildlets,
+// grouped by host type.
+func (p *ReverseBuildletPool) ReverseStatus() *types.ReverseBuilderStatus {
+	status := new(types.ReverseBuilderStatus)
+	for typ, host := range dashboard.Hosts {
+		if host.ExpectNum > 0 {
+			status.Host(typ).Expect = host.ExpectNum
+		}
+	}
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, b := range p.buildlets {
+		hs := status.Host(b.hostType)
+		if hs.Machines == nil {
+			hs.Machines = make(map[string]*types.ReverseBuilder)
+		}
+		hs.Connected++
+		rb := &types.ReverseBuilder{
+			Name:         b.hostname,
+			HostType:     b.hostType,
+			ConnectedSec: now.Sub(b.regTime).Seconds(),
+			Version:      b.version,
+			Busy:         b.inUse,
+			Disabled:     p.disabled[b.hostname],
+		}
+		switch {
+		case b.inUse:
+			hs.Busy++
+			rb.BusySec = now.Sub(b.inUseTime).Seconds()
+		case !rb.Disabled:
+			hs.Idle++
+			rb.IdleSec = now.Sub(b.inUseTime).Seconds()
+		}
+		hs.Machines[b.hostname] = rb
+	}
+	for typ, q := range p.hostQueue {
+		if hs, ok := status.HostTypes[typ]; ok {
+			hs.Waiters = len(q.ToExported().Items)
+		}
+	}
+	for typ := range p.maxByHostType {
+		status.Host(typ)
+	}
+	for typ, hs := range status.HostTypes {
+		hs.Max = p.maxLocked(typ)
+	}
+	return status
+}
+
+// ServeReverseStatusJSON serves the ReverseStatus of p as JSON.
+func (p *ReverseBuildletPool) ServeReverseStatusJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	j, err := json.MarshalIndent(p.ReverseStatus(), "", "\t")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(j)
+}
+
 func (p *ReverseBuildletPool) QuotaStats() map[string]*queue.QuotaStats {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -406,7 +620,7 @@ func (p *ReverseBuildletPool) CanBuild(hostType string) bool {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	for _, b := range p.buildlets {
-		if b.hostType == hostType {
+		if b.hostType == hostType && !p.disabled[b.hostname] {
 			return true
 		}
 	}
@@ -423,6 +637,12 @@ func (p *ReverseBuildletPool) updateQuotasLocked() {
 	limits := make(map[string]int)
 	used := make(map[string]int)
 	for _, b := range p.buildlets {
+		if p.disabled[b.hostname] {
+			// Keep the host type's quota up to date even if
+			// all its buildlets are disabled.
+			limits[b.hostType] += 0
+			continue
+		}
 		limits[b.hostType] += 1
 		if b.inUse {
 			used[b.hostType] += 1
@@ -434,13 +654,19 @@ func (p *ReverseBuildletPool) updateQuotasLocked() {
 	}
 }
 
-func (p *ReverseBuildletPool) addBuildlet(b *reverseBuildlet) {
+// addBuildlet adds b to the pool. It returns an error if the pool
+// already has the maximum number of buildlets of b's host type.
+func (p *ReverseBuildletPool) addBuildlet(b *reverseBuildlet) error {
 	p.mu.Lock()
 	defer p.updateQuotas()
 	defer p.mu.Unlock()
+	if p.atCapLocked(b.hostType) {
+		return fmt.Errorf("already have the maximum of %d reverse buildlets for host type %s", p.maxLocked(b.hostType), b.hostType)
+	}
 	p.buildlets = append(p.buildlets, b)
 	p.recordHealthy(b)
 	go p.healthCheckBuildletLoop(b)
+	return nil
 }
 
 // BuildletHostnames returns a slice of reverse buildlet hostnames.
@@ -496,6 +722,7 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 		buildKey        = r.Header.Get("X-Go-Builder-Key")
 		buildletVersion = r.Header.Get("X-Go-Builder-Version")
 		hostname        = r.Header.Get("X-Go-Builder-Hostname")
+		regToken        = r.Header.Get("X-Go-Builder-Token")
 	)
 
 	switch r.Header.Get("X-Revdial-Version") {
@@ -524,6 +751,18 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid build key", http.StatusPreconditionFailed)
 		return
 	}
+	if reversePool.requiresToken() {
+		if err := buildlet.CheckRegistrationToken(builderMasterKey, hostType, regToken, time.Now()); err != nil {
+			log.Printf("Rejecting reverse buildlet %q (%s) for host type %v: %v", hostname, r.RemoteAddr, hostType, err)
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+	}
+	if reversePool.atCap(hostType) {
+		log.Printf("Rejecting reverse buildlet %q (%s) for host type %v: too many connected", hostname, r.RemoteAddr, hostType)
+		http.Error(w, "too many reverse buildlets connected for host type", http.StatusServiceUnavailable)
+		return
+	}
 
 	conn, _, err := w.(http.Hijacker).Hijack()
 	if err != nil {
@@ -603,7 +842,13 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 		inUseTime: now,
 		regTime:   now,
 	}
-	reversePool.addBuildlet(b)
+	if err := reversePool.addBuildlet(b); err != nil {
+		// Another buildlet of the same host type registered
+		// since the check above.
+		log.Printf("Rejecting reverse buildlet %s/%s: %v", hostname, r.RemoteAddr, err)
+		client.Close()
+		conn.Close()
+	}
 }
 
 type byTypeThenHostname []*reverseBuildlet
@@ -0,0 +1,128 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package pool
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+func TestReversePoolDisabledHost(t *testing.T) {
+	p := &ReverseBuildletPool{
+		hostLastGood: make(map[string]time.Time),
+		hostQueue:    make(map[string]*queue.Quota),
+		disabled:     make(map[string]bool),
+	}
+	const hostType = "host-darwin-arm64-test"
+	bad := &reverseBuildlet{hostname: "bad", hostType: hostType, client: new(buildlet.FakeClient)}
+	p.buildlets = []*reverseBuildlet{bad}
+
+	p.SetHostDisabled("bad", true)
+	if p.CanBuild(hostType) {
+		t.Errorf("CanBuild = true with the only buildlet disabled")
+	}
+	if bc, _ := p.tryToGrab(hostType); bc != nil {
+		t.Errorf("tryToGrab returned a disabled buildlet")
+	}
+	if got := p.ReverseStatus().HostTypes[hostType]; got.Idle != 0 || !got.Machines["bad"].Disabled {
+		t.Errorf("status of disabled buildlet = %+v, %+v; want 0 idle, disabled", got, got.Machines["bad"])
+	}
+	if got, want := p.DisabledHosts(), []string{"bad"}; !slices.Equal(got, want) {
+		t.Errorf("DisabledHosts = %q, want %q", got, want)
+	}
+
+	p.SetHostDisabled("bad", false)
+	if !p.CanBuild(hostType) {
+		t.Errorf("CanBuild = false after re-enabling the buildlet")
+	}
+	if bc, _ := p.tryToGrab(hostType); bc != bad.client {
+		t.Errorf("tryToGrab didn't return the re-enabled buildlet")
+	}
+	if hosts := p.DisabledHosts(); len(hosts) != 0 {
+		t.Errorf("DisabledHosts = %q after re-enabling, want none", hosts)
+	}
+}
+
+func TestReversePoolMaxPerHostType(t *testing.T) {
+	p := &ReverseBuildletPool{
+		hostLastGood: make(map[string]time.Time),
+		hostQueue:    make(map[string]*queue.Quota),
+		disabled:     make(map[string]bool),
+	}
+	const hostType = "host-darwin-arm64-test"
+	p.buildlets = []*reverseBuildlet{
+		{hostname: "a", hostType: hostType, client: new(buildlet.FakeClient)},
+		{hostname: "b", hostType: hostType, client: new(buildlet.FakeClient)},
+	}
+	if p.atCap(hostType) {
+		t.Errorf("atCap = true with no limit set")
+	}
+
+	p.SetMaxPerHostType(2, nil)
+	if !p.atCap(hostType) {
+		t.Errorf("atCap = false with 2 of 2 buildlets connected")
+	}
+	if p.atCap("host-other") {
+		t.Errorf("atCap = true for a host type with no buildlets connected")
+	}
+	if err := p.addBuildlet(&reverseBuildlet{hostname: "c", hostType: hostType}); err == nil {
+		t.Errorf("addBuildlet beyond the limit succeeded, want error")
+	}
+	if got := len(p.buildlets); got != 2 {
+		t.Errorf("pool has %d buildlets after a rejected addBuildlet, want 2", got)
+	}
+	if got := p.ReverseStatus().HostTypes[hostType]; got.Max != 2 || got.Connected != 2 {
+		t.Errorf("status = %+v, want Max 2, Connected 2", got)
+	}
+
+	p.SetMaxPerHostType(2, map[string]int{hostType: 3, "host-other": 0})
+	if p.atCap(hostType) {
+		t.Errorf("atCap = true with 2 of 3 buildlets connected")
+	}
+	if got := p.ReverseStatus().HostTypes["host-other"]; got == nil || got.Max != 0 {
+		t.Errorf("status for host-other = %+v, want Max 0", got)
+	}
+	if got := p.ReverseStatus().HostTypes[hostType]; got.Max != 3 {
+		t.Errorf("status = %+v, want Max 3", got)
+	}
+}
+
+func TestParseMaxPerHostType(t *testing.T) {
+	tests := []struct {
+		in      string
+		def     int
+		byHost  map[string]int
+		wantErr bool
+	}{
+		{in: ""},
+		{in: "5", def: 5},
+		{in: "host-a=2", byHost: map[string]int{"host-a": 2}},
+		{in: "host-a=2,10,host-b=0", def: 10, byHost: map[string]int{"host-a": 2, "host-b": 0}},
+		{in: "1,2", wantErr: true},
+		{in: "host-a=1,host-a=2", wantErr: true},
+		{in: "=3", wantErr: true},
+		{in: "host-a=x", wantErr: true},
+		{in: "-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		def, byHost, err := ParseMaxPerHostType(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseMaxPerHostType(%q) succeeded, want error", tt.in)
+			}
+			continue
+		}
+		if err != nil || def != tt.def || !maps.Equal(byHost, tt.byHost) {
+			t.Errorf("ParseMaxPerHostType(%q) = %d, %v, %v; want %d, %v, nil", tt.in, def, byHost, err, tt.def, tt.byHost)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/build/buildlet"
+)
+
+// SessionRecord is the durable form of a session, saved so that the
+// session can be restored after the coordinator restarts.
+type SessionRecord struct {
+	ID          string
+	OwnerID     string
+	User        string
+	BuilderType string
+	HostType    string
+	Created     time.Time
+	Expires     time.Time
+	IdleTimeout time.Duration `json:",omitempty"`
+
+	// Identity of the underlying buildlet.
+	BuildletName string
+	InstanceName string `json:",omitempty"` // GCE or EC2 instance name
+	IPPort       string `json:",omitempty"`
+}
+
+// A SessionStore durably stores the records of the sessions in a pool.
+type SessionStore interface {
+	// Load returns the saved records. It returns no records and no
+	// error if none have been saved yet.
+	Load(ctx context.Context) ([]SessionRecord, error)
+	// Save replaces the saved records with recs.
+	Save(ctx context.Context, recs []SessionRecord) error
+}
+
+// A ReattachFunc returns a client for the still-living buildlet of a
+// restored session. It returns an error if the buildlet is gone.
+type ReattachFunc func(ctx context.Context, rec SessionRecord) (buildlet.Client, error)
+
+// saveTimeout bounds how long saving session records may take.
+const saveTimeout = 30 * time.Second
+
+// Restore restores the sessions saved in store by a previous process,
+// reattaching to their buildlets with reattach. Sessions that have
+// expired, or whose buildlets can't be reattached to, are pruned.
+// After Restore, the pool saves its sessions to store whenever they
+// change, and periodically to keep their expiration up to date.
+func (sp *SessionPool) Restore(ctx context.Context, store SessionStore, reattach ReattachFunc) error {
+	recs, err := store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("loading remote sessions: %w", err)
+	}
+	var restored int
+	for _, rec := range recs {
+		if !rec.Expires.IsZero() && rec.Expires.Before(time.Now()) {
+			log.Printf("remote: pruning expired session %s", rec.ID)
+			continue
+		}
+		bc, err := reattach(ctx, rec)
+		if err != nil {
+			log.Printf("remote: pruning session %s; unable to reattach to its buildlet: %v", rec.ID, err)
+			continue
+		}
+		sp.mu.Lock()
+		if _, ok := sp.m[rec.ID]; ok {
+			// Already restored; leave its buildlet alone.
+			sp.mu.Unlock()
+			continue
+		}
+		sp.m[rec.ID] = &Session{
+			BuilderType: rec.BuilderType,
+			Created:     rec.Created,
+			Expires:     rec.Expires,
+			HostType:    rec.HostType,
+			ID:          rec.ID,
+			OwnerID:     rec.OwnerID,
+			buildlet:    bc,
+			user:        rec.User,
+			idleTimeout: rec.IdleTimeout,
+		}
+		sp.mu.Unlock()
+		restored++
+	}
+	log.Printf("remote: restored %d of %d saved sessions", restored, len(recs))
+	sp.mu.Lock()
+	sp.store = store
+	sp.mu.Unlock()
+	return sp.save(ctx)
+}
+
+// recordsLocked returns the records of all sessions, sorted by ID.
+// The SessionPool lock should be held before calling.
+func (sp *SessionPool) recordsLocked() []SessionRecord {
+	recs := make([]SessionRecord, 0, len(sp.m))
+	for _, s := range sp.m {
+		recs = append(recs, SessionRecord{
+			ID:           s.ID,
+			OwnerID:      s.OwnerID,
+			User:         s.user,
+			BuilderType:  s.BuilderType,
+			HostType:     s.HostType,
+			Created:      s.Created,
+			Expires:      s.Expires,
+			IdleTimeout:  s.idleTimeout,
+			BuildletName: s.buildlet.Name(),
+			InstanceName: s.buildlet.InstanceName(),
+			IPPort:       s.buildlet.IPPort(),
+		})
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].ID < recs[j].ID })
+	return recs
+}
+
+// save saves the records of all sessions to the pool's store, if it
+// has one. Saves are serialized, so the last one always has the
+// latest state.
+func (sp *SessionPool) save(ctx context.Context) error {
+	sp.saveMu.Lock()
+	defer sp.saveMu.Unlock()
+
+	sp.mu.RLock()
+	store := sp.store
+	var recs []SessionRecord
+	if store != nil {
+		recs = sp.recordsLocked()
+	}
+	sp.mu.RUnlock()
+	if store == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, saveTimeout)
+	defer cancel()
+	if err := store.Save(ctx, recs); err != nil {
+		return fmt.Errorf("saving remote sessions: %w", err)
+	}
+	return nil
+}
+
+// saveOrLog saves the records of all sessions, logging any error.
+func (sp *SessionPool) saveOrLog() {
+	if err := sp.save(context.Background()); err != nil {
+		log.Printf("remote: %v", err)
+	}
+}
+
+// gcsSessionStore is a SessionStore that stores session records as
+// JSON in a GCS object.
+type gcsSessionStore struct {
+	obj *storage.ObjectHandle
+}
+
+// NewGCSSessionStore returns a SessionStore that stores session
+// records in the GCS object obj.
+func NewGCSSessionStore(obj *storage.ObjectHandle) SessionStore {
+	return &gcsSessionStore{obj: obj}
+}
+
+func (s *gcsSessionStore) Load(ctx context.Context) ([]SessionRecord, error) {
+	r, err := s.obj.NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var recs []SessionRecord
+	if err := json.NewDecoder(r).Decode(&recs); err != nil {
+		return nil, fmt.Errorf("decoding %s: %v", s.obj.ObjectName(), err)
+	}
+	return recs, nil
+}
+
+func (s *gcsSessionStore) Save(ctx context.Context, recs []SessionRecord) error {
+	w := s.obj.NewWriter(ctx)
+	w.ContentType = "application/json"
+	if err := json.NewEncoder(w).Encode(recs); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
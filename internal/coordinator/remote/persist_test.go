@@ -0,0 +1,109 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/buildlet"
+)
+
+// memStore is a SessionStore that stores session records in memory.
+type memStore struct {
+	mu   sync.Mutex
+	recs []SessionRecord
+}
+
+func (s *memStore) Load(ctx context.Context) ([]SessionRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recs, nil
+}
+
+func (s *memStore) Save(ctx context.Context, recs []SessionRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recs = recs
+	return nil
+}
+
+func (s *memStore) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, r := range s.recs {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+func TestSessionPoolRestore(t *testing.T) {
+	now := time.Now()
+	store := &memStore{recs: []SessionRecord{
+		{ID: "user-a-linux-amd64-0", User: "user-a", InstanceName: "alive", Created: now, Expires: now.Add(time.Hour)},
+		{ID: "user-a-linux-amd64-1", User: "user-a", InstanceName: "gone", Created: now, Expires: now.Add(time.Hour)},
+		{ID: "user-b-linux-amd64-0", User: "user-b", InstanceName: "alive", Created: now, Expires: now.Add(-time.Minute)},
+	}}
+	var reattached []string
+	reattach := func(ctx context.Context, rec SessionRecord) (buildlet.Client, error) {
+		reattached = append(reattached, rec.ID)
+		if rec.InstanceName == "gone" {
+			return nil, errors.New("no such instance")
+		}
+		bc := &buildlet.FakeClient{}
+		bc.SetInstanceName(rec.InstanceName)
+		return bc, nil
+	}
+
+	sp := NewSessionPool(context.Background())
+	defer sp.Close()
+	if err := sp.Restore(context.Background(), store, reattach); err != nil {
+		t.Fatalf("Restore() = %s; want no error", err)
+	}
+	// The expired session is pruned without trying to reattach.
+	if diff := cmp.Diff([]string{"user-a-linux-amd64-0", "user-a-linux-amd64-1"}, reattached); diff != "" {
+		t.Errorf("reattached sessions mismatch (-want +got):\n%s", diff)
+	}
+	if !sp.IsSession("alive") {
+		t.Errorf("IsSession(%q) = false; want true", "alive")
+	}
+	if _, err := sp.Session("user-a-linux-amd64-1"); err == nil {
+		t.Errorf("Session() for session with a gone buildlet = no error; want error")
+	}
+	// The pruned sessions are no longer saved.
+	if diff := cmp.Diff([]string{"user-a-linux-amd64-0"}, store.ids()); diff != "" {
+		t.Errorf("saved sessions mismatch (-want +got):\n%s", diff)
+	}
+
+	// Changes to the pool are saved from now on.
+	name := sp.AddSession("accounts.google.com:user-xyz-124", "user-c", "linux-amd64", "host", &buildlet.FakeClient{})
+	if diff := cmp.Diff([]string{"user-a-linux-amd64-0", name}, store.ids()); diff != "" {
+		t.Errorf("saved sessions after AddSession mismatch (-want +got):\n%s", diff)
+	}
+	if err := sp.DestroySession("user-a-linux-amd64-0"); err != nil {
+		t.Fatalf("DestroySession() = %s; want no error", err)
+	}
+	if diff := cmp.Diff([]string{name}, store.ids()); diff != "" {
+		t.Errorf("saved sessions after DestroySession mismatch (-want +got):\n%s", diff)
+	}
+
+	// A restored session counts against its user's limit.
+	sp2 := NewSessionPool(context.Background())
+	defer sp2.Close()
+	sp2.SetUserLimits(1, nil)
+	if err := sp2.Restore(context.Background(), store, reattach); err != nil {
+		t.Fatalf("Restore() = %s; want no error", err)
+	}
+	if _, err := sp2.Reserve("user-c"); !errors.Is(err, ErrTooManySessions) {
+		t.Errorf("Reserve() = %v; want %v", err, ErrTooManySessions)
+	}
+}
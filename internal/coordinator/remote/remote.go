@@ -8,9 +8,12 @@ package remote
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,6 +24,13 @@ import (
 const (
 	remoteBuildletIdleTimeout   = 30 * time.Minute
 	remoteBuildletCleanInterval = time.Minute
+
+	// MaxIdleTimeout is the longest idle timeout a session's lease
+	// can be extended to.
+	MaxIdleTimeout = 4 * time.Hour
+	// MaxLifetime is the longest a session's lease can be extended
+	// to last, measured from when the session was created.
+	MaxLifetime = 24 * time.Hour
 )
 
 // Session stores the metadata for a remote buildlet Session.
@@ -32,12 +42,34 @@ type Session struct {
 	ID          string // unique identifier for instance "user-bradfitz-linux-amd64-0"
 	OwnerID     string // identity aware proxy user id: "accounts.google.com:userIDvalue"
 	buildlet    buildlet.Client
+	user        string // username the session counts against for user limits
+
+	// idleTimeout is how long the session lives without being used,
+	// if it's been extended by ExtendLease. Zero means the default,
+	// remoteBuildletIdleTimeout.
+	idleTimeout time.Duration
 }
 
-// renew extends the expiration timestamp for a session.
+// renew extends the expiration timestamp for a session. It never moves
+// the expiration earlier, or an extended lease past MaxLifetime.
 // The SessionPool lock should be held before calling.
 func (s *Session) renew() {
-	s.Expires = time.Now().Add(remoteBuildletIdleTimeout)
+	now := time.Now()
+	expires := now.Add(remoteBuildletIdleTimeout)
+	if s.idleTimeout > remoteBuildletIdleTimeout {
+		expires = now.Add(s.idleTimeout)
+		if limit := s.Created.Add(MaxLifetime); expires.After(limit) {
+			expires = later(limit, now.Add(remoteBuildletIdleTimeout))
+		}
+	}
+	s.Expires = later(s.Expires, expires)
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
 }
 
 // isExpired determines if the remote buildlet session has expired.
@@ -54,6 +86,13 @@ type SessionPool struct {
 	pollWait   sync.WaitGroup
 	cancelPoll context.CancelFunc
 	m          map[string]*Session // keyed by buildletName
+
+	userLimit  int            // default maximum sessions per user; <= 0 means no limit
+	userLimits map[string]int // per-user overrides of userLimit, keyed by username
+	reserved   map[string]int // sessions being created, keyed by username
+
+	store  SessionStore // set by Restore; nil if sessions aren't saved
+	saveMu sync.Mutex   // serializes saves to store
 }
 
 // NewSessionPool creates a session pool which stores and provides access to active remote buildlet sessions.
@@ -63,6 +102,7 @@ func NewSessionPool(ctx context.Context) *SessionPool {
 	sp := &SessionPool{
 		cancelPoll: cancel,
 		m:          map[string]*Session{},
+		reserved:   map[string]int{},
 	}
 	sp.pollWait.Add(1)
 	go func() {
@@ -76,6 +116,12 @@ func NewSessionPool(ctx context.Context) *SessionPool {
 
 // AddSession adds the provided session to the session pool.
 func (sp *SessionPool) AddSession(ownerID, username, builderType, hostType string, bc buildlet.Client) (name string) {
+	name = sp.addSession(ownerID, username, builderType, hostType, bc)
+	sp.saveOrLog()
+	return name
+}
+
+func (sp *SessionPool) addSession(ownerID, username, builderType, hostType string, bc buildlet.Client) (name string) {
 	sp.mu.Lock()
 	defer sp.mu.Unlock()
 
@@ -91,12 +137,93 @@ func (sp *SessionPool) AddSession(ownerID, username, builderType, hostType strin
 				HostType:    hostType,
 				ID:          name,
 				OwnerID:     ownerID,
+				user:        username,
 			}
 			return name
 		}
 	}
 }
 
+// ErrTooManySessions is returned by Reserve when a user already has as
+// many sessions as they're allowed.
+var ErrTooManySessions = errors.New("too many concurrent gomote instances")
+
+// SetUserLimits sets the maximum number of sessions each user may have
+// at once to def, with per-user overrides in limits, keyed by username.
+// A limit of zero or less means no limit.
+func (sp *SessionPool) SetUserLimits(def int, limits map[string]int) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	sp.userLimit = def
+	sp.userLimits = limits
+}
+
+// userLimitLocked returns the maximum number of sessions for username,
+// or zero or less if there's no limit.
+// The SessionPool lock should be held before calling.
+func (sp *SessionPool) userLimitLocked(username string) int {
+	if n, ok := sp.userLimits[username]; ok {
+		return n
+	}
+	return sp.userLimit
+}
+
+// Reserve reserves a session for username while its instance is being
+// created, so that concurrent creations can't exceed the user's limit.
+// It returns an error wrapping ErrTooManySessions if the user's sessions
+// and reservations are already at the limit. Otherwise, the caller must
+// call release once the instance has been added with AddSession, or
+// has failed to be created.
+func (sp *SessionPool) Reserve(username string) (release func(), err error) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if limit := sp.userLimitLocked(username); limit > 0 {
+		n := sp.reserved[username]
+		for _, s := range sp.m {
+			if s.user == username {
+				n++
+			}
+		}
+		if n >= limit {
+			return nil, fmt.Errorf("%w: user %s has %d of at most %d", ErrTooManySessions, username, n, limit)
+		}
+	}
+	sp.reserved[username]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			sp.mu.Lock()
+			defer sp.mu.Unlock()
+			if sp.reserved[username]--; sp.reserved[username] == 0 {
+				delete(sp.reserved, username)
+			}
+		})
+	}, nil
+}
+
+// ParseUserLimits parses per-user session limits in the form
+// "user1=limit1,user2=limit2", as accepted by SetUserLimits.
+func ParseUserLimits(s string) (map[string]int, error) {
+	m := make(map[string]int)
+	if s == "" {
+		return m, nil
+	}
+	for _, f := range strings.Split(s, ",") {
+		user, v, ok := strings.Cut(strings.TrimSpace(f), "=")
+		if !ok || user == "" {
+			return nil, fmt.Errorf("invalid user limit %q: want user=limit", f)
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user limit %q: %v", f, err)
+		}
+		m[user] = n
+	}
+	return m, nil
+}
+
 // IsSession is true if the instance is found in the session pool. The instance name is the not the public
 // name of the instance. It is the name of the instance as it is tracked in the cloud service.
 func (sp *SessionPool) IsSession(instName string) bool {
@@ -129,6 +256,9 @@ func (sp *SessionPool) destroyExpiredSessions(ctx context.Context) {
 			log.Printf("remote: unable to close buildlet connection for %s: %s", s.ID, err)
 		}
 	}
+	// Save even if no sessions expired, to keep the saved expiration
+	// times of the remaining sessions up to date.
+	sp.saveOrLog()
 }
 
 // DestroySession destroys a session.
@@ -142,6 +272,7 @@ func (sp *SessionPool) DestroySession(buildletName string) error {
 	if !ok {
 		return fmt.Errorf("remote buildlet does not exist=%s", buildletName)
 	}
+	sp.saveOrLog()
 	if err := s.buildlet.Close(); err != nil {
 		log.Printf("remote: unable to close buildlet connection %s: %s", buildletName, err)
 	}
@@ -251,3 +382,40 @@ func (sp *SessionPool) RenewTimeout(buildletName string) error {
 	s.renew()
 	return nil
 }
+
+// ErrLeaseTooLong is returned by ExtendLease when an extension is longer
+// than MaxIdleTimeout, or would keep a session alive past MaxLifetime.
+var ErrLeaseTooLong = errors.New("lease extension is too long")
+
+// ExtendLease extends the lease of the remote buildlet session so that
+// it only expires after being idle for d, and returns its new expiration
+// time. The expiration is never moved earlier.
+func (sp *SessionPool) ExtendLease(buildletName string, d time.Duration) (time.Time, error) {
+	expires, err := sp.extendLease(buildletName, d)
+	if err == nil {
+		sp.saveOrLog()
+	}
+	return expires, err
+}
+
+func (sp *SessionPool) extendLease(buildletName string, d time.Duration) (time.Time, error) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	s, ok := sp.m[buildletName]
+	if !ok {
+		return time.Time{}, fmt.Errorf("remote buildlet does not exist=%s", buildletName)
+	}
+	if d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid lease extension %v", d)
+	}
+	if d > MaxIdleTimeout {
+		return time.Time{}, fmt.Errorf("%w: %v is longer than the maximum of %v", ErrLeaseTooLong, d, MaxIdleTimeout)
+	}
+	if limit := s.Created.Add(MaxLifetime); time.Now().Add(d).After(limit) {
+		return time.Time{}, fmt.Errorf("%w: sessions can't be extended past %v after they're created, at %s", ErrLeaseTooLong, MaxLifetime, limit.Format(time.RFC3339))
+	}
+	s.idleTimeout = d
+	s.renew()
+	return s.Expires, nil
+}
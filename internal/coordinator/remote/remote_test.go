@@ -8,6 +8,7 @@ package remote
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -117,3 +118,100 @@ func TestRenewTimeoutError(t *testing.T) {
 		t.Errorf("SessionPool.RenewTimeout(%q) = %s; want error", name, err)
 	}
 }
+
+func TestSessionPoolExtendLease(t *testing.T) {
+	sp := NewSessionPool(context.Background())
+	defer sp.Close()
+
+	name := sp.AddSession("accounts.google.com:user-xyz-124", "test-user", "builder-type-x", "host-type-x", &buildlet.FakeClient{})
+	expires, err := sp.ExtendLease(name, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("SessionPool.ExtendLease(%q, 2h) = %s; want no error", name, err)
+	}
+	if want := time.Now().Add(2 * time.Hour); expires.After(want) || expires.Before(want.Add(-time.Minute)) {
+		t.Errorf("SessionPool.ExtendLease(%q, 2h) = %s; want about %s", name, expires, want)
+	}
+	// Using the session doesn't shorten the extended lease.
+	s, err := sp.Session(name)
+	if err != nil {
+		t.Fatalf("SessionPool.Session(%q) = %s; want no error", name, err)
+	}
+	if s.Expires.Before(expires) {
+		t.Errorf("Session.Expires = %s after use; want at least %s", s.Expires, expires)
+	}
+
+	for _, d := range []time.Duration{0, -time.Minute, MaxIdleTimeout + time.Minute} {
+		if _, err := sp.ExtendLease(name, d); err == nil {
+			t.Errorf("SessionPool.ExtendLease(%q, %v) succeeded; want error", name, d)
+		}
+	}
+	if _, err := sp.ExtendLease("xyz", time.Hour); err == nil {
+		t.Errorf("SessionPool.ExtendLease(%q, 1h) succeeded; want error", "xyz")
+	}
+}
+
+func TestSessionPoolExtendLeaseMaxLifetime(t *testing.T) {
+	sp := NewSessionPool(context.Background())
+	defer sp.Close()
+
+	name := sp.AddSession("accounts.google.com:user-xyz-124", "test-user", "builder-type-x", "host-type-x", &buildlet.FakeClient{})
+	sp.mu.Lock()
+	sp.m[name].Created = time.Now().Add(-MaxLifetime + time.Hour)
+	sp.mu.Unlock()
+	if _, err := sp.ExtendLease(name, 2*time.Hour); !errors.Is(err, ErrLeaseTooLong) {
+		t.Errorf("SessionPool.ExtendLease(%q, 2h) = %v; want %v", name, err, ErrLeaseTooLong)
+	}
+	if _, err := sp.ExtendLease(name, 30*time.Minute); err != nil {
+		t.Errorf("SessionPool.ExtendLease(%q, 30m) = %v; want no error", name, err)
+	}
+}
+
+func TestSessionPoolReserve(t *testing.T) {
+	sp := NewSessionPool(context.Background())
+	defer sp.Close()
+	sp.SetUserLimits(2, map[string]int{"big-user": 0})
+
+	release, err := sp.Reserve("test-user")
+	if err != nil {
+		t.Fatalf("Reserve() = %s; want no error", err)
+	}
+	sp.AddSession("accounts.google.com:user-xyz-124", "test-user", "builder", "host", &buildlet.FakeClient{})
+	release()
+	release() // release is idempotent
+	release, err = sp.Reserve("test-user")
+	if err != nil {
+		t.Fatalf("Reserve() = %s; want no error", err)
+	}
+	// The pending reservation counts against the limit.
+	if _, err := sp.Reserve("test-user"); !errors.Is(err, ErrTooManySessions) {
+		t.Errorf("Reserve() = %v; want %v", err, ErrTooManySessions)
+	}
+	release()
+	if _, err := sp.Reserve("test-user"); err != nil {
+		t.Errorf("Reserve() after release = %s; want no error", err)
+	}
+	// Other users have their own limits.
+	for i := 0; i < 3; i++ {
+		if _, err := sp.Reserve("big-user"); err != nil {
+			t.Fatalf("Reserve() for user without limit = %s; want no error", err)
+		}
+	}
+	if _, err := sp.Reserve("other-user"); err != nil {
+		t.Errorf("Reserve() for other user = %s; want no error", err)
+	}
+}
+
+func TestParseUserLimits(t *testing.T) {
+	got, err := ParseUserLimits("gopher=20, relui-prod=0")
+	if err != nil {
+		t.Fatalf("ParseUserLimits() = %s; want no error", err)
+	}
+	if len(got) != 2 || got["gopher"] != 20 || got["relui-prod"] != 0 {
+		t.Errorf("ParseUserLimits() = %v; want map[gopher:20 relui-prod:0]", got)
+	}
+	for _, s := range []string{"gopher", "=3", "gopher=x"} {
+		if _, err := ParseUserLimits(s); err == nil {
+			t.Errorf("ParseUserLimits(%q) = nil error; want error", s)
+		}
+	}
+}
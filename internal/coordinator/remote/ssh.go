@@ -178,7 +178,7 @@ func (ss *SSHServer) HandleIncomingSSHPostAuth(s gssh.Session) {
 		fmt.Fprintf(s, "instance %q has unknown host type %q\n", inst, rs.HostType)
 		return
 	}
-	bconf, ok := dashboard.Builders[rs.BuilderType]
+	bconf, ok := dashboard.ActiveBuilders()[rs.BuilderType]
 	if !ok {
 		fmt.Fprintf(s, "instance %q has unknown builder type %q\n", inst, rs.BuilderType)
 		return
@@ -38,6 +38,12 @@ type Scheduler struct {
 	hostsCreating map[string]int // hostType -> count
 
 	lastProgress map[string]time.Time // hostType -> time last delivered buildlet
+
+	// draining is the set of host types that aren't given any more
+	// buildlets, so their capacity can be reduced once the builds
+	// already using them finish. Each one's channel is closed when
+	// it stops draining.
+	draining map[string]chan struct{} // hostType -> closed when undrained
 }
 
 // NewScheduler returns a new scheduler.
@@ -46,10 +52,51 @@ func NewScheduler() *Scheduler {
 		hostsCreating: make(map[string]int),
 		waiting:       make(map[string]map[*queue.SchedItem]bool),
 		lastProgress:  make(map[string]time.Time),
+		draining:      make(map[string]chan struct{}),
 	}
 	return s
 }
 
+// SetDraining sets whether hostType is draining. While it is,
+// GetBuildlet calls for it stay queued instead of getting a buildlet,
+// but buildlets that were already given out are unaffected.
+func (s *Scheduler) SetDraining(hostType string, draining bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	undrained, ok := s.draining[hostType]
+	if draining && !ok {
+		s.draining[hostType] = make(chan struct{})
+	} else if !draining && ok {
+		close(undrained)
+		delete(s.draining, hostType)
+	}
+}
+
+// IsDraining reports whether hostType is draining.
+func (s *Scheduler) IsDraining(hostType string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.draining[hostType]
+	return ok
+}
+
+// waitUndrained waits until hostType isn't draining or ctx is done.
+func (s *Scheduler) waitUndrained(ctx context.Context, hostType string) error {
+	for {
+		s.mu.Lock()
+		undrained, ok := s.draining[hostType]
+		s.mu.Unlock()
+		if !ok {
+			return nil
+		}
+		select {
+		case <-undrained:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 type stderrLogger struct{}
 
 func (stderrLogger) LogEventTime(event string, optText ...string) {
@@ -106,6 +153,7 @@ func (st *SchedulerWaitingState) add(si *queue.SchedItem) {
 
 type SchedulerHostState struct {
 	HostType     string
+	Draining     bool
 	LastProgress time.Duration
 	Total        SchedulerWaitingState
 	Gomote       SchedulerWaitingState
@@ -121,12 +169,20 @@ func (s *Scheduler) State() (st SchedulerState) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	hostTypes := make(map[string]bool)
 	for hostType, m := range s.waiting {
-		if len(m) == 0 {
-			continue
+		if len(m) != 0 {
+			hostTypes[hostType] = true
 		}
+	}
+	for hostType := range s.draining {
+		hostTypes[hostType] = true
+	}
+	for hostType := range hostTypes {
+		m := s.waiting[hostType]
 		var hst SchedulerHostState
 		hst.HostType = hostType
+		_, hst.Draining = s.draining[hostType]
 		for si := range m {
 			hst.Total.add(si)
 			if si.IsGomote {
@@ -179,5 +235,9 @@ func (s *Scheduler) GetBuildlet(ctx context.Context, si *queue.SchedItem) (build
 	s.addWaiter(si)
 	defer s.removeWaiter(si)
 
+	if err := s.waitUndrained(ctx, si.HostType); err != nil {
+		return nil, err
+	}
+
 	return pool.ForHost(hostConf).GetBuildlet(ctx, si.HostType, stderrLogger{}, si)
 }
@@ -189,6 +189,30 @@ func TestScheduler(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "draining-host-type",
+			steps: func() []step {
+				drainedGet := newGetBuildletCall(&queue.SchedItem{HostType: "test-host-foo"})
+				return []step{
+					func(t *testing.T, s *Scheduler) { s.SetDraining("test-host-foo", true) },
+					buildletAvailable("test-host-foo"),
+					drainedGet.start,
+					func(t *testing.T, s *Scheduler) {
+						select {
+						case <-drainedGet.done:
+							t.Fatalf("GetBuildlet of draining host type returned %v, %v; want it to wait", drainedGet.gotClient, drainedGet.gotErr)
+						case <-time.After(50 * time.Millisecond):
+						}
+						st := s.State()
+						if len(st.HostTypes) != 1 || st.HostTypes[0].HostType != "test-host-foo" || !st.HostTypes[0].Draining || st.HostTypes[0].Total.Count != 1 {
+							t.Errorf("State() = %+v; want test-host-foo draining with 1 waiting", st)
+						}
+						s.SetDraining("test-host-foo", false)
+					},
+					drainedGet.wantGetBuildlet,
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		pool = &fakePool{poolChan: map[string]chan interface{}{}}
@@ -0,0 +1,186 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitfs
+
+import (
+	"container/list"
+	"fmt"
+	"io/fs"
+	"sync"
+	"time"
+)
+
+// A Cache holds the file trees of recently cloned commits in memory,
+// so that cloning the same commit of a repo again doesn't download it
+// again. Trees are keyed by repo URL, commit hash and directory, and
+// since the tree of a commit never changes, the same read-only fs.FS is
+// returned to every caller.
+//
+// A Cache is safe for concurrent use. Concurrent clones of the same
+// commit share a single download.
+type Cache struct {
+	maxBytes int
+	ttl      time.Duration
+	now      func() time.Time // for testing
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element // values are *cacheEntry
+	lru     *list.List                 // most recently used first
+	size    int                        // total size of the loaded entries
+}
+
+type cacheKey struct {
+	url  string
+	hash Hash
+	dir  string // "" for the whole tree
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	ready chan struct{} // closed once the fields below are set
+
+	fsys  fs.FS
+	err   error
+	size  int
+	added time.Time
+}
+
+// NewCache returns a new Cache that keeps file trees with a total size
+// of at most maxBytes, evicting the least recently used ones first,
+// and for at most ttl after they were downloaded. The most recently
+// used tree is kept even if it's larger than maxBytes. A maxBytes or
+// ttl of zero means no limit.
+func NewCache(maxBytes int, ttl time.Duration) *Cache {
+	return &Cache{
+		maxBytes: maxBytes,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[cacheKey]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Clone resolves the given ref to a hash in r and returns the
+// corresponding fs.FS, downloading it only if it isn't cached.
+func (c *Cache) Clone(r *Repo, ref string) (Hash, fs.FS, error) {
+	h, err := r.Resolve(ref)
+	if err != nil {
+		return Hash{}, nil, fmt.Errorf("clone %s: %v", ref, err)
+	}
+	fsys, err := c.CloneHash(r, h)
+	if err != nil {
+		return Hash{}, nil, err
+	}
+	return h, fsys, nil
+}
+
+// CloneHash returns the fs.FS for the given hash in r, downloading it
+// only if it isn't cached.
+func (c *Cache) CloneHash(r *Repo, h Hash) (fs.FS, error) {
+	return c.get(cacheKey{r.url, h, ""}, func() (fs.FS, error) {
+		return r.CloneHash(h)
+	})
+}
+
+// CloneHashDir returns the fs.FS for the directory dir of the given
+// hash in r, as returned by Repo.CloneHashDir, downloading it only if
+// it isn't cached.
+func (c *Cache) CloneHashDir(r *Repo, h Hash, dir string) (fs.FS, error) {
+	return c.get(cacheKey{r.url, h, dir}, func() (fs.FS, error) {
+		return r.CloneHashDir(h, dir)
+	})
+}
+
+// Clear removes all entries from the cache. Downloads that are in
+// progress complete, but their results aren't cached.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[cacheKey]*list.Element)
+	c.lru.Init()
+	c.size = 0
+}
+
+// get returns the cached fs.FS for key, calling load to get it if it
+// isn't cached. Failed loads aren't cached.
+func (c *Cache) get(key cacheKey, load func() (fs.FS, error)) (fs.FS, error) {
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*cacheEntry)
+		if !c.expired(e) {
+			c.lru.MoveToFront(el)
+			c.mu.Unlock()
+			<-e.ready
+			return e.fsys, e.err
+		}
+		c.remove(el)
+	}
+	e := &cacheEntry{key: key, ready: make(chan struct{})}
+	el := c.lru.PushFront(e)
+	c.entries[key] = el
+	c.mu.Unlock()
+
+	fsys, err := load()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.fsys, e.err = fsys, err
+	e.added = c.now()
+	close(e.ready)
+	if c.entries[key] != el {
+		// Removed by Clear or evicted while loading.
+		return fsys, err
+	}
+	if err != nil {
+		c.remove(el)
+		return fsys, err
+	}
+	e.size = treeSize(fsys)
+	c.size += e.size
+	c.evict()
+	return fsys, err
+}
+
+// expired reports whether the loaded entry e is older than the cache's
+// TTL. Entries that are still loading never expire.
+// c.mu must be held.
+func (c *Cache) expired(e *cacheEntry) bool {
+	select {
+	case <-e.ready:
+	default:
+		return false
+	}
+	return c.ttl > 0 && c.now().Sub(e.added) > c.ttl
+}
+
+// evict removes the least recently used entries until the cache is no
+// larger than its maximum size, keeping at least the most recently
+// used one.
+// c.mu must be held.
+func (c *Cache) evict() {
+	if c.maxBytes <= 0 {
+		return
+	}
+	for c.size > c.maxBytes && c.lru.Len() > 1 {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove removes the entry el from the cache.
+// c.mu must be held.
+func (c *Cache) remove(el *list.Element) {
+	e := el.Value.(*cacheEntry)
+	c.lru.Remove(el)
+	delete(c.entries, e.key)
+	c.size -= e.size
+}
+
+// treeSize returns the number of bytes of object data that back fsys.
+func treeSize(fsys fs.FS) int {
+	if t, ok := fsys.(*treeFS); ok {
+		return len(t.s.data)
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitfs
+
+import (
+	"errors"
+	"io/fs"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewCache(250, time.Hour)
+	c.now = func() time.Time { return now }
+
+	loads := make(map[cacheKey]int)
+	get := func(url string, size int) fs.FS {
+		t.Helper()
+		key := cacheKey{url: url}
+		fsys, err := c.get(key, func() (fs.FS, error) {
+			loads[key]++
+			return &treeFS{s: &store{data: make([]byte, size)}}, nil
+		})
+		if err != nil {
+			t.Fatalf("get(%q): %v", url, err)
+		}
+		return fsys
+	}
+	checkLoads := func(url string, want int) {
+		t.Helper()
+		if got := loads[cacheKey{url: url}]; got != want {
+			t.Errorf("%q loaded %d times, want %d", url, got, want)
+		}
+	}
+
+	a := get("a", 100)
+	if get("a", 100) != a {
+		t.Errorf("second get of a returned a different fs.FS")
+	}
+	checkLoads("a", 1)
+
+	// b fits alongside a, but c doesn't, so the least recently used
+	// one, b, is evicted.
+	get("b", 100)
+	get("a", 100)
+	get("c", 100)
+	get("a", 100)
+	checkLoads("a", 1)
+	get("b", 100)
+	checkLoads("b", 2)
+
+	// Entries expire after the TTL.
+	now = now.Add(2 * time.Hour)
+	get("b", 100)
+	checkLoads("b", 3)
+
+	c.Clear()
+	get("b", 100)
+	checkLoads("b", 4)
+}
+
+func TestCacheError(t *testing.T) {
+	c := NewCache(0, 0)
+	key := cacheKey{url: "a"}
+	errLoad := errors.New("load failed")
+	if _, err := c.get(key, func() (fs.FS, error) { return nil, errLoad }); err != errLoad {
+		t.Fatalf("get: got error %v, want %v", err, errLoad)
+	}
+	// Failed loads aren't cached.
+	want := &treeFS{s: &store{}}
+	got, err := c.get(key, func() (fs.FS, error) { return want, nil })
+	if err != nil || got != want {
+		t.Errorf("get after failure = %v, %v; want %v, nil", got, err, want)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := NewCache(0, 0)
+	key := cacheKey{url: "a"}
+	release := make(chan struct{})
+	var mu sync.Mutex
+	loads := 0
+	load := func() (fs.FS, error) {
+		mu.Lock()
+		loads++
+		mu.Unlock()
+		<-release
+		return &treeFS{s: &store{}}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]fs.FS, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = c.get(key, load)
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	if loads != 1 {
+		t.Errorf("loaded %d times, want 1", loads)
+	}
+	for i, fsys := range results {
+		if fsys != results[0] {
+			t.Errorf("result %d differs from result 0", i)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitfs
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Commit is the metadata of a Git commit.
+type Commit struct {
+	Hash      Hash
+	Tree      Hash
+	Parents   []Hash
+	Author    Signature
+	Committer Signature
+	Message   string
+}
+
+// A Signature identifies who made a commit, and when.
+type Signature struct {
+	Name  string
+	Email string
+	When  time.Time // in the time zone recorded in the commit
+}
+
+// CloneHashCommit returns the metadata of the commit with the given hash
+// and the fs.FS for its tree, like CloneHash.
+func (r *Repo) CloneHashCommit(h Hash) (*Commit, fs.FS, error) {
+	fsys, err := r.CloneHash(h)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, data := fsys.(*treeFS).s.object(h)
+	c, err := parseCommit(h, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("clone %s: %v", h, err)
+	}
+	return c, fsys, nil
+}
+
+// parseCommit parses the commit object data of the commit with hash h.
+// (Try 'git cat-file -p <commithash>' to see the commit data format.)
+func parseCommit(h Hash, data []byte) (*Commit, error) {
+	c := &Commit{Hash: h}
+	hdr, msg, ok := bytes.Cut(data, []byte("\n\n"))
+	if !ok {
+		return nil, fmt.Errorf("commit %s: no message", h)
+	}
+	c.Message = string(msg)
+	for _, line := range strings.Split(string(hdr), "\n") {
+		if strings.HasPrefix(line, " ") {
+			// Continuation of a multi-line value, like gpgsig.
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		var err error
+		switch key {
+		case "tree":
+			c.Tree, err = parseHash(value)
+		case "parent":
+			var p Hash
+			p, err = parseHash(value)
+			c.Parents = append(c.Parents, p)
+		case "author":
+			c.Author, err = parseSignature(value)
+		case "committer":
+			c.Committer, err = parseSignature(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("commit %s: invalid %s %q", h, key, value)
+		}
+	}
+	if c.Tree == (Hash{}) {
+		return nil, fmt.Errorf("commit %s: no tree", h)
+	}
+	return c, nil
+}
+
+// parseSignature parses the value of a commit's author or committer
+// header, like "Gopher <gopher@golang.org> 1700000000 -0500".
+func parseSignature(s string) (Signature, error) {
+	name, rest, ok := strings.Cut(s, " <")
+	if !ok {
+		return Signature{}, fmt.Errorf("no email")
+	}
+	email, rest, ok := strings.Cut(rest, "> ")
+	if !ok {
+		return Signature{}, fmt.Errorf("no time")
+	}
+	sec, zone, ok := strings.Cut(rest, " ")
+	if !ok {
+		return Signature{}, fmt.Errorf("no time zone")
+	}
+	unix, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return Signature{}, err
+	}
+	offset, err := time.Parse("-0700", zone)
+	if err != nil {
+		return Signature{}, err
+	}
+	_, off := offset.Zone()
+	return Signature{
+		Name:  name,
+		Email: email,
+		When:  time.Unix(unix, 0).In(time.FixedZone(zone, off)),
+	}, nil
+}
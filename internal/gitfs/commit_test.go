@@ -0,0 +1,81 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitfs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustParseHash(t *testing.T, text string) Hash {
+	t.Helper()
+	h, err := parseHash(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestParseCommit(t *testing.T) {
+	// testdata/commit.txt is a commit object with two parents and a
+	// signature, as printed by 'git cat-file commit'.
+	data, err := os.ReadFile("testdata/commit.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s store
+	h, _ := s.add(objCommit, data)
+	if got, want := h.String(), "c8d3f76fb292d24cca6f9f705fcd6748b8308690"; got != want {
+		t.Fatalf("hash of testdata/commit.txt = %s, want %s", got, want)
+	}
+	_, data = s.object(h)
+	got, err := parseCommit(h, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Commit{
+		Hash: h,
+		Tree: mustParseHash(t, "4b825dc642cb6eb9a060e54bf8d69288fbee4904"),
+		Parents: []Hash{
+			mustParseHash(t, "8f2f3d2e0a2b8f1d6d6c2a7a2f9d1c0e1b2a3c4d"),
+			mustParseHash(t, "0123456789abcdef0123456789abcdef01234567"),
+		},
+		Author: Signature{
+			Name:  "Gopher Author",
+			Email: "author@golang.org",
+			When:  time.Date(2023, 11, 14, 17, 13, 20, 0, time.FixedZone("", -5*60*60)),
+		},
+		Committer: Signature{
+			Name:  "Gopher Committer",
+			Email: "committer@golang.org",
+			When:  time.Date(2023, 11, 15, 0, 13, 20, 0, time.FixedZone("", 60*60)),
+		},
+		Message: "internal/gitfs: add a fixture commit\n\nThis commit has two parents and a signature.\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n",
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool {
+		_, aoff := a.Zone()
+		_, boff := b.Zone()
+		return a.Equal(b) && aoff == boff
+	})); diff != "" {
+		t.Errorf("parseCommit mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseCommitError(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n",
+		"author Gopher <gopher@golang.org> 1700000000 -0500\n\nno tree\n",
+		"tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nauthor Gopher 1700000000 -0500\n\nno email\n",
+		"tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nparent nope\n\nbad parent\n",
+	} {
+		if c, err := parseCommit(Hash{}, []byte(data)); err == nil {
+			t.Errorf("parseCommit(%q) = %+v, want error", data, c)
+		}
+	}
+}
@@ -160,6 +160,12 @@ type store struct {
 	sha1  hashpkg.Hash    // reused hash state
 	index map[Hash]stored // lookup index
 	data  []byte          // concatenation of all object data
+
+	// If keep is non-nil, only the objects it reports true for are
+	// stored. The pack offsets of the others are kept in dropped,
+	// so that unpacking can still use them as delta bases.
+	keep    func(typ objType, h Hash) bool
+	dropped map[Hash]int
 }
 
 // A stored describes a single stored object.
@@ -185,6 +191,9 @@ func (s *store) add(typ objType, data []byte) (Hash, []byte) {
 
 	e, ok := s.index[h]
 	if !ok {
+		if s.keep != nil && !s.keep(typ, h) {
+			return h, data
+		}
 		if s.index == nil {
 			s.index = make(map[Hash]stored)
 		}
@@ -548,6 +557,26 @@ func (r *Repo) CloneHash(h Hash) (fs.FS, error) {
 
 // fetch returns the fs.FS for a given hash.
 func (r *Repo) fetch(h Hash) (fs.FS, error) {
+	data, err := r.fetchPack(h)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unpack pack file and return fs.FS for the commit we downloaded.
+	var s store
+	if err := unpack(&s, data); err != nil {
+		return nil, fmt.Errorf("fetch: %v", err)
+	}
+	tfs, err := s.commit(h)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: %v", err)
+	}
+	return tfs, nil
+}
+
+// fetchPack returns the pack file holding the commit with the given hash
+// and its tree.
+func (r *Repo) fetchPack(h Hash) ([]byte, error) {
 	// Fetch a shallow packfile from the remote server.
 	// Shallow means it only contains the tree at that one commit,
 	// not the entire history of the repo.
@@ -631,17 +660,7 @@ func (r *Repo) fetch(h Hash) (fs.FS, error) {
 	if !bytes.HasPrefix(data, []byte("PACK")) {
 		return nil, fmt.Errorf("fetch: malformed response: not packfile")
 	}
-
-	// Unpack pack file and return fs.FS for the commit we downloaded.
-	var s store
-	if err := unpack(&s, data); err != nil {
-		return nil, fmt.Errorf("fetch: %v", err)
-	}
-	tfs, err := s.commit(h)
-	if err != nil {
-		return nil, fmt.Errorf("fetch: %v", err)
-	}
-	return tfs, nil
+	return data, nil
 }
 
 // unpack parses data, which is a Git pack-formatted archive,
@@ -652,7 +671,8 @@ func unpack(s *store, data []byte) error {
 	// If the store is empty, pre-allocate the length of data.
 	// This should be about the right order of magnitude for the eventual data,
 	// avoiding many growing steps during append.
-	if len(s.data) == 0 {
+	// A store that keeps only some objects would usually need much less.
+	if len(s.data) == 0 && s.keep == nil {
 		s.data = make([]byte, 0, len(data))
 	}
 
@@ -732,6 +752,14 @@ func unpackObject(s *store, objs []byte, off int) (typ objType, h Hash, content
 		copy(h[:], objs[off+size:])
 		size += 20
 		deltaTyp, deltaBase = s.object(h)
+		if off, ok := s.dropped[h]; ok && deltaTyp == 0 {
+			// Not kept in the store, so unpack it again.
+			var err error
+			deltaTyp, _, deltaBase, _, err = unpackObject(s, objs, off)
+			if err != nil {
+				return fail(fmt.Errorf("invalid object: bad delta ref %v", h))
+			}
+		}
 		if deltaTyp == 0 {
 			return fail(fmt.Errorf("invalid object: unknown delta ref %v", h))
 		}
@@ -811,6 +839,14 @@ func unpackObject(s *store, objs []byte, off int) (typ objType, h Hash, content
 	}
 
 	h, data = s.add(typ, data)
+	if s.keep != nil {
+		if _, ok := s.index[h]; !ok {
+			if s.dropped == nil {
+				s.dropped = make(map[Hash]int)
+			}
+			s.dropped[h] = off
+		}
+	}
 	return typ, h, data, encSize, nil
 }
 
@@ -0,0 +1,124 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitfs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// CloneDir resolves the given ref to a hash and returns an fs.FS for
+// the directory dir of its tree, such as "doc". Only the objects under
+// dir are kept in memory.
+func (r *Repo) CloneDir(ref, dir string) (Hash, fs.FS, error) {
+	h, err := r.Resolve(ref)
+	if err != nil {
+		return Hash{}, nil, fmt.Errorf("clone %s: %v", ref, err)
+	}
+	fsys, err := r.CloneHashDir(h, dir)
+	if err != nil {
+		return Hash{}, nil, err
+	}
+	return h, fsys, nil
+}
+
+// CloneHashDir returns an fs.FS for the directory dir of the tree of
+// the given hash, such as "doc". Only the objects under dir are kept in
+// memory.
+//
+// The whole tree is still downloaded, since the Git protocol doesn't
+// support fetching part of one, but everything outside of dir is
+// discarded while unpacking it.
+func (r *Repo) CloneHashDir(h Hash, dir string) (fs.FS, error) {
+	if !fs.ValidPath(dir) {
+		return nil, fmt.Errorf("clone %s: %v", h, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrInvalid})
+	}
+	data, err := r.fetchPack(h)
+	if err != nil {
+		return nil, fmt.Errorf("clone %s: %v", h, err)
+	}
+	t, err := unpackDir(data, h, dir)
+	if err != nil {
+		return nil, fmt.Errorf("clone %s: %v", h, err)
+	}
+	return t, nil
+}
+
+// unpackDir unpacks the objects in the pack file data that are under
+// the directory dir of the tree of commit h, and returns a treeFS for dir.
+//
+// It walks the pack twice: first keeping only the commits and trees,
+// which are enough to find the objects under dir, and then keeping only
+// those objects.
+func unpackDir(data []byte, h Hash, dir string) (*treeFS, error) {
+	trees := &store{keep: func(typ objType, _ Hash) bool { return typ != objBlob }}
+	if err := unpack(trees, data); err != nil {
+		return nil, fmt.Errorf("fetch: %v", err)
+	}
+	root, err := trees.commit(h)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: %v", err)
+	}
+	dh, err := root.dir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	want := make(map[Hash]bool)
+	markTree(want, trees, dh)
+	s := &store{keep: func(_ objType, h Hash) bool { return want[h] }}
+	if err := unpack(s, data); err != nil {
+		return nil, fmt.Errorf("fetch: %v", err)
+	}
+	return &treeFS{s, dh}, nil
+}
+
+// dir returns the hash of the tree object for the directory dir of t.
+func (t *treeFS) dir(dir string) (Hash, error) {
+	if !fs.ValidPath(dir) {
+		return Hash{}, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrInvalid}
+	}
+	h := t.tree
+	if dir != "." {
+		for _, elem := range strings.Split(dir, "/") {
+			typ, data := t.s.object(h)
+			if typ != objTree {
+				return Hash{}, &fs.PathError{Op: "sub", Path: dir, Err: errNotDir}
+			}
+			_, eh, ok := treeLookup(data, elem)
+			if !ok {
+				return Hash{}, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrNotExist}
+			}
+			h = eh
+		}
+	}
+	if typ, _ := t.s.object(h); typ != objTree {
+		return Hash{}, &fs.PathError{Op: "sub", Path: dir, Err: errNotDir}
+	}
+	return h, nil
+}
+
+var errNotDir = errors.New("not a directory")
+
+// markTree marks the tree object h in s, and every object under it, in
+// want. The objects under it needn't be in s, but only the trees that
+// are in s are walked.
+func markTree(want map[Hash]bool, s *store, h Hash) {
+	want[h] = true
+	typ, data := s.object(h)
+	if typ != objTree {
+		return
+	}
+	for len(data) > 0 {
+		e, size := parseDirEntry(data)
+		if size == 0 {
+			break
+		}
+		markTree(want, s, e.hash)
+		data = data[size:]
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func addBlob(s *store, content string) Hash {
+	h, _ := s.add(objBlob, []byte(content))
+	return h
+}
+
+// packObject is an object to write to a pack file by writePack.
+type packObject struct {
+	typ  objType
+	data []byte
+	base Hash // if typ is objRefDelta, the base object data is a delta from
+}
+
+// writePack returns a pack file holding objs, in order.
+func writePack(t *testing.T, objs []packObject) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("PACK")
+	binary.Write(&buf, binary.BigEndian, uint32(2))
+	binary.Write(&buf, binary.BigEndian, uint32(len(objs)))
+	for _, obj := range objs {
+		// The header is the type and size in a varint with 4 bits
+		// of size in the first byte, after the 3 bits of type.
+		n := len(obj.data)
+		b := byte(obj.typ)<<4 | byte(n&15)
+		for n >>= 4; n > 0; n >>= 7 {
+			buf.WriteByte(b | 0x80)
+			b = byte(n & 0x7f)
+		}
+		buf.WriteByte(b)
+		if obj.typ == objRefDelta {
+			buf.Write(obj.base[:])
+		}
+		zw := zlib.NewWriter(&buf)
+		zw.Write(obj.data)
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sum := sha1.Sum(buf.Bytes())
+	buf.Write(sum[:])
+	return buf.Bytes()
+}
+
+// insertDelta returns a delta that replaces base with target,
+// which must be shorter than 128 bytes.
+func insertDelta(base, target []byte) []byte {
+	delta := binary.AppendUvarint(nil, uint64(len(base)))
+	delta = binary.AppendUvarint(delta, uint64(len(target)))
+	delta = append(delta, byte(len(target)))
+	return append(delta, target...)
+}
+
+func treeData(entries ...dirEntry) []byte {
+	var data []byte
+	for _, e := range entries {
+		data = fmt.Appendf(data, "%o %s\x00", e.mode, e.name)
+		data = append(data, e.hash[:]...)
+	}
+	return data
+}
+
+func TestUnpackDir(t *testing.T) {
+	s := new(store)
+	readme := addBlob(s, "readme")
+	guide := addBlob(s, "guide")
+	main := addBlob(s, "package main")
+	sub := treeData(dirEntry{0100644, []byte("README"), readme})
+	subHash, _ := s.add(objTree, sub)
+	doc := treeData(
+		dirEntry{0100644, []byte("guide.md"), guide},
+		dirEntry{040000, []byte("sub"), subHash},
+	)
+	docHash, _ := s.add(objTree, doc)
+	root := treeData(
+		dirEntry{0100644, []byte("README"), readme},
+		dirEntry{040000, []byte("doc"), docHash},
+		dirEntry{0100644, []byte("main.go"), main},
+	)
+	rootHash, _ := s.add(objTree, root)
+	commit := []byte(fmt.Sprintf("tree %s\nauthor Gopher <gopher@golang.org> 1700000000 +0000\n\nmessage\n", rootHash))
+	commitHash, _ := s.add(objCommit, commit)
+
+	// guide.md is stored as a delta from main.go, outside of doc.
+	pack := writePack(t, []packObject{
+		{typ: objCommit, data: commit},
+		{typ: objTree, data: root},
+		{typ: objTree, data: doc},
+		{typ: objTree, data: sub},
+		{typ: objBlob, data: []byte("package main")},
+		{typ: objBlob, data: []byte("readme")},
+		{typ: objRefDelta, data: insertDelta([]byte("package main"), []byte("guide")), base: main},
+	})
+
+	tfs, err := unpackDir(pack, commitHash, "doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(tfs, "guide.md", "sub/README"); err != nil {
+		t.Error(err)
+	}
+	for _, h := range []Hash{main, rootHash, commitHash} {
+		if typ, _ := tfs.s.object(h); typ != objNone {
+			t.Errorf("unpackDir kept %s object %s, outside of doc", typ, h)
+		}
+	}
+
+	if _, err := unpackDir(pack, commitHash, "doc/guide.md"); !errors.Is(err, errNotDir) {
+		t.Errorf("unpackDir of a file: got error %v, want not a directory", err)
+	}
+	if _, err := unpackDir(pack, commitHash, "main.go/x"); !errors.Is(err, errNotDir) {
+		t.Errorf("unpackDir under a file: got error %v, want not a directory", err)
+	}
+	if _, err := unpackDir(pack, commitHash, "nope"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("unpackDir of a missing directory: got error %v, want fs.ErrNotExist", err)
+	}
+	if _, err := unpackDir(pack, commitHash, "/doc"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("unpackDir of an invalid path: got error %v, want fs.ErrInvalid", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+	"golang.org/x/build/internal/access"
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxCreateInstances is the maximum number of instances a single
+	// CreateInstances request may create.
+	maxCreateInstances = 32
+	// createInstancesParallelism is the maximum number of instances a
+	// single CreateInstances request creates at once.
+	createInstancesParallelism = 4
+)
+
+// CreateInstances creates gomote instances of one or more builder types.
+func (s *Server) CreateInstances(req *protos.CreateInstancesRequest, stream protos.GomoteService_CreateInstancesServer) error {
+	return createInstances(req, stream, s.CreateInstance)
+}
+
+// CreateInstances creates gomote instances of one or more builder types.
+func (ss *SwarmingServer) CreateInstances(req *protos.CreateInstancesRequest, stream protos.GomoteService_CreateInstancesServer) error {
+	return createInstances(req, stream, ss.CreateInstance)
+}
+
+// createInstances implements CreateInstances by calling create, a
+// CreateInstance implementation, for each requested instance, with
+// bounded parallelism. Status updates and errors are streamed for each
+// instance; a failure to create one instance doesn't stop the others.
+func createInstances(req *protos.CreateInstancesRequest, stream protos.GomoteService_CreateInstancesServer, create func(*protos.CreateInstanceRequest, protos.GomoteService_CreateInstanceServer) error) error {
+	if _, err := access.IAPFromContext(stream.Context()); err != nil {
+		log.Printf("CreateInstances access.IAPFromContext(ctx) = nil, %s", err)
+		return status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	var total int
+	for _, b := range req.GetBuilders() {
+		if b.GetBuilderType() == "" {
+			return status.Errorf(codes.InvalidArgument, "invalid builder type")
+		}
+		if b.GetCount() < 0 {
+			return status.Errorf(codes.InvalidArgument, "invalid count %d for builder type %s", b.GetCount(), b.GetBuilderType())
+		}
+		total += max(int(b.GetCount()), 1)
+	}
+	if total == 0 {
+		return status.Errorf(codes.InvalidArgument, "no builder types")
+	}
+	if total > maxCreateInstances {
+		return status.Errorf(codes.InvalidArgument, "too many instances requested: %d, the maximum is %d", total, maxCreateInstances)
+	}
+	groupID := req.GetGroupId()
+	if groupID == "" {
+		groupID = "group-" + uuid.NewString()[:8]
+	}
+
+	var sendMu sync.Mutex // grpc streams don't support concurrent sends
+	send := func(resp *protos.CreateInstancesResponse) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		resp.GroupId = groupID
+		return stream.Send(resp)
+	}
+	sem := make(chan struct{}, createInstancesParallelism)
+	var wg sync.WaitGroup
+	var index int32
+	for _, b := range req.GetBuilders() {
+		for range max(int(b.GetCount()), 1) {
+			index++
+			idx, builderType := index-1, b.GetBuilderType()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var err error
+				select {
+				case sem <- struct{}{}:
+					err = create(&protos.CreateInstanceRequest{
+						BuilderType:      builderType,
+						ExperimentOption: b.GetExperimentOption(),
+					}, &instanceStream{ServerStream: stream, send: func(update *protos.CreateInstanceResponse) error {
+						return send(&protos.CreateInstancesResponse{Index: idx, BuilderType: builderType, Update: update})
+					}})
+					<-sem
+				case <-stream.Context().Done():
+					err = status.Errorf(codes.DeadlineExceeded, "timed out waiting for gomote instance to be created")
+				}
+				if err != nil {
+					if err := send(&protos.CreateInstancesResponse{Index: idx, BuilderType: builderType, Error: status.Convert(err).Message()}); err != nil {
+						log.Printf("CreateInstances: unable to stream error for instance %d: %s", idx, err)
+					}
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	return nil
+}
+
+// instanceStream is the stream a single CreateInstance call within
+// CreateInstances sends its status updates to.
+type instanceStream struct {
+	grpc.ServerStream
+	send func(*protos.CreateInstanceResponse) error
+}
+
+func (s *instanceStream) Send(resp *protos.CreateInstanceResponse) error {
+	return s.send(resp)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gomote
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/envutil"
+	"golang.org/x/build/internal/gomote/protos"
+	"golang.org/x/build/internal/redact"
+)
+
+// redacted replaces values which may be secret in builder environments.
+const redacted = redact.Redacted
+
+// redactEnv returns a copy of the KEY=VALUE pairs in env with
+// secret-looking values redacted.
+func redactEnv(env []string) []string {
+	out := make([]string, 0, len(env))
+	for _, kv := range env {
+		if k, _, ok := strings.Cut(kv, "="); ok && redact.IsSecretName(k) {
+			kv = k + "=" + redacted
+		}
+		out = append(out, kv)
+	}
+	return out
+}
+
+// coordinatorBuilderEnvironment describes the coordinator builder conf.
+// The environment is the one ExecuteCommand uses.
+func coordinatorBuilderEnvironment(conf *dashboard.BuildConfig) *protos.GetBuilderEnvironmentResponse {
+	hconf := conf.HostConfig()
+	image := hconf.ContainerImage
+	if image == "" {
+		image = hconf.VMImage
+	}
+	return &protos.GetBuilderEnvironmentResponse{
+		BuilderType: conf.Name,
+		Goos:        conf.GOOS(),
+		Goarch:      conf.GOARCH(),
+		Environment: redactEnv(envutil.Dedup(conf.GOOS(), conf.Env())),
+		Config: []string{
+			"host_type: " + conf.HostType,
+			"image: " + image,
+			"make_script: " + strings.Join(append([]string{conf.MakeScript()}, conf.MakeScriptArgs()...), " "),
+			"all_script: " + strings.Join(append([]string{conf.AllScript()}, conf.AllScriptArgs()...), " "),
+			fmt.Sprintf("go_test_timeout_scale: %d", conf.GoTestTimeoutScale()),
+			fmt.Sprintf("race: %t", conf.IsRace()),
+			fmt.Sprintf("longtest: %t", conf.IsLongTest()),
+			fmt.Sprintf("outbound_network_allowed: %t", conf.OutboundNetworkAllowed()),
+		},
+	}
+}
+
+// swarmingBuilderEnvironment describes the LUCI builder, using its
+// "env" and "target" properties and its cipd_platform dimension.
+// The remaining properties and dimensions are reported as config.
+func swarmingBuilderEnvironment(builder *buildbucketpb.BuilderItem) (*protos.GetBuilderEnvironmentResponse, error) {
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(builder.GetConfig().GetProperties()), &props); err != nil {
+		return nil, fmt.Errorf("builder property unmarshal error: %s", err)
+	}
+	resp := &protos.GetBuilderEnvironmentResponse{
+		BuilderType: builder.GetId().GetBuilder(),
+	}
+	var target struct {
+		GOOS   string `json:"goos"`
+		GOARCH string `json:"goarch"`
+	}
+	if raw, ok := props["target"]; ok {
+		if err := json.Unmarshal(raw, &target); err != nil {
+			return nil, fmt.Errorf("builder target property unmarshal error: %s", err)
+		}
+	}
+	resp.Goos, resp.Goarch = target.GOOS, target.GOARCH
+	if raw, ok := props["env"]; ok {
+		var env map[string]string
+		if err := json.Unmarshal(raw, &env); err != nil {
+			return nil, fmt.Errorf("builder env property unmarshal error: %s", err)
+		}
+		for k, v := range env {
+			resp.Environment = append(resp.Environment, k+"="+v)
+		}
+		sort.Strings(resp.Environment)
+		resp.Environment = redactEnv(resp.Environment)
+	}
+
+	for name, raw := range props {
+		if name == "env" {
+			continue
+		}
+		value := string(raw)
+		if redact.IsSecretName(name) {
+			value = redacted
+		}
+		resp.Config = append(resp.Config, name+": "+value)
+	}
+	sort.Strings(resp.Config)
+	for _, dim := range builder.GetConfig().GetDimensions() {
+		resp.Config = append(resp.Config, "dimension: "+dim)
+		if platform, ok := strings.CutPrefix(dim, "cipd_platform:"); ok && resp.Goos == "" {
+			if goos, goarch, err := platformToGoValues(platform); err == nil {
+				resp.Goos, resp.Goarch = goos, goarch
+			}
+		}
+	}
+	return resp, nil
+}
@@ -89,7 +89,7 @@ func (s *Server) AddBootstrap(ctx context.Context, req *protos.AddBootstrapReque
 		// the helper function returns meaningful GRPC error.
 		return nil, err
 	}
-	bconf, ok := dashboard.Builders[ses.BuilderType]
+	bconf, ok := sessionBuilderConfig(ses.BuilderType)
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "unknown builder type")
 	}
@@ -124,22 +124,31 @@ func (s *Server) CreateInstance(req *protos.CreateInstanceRequest, stream protos
 	if req.GetBuilderType() == "" {
 		return status.Errorf(codes.InvalidArgument, "invalid builder type")
 	}
-	bconf, ok := dashboard.Builders[req.GetBuilderType()]
+	bconf, ok := dashboard.ActiveBuilders()[req.GetBuilderType()]
 	if !ok {
 		return status.Errorf(codes.InvalidArgument, "unknown builder type")
 	}
 	if ((!bconf.HostConfig().IsHermetic() && bconf.HostConfig().IsGoogle()) || bconf.IsRestricted()) && !isPrivilegedUser(creds.Email) {
 		return status.Errorf(codes.PermissionDenied, "user is unable to create gomote of that builder type")
 	}
+	if img := req.GetImage(); img != "" && !bconf.HostConfig().IsAllowedImage(img) {
+		return status.Errorf(codes.InvalidArgument, "image %q is not allowed for builder type %s", img, req.GetBuilderType())
+	}
 	userName, err := emailToUser(creds.Email)
 	if err != nil {
 		return status.Errorf(codes.Internal, "invalid user email format")
 	}
+	release, err := s.buildlets.Reserve(userName)
+	if err != nil {
+		return status.Errorf(codes.ResourceExhausted, "%s", err)
+	}
+	defer release()
 	si := &queue.SchedItem{
 		HostType:  bconf.HostType,
 		IsGomote:  true,
 		IsRelease: userName == "relui-prod",
 		User:      creds.Email,
+		Image:     req.GetImage(),
 	}
 	type result struct {
 		buildletClient buildlet.Client
@@ -186,6 +195,7 @@ func (s *Server) CreateInstance(req *protos.CreateInstanceRequest, stream protos
 					GomoteId:    gomoteID,
 					BuilderType: req.GetBuilderType(),
 					HostType:    bconf.HostType,
+					Created:     session.Created.Unix(),
 					Expires:     session.Expires.Unix(),
 					WorkingDir:  wd,
 				},
@@ -221,15 +231,73 @@ func (s *Server) InstanceAlive(ctx context.Context, req *protos.InstanceAliveReq
 	return &protos.InstanceAliveResponse{}, nil
 }
 
+// ExtendInstanceLease extends how long a gomote instance may sit idle before it's destroyed, and
+// returns its new expiration time. The requester must be authenticated and own the instance.
+func (s *Server) ExtendInstanceLease(ctx context.Context, req *protos.ExtendInstanceLeaseRequest) (*protos.ExtendInstanceLeaseResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("ExtendInstanceLease access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if req.GetGomoteId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid gomote ID")
+	}
+	if req.GetIdleTimeoutSeconds() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid idle timeout")
+	}
+	_, err = s.session(req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	expires, err := s.buildlets.ExtendLease(req.GetGomoteId(), time.Duration(req.GetIdleTimeoutSeconds())*time.Second)
+	if errors.Is(err, remote.ErrLeaseTooLong) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to extend lease")
+	}
+	return &protos.ExtendInstanceLeaseResponse{Expires: expires.Unix()}, nil
+}
+
+// GetBuilderEnvironment returns the environment and configuration of the builder the gomote instance
+// was created for. The requester must be authenticated.
+func (s *Server) GetBuilderEnvironment(ctx context.Context, req *protos.GetBuilderEnvironmentRequest) (*protos.GetBuilderEnvironmentResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("GetBuilderEnvironment access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	ses, err := s.session(req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	conf, ok := sessionBuilderConfig(ses.BuilderType)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unable to retrieve configuration for instance")
+	}
+	return coordinatorBuilderEnvironment(conf), nil
+}
+
+// sessionBuilderConfig returns the configuration of builderType for
+// an existing session. Sessions outlive changes to the active builder
+// configuration, so a builder that has since been disabled is looked
+// up in dashboard.Builders; only new instances are refused for it.
+func sessionBuilderConfig(builderType string) (*dashboard.BuildConfig, bool) {
+	if conf, ok := dashboard.ActiveBuilders()[builderType]; ok {
+		return conf, true
+	}
+	conf, ok := dashboard.Builders[builderType]
+	return conf, ok
+}
+
 // ListDirectory lists the contents of the directory on a gomote instance.
 func (s *Server) ListDirectory(ctx context.Context, req *protos.ListDirectoryRequest) (*protos.ListDirectoryResponse, error) {
 	entries, err := s.listDirectory(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	return &protos.ListDirectoryResponse{
-		Entries: entries,
-	}, nil
+	return listDirectoryResponse(entries), nil
 }
 
 // ListDirectoryStreaming lists the contents of the directory on a gomote instance.
@@ -242,9 +310,7 @@ func (s *Server) ListDirectoryStreaming(req *protos.ListDirectoryRequest, stream
 	const chunkSize = 100
 	for i := 0; i < len(entries); i += chunkSize {
 		end := min(chunkSize, len(entries[i:]))
-		if err := stream.Send(&protos.ListDirectoryResponse{
-			Entries: entries[i : i+end],
-		}); err != nil {
+		if err := stream.Send(listDirectoryResponse(entries[i : i+end])); err != nil {
 			return err
 		}
 	}
@@ -279,6 +345,29 @@ func (s *Server) listDirectory(ctx context.Context, req *protos.ListDirectoryReq
 	return entries, nil
 }
 
+// listDirectoryResponse returns the response listing the directory
+// entries, which are the lines of buildlet.DirEntry values.
+func listDirectoryResponse(entries []string) *protos.ListDirectoryResponse {
+	details := make([]*protos.DirectoryEntry, 0, len(entries))
+	for _, line := range entries {
+		de := buildlet.DirEntry{Line: line}
+		d := &protos.DirectoryEntry{
+			Name:   de.Name(),
+			Mode:   de.Perm(),
+			Size:   de.Size(),
+			Digest: de.Digest(),
+		}
+		if t := de.ModTime(); !t.IsZero() {
+			d.ModTime = t.Unix()
+		}
+		details = append(details, d)
+	}
+	return &protos.ListDirectoryResponse{
+		Entries: entries,
+		Details: details,
+	}
+}
+
 // ListInstances will list the gomote instances owned by the requester. The requester must be authenticated.
 func (s *Server) ListInstances(ctx context.Context, req *protos.ListInstancesRequest) (*protos.ListInstancesResponse, error) {
 	creds, err := access.IAPFromContext(ctx)
@@ -295,6 +384,7 @@ func (s *Server) ListInstances(ctx context.Context, req *protos.ListInstancesReq
 			GomoteId:    s.ID,
 			BuilderType: s.BuilderType,
 			HostType:    s.HostType,
+			Created:     s.Created.Unix(),
 			Expires:     s.Expires.Unix(),
 		})
 	}
@@ -335,11 +425,10 @@ func (s *Server) ExecuteCommand(req *protos.ExecuteCommandRequest, stream protos
 		// the helper function returns meaningful GRPC error.
 		return err
 	}
-	builderType := req.GetImitateHostType()
-	if builderType == "" {
-		builderType = ses.BuilderType
+	conf, ok := sessionBuilderConfig(ses.BuilderType)
+	if imitate := req.GetImitateHostType(); imitate != "" {
+		conf, ok = dashboard.ActiveBuilders()[imitate]
 	}
-	conf, ok := dashboard.Builders[builderType]
 	if !ok {
 		return status.Errorf(codes.Internal, "unable to retrieve configuration for instance")
 	}
@@ -467,6 +556,26 @@ func (s *Server) SignSSHKey(ctx context.Context, req *protos.SignSSHKeyRequest)
 	}, nil
 }
 
+// SnapshotInstance saves a tar.gz of the go directory of the gomote instance for later use. It returns the URL of the
+// snapshot, which the caller can pass to WriteTGZFromURL to expand the snapshot onto any of their instances.
+func (s *Server) SnapshotInstance(ctx context.Context, req *protos.SnapshotInstanceRequest) (*protos.SnapshotInstanceResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("SnapshotInstance access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	url, err := snapshotInstance(ctx, s.bucket, s.gceBucketName, bc, creds.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.SnapshotInstanceResponse{Url: url}, nil
+}
+
 // UploadFile creates a URL and a set of HTTP post fields which are used to upload a file to a staging GCS bucket. Uploaded files are made available to the
 // gomote instances via a subsequent call to one of the WriteFromURL endpoints.
 func (s *Server) UploadFile(ctx context.Context, req *protos.UploadFileRequest) (*protos.UploadFileResponse, error) {
@@ -527,41 +636,21 @@ func (s *Server) WriteFileFromURL(ctx context.Context, req *protos.WriteFileFrom
 		// the helper function returns meaningful GRPC error.
 		return nil, err
 	}
-	var rc io.ReadCloser
-	// objects stored in the gomote staging bucket are only accessible when you have been granted explicit permissions. A builder
-	// requires a signed URL in order to access objects stored in the gomote staging bucket.
-	if onObjectStore(s.gceBucketName, req.GetUrl()) {
-		object, err := objectFromURL(s.gceBucketName, req.GetUrl())
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid object URL")
-		}
-		rc, err = s.bucket.Object(object).NewReader(ctx)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "unable to create object reader: %s", err)
-		}
-	} else {
-		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, req.GetUrl(), nil)
-		// TODO(amedee) find sane client defaults, possibly rely on context timeout in request.
-		client := &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				TLSHandshakeTimeout: 5 * time.Second,
-			},
-		}
-		resp, err := client.Do(httpRequest)
-		if err != nil {
-			return nil, status.Errorf(codes.Aborted, "failed to get file from URL: %s", err)
-		}
-		if resp.StatusCode != http.StatusOK {
-			return nil, status.Errorf(codes.Aborted, "unable to get file from %q: response code: %d", req.GetUrl(), resp.StatusCode)
-		}
-		rc = resp.Body
+	if err := checkSnapshotAccess(s.gceBucketName, req.GetUrl(), creds.ID); err != nil {
+		return nil, err
+	}
+	rc, err := readerForURL(ctx, s.bucket, s.gceBucketName, req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid object URL")
 	}
 	defer rc.Close()
+	if err := rc.start(); err != nil {
+		return nil, status.Errorf(codes.Aborted, "failed to get file from URL: %s", err)
+	}
 	if err := bc.Put(ctx, rc, req.GetFilename(), fs.FileMode(req.GetMode())); err != nil {
 		return nil, status.Errorf(codes.Aborted, "failed to send the file to the gomote instance: %s", err)
 	}
-	return &protos.WriteFileFromURLResponse{}, nil
+	return &protos.WriteFileFromURLResponse{Attempts: int32(rc.attempts)}, nil
 }
 
 // WriteTGZFromURL will instruct the gomote instance to download the tar.gz from the provided URL. The tar.gz file will be unpacked in the work directory
@@ -584,6 +673,9 @@ func (s *Server) WriteTGZFromURL(ctx context.Context, req *protos.WriteTGZFromUR
 		return nil, err
 	}
 	url := req.GetUrl()
+	if err := checkSnapshotAccess(s.gceBucketName, url, creds.ID); err != nil {
+		return nil, err
+	}
 	if onObjectStore(s.gceBucketName, url) {
 		object, err := objectFromURL(s.gceBucketName, url)
 		if err != nil {
@@ -11,13 +11,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/dashboard"
 	"golang.org/x/build/internal/access"
 	"golang.org/x/build/internal/coordinator/remote"
 	"golang.org/x/build/internal/coordinator/schedule"
@@ -216,6 +219,15 @@ func TestCreateInstanceError(t *testing.T) {
 			},
 			wantCode: codes.InvalidArgument,
 		},
+		{
+			desc: "image not allowed",
+			ctx:  access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request: &protos.CreateInstanceRequest{
+				BuilderType: "linux-amd64",
+				Image:       "linux-x86-bullseye:some-old-revision",
+			},
+			wantCode: codes.InvalidArgument,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -239,6 +251,100 @@ func TestCreateInstanceError(t *testing.T) {
 	}
 }
 
+func TestCreateInstances(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	req := &protos.CreateInstancesRequest{
+		Builders: []*protos.CreateInstancesRequest_Builder{
+			{BuilderType: "linux-amd64", Count: 2},
+			{BuilderType: "funky-time-builder"},
+		},
+	}
+	client := setupGomoteTest(t, context.Background())
+	stream, err := client.CreateInstances(ctx, req)
+	if err != nil {
+		t.Fatalf("client.CreateInstances(ctx, %v) = %v,  %s; want no error", req, stream, err)
+	}
+	created := make(map[int32]string)
+	failed := make(map[int32]string)
+	groupIDs := make(map[string]bool)
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream.Recv() = nil, %s; want no error", err)
+		}
+		groupIDs[update.GetGroupId()] = true
+		switch {
+		case update.GetError() != "":
+			failed[update.GetIndex()] = update.GetBuilderType()
+		case update.GetUpdate().GetStatus() == protos.CreateInstanceResponse_COMPLETE:
+			created[update.GetIndex()] = update.GetUpdate().GetInstance().GetGomoteId()
+		}
+	}
+	if len(created) != 2 || created[0] == "" || created[1] == "" || created[0] == created[1] {
+		t.Errorf("created instances = %v, want two distinct instances at indexes 0 and 1", created)
+	}
+	if want := map[int32]string{2: "funky-time-builder"}; !cmp.Equal(failed, want) {
+		t.Errorf("failed instances = %v, want %v", failed, want)
+	}
+	if len(groupIDs) != 1 || groupIDs[""] {
+		t.Errorf("group IDs = %v, want a single non-empty one", groupIDs)
+	}
+}
+
+func TestCreateInstancesError(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ctx      context.Context
+		request  *protos.CreateInstancesRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "unauthenticated request",
+			ctx:      context.Background(),
+			request:  &protos.CreateInstancesRequest{},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:     "no builder types",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request:  &protos.CreateInstancesRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc: "too many instances",
+			ctx:  access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request: &protos.CreateInstancesRequest{
+				Builders: []*protos.CreateInstancesRequest_Builder{{BuilderType: "linux-amd64", Count: maxCreateInstances + 1}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := setupGomoteTest(t, context.Background())
+			stream, err := client.CreateInstances(tc.ctx, tc.request)
+			if err != nil {
+				t.Fatalf("client.CreateInstances(ctx, %v) = %v,  %s; want no error", tc.request, stream, err)
+			}
+			for {
+				_, got := stream.Recv()
+				if got == io.EOF {
+					t.Fatal("stream.Recv = stream, io.EOF; want no EOF")
+				}
+				if got != nil && status.Code(got) != tc.wantCode {
+					t.Fatalf("unexpected error: %s; want %s", got, tc.wantCode)
+				}
+				if got != nil {
+					break
+				}
+			}
+		})
+	}
+}
+
 func TestInstanceAlive(t *testing.T) {
 	client := setupGomoteTest(t, context.Background())
 	gomoteID := mustCreateInstance(t, client, fakeIAP())
@@ -252,6 +358,129 @@ func TestInstanceAlive(t *testing.T) {
 	}
 }
 
+func TestExtendInstanceLease(t *testing.T) {
+	client := setupGomoteTest(t, context.Background())
+	gomoteID := mustCreateInstance(t, client, fakeIAP())
+	req := &protos.ExtendInstanceLeaseRequest{
+		GomoteId:           gomoteID,
+		IdleTimeoutSeconds: int64((2 * time.Hour).Seconds()),
+	}
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	got, err := client.ExtendInstanceLease(ctx, req)
+	if err != nil {
+		t.Fatalf("client.ExtendInstanceLease(ctx, %v) = %v, %s; want no error", req, got, err)
+	}
+	if expires := time.Unix(got.GetExpires(), 0); expires.Before(time.Now().Add(2*time.Hour - time.Minute)) {
+		t.Errorf("client.ExtendInstanceLease(ctx, %v) expires at %s; want about 2h from now", req, expires)
+	}
+}
+
+func TestExtendInstanceLeaseError(t *testing.T) {
+	client := setupGomoteTest(t, context.Background())
+	gomoteID := mustCreateInstance(t, client, fakeIAP())
+	for _, tc := range []struct {
+		desc        string
+		ctx         context.Context
+		gomoteID    string
+		idleTimeout time.Duration
+		wantCode    codes.Code
+	}{
+		{"unauthenticated request", context.Background(), gomoteID, time.Hour, codes.Unauthenticated},
+		{"missing gomote id", access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()), "", time.Hour, codes.InvalidArgument},
+		{"missing idle timeout", access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()), gomoteID, 0, codes.InvalidArgument},
+		{"gomote does not exist", access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()), "xyz", time.Hour, codes.NotFound},
+		{"gomote is not owned by caller", access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("user-x", "email-y")), gomoteID, time.Hour, codes.PermissionDenied},
+		{"too long", access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()), gomoteID, remote.MaxIdleTimeout + time.Hour, codes.FailedPrecondition},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := &protos.ExtendInstanceLeaseRequest{
+				GomoteId:           tc.gomoteID,
+				IdleTimeoutSeconds: int64(tc.idleTimeout.Seconds()),
+			}
+			got, err := client.ExtendInstanceLease(tc.ctx, req)
+			if err != nil && status.Code(err) != tc.wantCode {
+				t.Fatalf("unexpected error: %s; want %s", err, tc.wantCode)
+			}
+			if err == nil {
+				t.Fatalf("client.ExtendInstanceLease(ctx, %v) = %v, nil; want error", req, got)
+			}
+		})
+	}
+}
+
+func TestGetBuilderEnvironment(t *testing.T) {
+	client := setupGomoteTest(t, context.Background())
+	gomoteID := mustCreateInstance(t, client, fakeIAP())
+	req := &protos.GetBuilderEnvironmentRequest{
+		GomoteId: gomoteID,
+	}
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	got, err := client.GetBuilderEnvironment(ctx, req)
+	if err != nil {
+		t.Fatalf("client.GetBuilderEnvironment(ctx, %v) = %v, %s; want no error", req, got, err)
+	}
+	if got.GetBuilderType() != "linux-amd64" || got.GetGoos() != "linux" || got.GetGoarch() != "amd64" {
+		t.Errorf("client.GetBuilderEnvironment(ctx, %v) = %v; want linux-amd64 builder with GOOS=linux, GOARCH=amd64", req, got)
+	}
+	if !slices.Contains(got.GetConfig(), "host_type: "+dashboard.Builders["linux-amd64"].HostType) {
+		t.Errorf("client.GetBuilderEnvironment(ctx, %v).Config = %q; want it to include the host type", req, got.GetConfig())
+	}
+}
+
+func TestGetBuilderEnvironmentDisabledBuilder(t *testing.T) {
+	client := setupGomoteTest(t, context.Background())
+	gomoteID := mustCreateInstance(t, client, fakeIAP())
+	active := maps.Clone(dashboard.Builders)
+	delete(active, "linux-amd64")
+	dashboard.SetActiveBuilders(active)
+	defer dashboard.SetActiveBuilders(nil)
+
+	req := &protos.GetBuilderEnvironmentRequest{
+		GomoteId: gomoteID,
+	}
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	got, err := client.GetBuilderEnvironment(ctx, req)
+	if err != nil {
+		t.Fatalf("client.GetBuilderEnvironment(ctx, %v) = %v, %s; want no error for a session of a disabled builder", req, got, err)
+	}
+	if got.GetBuilderType() != "linux-amd64" {
+		t.Errorf("client.GetBuilderEnvironment(ctx, %v) = %v; want linux-amd64 builder", req, got)
+	}
+	stream, err := client.CreateInstance(ctx, &protos.CreateInstanceRequest{BuilderType: "linux-amd64"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("client.CreateInstance for a disabled builder: err = %v; want InvalidArgument", err)
+	}
+}
+
+func TestGetBuilderEnvironmentError(t *testing.T) {
+	client := setupGomoteTest(t, context.Background())
+	gomoteID := mustCreateInstance(t, client, fakeIAP())
+	for _, tc := range []struct {
+		desc     string
+		ctx      context.Context
+		gomoteID string
+		wantCode codes.Code
+	}{
+		{"unauthenticated request", context.Background(), gomoteID, codes.Unauthenticated},
+		{"gomote does not exist", access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()), "xyz", codes.NotFound},
+		{"gomote is not owned by caller", access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("user-x", "email-y")), gomoteID, codes.PermissionDenied},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := &protos.GetBuilderEnvironmentRequest{GomoteId: tc.gomoteID}
+			got, err := client.GetBuilderEnvironment(tc.ctx, req)
+			if err != nil && status.Code(err) != tc.wantCode {
+				t.Fatalf("unexpected error: %s; want %s", err, tc.wantCode)
+			}
+			if err == nil {
+				t.Fatalf("client.GetBuilderEnvironment(ctx, %v) = %v, nil; want error", req, got)
+			}
+		})
+	}
+}
+
 func TestInstanceAliveError(t *testing.T) {
 	// This test will create a gomote instance and attempt to call InstanceAlive.
 	// If overrideID is set to true, the test will use a different gomoteID than
@@ -431,7 +660,7 @@ func TestListInstance(t *testing.T) {
 		t.Fatalf("client.ListInstances = nil, %s; want no error", err)
 	}
 	got := response.GetInstances()
-	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.IgnoreFields(&protos.Instance{}, "expires", "host_type")); diff != "" {
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.IgnoreFields(&protos.Instance{}, "created", "expires", "host_type")); diff != "" {
 		t.Errorf("ListInstances() mismatch (-want, +got):\n%s", diff)
 	}
 }
@@ -833,6 +1062,56 @@ func TestSignSSHKeyError(t *testing.T) {
 	}
 }
 
+func TestSnapshotInstanceError(t *testing.T) {
+	// This test will create a gomote instance and attempt to call SnapshotInstance.
+	// If overrideID is set to true, the test will use a different gomoteID than
+	// the one created for the test.
+	testCases := []struct {
+		desc       string
+		ctx        context.Context
+		overrideID bool
+		gomoteID   string // Used iff overrideID is true.
+		wantCode   codes.Code
+	}{
+		{
+			desc:     "unauthenticated request",
+			ctx:      context.Background(),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:       "gomote does not exist",
+			ctx:        access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("foo", "bar")),
+			overrideID: true,
+			gomoteID:   "chucky",
+			wantCode:   codes.NotFound,
+		},
+		{
+			desc:     "wrong gomote id",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("foo", "bar")),
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := setupGomoteTest(t, context.Background())
+			gomoteID := mustCreateInstance(t, client, fakeIAP())
+			if tc.overrideID {
+				gomoteID = tc.gomoteID
+			}
+			req := &protos.SnapshotInstanceRequest{
+				GomoteId: gomoteID,
+			}
+			got, err := client.SnapshotInstance(tc.ctx, req)
+			if err != nil && status.Code(err) != tc.wantCode {
+				t.Fatalf("unexpected error: %s; want %s", err, tc.wantCode)
+			}
+			if err == nil {
+				t.Fatalf("client.SnapshotInstance(ctx, %v) = %v, nil; want error", req, got)
+			}
+		})
+	}
+}
+
 func TestUploadFile(t *testing.T) {
 	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
 	client := setupGomoteTest(t, context.Background())
@@ -986,6 +1265,28 @@ func TestWriteTGZFromURLGomoteStaging(t *testing.T) {
 	}
 }
 
+func TestWriteTGZFromURLSnapshot(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteTest(t, context.Background())
+	gomoteID := mustCreateInstance(t, client, fakeIAP())
+	snapshotURL := func(ownerID string) string {
+		return fmt.Sprintf("https://storage.googleapis.com/%s/%sabc.tar.gz", testBucketName, snapshotObjectPrefix(ownerID))
+	}
+	if _, err := client.WriteTGZFromURL(ctx, &protos.WriteTGZFromURLRequest{
+		GomoteId: gomoteID,
+		Url:      snapshotURL(fakeIAP().ID),
+	}); err != nil {
+		t.Fatalf("client.WriteTGZFromURL(ctx, req) with own snapshot = response, %s; want no error", err)
+	}
+	_, err := client.WriteTGZFromURL(ctx, &protos.WriteTGZFromURLRequest{
+		GomoteId: gomoteID,
+		Url:      snapshotURL(fakeIAPWithUser("foo", "bar").ID),
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("client.WriteTGZFromURL(ctx, req) with another user's snapshot = response, %v; want %s", err, codes.PermissionDenied)
+	}
+}
+
 func TestWriteTGZFromURLError(t *testing.T) {
 	// This test will create a gomote instance and attempt to call TestWriteTGZFromURL.
 	// If overrideID is set to true, the test will use a different gomoteID than
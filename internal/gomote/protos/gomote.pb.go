@@ -260,6 +260,10 @@ type CreateInstanceRequest struct {
 
 	BuilderType      string   `protobuf:"bytes,1,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
 	ExperimentOption []string `protobuf:"bytes,2,rep,name=experiment_option,json=experimentOption,proto3" json:"experiment_option,omitempty"`
+	// The base image revision to create the instance from. If empty, the
+	// builder type's current image is used. It must be one of the images
+	// allowed for the builder type.
+	Image string `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
 }
 
 func (x *CreateInstanceRequest) Reset() {
@@ -308,6 +312,13 @@ func (x *CreateInstanceRequest) GetExperimentOption() []string {
 	return nil
 }
 
+func (x *CreateInstanceRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
 // CreateInstanceResponse contains data about a created gomote instance.
 type CreateInstanceResponse struct {
 	state         protoimpl.MessageState
@@ -376,6 +387,151 @@ func (x *CreateInstanceResponse) GetWaitersAhead() int64 {
 	return 0
 }
 
+// CreateInstancesRequest specifies the gomote instances to create.
+type CreateInstancesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Builders []*CreateInstancesRequest_Builder `protobuf:"bytes,1,rep,name=builders,proto3" json:"builders,omitempty"`
+	// The ID of the group the instances are meant for. If empty, a new one
+	// is generated.
+	GroupId string `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+}
+
+func (x *CreateInstancesRequest) Reset() {
+	*x = CreateInstancesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInstancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInstancesRequest) ProtoMessage() {}
+
+func (x *CreateInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInstancesRequest.ProtoReflect.Descriptor instead.
+func (*CreateInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateInstancesRequest) GetBuilders() []*CreateInstancesRequest_Builder {
+	if x != nil {
+		return x.Builders
+	}
+	return nil
+}
+
+func (x *CreateInstancesRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+// CreateInstancesResponse contains a status update about one of the
+// gomote instances being created.
+type CreateInstancesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The group ID of the instances. It's set in every response.
+	GroupId string `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// The position of the instance among all the requested instances,
+	// counting the instances of each builder type in request order.
+	Index       int32  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	BuilderType string `protobuf:"bytes,3,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
+	// The status update for the instance, as CreateInstance would send it.
+	Update *CreateInstanceResponse `protobuf:"bytes,4,opt,name=update,proto3" json:"update,omitempty"`
+	// If non-empty, the reason the instance couldn't be created. It's the
+	// last response for the instance.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *CreateInstancesResponse) Reset() {
+	*x = CreateInstancesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInstancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInstancesResponse) ProtoMessage() {}
+
+func (x *CreateInstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInstancesResponse.ProtoReflect.Descriptor instead.
+func (*CreateInstancesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateInstancesResponse) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CreateInstancesResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CreateInstancesResponse) GetBuilderType() string {
+	if x != nil {
+		return x.BuilderType
+	}
+	return ""
+}
+
+func (x *CreateInstancesResponse) GetUpdate() *CreateInstanceResponse {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *CreateInstancesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 // DestroyInstanceRequest specifies the data needed to destroy a gomote instance.
 type DestroyInstanceRequest struct {
 	state         protoimpl.MessageState
@@ -389,7 +545,7 @@ type DestroyInstanceRequest struct {
 func (x *DestroyInstanceRequest) Reset() {
 	*x = DestroyInstanceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[6]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +558,7 @@ func (x *DestroyInstanceRequest) String() string {
 func (*DestroyInstanceRequest) ProtoMessage() {}
 
 func (x *DestroyInstanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[6]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +571,7 @@ func (x *DestroyInstanceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DestroyInstanceRequest.ProtoReflect.Descriptor instead.
 func (*DestroyInstanceRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{6}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DestroyInstanceRequest) GetGomoteId() string {
@@ -435,7 +591,7 @@ type DestroyInstanceResponse struct {
 func (x *DestroyInstanceResponse) Reset() {
 	*x = DestroyInstanceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[7]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -448,7 +604,7 @@ func (x *DestroyInstanceResponse) String() string {
 func (*DestroyInstanceResponse) ProtoMessage() {}
 
 func (x *DestroyInstanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[7]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +617,7 @@ func (x *DestroyInstanceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DestroyInstanceResponse.ProtoReflect.Descriptor instead.
 func (*DestroyInstanceResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{7}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{9}
 }
 
 // ExecuteCommandRequest specifies the data needed to execute a command on a gomote instance.
@@ -499,7 +655,7 @@ type ExecuteCommandRequest struct {
 func (x *ExecuteCommandRequest) Reset() {
 	*x = ExecuteCommandRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[8]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -512,7 +668,7 @@ func (x *ExecuteCommandRequest) String() string {
 func (*ExecuteCommandRequest) ProtoMessage() {}
 
 func (x *ExecuteCommandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[8]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,7 +681,7 @@ func (x *ExecuteCommandRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteCommandRequest.ProtoReflect.Descriptor instead.
 func (*ExecuteCommandRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{8}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ExecuteCommandRequest) GetGomoteId() string {
@@ -604,7 +760,7 @@ type ExecuteCommandResponse struct {
 func (x *ExecuteCommandResponse) Reset() {
 	*x = ExecuteCommandResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[9]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -617,7 +773,222 @@ func (x *ExecuteCommandResponse) String() string {
 func (*ExecuteCommandResponse) ProtoMessage() {}
 
 func (x *ExecuteCommandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[9]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteCommandResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteCommandResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExecuteCommandResponse) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+// ExtendInstanceLeaseRequest specifies the data needed to extend the lease of a gomote instance.
+type ExtendInstanceLeaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	// How long, in seconds, the instance may sit idle before it's destroyed.
+	IdleTimeoutSeconds int64 `protobuf:"varint,2,opt,name=idle_timeout_seconds,json=idleTimeoutSeconds,proto3" json:"idle_timeout_seconds,omitempty"`
+}
+
+func (x *ExtendInstanceLeaseRequest) Reset() {
+	*x = ExtendInstanceLeaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendInstanceLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendInstanceLeaseRequest) ProtoMessage() {}
+
+func (x *ExtendInstanceLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendInstanceLeaseRequest.ProtoReflect.Descriptor instead.
+func (*ExtendInstanceLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExtendInstanceLeaseRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+func (x *ExtendInstanceLeaseRequest) GetIdleTimeoutSeconds() int64 {
+	if x != nil {
+		return x.IdleTimeoutSeconds
+	}
+	return 0
+}
+
+// ExtendInstanceLeaseResponse contains the new expiration time of a gomote instance.
+type ExtendInstanceLeaseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The timestamp for when the instance will expire if it remains idle. It is
+	// represented in Unix epoch time format.
+	Expires int64 `protobuf:"varint,1,opt,name=expires,proto3" json:"expires,omitempty"`
+}
+
+func (x *ExtendInstanceLeaseResponse) Reset() {
+	*x = ExtendInstanceLeaseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendInstanceLeaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendInstanceLeaseResponse) ProtoMessage() {}
+
+func (x *ExtendInstanceLeaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendInstanceLeaseResponse.ProtoReflect.Descriptor instead.
+func (*ExtendInstanceLeaseResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExtendInstanceLeaseResponse) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+// GetBuilderEnvironmentRequest specifies the data needed to get the builder environment of a gomote instance.
+type GetBuilderEnvironmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+}
+
+func (x *GetBuilderEnvironmentRequest) Reset() {
+	*x = GetBuilderEnvironmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBuilderEnvironmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuilderEnvironmentRequest) ProtoMessage() {}
+
+func (x *GetBuilderEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuilderEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*GetBuilderEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetBuilderEnvironmentRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+// GetBuilderEnvironmentResponse contains the environment and configuration of a builder.
+type GetBuilderEnvironmentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The builder type of the instance.
+	BuilderType string `protobuf:"bytes,1,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
+	// The GOOS and GOARCH the builder targets.
+	Goos   string `protobuf:"bytes,2,opt,name=goos,proto3" json:"goos,omitempty"`
+	Goarch string `protobuf:"bytes,3,opt,name=goarch,proto3" json:"goarch,omitempty"`
+	// The environment variables set for builds, in KEY=VALUE form.
+	// Values which may be secret are redacted.
+	Environment []string `protobuf:"bytes,4,rep,name=environment,proto3" json:"environment,omitempty"`
+	// Other builder configuration, in "name: value" form.
+	// Values which may be secret are redacted.
+	Config []string `protobuf:"bytes,5,rep,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *GetBuilderEnvironmentResponse) Reset() {
+	*x = GetBuilderEnvironmentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBuilderEnvironmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuilderEnvironmentResponse) ProtoMessage() {}
+
+func (x *GetBuilderEnvironmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,14 +999,42 @@ func (x *ExecuteCommandResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecuteCommandResponse.ProtoReflect.Descriptor instead.
-func (*ExecuteCommandResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetBuilderEnvironmentResponse.ProtoReflect.Descriptor instead.
+func (*GetBuilderEnvironmentResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ExecuteCommandResponse) GetOutput() []byte {
+func (x *GetBuilderEnvironmentResponse) GetBuilderType() string {
 	if x != nil {
-		return x.Output
+		return x.BuilderType
+	}
+	return ""
+}
+
+func (x *GetBuilderEnvironmentResponse) GetGoos() string {
+	if x != nil {
+		return x.Goos
+	}
+	return ""
+}
+
+func (x *GetBuilderEnvironmentResponse) GetGoarch() string {
+	if x != nil {
+		return x.Goarch
+	}
+	return ""
+}
+
+func (x *GetBuilderEnvironmentResponse) GetEnvironment() []string {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+func (x *GetBuilderEnvironmentResponse) GetConfig() []string {
+	if x != nil {
+		return x.Config
 	}
 	return nil
 }
@@ -657,12 +1056,15 @@ type Instance struct {
 	Expires int64 `protobuf:"varint,4,opt,name=expires,proto3" json:"expires,omitempty"`
 	// The working directory of the instance.
 	WorkingDir string `protobuf:"bytes,5,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	// The timestamp for when the instance was created. It is
+	// represented in Unix epoch time format.
+	Created int64 `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
 }
 
 func (x *Instance) Reset() {
 	*x = Instance{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[10]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -675,7 +1077,7 @@ func (x *Instance) String() string {
 func (*Instance) ProtoMessage() {}
 
 func (x *Instance) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[10]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -688,7 +1090,7 @@ func (x *Instance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Instance.ProtoReflect.Descriptor instead.
 func (*Instance) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{10}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Instance) GetGomoteId() string {
@@ -726,6 +1128,13 @@ func (x *Instance) GetWorkingDir() string {
 	return ""
 }
 
+func (x *Instance) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
 // InstanceAliveRequest specifies the data needed to check the liveness of a gomote instance.
 type InstanceAliveRequest struct {
 	state         protoimpl.MessageState
@@ -739,7 +1148,7 @@ type InstanceAliveRequest struct {
 func (x *InstanceAliveRequest) Reset() {
 	*x = InstanceAliveRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[11]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -752,7 +1161,7 @@ func (x *InstanceAliveRequest) String() string {
 func (*InstanceAliveRequest) ProtoMessage() {}
 
 func (x *InstanceAliveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[11]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -765,7 +1174,7 @@ func (x *InstanceAliveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceAliveRequest.ProtoReflect.Descriptor instead.
 func (*InstanceAliveRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{11}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *InstanceAliveRequest) GetGomoteId() string {
@@ -785,7 +1194,7 @@ type InstanceAliveResponse struct {
 func (x *InstanceAliveResponse) Reset() {
 	*x = InstanceAliveResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[12]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -798,7 +1207,7 @@ func (x *InstanceAliveResponse) String() string {
 func (*InstanceAliveResponse) ProtoMessage() {}
 
 func (x *InstanceAliveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[12]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,7 +1220,7 @@ func (x *InstanceAliveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceAliveResponse.ProtoReflect.Descriptor instead.
 func (*InstanceAliveResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{12}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{18}
 }
 
 // ListDirectoryRequest specifies the data needed to list contents of a directory from a gomote instance.
@@ -836,7 +1245,7 @@ type ListDirectoryRequest struct {
 func (x *ListDirectoryRequest) Reset() {
 	*x = ListDirectoryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[13]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -849,7 +1258,7 @@ func (x *ListDirectoryRequest) String() string {
 func (*ListDirectoryRequest) ProtoMessage() {}
 
 func (x *ListDirectoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[13]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -862,7 +1271,7 @@ func (x *ListDirectoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDirectoryRequest.ProtoReflect.Descriptor instead.
 func (*ListDirectoryRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{13}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ListDirectoryRequest) GetGomoteId() string {
@@ -908,12 +1317,14 @@ type ListDirectoryResponse struct {
 
 	// The directory entries.
 	Entries []string `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// The directory entries in structured form, in the same order as entries.
+	Details []*DirectoryEntry `protobuf:"bytes,2,rep,name=details,proto3" json:"details,omitempty"`
 }
 
 func (x *ListDirectoryResponse) Reset() {
 	*x = ListDirectoryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[14]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -926,7 +1337,7 @@ func (x *ListDirectoryResponse) String() string {
 func (*ListDirectoryResponse) ProtoMessage() {}
 
 func (x *ListDirectoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[14]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,7 +1350,7 @@ func (x *ListDirectoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDirectoryResponse.ProtoReflect.Descriptor instead.
 func (*ListDirectoryResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{14}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ListDirectoryResponse) GetEntries() []string {
@@ -949,6 +1360,98 @@ func (x *ListDirectoryResponse) GetEntries() []string {
 	return nil
 }
 
+func (x *ListDirectoryResponse) GetDetails() []*DirectoryEntry {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// DirectoryEntry is the information about a file on a gomote instance.
+type DirectoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The path of the file relative to the listed directory. Directories end in a slash.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The file mode, such as "-rw-r--r--" or "drwxr-xr-x".
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// The size of a regular file in bytes, or -1 for other files.
+	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// The modification time of a regular file in seconds since the Unix epoch, or zero for other files.
+	ModTime int64 `protobuf:"varint,4,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
+	// The SHA-1 digest of a regular file, if requested.
+	Digest string `protobuf:"bytes,5,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *DirectoryEntry) Reset() {
+	*x = DirectoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DirectoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirectoryEntry) ProtoMessage() {}
+
+func (x *DirectoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirectoryEntry.ProtoReflect.Descriptor instead.
+func (*DirectoryEntry) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DirectoryEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DirectoryEntry) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *DirectoryEntry) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DirectoryEntry) GetModTime() int64 {
+	if x != nil {
+		return x.ModTime
+	}
+	return 0
+}
+
+func (x *DirectoryEntry) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
 // ListInstancesRequest specifies the data needed to list the live gomote instances owned by the caller.
 type ListInstancesRequest struct {
 	state         protoimpl.MessageState
@@ -959,7 +1462,7 @@ type ListInstancesRequest struct {
 func (x *ListInstancesRequest) Reset() {
 	*x = ListInstancesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[15]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -972,7 +1475,7 @@ func (x *ListInstancesRequest) String() string {
 func (*ListInstancesRequest) ProtoMessage() {}
 
 func (x *ListInstancesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[15]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -985,7 +1488,7 @@ func (x *ListInstancesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInstancesRequest.ProtoReflect.Descriptor instead.
 func (*ListInstancesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{15}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{22}
 }
 
 // ListInstancesResponse contains the list of live gomote instances owned by the caller.
@@ -1000,7 +1503,7 @@ type ListInstancesResponse struct {
 func (x *ListInstancesResponse) Reset() {
 	*x = ListInstancesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[16]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1013,7 +1516,7 @@ func (x *ListInstancesResponse) String() string {
 func (*ListInstancesResponse) ProtoMessage() {}
 
 func (x *ListInstancesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[16]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1026,7 +1529,7 @@ func (x *ListInstancesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInstancesResponse.ProtoReflect.Descriptor instead.
 func (*ListInstancesResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{16}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListInstancesResponse) GetInstances() []*Instance {
@@ -1046,7 +1549,7 @@ type ListSwarmingBuildersRequest struct {
 func (x *ListSwarmingBuildersRequest) Reset() {
 	*x = ListSwarmingBuildersRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[17]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1059,7 +1562,7 @@ func (x *ListSwarmingBuildersRequest) String() string {
 func (*ListSwarmingBuildersRequest) ProtoMessage() {}
 
 func (x *ListSwarmingBuildersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[17]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1072,7 +1575,7 @@ func (x *ListSwarmingBuildersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSwarmingBuildersRequest.ProtoReflect.Descriptor instead.
 func (*ListSwarmingBuildersRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{17}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{24}
 }
 
 // ListSwarmingBuildersResponse contains a list of swarming builders.
@@ -1087,7 +1590,7 @@ type ListSwarmingBuildersResponse struct {
 func (x *ListSwarmingBuildersResponse) Reset() {
 	*x = ListSwarmingBuildersResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[18]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1100,7 +1603,7 @@ func (x *ListSwarmingBuildersResponse) String() string {
 func (*ListSwarmingBuildersResponse) ProtoMessage() {}
 
 func (x *ListSwarmingBuildersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[18]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1113,7 +1616,7 @@ func (x *ListSwarmingBuildersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSwarmingBuildersResponse.ProtoReflect.Descriptor instead.
 func (*ListSwarmingBuildersResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{18}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ListSwarmingBuildersResponse) GetBuilders() []string {
@@ -1138,7 +1641,7 @@ type ReadTGZToURLRequest struct {
 func (x *ReadTGZToURLRequest) Reset() {
 	*x = ReadTGZToURLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[19]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1151,7 +1654,7 @@ func (x *ReadTGZToURLRequest) String() string {
 func (*ReadTGZToURLRequest) ProtoMessage() {}
 
 func (x *ReadTGZToURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[19]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1164,7 +1667,7 @@ func (x *ReadTGZToURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadTGZToURLRequest.ProtoReflect.Descriptor instead.
 func (*ReadTGZToURLRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{19}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ReadTGZToURLRequest) GetGomoteId() string {
@@ -1194,7 +1697,7 @@ type ReadTGZToURLResponse struct {
 func (x *ReadTGZToURLResponse) Reset() {
 	*x = ReadTGZToURLResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[20]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1207,7 +1710,7 @@ func (x *ReadTGZToURLResponse) String() string {
 func (*ReadTGZToURLResponse) ProtoMessage() {}
 
 func (x *ReadTGZToURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[20]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,7 +1723,7 @@ func (x *ReadTGZToURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadTGZToURLResponse.ProtoReflect.Descriptor instead.
 func (*ReadTGZToURLResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{20}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ReadTGZToURLResponse) GetUrl() string {
@@ -1247,7 +1750,7 @@ type RemoveFilesRequest struct {
 func (x *RemoveFilesRequest) Reset() {
 	*x = RemoveFilesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[21]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1260,7 +1763,7 @@ func (x *RemoveFilesRequest) String() string {
 func (*RemoveFilesRequest) ProtoMessage() {}
 
 func (x *RemoveFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[21]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1273,7 +1776,7 @@ func (x *RemoveFilesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveFilesRequest.ProtoReflect.Descriptor instead.
 func (*RemoveFilesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{21}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *RemoveFilesRequest) GetGomoteId() string {
@@ -1300,7 +1803,7 @@ type RemoveFilesResponse struct {
 func (x *RemoveFilesResponse) Reset() {
 	*x = RemoveFilesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[22]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1313,7 +1816,7 @@ func (x *RemoveFilesResponse) String() string {
 func (*RemoveFilesResponse) ProtoMessage() {}
 
 func (x *RemoveFilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[22]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1326,7 +1829,7 @@ func (x *RemoveFilesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveFilesResponse.ProtoReflect.Descriptor instead.
 func (*RemoveFilesResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{22}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{29}
 }
 
 // SignSSHKeyRequest specifies the data needed to sign a public SSH key which attaches a certificate to the key.
@@ -1344,7 +1847,7 @@ type SignSSHKeyRequest struct {
 func (x *SignSSHKeyRequest) Reset() {
 	*x = SignSSHKeyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[23]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1357,7 +1860,7 @@ func (x *SignSSHKeyRequest) String() string {
 func (*SignSSHKeyRequest) ProtoMessage() {}
 
 func (x *SignSSHKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[23]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1370,7 +1873,7 @@ func (x *SignSSHKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignSSHKeyRequest.ProtoReflect.Descriptor instead.
 func (*SignSSHKeyRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{23}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SignSSHKeyRequest) GetGomoteId() string {
@@ -1401,7 +1904,7 @@ type SignSSHKeyResponse struct {
 func (x *SignSSHKeyResponse) Reset() {
 	*x = SignSSHKeyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[24]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1413,8 +1916,107 @@ func (x *SignSSHKeyResponse) String() string {
 
 func (*SignSSHKeyResponse) ProtoMessage() {}
 
-func (x *SignSSHKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[24]
+func (x *SignSSHKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignSSHKeyResponse.ProtoReflect.Descriptor instead.
+func (*SignSSHKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SignSSHKeyResponse) GetSignedPublicSshKey() []byte {
+	if x != nil {
+		return x.SignedPublicSshKey
+	}
+	return nil
+}
+
+// SnapshotInstanceRequest specifies the data needed to snapshot the go directory of a gomote instance.
+type SnapshotInstanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+}
+
+func (x *SnapshotInstanceRequest) Reset() {
+	*x = SnapshotInstanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotInstanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotInstanceRequest) ProtoMessage() {}
+
+func (x *SnapshotInstanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotInstanceRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotInstanceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SnapshotInstanceRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+// SnapshotInstanceResponse contains the location of a snapshot of the go directory of a gomote instance.
+type SnapshotInstanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// URL of the snapshot. It can be passed to WriteTGZFromURL by the owner of the snapshot.
+	// It can't be read directly.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *SnapshotInstanceResponse) Reset() {
+	*x = SnapshotInstanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotInstanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotInstanceResponse) ProtoMessage() {}
+
+func (x *SnapshotInstanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1425,16 +2027,16 @@ func (x *SignSSHKeyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignSSHKeyResponse.ProtoReflect.Descriptor instead.
-func (*SignSSHKeyResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use SnapshotInstanceResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotInstanceResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *SignSSHKeyResponse) GetSignedPublicSshKey() []byte {
+func (x *SnapshotInstanceResponse) GetUrl() string {
 	if x != nil {
-		return x.SignedPublicSshKey
+		return x.Url
 	}
-	return nil
+	return ""
 }
 
 // UploadFileRequest specifies the data needed to create a request to upload an object to GCS.
@@ -1447,7 +2049,7 @@ type UploadFileRequest struct {
 func (x *UploadFileRequest) Reset() {
 	*x = UploadFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[25]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1460,7 +2062,7 @@ func (x *UploadFileRequest) String() string {
 func (*UploadFileRequest) ProtoMessage() {}
 
 func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[25]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1473,7 +2075,7 @@ func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
 func (*UploadFileRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{25}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{34}
 }
 
 // UploadFileResponse contains the results from a request to upload an object to GCS.
@@ -1493,7 +2095,7 @@ type UploadFileResponse struct {
 func (x *UploadFileResponse) Reset() {
 	*x = UploadFileResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[26]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1506,7 +2108,7 @@ func (x *UploadFileResponse) String() string {
 func (*UploadFileResponse) ProtoMessage() {}
 
 func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[26]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1519,7 +2121,7 @@ func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
 func (*UploadFileResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{26}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *UploadFileResponse) GetUrl() string {
@@ -1558,12 +2160,20 @@ type WriteFileFromURLRequest struct {
 	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
 	// The file mode.
 	Mode uint32 `protobuf:"fixed32,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	// The maximum number of attempts at reading the URL. Each retry resumes
+	// from the last byte read, using an HTTP Range request for URLs outside
+	// the gomote transfer bucket. Zero means a single attempt. At most 10
+	// attempts are made.
+	MaxAttempts int32 `protobuf:"varint,5,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// How long to wait before the first retry, in milliseconds. The wait
+	// doubles after each retry, up to one minute. Zero means one second.
+	InitialBackoffMs int64 `protobuf:"varint,6,opt,name=initial_backoff_ms,json=initialBackoffMs,proto3" json:"initial_backoff_ms,omitempty"`
 }
 
 func (x *WriteFileFromURLRequest) Reset() {
 	*x = WriteFileFromURLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[27]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1576,7 +2186,7 @@ func (x *WriteFileFromURLRequest) String() string {
 func (*WriteFileFromURLRequest) ProtoMessage() {}
 
 func (x *WriteFileFromURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[27]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1589,7 +2199,7 @@ func (x *WriteFileFromURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteFileFromURLRequest.ProtoReflect.Descriptor instead.
 func (*WriteFileFromURLRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{27}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *WriteFileFromURLRequest) GetGomoteId() string {
@@ -1620,17 +2230,34 @@ func (x *WriteFileFromURLRequest) GetMode() uint32 {
 	return 0
 }
 
+func (x *WriteFileFromURLRequest) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *WriteFileFromURLRequest) GetInitialBackoffMs() int64 {
+	if x != nil {
+		return x.InitialBackoffMs
+	}
+	return 0
+}
+
 // WriteFileFromURLResponse contains the results from requesting that a file be downloaded onto a gomote instance.
 type WriteFileFromURLResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The number of attempts it took to read the URL.
+	Attempts int32 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
 }
 
 func (x *WriteFileFromURLResponse) Reset() {
 	*x = WriteFileFromURLResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[28]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1643,7 +2270,7 @@ func (x *WriteFileFromURLResponse) String() string {
 func (*WriteFileFromURLResponse) ProtoMessage() {}
 
 func (x *WriteFileFromURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[28]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1656,7 +2283,14 @@ func (x *WriteFileFromURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteFileFromURLResponse.ProtoReflect.Descriptor instead.
 func (*WriteFileFromURLResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{28}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *WriteFileFromURLResponse) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
 }
 
 // WriteTGZFromURLRequest specifies the data needed to retrieve a file and expand it onto the file system of a gomote instance.
@@ -1677,7 +2311,7 @@ type WriteTGZFromURLRequest struct {
 func (x *WriteTGZFromURLRequest) Reset() {
 	*x = WriteTGZFromURLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[29]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1690,7 +2324,7 @@ func (x *WriteTGZFromURLRequest) String() string {
 func (*WriteTGZFromURLRequest) ProtoMessage() {}
 
 func (x *WriteTGZFromURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[29]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1703,7 +2337,7 @@ func (x *WriteTGZFromURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteTGZFromURLRequest.ProtoReflect.Descriptor instead.
 func (*WriteTGZFromURLRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{29}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *WriteTGZFromURLRequest) GetGomoteId() string {
@@ -1737,7 +2371,7 @@ type WriteTGZFromURLResponse struct {
 func (x *WriteTGZFromURLResponse) Reset() {
 	*x = WriteTGZFromURLResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[30]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1750,7 +2384,7 @@ func (x *WriteTGZFromURLResponse) String() string {
 func (*WriteTGZFromURLResponse) ProtoMessage() {}
 
 func (x *WriteTGZFromURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[30]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1763,7 +2397,71 @@ func (x *WriteTGZFromURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteTGZFromURLResponse.ProtoReflect.Descriptor instead.
 func (*WriteTGZFromURLResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{30}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{39}
+}
+
+type CreateInstancesRequest_Builder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuilderType string `protobuf:"bytes,1,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
+	// The number of instances of the builder type to create. Zero means one.
+	Count            int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	ExperimentOption []string `protobuf:"bytes,3,rep,name=experiment_option,json=experimentOption,proto3" json:"experiment_option,omitempty"`
+}
+
+func (x *CreateInstancesRequest_Builder) Reset() {
+	*x = CreateInstancesRequest_Builder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInstancesRequest_Builder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInstancesRequest_Builder) ProtoMessage() {}
+
+func (x *CreateInstancesRequest_Builder) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInstancesRequest_Builder.ProtoReflect.Descriptor instead.
+func (*CreateInstancesRequest_Builder) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *CreateInstancesRequest_Builder) GetBuilderType() string {
+	if x != nil {
+		return x.BuilderType
+	}
+	return ""
+}
+
+func (x *CreateInstancesRequest_Builder) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CreateInstancesRequest_Builder) GetExperimentOption() []string {
+	if x != nil {
+		return x.ExperimentOption
+	}
+	return nil
 }
 
 var File_internal_gomote_protos_gomote_proto protoreflect.FileDescriptor
@@ -1782,119 +2480,191 @@ var file_internal_gomote_protos_gomote_proto_rawDesc = []byte{
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x6f, 0x6f, 0x74,
 	0x73, 0x74, 0x72, 0x61, 0x70, 0x5f, 0x67, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x0e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x47, 0x6f, 0x55,
-	0x72, 0x6c, 0x22, 0x67, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x72, 0x6c, 0x22, 0x7d, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74,
 	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x62,
 	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2b,
 	0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6f, 0x70, 0x74,
 	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x72,
-	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xdc, 0x01, 0x0a, 0x16,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x61, 0x69, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x61,
-	0x68, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x77, 0x61, 0x69, 0x74,
-	0x65, 0x72, 0x73, 0x41, 0x68, 0x65, 0x61, 0x64, 0x22, 0x30, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
-	0x0b, 0x0a, 0x07, 0x57, 0x41, 0x49, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
-	0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x35, 0x0a, 0x16, 0x44, 0x65,
-	0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49,
-	0x64, 0x22, 0x19, 0x0a, 0x17, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa8, 0x02, 0x0a,
-	0x15, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x21, 0x0a,
-	0x0c, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4c, 0x65, 0x76, 0x65, 0x6c,
-	0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64,
-	0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x11, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f,
-	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69,
-	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18,
-	0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x69,
-	0x6d, 0x69, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6d, 0x69, 0x74, 0x61, 0x74, 0x65, 0x48,
-	0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x30, 0x0a, 0x16, 0x45, 0x78, 0x65, 0x63, 0x75,
-	0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0xa2, 0x01, 0x0a, 0x08, 0x49, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x1f, 0x0a,
-	0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x22, 0x33,
-	0x0a, 0x14, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x22, 0xdc, 0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x61, 0x69,
+	0x74, 0x65, 0x72, 0x73, 0x5f, 0x61, 0x68, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x77, 0x61, 0x69, 0x74, 0x65, 0x72, 0x73, 0x41, 0x68, 0x65, 0x61, 0x64, 0x22, 0x30,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x49, 0x54, 0x49, 0x4e, 0x47,
+	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02,
+	0x22, 0xe8, 0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x08, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x1a, 0x6f, 0x0a, 0x07, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b,
+	0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xbb, 0x01, 0x0a, 0x17,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x35, 0x0a, 0x16, 0x44, 0x65, 0x73,
+	0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64,
+	0x22, 0x19, 0x0a, 0x17, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa8, 0x02, 0x0a, 0x15,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x5f,
+	0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x11, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6d,
+	0x69, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6d, 0x69, 0x74, 0x61, 0x74, 0x65, 0x48, 0x6f,
+	0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x30, 0x0a, 0x16, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x6b, 0x0a, 0x1a, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41,
-	0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa6, 0x01, 0x0a,
-	0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x65, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x12, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x37, 0x0a, 0x1b, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x22, 0x3b,
+	0x0a, 0x1c, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x69,
+	0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x22, 0xa8, 0x01, 0x0a, 0x1d,
+	0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x67, 0x6f, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x67, 0x6f, 0x6f, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x6f, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x6f, 0x61, 0x72, 0x63, 0x68, 0x12, 0x20, 0x0a, 0x0b,
+	0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xbc, 0x01, 0x0a, 0x08, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f,
+	0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0x33, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0xa6, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72,
+	0x73, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x75,
+	0x72, 0x73, 0x69, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x63, 0x0a, 0x15,
+	0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x30, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x22, 0x7f, 0x0a, 0x0e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6d, 0x6f, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x47, 0x0a, 0x15, 0x4c, 0x69,
+	0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x22, 0x1d, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x72, 0x6d,
+	0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x3a, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x72, 0x6d, 0x69,
+	0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x22, 0x50,
+	0x0a, 0x13, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
 	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
 	0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x12, 0x1d,
-	0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x47, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x1d, 0x0a, 0x1b, 0x4c, 0x69, 0x73,
-	0x74, 0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3a, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74,
-	0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x65, 0x72, 0x73, 0x22, 0x50, 0x0a, 0x13, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54,
-	0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67,
-	0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x28, 0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47,
-	0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
-	0x22, 0x47, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52,
+	0x22, 0x28, 0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x47, 0x0a, 0x12, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x56, 0x0a, 0x11, 0x53, 0x69,
+	0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x73, 0x68, 0x4b,
+	0x65, 0x79, 0x22, 0x47, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x22, 0x36, 0x0a, 0x17, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x56, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x73, 0x73, 0x68,
-	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x53, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x22, 0x47, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e,
-	0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
-	0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
-	0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x73,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x73, 0x68, 0x4b, 0x65,
-	0x79, 0x22, 0x13, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x18, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x22, 0x13, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc2, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61,
 	0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a,
 	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
@@ -1907,51 +2677,76 @@ var file_internal_gomote_protos_gomote_proto_rawDesc = []byte{
 	0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
 	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
 	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x78, 0x0a, 0x17, 0x57,
-	0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x07, 0x52,
-	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69,
-	0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x65, 0x0a, 0x16, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f,
-	0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67,
-	0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69,
-	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64,
-	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x19, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x32, 0xad, 0x0a, 0x0a, 0x0d, 0x47, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
-	0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41,
-	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68,
-	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72,
-	0x61, 0x70, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x42,
-	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f, 0x74,
-	0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x53, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x0f, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0e, 0x45, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1d, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
-	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc9, 0x01, 0x0a, 0x17,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x07,
+	0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61,
+	0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x6d, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61,
+	0x63, 0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x73, 0x22, 0x36, 0x0a, 0x18, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x22,
+	0x65, 0x0a, 0x16, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55,
+	0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d,
+	0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f,
+	0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x19, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54,
+	0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x32, 0xa8, 0x0d, 0x0a, 0x0d, 0x47, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a,
+	0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x56, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x0f, 0x44, 0x65,
+	0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x53, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x60, 0x0a, 0x13, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x22, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x66, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
 	0x4e, 0x0a, 0x0d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65,
 	0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
 	0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
@@ -1992,26 +2787,32 @@ var file_internal_gomote_protos_gomote_proto_rawDesc = []byte{
 	0x48, 0x4b, 0x65, 0x79, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x69,
 	0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
 	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53, 0x48,
-	0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a,
-	0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c,
-	0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55,
-	0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d,
-	0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a,
-	0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c,
-	0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54,
-	0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54,
-	0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2e, 0x6f, 0x72,
-	0x67, 0x2f, 0x78, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x2f, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a,
+	0x10, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a,
+	0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52,
+	0x4c, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54,
+	0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55,
+	0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55,
+	0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29,
+	0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x78, 0x2f, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -2027,84 +2828,105 @@ func file_internal_gomote_protos_gomote_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_gomote_protos_gomote_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_gomote_protos_gomote_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_internal_gomote_protos_gomote_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_internal_gomote_protos_gomote_proto_goTypes = []interface{}{
-	(CreateInstanceResponse_Status)(0),   // 0: protos.CreateInstanceResponse.Status
-	(*AuthenticateRequest)(nil),          // 1: protos.AuthenticateRequest
-	(*AuthenticateResponse)(nil),         // 2: protos.AuthenticateResponse
-	(*AddBootstrapRequest)(nil),          // 3: protos.AddBootstrapRequest
-	(*AddBootstrapResponse)(nil),         // 4: protos.AddBootstrapResponse
-	(*CreateInstanceRequest)(nil),        // 5: protos.CreateInstanceRequest
-	(*CreateInstanceResponse)(nil),       // 6: protos.CreateInstanceResponse
-	(*DestroyInstanceRequest)(nil),       // 7: protos.DestroyInstanceRequest
-	(*DestroyInstanceResponse)(nil),      // 8: protos.DestroyInstanceResponse
-	(*ExecuteCommandRequest)(nil),        // 9: protos.ExecuteCommandRequest
-	(*ExecuteCommandResponse)(nil),       // 10: protos.ExecuteCommandResponse
-	(*Instance)(nil),                     // 11: protos.Instance
-	(*InstanceAliveRequest)(nil),         // 12: protos.InstanceAliveRequest
-	(*InstanceAliveResponse)(nil),        // 13: protos.InstanceAliveResponse
-	(*ListDirectoryRequest)(nil),         // 14: protos.ListDirectoryRequest
-	(*ListDirectoryResponse)(nil),        // 15: protos.ListDirectoryResponse
-	(*ListInstancesRequest)(nil),         // 16: protos.ListInstancesRequest
-	(*ListInstancesResponse)(nil),        // 17: protos.ListInstancesResponse
-	(*ListSwarmingBuildersRequest)(nil),  // 18: protos.ListSwarmingBuildersRequest
-	(*ListSwarmingBuildersResponse)(nil), // 19: protos.ListSwarmingBuildersResponse
-	(*ReadTGZToURLRequest)(nil),          // 20: protos.ReadTGZToURLRequest
-	(*ReadTGZToURLResponse)(nil),         // 21: protos.ReadTGZToURLResponse
-	(*RemoveFilesRequest)(nil),           // 22: protos.RemoveFilesRequest
-	(*RemoveFilesResponse)(nil),          // 23: protos.RemoveFilesResponse
-	(*SignSSHKeyRequest)(nil),            // 24: protos.SignSSHKeyRequest
-	(*SignSSHKeyResponse)(nil),           // 25: protos.SignSSHKeyResponse
-	(*UploadFileRequest)(nil),            // 26: protos.UploadFileRequest
-	(*UploadFileResponse)(nil),           // 27: protos.UploadFileResponse
-	(*WriteFileFromURLRequest)(nil),      // 28: protos.WriteFileFromURLRequest
-	(*WriteFileFromURLResponse)(nil),     // 29: protos.WriteFileFromURLResponse
-	(*WriteTGZFromURLRequest)(nil),       // 30: protos.WriteTGZFromURLRequest
-	(*WriteTGZFromURLResponse)(nil),      // 31: protos.WriteTGZFromURLResponse
-	nil,                                  // 32: protos.UploadFileResponse.FieldsEntry
+	(CreateInstanceResponse_Status)(0),     // 0: protos.CreateInstanceResponse.Status
+	(*AuthenticateRequest)(nil),            // 1: protos.AuthenticateRequest
+	(*AuthenticateResponse)(nil),           // 2: protos.AuthenticateResponse
+	(*AddBootstrapRequest)(nil),            // 3: protos.AddBootstrapRequest
+	(*AddBootstrapResponse)(nil),           // 4: protos.AddBootstrapResponse
+	(*CreateInstanceRequest)(nil),          // 5: protos.CreateInstanceRequest
+	(*CreateInstanceResponse)(nil),         // 6: protos.CreateInstanceResponse
+	(*CreateInstancesRequest)(nil),         // 7: protos.CreateInstancesRequest
+	(*CreateInstancesResponse)(nil),        // 8: protos.CreateInstancesResponse
+	(*DestroyInstanceRequest)(nil),         // 9: protos.DestroyInstanceRequest
+	(*DestroyInstanceResponse)(nil),        // 10: protos.DestroyInstanceResponse
+	(*ExecuteCommandRequest)(nil),          // 11: protos.ExecuteCommandRequest
+	(*ExecuteCommandResponse)(nil),         // 12: protos.ExecuteCommandResponse
+	(*ExtendInstanceLeaseRequest)(nil),     // 13: protos.ExtendInstanceLeaseRequest
+	(*ExtendInstanceLeaseResponse)(nil),    // 14: protos.ExtendInstanceLeaseResponse
+	(*GetBuilderEnvironmentRequest)(nil),   // 15: protos.GetBuilderEnvironmentRequest
+	(*GetBuilderEnvironmentResponse)(nil),  // 16: protos.GetBuilderEnvironmentResponse
+	(*Instance)(nil),                       // 17: protos.Instance
+	(*InstanceAliveRequest)(nil),           // 18: protos.InstanceAliveRequest
+	(*InstanceAliveResponse)(nil),          // 19: protos.InstanceAliveResponse
+	(*ListDirectoryRequest)(nil),           // 20: protos.ListDirectoryRequest
+	(*ListDirectoryResponse)(nil),          // 21: protos.ListDirectoryResponse
+	(*DirectoryEntry)(nil),                 // 22: protos.DirectoryEntry
+	(*ListInstancesRequest)(nil),           // 23: protos.ListInstancesRequest
+	(*ListInstancesResponse)(nil),          // 24: protos.ListInstancesResponse
+	(*ListSwarmingBuildersRequest)(nil),    // 25: protos.ListSwarmingBuildersRequest
+	(*ListSwarmingBuildersResponse)(nil),   // 26: protos.ListSwarmingBuildersResponse
+	(*ReadTGZToURLRequest)(nil),            // 27: protos.ReadTGZToURLRequest
+	(*ReadTGZToURLResponse)(nil),           // 28: protos.ReadTGZToURLResponse
+	(*RemoveFilesRequest)(nil),             // 29: protos.RemoveFilesRequest
+	(*RemoveFilesResponse)(nil),            // 30: protos.RemoveFilesResponse
+	(*SignSSHKeyRequest)(nil),              // 31: protos.SignSSHKeyRequest
+	(*SignSSHKeyResponse)(nil),             // 32: protos.SignSSHKeyResponse
+	(*SnapshotInstanceRequest)(nil),        // 33: protos.SnapshotInstanceRequest
+	(*SnapshotInstanceResponse)(nil),       // 34: protos.SnapshotInstanceResponse
+	(*UploadFileRequest)(nil),              // 35: protos.UploadFileRequest
+	(*UploadFileResponse)(nil),             // 36: protos.UploadFileResponse
+	(*WriteFileFromURLRequest)(nil),        // 37: protos.WriteFileFromURLRequest
+	(*WriteFileFromURLResponse)(nil),       // 38: protos.WriteFileFromURLResponse
+	(*WriteTGZFromURLRequest)(nil),         // 39: protos.WriteTGZFromURLRequest
+	(*WriteTGZFromURLResponse)(nil),        // 40: protos.WriteTGZFromURLResponse
+	(*CreateInstancesRequest_Builder)(nil), // 41: protos.CreateInstancesRequest.Builder
+	nil,                                    // 42: protos.UploadFileResponse.FieldsEntry
 }
 var file_internal_gomote_protos_gomote_proto_depIdxs = []int32{
-	11, // 0: protos.CreateInstanceResponse.instance:type_name -> protos.Instance
+	17, // 0: protos.CreateInstanceResponse.instance:type_name -> protos.Instance
 	0,  // 1: protos.CreateInstanceResponse.status:type_name -> protos.CreateInstanceResponse.Status
-	11, // 2: protos.ListInstancesResponse.instances:type_name -> protos.Instance
-	32, // 3: protos.UploadFileResponse.fields:type_name -> protos.UploadFileResponse.FieldsEntry
-	1,  // 4: protos.GomoteService.Authenticate:input_type -> protos.AuthenticateRequest
-	3,  // 5: protos.GomoteService.AddBootstrap:input_type -> protos.AddBootstrapRequest
-	5,  // 6: protos.GomoteService.CreateInstance:input_type -> protos.CreateInstanceRequest
-	7,  // 7: protos.GomoteService.DestroyInstance:input_type -> protos.DestroyInstanceRequest
-	9,  // 8: protos.GomoteService.ExecuteCommand:input_type -> protos.ExecuteCommandRequest
-	12, // 9: protos.GomoteService.InstanceAlive:input_type -> protos.InstanceAliveRequest
-	14, // 10: protos.GomoteService.ListDirectory:input_type -> protos.ListDirectoryRequest
-	14, // 11: protos.GomoteService.ListDirectoryStreaming:input_type -> protos.ListDirectoryRequest
-	16, // 12: protos.GomoteService.ListInstances:input_type -> protos.ListInstancesRequest
-	18, // 13: protos.GomoteService.ListSwarmingBuilders:input_type -> protos.ListSwarmingBuildersRequest
-	20, // 14: protos.GomoteService.ReadTGZToURL:input_type -> protos.ReadTGZToURLRequest
-	22, // 15: protos.GomoteService.RemoveFiles:input_type -> protos.RemoveFilesRequest
-	24, // 16: protos.GomoteService.SignSSHKey:input_type -> protos.SignSSHKeyRequest
-	26, // 17: protos.GomoteService.UploadFile:input_type -> protos.UploadFileRequest
-	28, // 18: protos.GomoteService.WriteFileFromURL:input_type -> protos.WriteFileFromURLRequest
-	30, // 19: protos.GomoteService.WriteTGZFromURL:input_type -> protos.WriteTGZFromURLRequest
-	2,  // 20: protos.GomoteService.Authenticate:output_type -> protos.AuthenticateResponse
-	4,  // 21: protos.GomoteService.AddBootstrap:output_type -> protos.AddBootstrapResponse
-	6,  // 22: protos.GomoteService.CreateInstance:output_type -> protos.CreateInstanceResponse
-	8,  // 23: protos.GomoteService.DestroyInstance:output_type -> protos.DestroyInstanceResponse
-	10, // 24: protos.GomoteService.ExecuteCommand:output_type -> protos.ExecuteCommandResponse
-	13, // 25: protos.GomoteService.InstanceAlive:output_type -> protos.InstanceAliveResponse
-	15, // 26: protos.GomoteService.ListDirectory:output_type -> protos.ListDirectoryResponse
-	15, // 27: protos.GomoteService.ListDirectoryStreaming:output_type -> protos.ListDirectoryResponse
-	17, // 28: protos.GomoteService.ListInstances:output_type -> protos.ListInstancesResponse
-	19, // 29: protos.GomoteService.ListSwarmingBuilders:output_type -> protos.ListSwarmingBuildersResponse
-	21, // 30: protos.GomoteService.ReadTGZToURL:output_type -> protos.ReadTGZToURLResponse
-	23, // 31: protos.GomoteService.RemoveFiles:output_type -> protos.RemoveFilesResponse
-	25, // 32: protos.GomoteService.SignSSHKey:output_type -> protos.SignSSHKeyResponse
-	27, // 33: protos.GomoteService.UploadFile:output_type -> protos.UploadFileResponse
-	29, // 34: protos.GomoteService.WriteFileFromURL:output_type -> protos.WriteFileFromURLResponse
-	31, // 35: protos.GomoteService.WriteTGZFromURL:output_type -> protos.WriteTGZFromURLResponse
-	20, // [20:36] is the sub-list for method output_type
-	4,  // [4:20] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	41, // 2: protos.CreateInstancesRequest.builders:type_name -> protos.CreateInstancesRequest.Builder
+	6,  // 3: protos.CreateInstancesResponse.update:type_name -> protos.CreateInstanceResponse
+	22, // 4: protos.ListDirectoryResponse.details:type_name -> protos.DirectoryEntry
+	17, // 5: protos.ListInstancesResponse.instances:type_name -> protos.Instance
+	42, // 6: protos.UploadFileResponse.fields:type_name -> protos.UploadFileResponse.FieldsEntry
+	1,  // 7: protos.GomoteService.Authenticate:input_type -> protos.AuthenticateRequest
+	3,  // 8: protos.GomoteService.AddBootstrap:input_type -> protos.AddBootstrapRequest
+	5,  // 9: protos.GomoteService.CreateInstance:input_type -> protos.CreateInstanceRequest
+	7,  // 10: protos.GomoteService.CreateInstances:input_type -> protos.CreateInstancesRequest
+	9,  // 11: protos.GomoteService.DestroyInstance:input_type -> protos.DestroyInstanceRequest
+	11, // 12: protos.GomoteService.ExecuteCommand:input_type -> protos.ExecuteCommandRequest
+	13, // 13: protos.GomoteService.ExtendInstanceLease:input_type -> protos.ExtendInstanceLeaseRequest
+	15, // 14: protos.GomoteService.GetBuilderEnvironment:input_type -> protos.GetBuilderEnvironmentRequest
+	18, // 15: protos.GomoteService.InstanceAlive:input_type -> protos.InstanceAliveRequest
+	20, // 16: protos.GomoteService.ListDirectory:input_type -> protos.ListDirectoryRequest
+	20, // 17: protos.GomoteService.ListDirectoryStreaming:input_type -> protos.ListDirectoryRequest
+	23, // 18: protos.GomoteService.ListInstances:input_type -> protos.ListInstancesRequest
+	25, // 19: protos.GomoteService.ListSwarmingBuilders:input_type -> protos.ListSwarmingBuildersRequest
+	27, // 20: protos.GomoteService.ReadTGZToURL:input_type -> protos.ReadTGZToURLRequest
+	29, // 21: protos.GomoteService.RemoveFiles:input_type -> protos.RemoveFilesRequest
+	31, // 22: protos.GomoteService.SignSSHKey:input_type -> protos.SignSSHKeyRequest
+	33, // 23: protos.GomoteService.SnapshotInstance:input_type -> protos.SnapshotInstanceRequest
+	35, // 24: protos.GomoteService.UploadFile:input_type -> protos.UploadFileRequest
+	37, // 25: protos.GomoteService.WriteFileFromURL:input_type -> protos.WriteFileFromURLRequest
+	39, // 26: protos.GomoteService.WriteTGZFromURL:input_type -> protos.WriteTGZFromURLRequest
+	2,  // 27: protos.GomoteService.Authenticate:output_type -> protos.AuthenticateResponse
+	4,  // 28: protos.GomoteService.AddBootstrap:output_type -> protos.AddBootstrapResponse
+	6,  // 29: protos.GomoteService.CreateInstance:output_type -> protos.CreateInstanceResponse
+	8,  // 30: protos.GomoteService.CreateInstances:output_type -> protos.CreateInstancesResponse
+	10, // 31: protos.GomoteService.DestroyInstance:output_type -> protos.DestroyInstanceResponse
+	12, // 32: protos.GomoteService.ExecuteCommand:output_type -> protos.ExecuteCommandResponse
+	14, // 33: protos.GomoteService.ExtendInstanceLease:output_type -> protos.ExtendInstanceLeaseResponse
+	16, // 34: protos.GomoteService.GetBuilderEnvironment:output_type -> protos.GetBuilderEnvironmentResponse
+	19, // 35: protos.GomoteService.InstanceAlive:output_type -> protos.InstanceAliveResponse
+	21, // 36: protos.GomoteService.ListDirectory:output_type -> protos.ListDirectoryResponse
+	21, // 37: protos.GomoteService.ListDirectoryStreaming:output_type -> protos.ListDirectoryResponse
+	24, // 38: protos.GomoteService.ListInstances:output_type -> protos.ListInstancesResponse
+	26, // 39: protos.GomoteService.ListSwarmingBuilders:output_type -> protos.ListSwarmingBuildersResponse
+	28, // 40: protos.GomoteService.ReadTGZToURL:output_type -> protos.ReadTGZToURLResponse
+	30, // 41: protos.GomoteService.RemoveFiles:output_type -> protos.RemoveFilesResponse
+	32, // 42: protos.GomoteService.SignSSHKey:output_type -> protos.SignSSHKeyResponse
+	34, // 43: protos.GomoteService.SnapshotInstance:output_type -> protos.SnapshotInstanceResponse
+	36, // 44: protos.GomoteService.UploadFile:output_type -> protos.UploadFileResponse
+	38, // 45: protos.GomoteService.WriteFileFromURL:output_type -> protos.WriteFileFromURLResponse
+	40, // 46: protos.GomoteService.WriteTGZFromURL:output_type -> protos.WriteTGZFromURLResponse
+	27, // [27:47] is the sub-list for method output_type
+	7,  // [7:27] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_internal_gomote_protos_gomote_proto_init() }
@@ -2186,7 +3008,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestroyInstanceRequest); i {
+			switch v := v.(*CreateInstancesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2198,7 +3020,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestroyInstanceResponse); i {
+			switch v := v.(*CreateInstancesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2210,7 +3032,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecuteCommandRequest); i {
+			switch v := v.(*DestroyInstanceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2222,7 +3044,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecuteCommandResponse); i {
+			switch v := v.(*DestroyInstanceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2234,7 +3056,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Instance); i {
+			switch v := v.(*ExecuteCommandRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2246,7 +3068,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InstanceAliveRequest); i {
+			switch v := v.(*ExecuteCommandResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2258,7 +3080,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InstanceAliveResponse); i {
+			switch v := v.(*ExtendInstanceLeaseRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2270,7 +3092,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListDirectoryRequest); i {
+			switch v := v.(*ExtendInstanceLeaseResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2282,7 +3104,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListDirectoryResponse); i {
+			switch v := v.(*GetBuilderEnvironmentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2294,7 +3116,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListInstancesRequest); i {
+			switch v := v.(*GetBuilderEnvironmentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2306,7 +3128,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListInstancesResponse); i {
+			switch v := v.(*Instance); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2318,7 +3140,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListSwarmingBuildersRequest); i {
+			switch v := v.(*InstanceAliveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2330,7 +3152,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListSwarmingBuildersResponse); i {
+			switch v := v.(*InstanceAliveResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2342,7 +3164,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadTGZToURLRequest); i {
+			switch v := v.(*ListDirectoryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2354,7 +3176,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadTGZToURLResponse); i {
+			switch v := v.(*ListDirectoryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2366,7 +3188,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveFilesRequest); i {
+			switch v := v.(*DirectoryEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2378,7 +3200,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveFilesResponse); i {
+			switch v := v.(*ListInstancesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2390,7 +3212,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignSSHKeyRequest); i {
+			switch v := v.(*ListInstancesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2402,7 +3224,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignSSHKeyResponse); i {
+			switch v := v.(*ListSwarmingBuildersRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2414,7 +3236,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadFileRequest); i {
+			switch v := v.(*ListSwarmingBuildersResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2426,7 +3248,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadFileResponse); i {
+			switch v := v.(*ReadTGZToURLRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2438,7 +3260,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteFileFromURLRequest); i {
+			switch v := v.(*ReadTGZToURLResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2450,7 +3272,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteFileFromURLResponse); i {
+			switch v := v.(*RemoveFilesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2462,7 +3284,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteTGZFromURLRequest); i {
+			switch v := v.(*RemoveFilesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2474,6 +3296,114 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			}
 		}
 		file_internal_gomote_protos_gomote_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignSSHKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignSSHKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotInstanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotInstanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadFileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadFileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteFileFromURLRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteFileFromURLResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteTGZFromURLRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteTGZFromURLResponse); i {
 			case 0:
 				return &v.state
@@ -2485,6 +3415,18 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateInstancesRequest_Builder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2492,7 +3434,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_gomote_protos_gomote_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   32,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,8 +26,11 @@ const (
 	GomoteService_Authenticate_FullMethodName           = "/protos.GomoteService/Authenticate"
 	GomoteService_AddBootstrap_FullMethodName           = "/protos.GomoteService/AddBootstrap"
 	GomoteService_CreateInstance_FullMethodName         = "/protos.GomoteService/CreateInstance"
+	GomoteService_CreateInstances_FullMethodName        = "/protos.GomoteService/CreateInstances"
 	GomoteService_DestroyInstance_FullMethodName        = "/protos.GomoteService/DestroyInstance"
 	GomoteService_ExecuteCommand_FullMethodName         = "/protos.GomoteService/ExecuteCommand"
+	GomoteService_ExtendInstanceLease_FullMethodName    = "/protos.GomoteService/ExtendInstanceLease"
+	GomoteService_GetBuilderEnvironment_FullMethodName  = "/protos.GomoteService/GetBuilderEnvironment"
 	GomoteService_InstanceAlive_FullMethodName          = "/protos.GomoteService/InstanceAlive"
 	GomoteService_ListDirectory_FullMethodName          = "/protos.GomoteService/ListDirectory"
 	GomoteService_ListDirectoryStreaming_FullMethodName = "/protos.GomoteService/ListDirectoryStreaming"
@@ -36,6 +39,7 @@ const (
 	GomoteService_ReadTGZToURL_FullMethodName           = "/protos.GomoteService/ReadTGZToURL"
 	GomoteService_RemoveFiles_FullMethodName            = "/protos.GomoteService/RemoveFiles"
 	GomoteService_SignSSHKey_FullMethodName             = "/protos.GomoteService/SignSSHKey"
+	GomoteService_SnapshotInstance_FullMethodName       = "/protos.GomoteService/SnapshotInstance"
 	GomoteService_UploadFile_FullMethodName             = "/protos.GomoteService/UploadFile"
 	GomoteService_WriteFileFromURL_FullMethodName       = "/protos.GomoteService/WriteFileFromURL"
 	GomoteService_WriteTGZFromURL_FullMethodName        = "/protos.GomoteService/WriteTGZFromURL"
@@ -53,10 +57,18 @@ type GomoteServiceClient interface {
 	AddBootstrap(ctx context.Context, in *AddBootstrapRequest, opts ...grpc.CallOption) (*AddBootstrapResponse, error)
 	// CreateInstance creates a gomote instance.
 	CreateInstance(ctx context.Context, in *CreateInstanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CreateInstanceResponse], error)
+	// CreateInstances creates gomote instances of one or more builder types
+	// concurrently, streaming status updates for each of them.
+	CreateInstances(ctx context.Context, in *CreateInstancesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CreateInstancesResponse], error)
 	// DestroyInstance destroys a gomote instance.
 	DestroyInstance(ctx context.Context, in *DestroyInstanceRequest, opts ...grpc.CallOption) (*DestroyInstanceResponse, error)
 	// ExecuteCommand executes a command on the gomote instance.
 	ExecuteCommand(ctx context.Context, in *ExecuteCommandRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteCommandResponse], error)
+	// ExtendInstanceLease extends how long a gomote instance may sit idle before it's destroyed.
+	ExtendInstanceLease(ctx context.Context, in *ExtendInstanceLeaseRequest, opts ...grpc.CallOption) (*ExtendInstanceLeaseResponse, error)
+	// GetBuilderEnvironment returns the environment and configuration of the builder a gomote instance
+	// was created for, so that its builds can be reproduced.
+	GetBuilderEnvironment(ctx context.Context, in *GetBuilderEnvironmentRequest, opts ...grpc.CallOption) (*GetBuilderEnvironmentResponse, error)
 	// InstanceAlive gives the liveness state of a gomote instance.
 	InstanceAlive(ctx context.Context, in *InstanceAliveRequest, opts ...grpc.CallOption) (*InstanceAliveResponse, error)
 	// ListDirectory lists the contents of a directory on an gomote instance.
@@ -74,6 +86,9 @@ type GomoteServiceClient interface {
 	RemoveFiles(ctx context.Context, in *RemoveFilesRequest, opts ...grpc.CallOption) (*RemoveFilesResponse, error)
 	// SignSSHKey signs an SSH public key which can be used to SSH into instances owned by the caller.
 	SignSSHKey(ctx context.Context, in *SignSSHKeyRequest, opts ...grpc.CallOption) (*SignSSHKeyResponse, error)
+	// SnapshotInstance tars and zips the go directory of a gomote instance and saves it for later use. The snapshot
+	// can be expanded onto any instance owned by the caller with WriteTGZFromURL.
+	SnapshotInstance(ctx context.Context, in *SnapshotInstanceRequest, opts ...grpc.CallOption) (*SnapshotInstanceResponse, error)
 	// UploadFile generates a signed URL and associated fields to be used when uploading the object to GCS. Once uploaded
 	// the corresponding Write endpoint can be used to send the file to the gomote instance.
 	UploadFile(ctx context.Context, in *UploadFileRequest, opts ...grpc.CallOption) (*UploadFileResponse, error)
@@ -130,6 +145,25 @@ func (c *gomoteServiceClient) CreateInstance(ctx context.Context, in *CreateInst
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GomoteService_CreateInstanceClient = grpc.ServerStreamingClient[CreateInstanceResponse]
 
+func (c *gomoteServiceClient) CreateInstances(ctx context.Context, in *CreateInstancesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CreateInstancesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[1], GomoteService_CreateInstances_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateInstancesRequest, CreateInstancesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_CreateInstancesClient = grpc.ServerStreamingClient[CreateInstancesResponse]
+
 func (c *gomoteServiceClient) DestroyInstance(ctx context.Context, in *DestroyInstanceRequest, opts ...grpc.CallOption) (*DestroyInstanceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DestroyInstanceResponse)
@@ -142,7 +176,7 @@ func (c *gomoteServiceClient) DestroyInstance(ctx context.Context, in *DestroyIn
 
 func (c *gomoteServiceClient) ExecuteCommand(ctx context.Context, in *ExecuteCommandRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteCommandResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[1], GomoteService_ExecuteCommand_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[2], GomoteService_ExecuteCommand_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +193,26 @@ func (c *gomoteServiceClient) ExecuteCommand(ctx context.Context, in *ExecuteCom
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GomoteService_ExecuteCommandClient = grpc.ServerStreamingClient[ExecuteCommandResponse]
 
+func (c *gomoteServiceClient) ExtendInstanceLease(ctx context.Context, in *ExtendInstanceLeaseRequest, opts ...grpc.CallOption) (*ExtendInstanceLeaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtendInstanceLeaseResponse)
+	err := c.cc.Invoke(ctx, GomoteService_ExtendInstanceLease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gomoteServiceClient) GetBuilderEnvironment(ctx context.Context, in *GetBuilderEnvironmentRequest, opts ...grpc.CallOption) (*GetBuilderEnvironmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBuilderEnvironmentResponse)
+	err := c.cc.Invoke(ctx, GomoteService_GetBuilderEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) InstanceAlive(ctx context.Context, in *InstanceAliveRequest, opts ...grpc.CallOption) (*InstanceAliveResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(InstanceAliveResponse)
@@ -181,7 +235,7 @@ func (c *gomoteServiceClient) ListDirectory(ctx context.Context, in *ListDirecto
 
 func (c *gomoteServiceClient) ListDirectoryStreaming(ctx context.Context, in *ListDirectoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListDirectoryResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[2], GomoteService_ListDirectoryStreaming_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[3], GomoteService_ListDirectoryStreaming_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -248,6 +302,16 @@ func (c *gomoteServiceClient) SignSSHKey(ctx context.Context, in *SignSSHKeyRequ
 	return out, nil
 }
 
+func (c *gomoteServiceClient) SnapshotInstance(ctx context.Context, in *SnapshotInstanceRequest, opts ...grpc.CallOption) (*SnapshotInstanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnapshotInstanceResponse)
+	err := c.cc.Invoke(ctx, GomoteService_SnapshotInstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) UploadFile(ctx context.Context, in *UploadFileRequest, opts ...grpc.CallOption) (*UploadFileResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UploadFileResponse)
@@ -290,10 +354,18 @@ type GomoteServiceServer interface {
 	AddBootstrap(context.Context, *AddBootstrapRequest) (*AddBootstrapResponse, error)
 	// CreateInstance creates a gomote instance.
 	CreateInstance(*CreateInstanceRequest, grpc.ServerStreamingServer[CreateInstanceResponse]) error
+	// CreateInstances creates gomote instances of one or more builder types
+	// concurrently, streaming status updates for each of them.
+	CreateInstances(*CreateInstancesRequest, grpc.ServerStreamingServer[CreateInstancesResponse]) error
 	// DestroyInstance destroys a gomote instance.
 	DestroyInstance(context.Context, *DestroyInstanceRequest) (*DestroyInstanceResponse, error)
 	// ExecuteCommand executes a command on the gomote instance.
 	ExecuteCommand(*ExecuteCommandRequest, grpc.ServerStreamingServer[ExecuteCommandResponse]) error
+	// ExtendInstanceLease extends how long a gomote instance may sit idle before it's destroyed.
+	ExtendInstanceLease(context.Context, *ExtendInstanceLeaseRequest) (*ExtendInstanceLeaseResponse, error)
+	// GetBuilderEnvironment returns the environment and configuration of the builder a gomote instance
+	// was created for, so that its builds can be reproduced.
+	GetBuilderEnvironment(context.Context, *GetBuilderEnvironmentRequest) (*GetBuilderEnvironmentResponse, error)
 	// InstanceAlive gives the liveness state of a gomote instance.
 	InstanceAlive(context.Context, *InstanceAliveRequest) (*InstanceAliveResponse, error)
 	// ListDirectory lists the contents of a directory on an gomote instance.
@@ -311,6 +383,9 @@ type GomoteServiceServer interface {
 	RemoveFiles(context.Context, *RemoveFilesRequest) (*RemoveFilesResponse, error)
 	// SignSSHKey signs an SSH public key which can be used to SSH into instances owned by the caller.
 	SignSSHKey(context.Context, *SignSSHKeyRequest) (*SignSSHKeyResponse, error)
+	// SnapshotInstance tars and zips the go directory of a gomote instance and saves it for later use. The snapshot
+	// can be expanded onto any instance owned by the caller with WriteTGZFromURL.
+	SnapshotInstance(context.Context, *SnapshotInstanceRequest) (*SnapshotInstanceResponse, error)
 	// UploadFile generates a signed URL and associated fields to be used when uploading the object to GCS. Once uploaded
 	// the corresponding Write endpoint can be used to send the file to the gomote instance.
 	UploadFile(context.Context, *UploadFileRequest) (*UploadFileResponse, error)
@@ -337,12 +412,21 @@ func (UnimplementedGomoteServiceServer) AddBootstrap(context.Context, *AddBootst
 func (UnimplementedGomoteServiceServer) CreateInstance(*CreateInstanceRequest, grpc.ServerStreamingServer[CreateInstanceResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method CreateInstance not implemented")
 }
+func (UnimplementedGomoteServiceServer) CreateInstances(*CreateInstancesRequest, grpc.ServerStreamingServer[CreateInstancesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateInstances not implemented")
+}
 func (UnimplementedGomoteServiceServer) DestroyInstance(context.Context, *DestroyInstanceRequest) (*DestroyInstanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyInstance not implemented")
 }
 func (UnimplementedGomoteServiceServer) ExecuteCommand(*ExecuteCommandRequest, grpc.ServerStreamingServer[ExecuteCommandResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method ExecuteCommand not implemented")
 }
+func (UnimplementedGomoteServiceServer) ExtendInstanceLease(context.Context, *ExtendInstanceLeaseRequest) (*ExtendInstanceLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendInstanceLease not implemented")
+}
+func (UnimplementedGomoteServiceServer) GetBuilderEnvironment(context.Context, *GetBuilderEnvironmentRequest) (*GetBuilderEnvironmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuilderEnvironment not implemented")
+}
 func (UnimplementedGomoteServiceServer) InstanceAlive(context.Context, *InstanceAliveRequest) (*InstanceAliveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InstanceAlive not implemented")
 }
@@ -367,6 +451,9 @@ func (UnimplementedGomoteServiceServer) RemoveFiles(context.Context, *RemoveFile
 func (UnimplementedGomoteServiceServer) SignSSHKey(context.Context, *SignSSHKeyRequest) (*SignSSHKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignSSHKey not implemented")
 }
+func (UnimplementedGomoteServiceServer) SnapshotInstance(context.Context, *SnapshotInstanceRequest) (*SnapshotInstanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotInstance not implemented")
+}
 func (UnimplementedGomoteServiceServer) UploadFile(context.Context, *UploadFileRequest) (*UploadFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
 }
@@ -444,6 +531,17 @@ func _GomoteService_CreateInstance_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GomoteService_CreateInstanceServer = grpc.ServerStreamingServer[CreateInstanceResponse]
 
+func _GomoteService_CreateInstances_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateInstancesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GomoteServiceServer).CreateInstances(m, &grpc.GenericServerStream[CreateInstancesRequest, CreateInstancesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_CreateInstancesServer = grpc.ServerStreamingServer[CreateInstancesResponse]
+
 func _GomoteService_DestroyInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DestroyInstanceRequest)
 	if err := dec(in); err != nil {
@@ -473,6 +571,42 @@ func _GomoteService_ExecuteCommand_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GomoteService_ExecuteCommandServer = grpc.ServerStreamingServer[ExecuteCommandResponse]
 
+func _GomoteService_ExtendInstanceLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendInstanceLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).ExtendInstanceLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_ExtendInstanceLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).ExtendInstanceLease(ctx, req.(*ExtendInstanceLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GomoteService_GetBuilderEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuilderEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).GetBuilderEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_GetBuilderEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).GetBuilderEnvironment(ctx, req.(*GetBuilderEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_InstanceAlive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InstanceAliveRequest)
 	if err := dec(in); err != nil {
@@ -610,6 +744,24 @@ func _GomoteService_SignSSHKey_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_SnapshotInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).SnapshotInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_SnapshotInstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).SnapshotInstance(ctx, req.(*SnapshotInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_UploadFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UploadFileRequest)
 	if err := dec(in); err != nil {
@@ -683,6 +835,14 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyInstance",
 			Handler:    _GomoteService_DestroyInstance_Handler,
 		},
+		{
+			MethodName: "ExtendInstanceLease",
+			Handler:    _GomoteService_ExtendInstanceLease_Handler,
+		},
+		{
+			MethodName: "GetBuilderEnvironment",
+			Handler:    _GomoteService_GetBuilderEnvironment_Handler,
+		},
 		{
 			MethodName: "InstanceAlive",
 			Handler:    _GomoteService_InstanceAlive_Handler,
@@ -711,6 +871,10 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SignSSHKey",
 			Handler:    _GomoteService_SignSSHKey_Handler,
 		},
+		{
+			MethodName: "SnapshotInstance",
+			Handler:    _GomoteService_SnapshotInstance_Handler,
+		},
 		{
 			MethodName: "UploadFile",
 			Handler:    _GomoteService_UploadFile_Handler,
@@ -730,6 +894,11 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GomoteService_CreateInstance_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CreateInstances",
+			Handler:       _GomoteService_CreateInstances_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ExecuteCommand",
 			Handler:       _GomoteService_ExecuteCommand_Handler,
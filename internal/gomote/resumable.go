@@ -0,0 +1,222 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+const (
+	// defaultInitialBackoff is how long a resumableReader waits before
+	// its first retry when the request doesn't say.
+	defaultInitialBackoff = time.Second
+	// maxBackoff is the longest a resumableReader waits between attempts.
+	maxBackoff = time.Minute
+	// maxAttemptsLimit is the most attempts a resumableReader makes,
+	// whatever the request asks for.
+	maxAttemptsLimit = 10
+)
+
+// resumableReader reads the contents of a URL or object, retrying
+// with exponential backoff when an attempt fails. Each retry resumes
+// from the last byte read rather than starting over.
+type resumableReader struct {
+	ctx         context.Context
+	name        string // for logging
+	maxAttempts int
+	backoff     time.Duration // wait before the next retry
+
+	// open starts reading the contents from offset.
+	open func(ctx context.Context, offset int64) (io.ReadCloser, error)
+
+	body     io.ReadCloser // current attempt's body, or nil
+	offset   int64         // bytes read so far
+	attempts int           // attempts made so far
+}
+
+// newResumableReader returns a reader for the contents opened by open,
+// which makes up to maxAttempts attempts, waiting initialBackoff before
+// the first retry and doubling the wait after each one, up to maxBackoff.
+// A maxAttempts below one means a single attempt, and one above
+// maxAttemptsLimit means maxAttemptsLimit. A non-positive initialBackoff
+// means defaultInitialBackoff.
+func newResumableReader(ctx context.Context, name string, maxAttempts int, initialBackoff time.Duration, open func(ctx context.Context, offset int64) (io.ReadCloser, error)) *resumableReader {
+	maxAttempts = max(1, min(maxAttempts, maxAttemptsLimit))
+	if initialBackoff <= 0 {
+		initialBackoff = defaultInitialBackoff
+	}
+	initialBackoff = min(initialBackoff, maxBackoff)
+	return &resumableReader{
+		ctx:         ctx,
+		name:        name,
+		maxAttempts: maxAttempts,
+		backoff:     initialBackoff,
+		open:        open,
+	}
+}
+
+func (r *resumableReader) Read(p []byte) (int, error) {
+	for {
+		if r.body == nil {
+			if err := r.retry(nil); err != nil {
+				return 0, err
+			}
+		}
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+		r.body.Close()
+		r.body = nil
+		if retryErr := r.retry(err); retryErr != nil {
+			return n, retryErr
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// retry makes attempts at opening the contents from r.offset until one
+// succeeds or r.maxAttempts is reached. lastErr is the error that ended
+// the previous attempt, if any.
+func (r *resumableReader) retry(lastErr error) error {
+	for {
+		if lastErr != nil {
+			if r.attempts >= r.maxAttempts {
+				return fmt.Errorf("giving up on %s after %d attempts: %w", r.name, r.attempts, lastErr)
+			}
+			log.Printf("gomote: reading %s failed at byte %d on attempt %d of %d: %s; retrying in %v", r.name, r.offset, r.attempts, r.maxAttempts, lastErr, r.backoff)
+			select {
+			case <-r.ctx.Done():
+				return r.ctx.Err()
+			case <-time.After(r.backoff):
+			}
+			r.backoff = min(2*r.backoff, maxBackoff)
+		}
+		r.attempts++
+		if r.attempts > 1 {
+			log.Printf("gomote: reading %s from byte %d, attempt %d of %d", r.name, r.offset, r.attempts, r.maxAttempts)
+		}
+		body, err := r.open(r.ctx, r.offset)
+		if err == nil {
+			r.body = body
+			return nil
+		}
+		var permanent permanentError
+		if errors.As(err, &permanent) {
+			return err
+		}
+		lastErr = err
+	}
+}
+
+// start makes the first attempt at opening the contents, retrying as
+// needed, so that errors can be reported before anything is read.
+func (r *resumableReader) start() error {
+	if r.body != nil || r.attempts > 0 {
+		return nil
+	}
+	return r.retry(nil)
+}
+
+// Close closes the current attempt's body, if any.
+func (r *resumableReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
+
+// permanentError is an error that retrying won't fix.
+type permanentError struct{ error }
+
+func (e permanentError) Unwrap() error { return e.error }
+
+// openHTTPRange returns an open function for newResumableReader that
+// fetches url with client, using an HTTP Range request to start from
+// a non-zero offset.
+func openHTTPRange(client *http.Client, url string) func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+	return func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, permanentError{err}
+		}
+		want := http.StatusOK
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			want = http.StatusPartialContent
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != want {
+			resp.Body.Close()
+			err := fmt.Errorf("response code: %d", resp.StatusCode)
+			switch {
+			case offset > 0 && resp.StatusCode == http.StatusOK:
+				return nil, permanentError{fmt.Errorf("server doesn't support resuming: %w", err)}
+			case resp.StatusCode >= 500:
+				return nil, err
+			default:
+				return nil, permanentError{err}
+			}
+		}
+		if offset > 0 && !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			resp.Body.Close()
+			return nil, permanentError{fmt.Errorf("server resumed with unexpected Content-Range %q", resp.Header.Get("Content-Range"))}
+		}
+		return resp.Body, nil
+	}
+}
+
+// readerForURL returns a resumableReader for the contents of the URL in
+// req, configured by its retry settings. Objects in the gomote transfer
+// bucket are only accessible with explicit permissions, so they're read
+// from the bucket directly rather than over HTTP.
+func readerForURL(ctx context.Context, bucket bucketHandle, bucketName string, req *protos.WriteFileFromURLRequest) (*resumableReader, error) {
+	maxAttempts, backoff := int(req.GetMaxAttempts()), maxBackoff
+	if ms := req.GetInitialBackoffMs(); ms < maxBackoff.Milliseconds() {
+		// Converting larger values could overflow.
+		backoff = time.Duration(ms) * time.Millisecond
+	}
+	if onObjectStore(bucketName, req.GetUrl()) {
+		object, err := objectFromURL(bucketName, req.GetUrl())
+		if err != nil {
+			return nil, err
+		}
+		return newResumableReader(ctx, req.GetUrl(), maxAttempts, backoff, func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+			rc, err := bucket.Object(object).NewRangeReader(ctx, offset, -1)
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				return nil, permanentError{err}
+			}
+			return rc, err
+		}), nil
+	}
+	// TODO(amedee) find sane client defaults, possibly rely on context timeout in request.
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+	}
+	return newResumableReader(ctx, req.GetUrl(), maxAttempts, backoff, openHTTPRange(client, req.GetUrl())), nil
+}
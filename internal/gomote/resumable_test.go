@@ -0,0 +1,118 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flakyHandler serves content, but cuts off requests without a Range
+// header halfway through. If ranges is false, it ignores Range headers.
+func flakyHandler(content []byte, ranges bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if ranges && r.Header.Get("Range") != "" {
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Write(content[:len(content)/2])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func TestResumableReader(t *testing.T) {
+	content := []byte(strings.Repeat("Go is an open source programming language. ", 1000))
+	ts := httptest.NewServer(flakyHandler(content, true))
+	defer ts.Close()
+
+	r := newResumableReader(context.Background(), ts.URL, 3, time.Millisecond, openHTTPRange(ts.Client(), ts.URL))
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("io.ReadAll = %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("read %d bytes, want the %d bytes of content", len(got), len(content))
+	}
+	if r.attempts != 2 {
+		t.Errorf("attempts = %d, want 2", r.attempts)
+	}
+}
+
+func TestResumableReaderErrors(t *testing.T) {
+	content := []byte(strings.Repeat("Go is an open source programming language. ", 1000))
+	for _, tc := range []struct {
+		desc         string
+		handler      http.HandlerFunc
+		maxAttempts  int
+		wantAttempts int
+	}{
+		{
+			desc:         "single attempt",
+			handler:      flakyHandler(content, true),
+			maxAttempts:  0,
+			wantAttempts: 1,
+		},
+		{
+			desc:         "server doesn't support ranges",
+			handler:      flakyHandler(content, false),
+			maxAttempts:  3,
+			wantAttempts: 2,
+		},
+		{
+			desc: "server errors",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			},
+			maxAttempts:  3,
+			wantAttempts: 3,
+		},
+		{
+			desc: "too many attempts",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			},
+			maxAttempts:  1000,
+			wantAttempts: maxAttemptsLimit,
+		},
+		{
+			desc:         "not found",
+			handler:      http.NotFound,
+			maxAttempts:  3,
+			wantAttempts: 1,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ts := httptest.NewServer(tc.handler)
+			defer ts.Close()
+			r := newResumableReader(context.Background(), ts.URL, tc.maxAttempts, time.Millisecond, openHTTPRange(ts.Client(), ts.URL))
+			defer r.Close()
+			if _, err := io.ReadAll(r); err == nil {
+				t.Errorf("io.ReadAll succeeded, want error")
+			}
+			if r.attempts != tc.wantAttempts {
+				t.Errorf("attempts = %d, want %d", r.attempts, tc.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestResumableReaderBackoffLimit(t *testing.T) {
+	r := newResumableReader(context.Background(), "example", 3, 1<<62, nil)
+	if r.backoff != maxBackoff {
+		t.Errorf("backoff = %v, want %v", r.backoff, maxBackoff)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/build/buildlet"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// snapshotPrefix is the prefix of the names of the objects in the gomote
+// transfer bucket that hold instance snapshots.
+const snapshotPrefix = "snapshots/"
+
+// snapshotObjectPrefix returns the prefix of the names of the snapshot
+// objects owned by ownerID. The owner ID is hashed so that it doesn't
+// appear in snapshot URLs.
+func snapshotObjectPrefix(ownerID string) string {
+	sum := sha256.Sum256([]byte(ownerID))
+	return snapshotPrefix + hex.EncodeToString(sum[:16]) + "/"
+}
+
+// checkSnapshotAccess returns an error if url is for a snapshot in the
+// gomote transfer bucket that isn't owned by ownerID. Snapshots can only
+// be read through the gomote server, so this keeps users from reading
+// each other's snapshots.
+func checkSnapshotAccess(bucketName, url, ownerID string) error {
+	if !onObjectStore(bucketName, url) {
+		return nil
+	}
+	object, err := objectFromURL(bucketName, url)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid URL")
+	}
+	if strings.HasPrefix(object, snapshotPrefix) && !strings.HasPrefix(object, snapshotObjectPrefix(ownerID)) {
+		return status.Errorf(codes.PermissionDenied, "not allowed to read this snapshot")
+	}
+	return nil
+}
+
+// snapshotInstance writes a tar.gz of the go directory of the buildlet to
+// the gomote transfer bucket, as a snapshot owned by ownerID. It returns
+// the URL of the snapshot.
+func snapshotInstance(ctx context.Context, bucket bucketHandle, bucketName string, bc buildlet.Client, ownerID string) (string, error) {
+	tgz, err := bc.GetTar(ctx, "go")
+	if err != nil {
+		return "", status.Errorf(codes.Aborted, "unable to retrieve tar from gomote instance: %s", err)
+	}
+	defer tgz.Close()
+	objectName := snapshotObjectPrefix(ownerID) + uuid.NewString() + ".tar.gz"
+	// As in ReadTGZToURL, canceling the context stops the write without
+	// saving the object.
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := bucket.Object(objectName).NewWriter(writeCtx)
+	w.ContentType = "application/gzip"
+	if _, err := io.Copy(w, tgz); err != nil {
+		return "", status.Errorf(codes.Aborted, "unable to stream tar.gz: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", status.Errorf(codes.Aborted, "unable to store object: %s", err)
+	}
+	log.Printf("gomote: saved snapshot of %s to %s", bc.Name(), objectName)
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucketName, objectName), nil
+}
@@ -157,6 +157,10 @@ func (ss *SwarmingServer) CreateInstance(req *protos.CreateInstanceRequest, stre
 	if req.GetBuilderType() == "" {
 		return status.Errorf(codes.InvalidArgument, "invalid builder type")
 	}
+	if req.GetImage() != "" {
+		// Swarming bots come with their image; there's no way to request a particular one.
+		return status.Errorf(codes.InvalidArgument, "selecting an image is not supported for LUCI builders")
+	}
 	bs, err := ss.validBuilders(stream.Context())
 	if err != nil {
 		return err
@@ -169,6 +173,11 @@ func (ss *SwarmingServer) CreateInstance(req *protos.CreateInstanceRequest, stre
 	if err != nil {
 		return status.Errorf(codes.Internal, "invalid user email format")
 	}
+	release, err := ss.buildlets.Reserve(userName)
+	if err != nil {
+		return status.Errorf(codes.ResourceExhausted, "%s", err)
+	}
+	defer release()
 	type result struct {
 		buildletClient buildlet.Client
 		err            error
@@ -232,6 +241,7 @@ func (ss *SwarmingServer) CreateInstance(req *protos.CreateInstanceRequest, stre
 					GomoteId:    gomoteID,
 					BuilderType: req.GetBuilderType(),
 					HostType:    "swarming task",
+					Created:     session.Created.Unix(),
 					Expires:     session.Expires.Unix(),
 					WorkingDir:  wd,
 				},
@@ -334,15 +344,73 @@ func (ss *SwarmingServer) InstanceAlive(ctx context.Context, req *protos.Instanc
 	return &protos.InstanceAliveResponse{}, nil
 }
 
+// ExtendInstanceLease extends how long a gomote instance may sit idle before it's destroyed, and
+// returns its new expiration time. The requester must be authenticated and own the instance.
+func (ss *SwarmingServer) ExtendInstanceLease(ctx context.Context, req *protos.ExtendInstanceLeaseRequest) (*protos.ExtendInstanceLeaseResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("ExtendInstanceLease access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if req.GetGomoteId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid gomote ID")
+	}
+	if req.GetIdleTimeoutSeconds() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid idle timeout")
+	}
+	_, err = ss.session(req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	expires, err := ss.buildlets.ExtendLease(req.GetGomoteId(), time.Duration(req.GetIdleTimeoutSeconds())*time.Second)
+	if errors.Is(err, remote.ErrLeaseTooLong) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to extend lease")
+	}
+	return &protos.ExtendInstanceLeaseResponse{Expires: expires.Unix()}, nil
+}
+
+// GetBuilderEnvironment returns the environment and configuration of the builder the gomote instance
+// was created for. The requester must be authenticated.
+func (ss *SwarmingServer) GetBuilderEnvironment(ctx context.Context, req *protos.GetBuilderEnvironmentRequest) (*protos.GetBuilderEnvironmentResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("GetBuilderEnvironment access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	ses, err := ss.session(req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	bs, err := ss.validBuilders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	builder, ok := bs[ses.BuilderType]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unable to determine builder definition")
+	}
+	resp, err := swarmingBuilderEnvironment(builder)
+	if err != nil {
+		log.Printf("GetBuilderEnvironment: %s: %s", ses.BuilderType, err)
+		return nil, status.Errorf(codes.Internal, "unable to read builder definition")
+	}
+	// Report the gomote builder type rather than the name of the
+	// -test_only builder it's defined by.
+	resp.BuilderType = ses.BuilderType
+	return resp, nil
+}
+
 // ListDirectory lists the contents of the directory on a gomote instance.
 func (ss *SwarmingServer) ListDirectory(ctx context.Context, req *protos.ListDirectoryRequest) (*protos.ListDirectoryResponse, error) {
 	entries, err := ss.listDirectory(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	return &protos.ListDirectoryResponse{
-		Entries: entries,
-	}, nil
+	return listDirectoryResponse(entries), nil
 }
 
 // ListDirectoryStreaming lists the contents of the directory on a gomote instance.
@@ -355,9 +423,7 @@ func (ss *SwarmingServer) ListDirectoryStreaming(req *protos.ListDirectoryReques
 	const chunkSize = 100
 	for i := 0; i < len(entries); i += chunkSize {
 		end := min(chunkSize, len(entries[i:]))
-		if err := stream.Send(&protos.ListDirectoryResponse{
-			Entries: entries[i : i+end],
-		}); err != nil {
+		if err := stream.Send(listDirectoryResponse(entries[i : i+end])); err != nil {
 			return err
 		}
 	}
@@ -506,6 +572,7 @@ func (ss *SwarmingServer) ListInstances(ctx context.Context, req *protos.ListIns
 			GomoteId:    s.ID,
 			BuilderType: s.BuilderType,
 			HostType:    s.HostType,
+			Created:     s.Created.Unix(),
 			Expires:     s.Expires.Unix(),
 		})
 	}
@@ -598,6 +665,26 @@ func (ss *SwarmingServer) SignSSHKey(ctx context.Context, req *protos.SignSSHKey
 	}, nil
 }
 
+// SnapshotInstance saves a tar.gz of the go directory of the gomote instance for later use. It returns the URL of the
+// snapshot, which the caller can pass to WriteTGZFromURL to expand the snapshot onto any of their instances.
+func (ss *SwarmingServer) SnapshotInstance(ctx context.Context, req *protos.SnapshotInstanceRequest) (*protos.SnapshotInstanceResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("SnapshotInstance access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	_, bc, err := ss.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	url, err := snapshotInstance(ctx, ss.bucket, ss.gceBucketName, bc, creds.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.SnapshotInstanceResponse{Url: url}, nil
+}
+
 // UploadFile creates a URL and a set of HTTP post fields which are used to upload a file to a staging GCS bucket. Uploaded files are made available to the
 // gomote instances via a subsequent call to one of the WriteFromURL endpoints.
 func (ss *SwarmingServer) UploadFile(ctx context.Context, req *protos.UploadFileRequest) (*protos.UploadFileResponse, error) {
@@ -645,45 +732,21 @@ func (ss *SwarmingServer) WriteFileFromURL(ctx context.Context, req *protos.Writ
 		// the helper function returns meaningful GRPC error.
 		return nil, err
 	}
-	var rc io.ReadCloser
-	// objects stored in the gomote staging bucket are only accessible when you have been granted explicit permissions. A builder
-	// requires a signed URL in order to access objects stored in the gomote staging bucket.
-	if onObjectStore(ss.gceBucketName, req.GetUrl()) {
-		object, err := objectFromURL(ss.gceBucketName, req.GetUrl())
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid object URL")
-		}
-		rc, err = ss.bucket.Object(object).NewReader(ctx)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "unable to create object reader: %s", err)
-		}
-	} else {
-		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, req.GetUrl(), nil)
-		if err != nil {
-			log.Printf("gomote: unable to create HTTP request: %s", err)
-			return nil, status.Errorf(codes.Internal, "unable to create HTTP request")
-		}
-		// TODO(amedee) find sane client defaults, possibly rely on context timeout in request.
-		client := &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				TLSHandshakeTimeout: 5 * time.Second,
-			},
-		}
-		resp, err := client.Do(httpRequest)
-		if err != nil {
-			return nil, status.Errorf(codes.Aborted, "failed to get file from URL: %s", err)
-		}
-		if resp.StatusCode != http.StatusOK {
-			return nil, status.Errorf(codes.Aborted, "unable to get file from %q: response code: %d", req.GetUrl(), resp.StatusCode)
-		}
-		rc = resp.Body
+	if err := checkSnapshotAccess(ss.gceBucketName, req.GetUrl(), creds.ID); err != nil {
+		return nil, err
+	}
+	rc, err := readerForURL(ctx, ss.bucket, ss.gceBucketName, req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid object URL")
 	}
 	defer rc.Close()
+	if err := rc.start(); err != nil {
+		return nil, status.Errorf(codes.Aborted, "failed to get file from URL: %s", err)
+	}
 	if err := bc.Put(ctx, rc, req.GetFilename(), fs.FileMode(req.GetMode())); err != nil {
 		return nil, status.Errorf(codes.Aborted, "failed to send the file to the gomote instance: %s", err)
 	}
-	return &protos.WriteFileFromURLResponse{}, nil
+	return &protos.WriteFileFromURLResponse{Attempts: int32(rc.attempts)}, nil
 }
 
 // WriteTGZFromURL will instruct the gomote instance to download the tar.gz from the provided URL. The tar.gz file will be unpacked in the work directory
@@ -706,6 +769,9 @@ func (ss *SwarmingServer) WriteTGZFromURL(ctx context.Context, req *protos.Write
 		return nil, err
 	}
 	url := req.GetUrl()
+	if err := checkSnapshotAccess(ss.gceBucketName, url, creds.ID); err != nil {
+		return nil, err
+	}
 	if onObjectStore(ss.gceBucketName, url) {
 		object, err := objectFromURL(ss.gceBucketName, url)
 		if err != nil {
@@ -280,6 +280,15 @@ func TestSwarmingCreateInstanceError(t *testing.T) {
 			},
 			wantCode: codes.InvalidArgument,
 		},
+		{
+			desc: "image not supported",
+			ctx:  access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request: &protos.CreateInstanceRequest{
+				BuilderType: "gotip-linux-amd64-boringcrypto",
+				Image:       "some-image",
+			},
+			wantCode: codes.InvalidArgument,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -494,6 +503,60 @@ func TestSwarmingInstanceAlive(t *testing.T) {
 	}
 }
 
+func TestSwarmingGetBuilderEnvironment(t *testing.T) {
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	req := &protos.GetBuilderEnvironmentRequest{
+		GomoteId: gomoteID,
+	}
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	got, err := client.GetBuilderEnvironment(ctx, req)
+	if err != nil {
+		t.Fatalf("client.GetBuilderEnvironment(ctx, %v) = %v, %s; want no error", req, got, err)
+	}
+	want := &protos.GetBuilderEnvironmentResponse{
+		BuilderType: "gotip-linux-amd64-boringcrypto",
+		Goos:        "linux",
+		Goarch:      "amd64",
+		Config: []string{
+			`bootstrap_version: "latest"`,
+			"mode: 0",
+			"dimension: cipd_platform:linux-amd64",
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("client.GetBuilderEnvironment(ctx, %v) mismatch (-want, +got):\n%s", req, diff)
+	}
+}
+
+func TestSwarmingBuilderEnvironmentRedaction(t *testing.T) {
+	builder := &buildbucketpb.BuilderItem{
+		Id: &buildbucketpb.BuilderID{Project: "golang", Bucket: "ci", Builder: "gotip-linux-arm64"},
+		Config: &buildbucketpb.BuilderConfig{
+			Dimensions: []string{"cipd_platform:linux-amd64"},
+			Properties: `{"target": {"goos": "linux", "goarch": "arm64"}, "env": {"GOARM64": "v8.1", "PROXY_TOKEN": "hunter2"}, "api_key": "hunter2"}`,
+		},
+	}
+	got, err := swarmingBuilderEnvironment(builder)
+	if err != nil {
+		t.Fatalf("swarmingBuilderEnvironment() = %s; want no error", err)
+	}
+	want := &protos.GetBuilderEnvironmentResponse{
+		BuilderType: "gotip-linux-arm64",
+		Goos:        "linux",
+		Goarch:      "arm64",
+		Environment: []string{"GOARM64=v8.1", "PROXY_TOKEN=" + redacted},
+		Config: []string{
+			"api_key: " + redacted,
+			`target: {"goos": "linux", "goarch": "arm64"}`,
+			"dimension: cipd_platform:linux-amd64",
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("swarmingBuilderEnvironment() mismatch (-want, +got):\n%s", diff)
+	}
+}
+
 func TestSwarmingInstanceAliveError(t *testing.T) {
 	// This test will create a gomote instance and attempt to call InstanceAlive.
 	// If overrideID is set to true, the test will use a different gomoteID than
@@ -687,7 +750,7 @@ func TestSwarmingListInstance(t *testing.T) {
 		t.Fatalf("client.ListInstances = nil, %s; want no error", err)
 	}
 	got := response.GetInstances()
-	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.IgnoreFields(&protos.Instance{}, "expires", "host_type")); diff != "" {
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.IgnoreFields(&protos.Instance{}, "created", "expires", "host_type")); diff != "" {
 		t.Errorf("ListInstances() mismatch (-want, +got):\n%s", diff)
 	}
 }
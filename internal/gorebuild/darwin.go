@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package main
+package gorebuild
 
 import (
 	"bytes"
@@ -122,6 +122,26 @@ func StripDarwinSig(log *Log, name string, data []byte) []byte {
 	return data
 }
 
+// MatchDarwin reports whether the posted macOS file named name, a .tar.gz
+// or .zip, matches the rebuilt one, either exactly or after stripping the
+// code signatures that signing adds to executables. Differences are logged.
+func MatchDarwin(log *Log, name string, rebuilt, posted []byte) bool {
+	if bytes.Equal(rebuilt, posted) {
+		return true
+	}
+	var ok bool
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"):
+		ok = DiffTarGz(log, rebuilt, posted, StripDarwinSig)
+	case strings.HasSuffix(name, ".zip"):
+		ok = DiffZip(log, rebuilt, posted, StripDarwinSig)
+	}
+	if ok {
+		log.Printf("verified match after stripping signatures from executables")
+	}
+	return ok
+}
+
 // DiffDarwinPkg diffs the content of the macOS pkg and tgz files provided,
 // logging differences. It returns true if the files were successfully parsed
 // and contain the same files, false otherwise.
@@ -2,12 +2,14 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package main_test
+package gorebuild_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"testing"
 
-	gorebuild "golang.org/x/build/cmd/gorebuild"
+	"golang.org/x/build/internal/gorebuild"
 )
 
 func TestDiffArchive(t *testing.T) {
@@ -57,3 +59,36 @@ func TestDiffArchive(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchDarwin(t *testing.T) {
+	makeZip := func(content string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("go/VERSION")
+		w.Write([]byte(content))
+		zw.Close()
+		return buf.Bytes()
+	}
+	for _, tc := range []struct {
+		name            string
+		rebuilt, posted []byte
+		want            bool
+	}{
+		{"identical", makeZip("go1.23.0"), makeZip("go1.23.0"), true},
+		{"different content", makeZip("go1.23.0"), makeZip("go1.23.1"), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var msgs []string
+			log := gorebuild.Log{Output: func(text string) { msgs = append(msgs, text) }}
+			if got := gorebuild.MatchDarwin(&log, "go.zip", tc.rebuilt, tc.posted); got != tc.want {
+				t.Errorf("MatchDarwin = %v, want %v", got, tc.want)
+			}
+			if !tc.want && len(msgs) == 0 {
+				t.Errorf("MatchDarwin logged nothing to Output for a mismatch")
+			}
+			if len(msgs) != len(log.Messages) {
+				t.Errorf("Output got %d messages, log has %d", len(msgs), len(log.Messages))
+			}
+		})
+	}
+}
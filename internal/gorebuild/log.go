@@ -0,0 +1,80 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gorebuild holds the logic golang.org/x/build/cmd/gorebuild
+// uses to compare rebuilt Go distribution files with the posted ones,
+// so that the release process can check them before they're posted.
+package gorebuild
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Log contains timestamped log messages as well as an overall
+// result status derived from them.
+type Log struct {
+	Name string
+
+	// Output, if non-nil, is called with the text of each message
+	// instead of writing it to standard error.
+	Output func(text string) `json:"-"`
+
+	// mu must be held when using the Log from multiple goroutines.
+	// It is OK not to hold mu when there is only a single goroutine accessing
+	// the data, such as during json.Marshal or json.Unmarshal.
+	mu       sync.Mutex
+	Messages []Message
+	Status   Status
+}
+
+// A Status reports the overall result of the report, version, or file:
+// FAIL, PASS, or SKIP.
+type Status string
+
+const (
+	FAIL Status = "FAIL"
+	PASS Status = "PASS"
+	SKIP Status = "SKIP"
+)
+
+// A Message is a single log message.
+type Message struct {
+	Time time.Time
+	Text string
+}
+
+// Printf adds a new message to the log.
+// If the message begins with FAIL:, PASS:, or SKIP:,
+// the status is updated accordingly.
+func (l *Log) Printf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	text := fmt.Sprintf(format, args...)
+	text = strings.TrimRight(text, "\n")
+	now := time.Now()
+	l.Messages = append(l.Messages, Message{now, text})
+
+	if strings.HasPrefix(format, "FAIL:") {
+		l.Status = FAIL
+	} else if strings.HasPrefix(format, "PASS:") && l.Status != FAIL {
+		l.Status = PASS
+	} else if strings.HasPrefix(format, "SKIP:") && l.Status == "" {
+		l.Status = SKIP
+	}
+
+	if l.Output != nil {
+		l.Output(text)
+		return
+	}
+	prefix := ""
+	if l.Name != "" {
+		prefix = "[" + l.Name + "] "
+	}
+	fmt.Fprintf(os.Stderr, "%s %s%s\n", now.Format("15:04:05.000"), prefix, text)
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package redact identifies values that may be secret, so that they
+// can be left out of logs, audit records and builder descriptions.
+package redact
+
+import "strings"
+
+// Redacted is the value that replaces secrets.
+const Redacted = "[REDACTED]"
+
+// secretNames are the case-insensitive substrings of names, such as
+// environment variables or JSON object members, whose values are secret.
+var secretNames = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "privatekey", "private_key", "credential"}
+
+// IsSecretName reports whether name suggests that its value is a secret,
+// such as "GITHUB_TOKEN" or "APIKey".
+func IsSecretName(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redact
+
+import "testing"
+
+func TestIsSecretName(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		want bool
+	}{
+		{"GITHUB_TOKEN", true},
+		{"Password", true},
+		{"APIKey", true},
+		{"api_key", true},
+		{"PrivateKey", true},
+		{"SSH_PRIVATE_KEY", true},
+		{"GoogleCredentials", true},
+		{"GOARCH", false},
+		{"Version", false},
+		{"", false},
+	} {
+		if got := IsSecretName(tt.name); got != tt.want {
+			t.Errorf("IsSecretName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -95,6 +96,10 @@ type releaseTestDeps struct {
 	buildTasks     *BuildReleaseTasks
 	milestoneTasks *task.MilestoneTasks
 	publishedFiles map[string]task.WebsiteFile
+
+	// verifyApprovals counts approvals requested by the tasks that
+	// verify signed macOS files.
+	verifyApprovals *atomic.Int32
 }
 
 func newReleaseTestDeps(t *testing.T, previousTag string, major int, wantVersion string) *releaseTestDeps {
@@ -156,6 +161,7 @@ func newReleaseTestDeps(t *testing.T, previousTag string, major int, wantVersion
 		ApproveAction: func(ctx *workflow.TaskContext) error {
 			return fmt.Errorf("unexpected approval request for %q", ctx.TaskName)
 		},
+		Gerrit: gerrit,
 	}
 	buildBucket := task.NewFakeBuildBucketClient(major, fakeGerrit.GerritURL(), "security-try", []string{"go"})
 
@@ -163,6 +169,7 @@ func newReleaseTestDeps(t *testing.T, previousTag string, major int, wantVersion
 
 	scratchDir := t.TempDir()
 
+	verifyApprovals := new(atomic.Int32)
 	buildTasks := &BuildReleaseTasks{
 		GerritClient:             gerrit,
 		GerritProject:            "go",
@@ -184,6 +191,13 @@ func newReleaseTestDeps(t *testing.T, previousTag string, major int, wantVersion
 			if strings.Contains(ctx.TaskName, "Release Coordinator Approval") {
 				return nil
 			}
+			if strings.Contains(ctx.TaskName, "Verify signed files are reproducible") {
+				// The fake signing service's binaries aren't Mach-O
+				// files, so the signed files never match the distpack.
+				// testRelease checks that every target asked.
+				verifyApprovals.Add(1)
+				return nil
+			}
 			return fmt.Errorf("unexpected approval request for %q", ctx.TaskName)
 		},
 	}
@@ -200,6 +214,8 @@ func newReleaseTestDeps(t *testing.T, previousTag string, major int, wantVersion
 		buildTasks:     buildTasks,
 		milestoneTasks: milestoneTasks,
 		publishedFiles: files,
+
+		verifyApprovals: verifyApprovals,
 	}
 }
 
@@ -232,9 +248,11 @@ func testRelease(t *testing.T, prevTag string, major int, wantVersion string, ki
 	wantPublishedFiles := map[string]string{
 		wantVersion + ".src.tar.gz": "source",
 	}
+	var darwinTargets int32
 	for _, t := range releasetargets.TargetsForGo1Point(major) {
 		switch t.GOOS {
 		case "darwin":
+			darwinTargets++
 			wantPublishedFiles[wantVersion+"."+t.Name+".tar.gz"] = "archive"
 			wantPublishedFiles[wantVersion+"."+t.Name+".pkg"] = "installer"
 		case "windows":
@@ -275,6 +293,9 @@ func testRelease(t *testing.T, prevTag string, major int, wantVersion string, ki
 	if len(wantPublishedFiles) != 0 {
 		t.Errorf("missing %d published files: %v", len(wantPublishedFiles), wantPublishedFiles)
 	}
+	if got := deps.verifyApprovals.Load(); got != darwinTargets {
+		t.Errorf("signed macOS file verification asked for approval %d times, want once for each of %d darwin targets", got, darwinTargets)
+	}
 	versionFile := outputs["VERSION file"].(string)
 	if !strings.Contains(versionFile, wantVersion) {
 		t.Errorf("version file should contain %q, got %q", wantVersion, versionFile)
@@ -459,6 +480,70 @@ func TestAdvisoryTestsFail(t *testing.T) {
 	}
 }
 
+func TestCheckVersionMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		files   map[string]string
+		version string
+		wantErr bool
+	}{
+		{
+			name:    "no metadata",
+			files:   map[string]string{"README": "Go"},
+			version: "go1.22.2",
+		},
+		{
+			name: "good minor release",
+			files: map[string]string{
+				"VERSION":        "go1.22.1\ntime 2024-03-05T22:44:40Z\n",
+				"src/go.mod":     "module std\n\ngo 1.22\n",
+				"src/cmd/go.mod": "module cmd\n\ngo 1.22\n",
+			},
+			version: "go1.22.2",
+		},
+		{
+			name:    "good final after RC",
+			files:   map[string]string{"VERSION": "go1.22rc2\n", "src/go.mod": "module std\n\ngo 1.22\n"},
+			version: "go1.22.0",
+		},
+		{
+			name:    "VERSION not before release",
+			files:   map[string]string{"VERSION": "go1.22.2\n"},
+			version: "go1.22.2",
+			wantErr: true,
+		},
+		{
+			name:    "VERSION from another major",
+			files:   map[string]string{"VERSION": "go1.21.8\n"},
+			version: "go1.22.2",
+			wantErr: true,
+		},
+		{
+			name:    "wrong go directive",
+			files:   map[string]string{"src/cmd/go.mod": "module cmd\n\ngo 1.23\n"},
+			version: "go1.22.2",
+			wantErr: true,
+		},
+		{
+			name:    "later toolchain",
+			files:   map[string]string{"src/go.mod": "module std\n\ngo 1.22\n\ntoolchain go1.22.3\n"},
+			version: "go1.22.2",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := task.NewFakeRepo(t, "go")
+			commit := repo.Commit(tc.files)
+			tasks := &BuildReleaseTasks{GerritClient: task.NewFakeGerrit(t, repo), GerritProject: "go"}
+			ctx := &workflow.TaskContext{Context: context.Background(), Logger: &testLogger{t: t}}
+			err := tasks.checkVersionMetadata(ctx, tc.version, commit, "")
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("checkVersionMetadata = %v, want error: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
 // makeScript pretends to be make.bash. It creates a fake go command that
 // knows how to fake the commands the release process runs.
 const makeScript = `#!/bin/bash -eu
@@ -797,3 +882,84 @@ func periodicallyDo(ctx context.Context, t *testing.T, period time.Duration, f f
 		t.Fatal(err)
 	}
 }
+
+func TestVerifySignedDarwinFiles(t *testing.T) {
+	makeTGZ := func(version string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for name, content := range map[string]string{"go/VERSION": version, "go/bin/go": "not a Mach-O binary"} {
+			tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: time.Unix(1700000000, 0)})
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		zw.Close()
+		return buf.Bytes()
+	}
+	makeZip := func(version string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("golang.org/toolchain@v0.0.1-go1.23.0.darwin-arm64/VERSION")
+		w.Write([]byte(version))
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	for _, tc := range []struct {
+		name         string
+		signedTGZ    []byte
+		signedZip    []byte
+		wantApproval bool
+		wantLog      string // prefix of a message logged to the task
+	}{
+		{"match", makeTGZ("go1.23.0"), makeZip("go1.23.0"), false, ""},
+		{"tgz mismatch", makeTGZ("go1.23.1"), makeZip("go1.23.0"), true, "go.tar.gz: "},
+		{"zip mismatch", makeTGZ("go1.23.0"), makeZip("go1.23.1"), true, "module.zip: "},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := new(recordingLogger)
+			ctx := &workflow.TaskContext{Context: context.Background(), Logger: logger, WorkflowID: uuid.New()}
+			dir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(dir, ctx.WorkflowID.String()), 0700); err != nil {
+				t.Fatal(err)
+			}
+			approved := false
+			tasks := &BuildReleaseTasks{
+				ScratchFS: &task.ScratchFS{BaseURL: "file://" + dir},
+				ApproveAction: func(*workflow.TaskContext) error {
+					approved = true
+					return nil
+				},
+			}
+			write := func(name string, data []byte) string {
+				if err := os.WriteFile(filepath.Join(dir, ctx.WorkflowID.String(), name), data, 0600); err != nil {
+					t.Fatal(err)
+				}
+				return name
+			}
+			err := tasks.verifySignedDarwinFiles(ctx,
+				artifact{Scratch: write("unsigned.tar.gz", makeTGZ("go1.23.0"))},
+				artifact{Scratch: write("signed.tar.gz", tc.signedTGZ)},
+				moduleArtifact{ZipScratch: write("unsigned.zip", makeZip("go1.23.0"))},
+				moduleArtifact{ZipScratch: write("signed.zip", tc.signedZip)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if approved != tc.wantApproval {
+				t.Errorf("approval requested = %v, want %v", approved, tc.wantApproval)
+			}
+			if tc.wantLog != "" && !slices.ContainsFunc(logger.msgs, func(m string) bool { return strings.HasPrefix(m, tc.wantLog) }) {
+				t.Errorf("task log = %q, want a message starting with %q", logger.msgs, tc.wantLog)
+			}
+		})
+	}
+}
+
+// recordingLogger records the messages logged to it.
+type recordingLogger struct {
+	msgs []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.msgs = append(l.msgs, fmt.Sprintf(format, v...))
+}
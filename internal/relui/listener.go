@@ -34,6 +34,8 @@ type PGListener struct {
 	templ *template.Template
 }
 
+var _ workflow.AuditListener = (*PGListener)(nil)
+
 // WorkflowStalled is called when no tasks are runnable.
 func (l *PGListener) WorkflowStalled(workflowID uuid.UUID) error {
 	wf, err := db.New(l.DB).Workflow(context.Background(), workflowID)
@@ -144,6 +146,18 @@ func (l *PGListener) baseLink(target string, extras ...string) string {
 	return BaseLink(l.BaseURL)(target, extras...)
 }
 
+// TaskAudited records the inputs and outputs of a task run in the
+// task's logs, so a workflow has an audit trail of what each task
+// received and produced. It implements workflow.AuditListener.
+func (l *PGListener) TaskAudited(workflowID uuid.UUID, taskName string, record *workflow.AuditRecord) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		log.Printf("TaskAudited(%v, %q): json.Marshal: %v", workflowID, taskName, err)
+		return
+	}
+	l.Logger(workflowID, taskName).Printf("audit: %s", b)
+}
+
 func (l *PGListener) Logger(workflowID uuid.UUID, taskName string) workflow.Logger {
 	return &postgresLogger{
 		db:         l.DB,
@@ -32,7 +32,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	pb "go.chromium.org/luci/buildbucket/proto"
 	"golang.org/x/build/dashboard"
+	"golang.org/x/build/gerrit"
 	"golang.org/x/build/internal/gcsfs"
+	"golang.org/x/build/internal/gorebuild"
 	"golang.org/x/build/internal/installer/darwinpkg"
 	"golang.org/x/build/internal/installer/windowsmsi"
 	"golang.org/x/build/internal/releasetargets"
@@ -43,6 +45,7 @@ import (
 	"golang.org/x/build/internal/workflow"
 	wf "golang.org/x/build/internal/workflow"
 	"golang.org/x/exp/maps"
+	"golang.org/x/mod/modfile"
 	"golang.org/x/net/context/ctxhttp"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -385,7 +388,7 @@ func registerProdReleaseWorkflows(ctx context.Context, h *DefinitionHolder, buil
 			securitySummary = wf.Param(wd, securitySummaryParameter)
 			securityFixes = wf.Param(wd, securityFixesParameter)
 		}
-		addCommTasks(wd, build, comm, r.kind, wf.Slice(published), securitySummary, securityFixes, coordinators)
+		addCommTasks(wd, build, milestone, comm, r.kind, wf.Slice(published), securitySummary, securityFixes, coordinators)
 		if r.major >= currentMajor {
 			// Add a task for updating the module proxy test repo that makes sure modules containing go directives
 			// of the latest published version are fetchable.
@@ -435,27 +438,67 @@ func createMinorReleaseWorkflow(build *BuildReleaseTasks, milestone *task.Milest
 
 	securitySummary := wf.Param(wd, securitySummaryParameter)
 	securityFixes := wf.Param(wd, securityFixesParameter)
-	addCommTasks(wd, build, comm, task.KindMinor, wf.Slice(currPublished, prevPublished), securitySummary, securityFixes, coordinators)
+	addCommTasks(wd, build, milestone, comm, task.KindMinor, wf.Slice(currPublished, prevPublished), securitySummary, securityFixes, coordinators)
 	wf.Task1(wd, "update-proxy-test", version.UpdateProxyTestRepo, currPublished)
 
 	return wd, nil
 }
 
 func addCommTasks(
-	wd *wf.Definition, build *BuildReleaseTasks, comm task.CommunicationTasks,
+	wd *wf.Definition, build *BuildReleaseTasks, milestone *task.MilestoneTasks, comm task.CommunicationTasks,
 	kind task.ReleaseKind, published wf.Value[[]task.Published], securitySummary wf.Value[string], securityFixes, coordinators wf.Value[[]string],
 ) {
-	okayToAnnounce := wf.Action0(wd, "Wait to Announce", build.ApproveAction, wf.After(published))
+	// Check that the security fixes' CVE and GHSA IDs have advisories to link to.
+	advisoriesChecked := wf.Action1(wd, "Check security advisories", milestone.CheckSecurityAdvisories, securityFixes, wf.After(published))
+	// Check that exactly the files expected for the release targets were published.
+	filesChecked := wf.Action1(wd, "Check published files", task.CheckPublishedFiles, published)
+	okayToAnnounce := wf.Action0(wd, "Wait to Announce", build.ApproveAction, wf.After(published, advisoriesChecked, filesChecked))
 
 	// Announce that a new Go release has been published.
-	sentMail := wf.Task4(wd, "mail-announcement", comm.AnnounceRelease, wf.Const(kind), published, securityFixes, coordinators, wf.After(okayToAnnounce))
+	sentMail := addAnnounceMail(wd, comm, kind, published, securityFixes, coordinators, wf.After(okayToAnnounce))
 	announcementURL := wf.Task1(wd, "await-announcement", comm.AwaitAnnounceMail, sentMail)
 	tweetURL := wf.Task4(wd, "post-tweet", comm.TweetRelease, wf.Const(kind), published, securitySummary, announcementURL, wf.After(okayToAnnounce))
 	mastodonURL := wf.Task4(wd, "post-mastodon", comm.TrumpetRelease, wf.Const(kind), published, securitySummary, announcementURL, wf.After(okayToAnnounce))
+	feedEntry := wf.Task4(wd, "publish-feed-entry", comm.PublishFeedEntry, wf.Const(kind), published, securityFixes, announcementURL, wf.After(okayToAnnounce))
 
 	wf.Output(wd, "Announcement URL", announcementURL)
 	wf.Output(wd, "Tweet URL", tweetURL)
 	wf.Output(wd, "Mastodon URL", mastodonURL)
+	wf.Output(wd, "Release feed entry", feedEntry)
+}
+
+// addAnnounceMail adds the task that mails the announcement of the
+// published release, using the subject template comm has for kind,
+// if any.
+func addAnnounceMail(
+	wd *wf.Definition, comm task.CommunicationTasks,
+	kind task.ReleaseKind, published wf.Value[[]task.Published], securityFixes, coordinators wf.Value[[]string], opts ...wf.TaskOption,
+) wf.Value[task.SentMail] {
+	return wf.Task4(wd, "mail-announcement", comm.AnnounceRelease, wf.Const(kind), published, securityFixes, coordinators, opts...)
+}
+
+// ParseSubjectTemplate parses an announcement subject template given as
+// kind=template, where kind is beta, rc, major, or minor, for use in
+// task.AnnounceMailTasks.SubjectTemplates. It checks that the template
+// works for announcements of that kind of release.
+func ParseSubjectTemplate(s string) (task.ReleaseKind, string, error) {
+	name, tmpl, ok := strings.Cut(s, "=")
+	if !ok {
+		return 0, "", fmt.Errorf("subject template %q isn't in kind=template form", s)
+	}
+	kind, ok := map[string]task.ReleaseKind{
+		"beta":  task.KindBeta,
+		"rc":    task.KindRC,
+		"major": task.KindMajor,
+		"minor": task.KindMinor,
+	}[name]
+	if !ok {
+		return 0, "", fmt.Errorf("unknown release kind %q; want beta, rc, major, or minor", name)
+	}
+	if err := task.CheckSubjectTemplate(kind, tmpl); err != nil {
+		return 0, "", err
+	}
+	return kind, tmpl, nil
 }
 
 func now(_ context.Context) (time.Time, error) {
@@ -502,10 +545,15 @@ This is intended for releases with 1+ PRIVATE-track security fixes.`,
 
 	// Build, test, and sign release.
 	source, signedAndTestedArtifacts, modules := build.addBuildTasks(wd, major, kind, nextVersion, timestamp, srcSpec)
-	waitReleaseApproval := wf.Action0(wd, "Wait for Release Coordinator Approval", build.ApproveAction, wf.After(signedAndTestedArtifacts))
+	metadataChecked := wf.Action3(wd, "Check version metadata", build.checkVersionMetadata, nextVersion, startingHead, securityCommit)
+	changelog := wf.Task2(wd, "Generate changelog", version.Changelog, nextVersion, startingHead)
+	wf.Output(wd, "Changelog", changelog)
+	dlFiles := wf.Task3(wd, "Generate DL files", version.GenerateDLFiles, wf.Const(major), kindVal, nextVersion)
+	wf.Output(wd, "DL files", dlFiles)
+	waitReleaseApproval := wf.Action0(wd, "Wait for Release Coordinator Approval", build.ApproveAction, wf.After(signedAndTestedArtifacts, metadataChecked, changelog, dlFiles))
 	okayToTagAndPublish := wf.Action3(wd, "Re-check blocking issues", milestone.CheckBlockers, milestones, nextVersion, kindVal, wf.After(waitReleaseApproval))
 
-	dlcl := wf.Task5(wd, "Mail DL CL", version.MailDLCL, wf.Const(major), kindVal, nextVersion, coordinators, wf.Const(false), wf.After(okayToTagAndPublish))
+	dlcl := wf.Task4(wd, "Mail DL CL", version.MailDLFilesCL, nextVersion, dlFiles, coordinators, wf.Const(false), wf.After(okayToTagAndPublish))
 	dlclCommit := wf.Task2(wd, "Wait for DL CL submission", version.AwaitCL, dlcl, wf.Const(""))
 	wf.Output(wd, "Download CL submitted", dlclCommit)
 
@@ -525,7 +573,10 @@ This is intended for releases with 1+ PRIVATE-track security fixes.`,
 	uploaded := wf.Action1(wd, "Upload artifacts to CDN", build.uploadArtifacts, signedAndTestedArtifacts, wf.After(tagged))
 	uploadedMods := wf.Action2(wd, "Upload modules to CDN", build.uploadModules, nextVersion, modules, wf.After(tagged))
 	availableOnProxy := wf.Action2(wd, "Wait for modules on proxy.golang.org", build.awaitProxy, nextVersion, modules, wf.After(uploadedMods))
-	pushed := wf.Action3(wd, "Push issues", milestone.PushIssues, milestones, nextVersion, kindVal, wf.After(tagged))
+	clsChecked := wf.Action2(wd, "Check milestone CLs", milestone.CheckMilestoneCLs, milestones, kindVal, wf.After(okayToTagAndPublish))
+	buildersAtRelease := wf.Task3(wd, "Snapshot builders at release", build.snapshotBuilders, wf.Const(major), kindVal, startingHead, wf.After(okayToTagAndPublish))
+	wf.Output(wd, "Builders at release", buildersAtRelease)
+	pushed := wf.Action3(wd, "Push issues", milestone.PushIssues, milestones, nextVersion, kindVal, wf.After(tagged, clsChecked))
 	published := wf.Task2(wd, "Publish to website", build.publishArtifacts, nextVersion, signedAndTestedArtifacts, wf.After(uploaded, availableOnProxy, pushed))
 	if kind == task.KindMajor {
 		xToolsStdlibCL := wf.Task2(wd, fmt.Sprintf("Mail x/tools stdlib CL for 1.%d", major), version.CreateUpdateStdlibIndexCL, coordinators, nextVersion, wf.After(published))
@@ -602,7 +653,10 @@ func (tasks *BuildReleaseTasks) addBuildTasks(wd *wf.Definition, major int, kind
 			pkg := wf.Task1(wd, "Build PKG installer", tasks.buildDarwinPKG, tar)
 			signedPKG := wf.Task2(wd, "Sign PKG installer", tasks.signArtifact, pkg, wf.Const(sign.BuildMacOS))
 			signedTGZ := wf.Task2(wd, "Merge signed files into .tgz", tasks.mergeSignedToTGZ, tar, signedPKG)
-			mod = wf.Task4(wd, "Merge signed files into module zip", tasks.mergeSignedToModule, version, timestamp, mod, signedPKG)
+			signedMod := wf.Task4(wd, "Merge signed files into module zip", tasks.mergeSignedToModule, version, timestamp, mod, signedPKG)
+			verified := wf.Action4(wd, "Verify signed files are reproducible", tasks.verifySignedDarwinFiles, tar, signedTGZ, mod, signedMod)
+			blockers = append(blockers, verified)
+			mod = signedMod
 			artifacts = append(artifacts, signedPKG, signedTGZ)
 		case "windows":
 			msi := wf.Task1(wd, "Build MSI installer", tasks.buildWindowsMSI, tar)
@@ -686,6 +740,24 @@ func (b *BuildReleaseTasks) readSecurityRef(ctx *wf.TaskContext, ref string) (st
 	return commit, nil
 }
 
+// snapshotBuilders records the results of the release branch's
+// post-submit builders at commit, for the release record.
+func (b *BuildReleaseTasks) snapshotBuilders(ctx *wf.TaskContext, major int, kind task.ReleaseKind, commit string) (task.BuildersSnapshot, error) {
+	if b.BuildBucketClient == nil {
+		ctx.Printf("no BuildBucket client; not taking a snapshot")
+		return task.BuildersSnapshot{}, nil
+	}
+	prefix := fmt.Sprintf("go1.%d-", major)
+	if kind == task.KindBeta {
+		prefix = "gotip-"
+	}
+	u, err := url.Parse(b.GerritClient.GitilesURL())
+	if err != nil {
+		return task.BuildersSnapshot{}, err
+	}
+	return task.SnapshotBuilders(ctx, b.BuildBucketClient, u.Host, b.GerritProject, commit, prefix)
+}
+
 func (b *BuildReleaseTasks) getGitSource(ctx *wf.TaskContext, branch, commit, securityCommit, versionFile string) (sourceSpec, error) {
 	client, project, rev := b.GerritClient, b.GerritProject, commit
 	if securityCommit != "" {
@@ -756,6 +828,70 @@ mv go/pkg/distpack/*.src.tar.gz src.tar.gz
 	return err
 }
 
+// checkVersionMetadata checks that the version metadata in the source
+// being released agrees with version, before it's baked into a tag.
+// The VERSION file, if present, must be for an earlier release in the
+// same major series. The go directives in src/go.mod and src/cmd/go.mod
+// must be for the same major series, and their toolchain directives, if
+// any, mustn't be for a later release.
+func (b *BuildReleaseTasks) checkVersionMetadata(ctx *wf.TaskContext, version, commit, securityCommit string) error {
+	client, project, rev := b.GerritClient, b.GerritProject, commit
+	if securityCommit != "" {
+		client, project, rev = b.PrivateGerritClient, b.PrivateGerritProject, securityCommit
+	}
+	lang := goversion.Lang(version)
+	var problems []string
+
+	versionFile, err := client.ReadFile(ctx, project, rev, "VERSION")
+	switch {
+	case errors.Is(err, gerrit.ErrResourceNotExist):
+		ctx.Printf("No VERSION file at %s.", rev)
+	case err != nil:
+		return err
+	default:
+		prev, _, _ := strings.Cut(string(versionFile), "\n")
+		switch {
+		case !goversion.IsValid(prev):
+			problems = append(problems, fmt.Sprintf("VERSION file has invalid version %q", prev))
+		case goversion.Lang(prev) != lang:
+			problems = append(problems, fmt.Sprintf("VERSION file has version %s, not in the %s series", prev, lang))
+		case goversion.Compare(prev, version) >= 0:
+			problems = append(problems, fmt.Sprintf("VERSION file has version %s, which isn't before %s", prev, version))
+		}
+	}
+
+	for _, file := range []string{"src/go.mod", "src/cmd/go.mod"} {
+		data, err := client.ReadFile(ctx, project, rev, file)
+		if errors.Is(err, gerrit.ErrResourceNotExist) {
+			ctx.Printf("No %s at %s.", file, rev)
+			continue
+		} else if err != nil {
+			return err
+		}
+		f, err := modfile.Parse(file, data, nil)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", file, err))
+			continue
+		}
+		if f.Go == nil {
+			problems = append(problems, fmt.Sprintf("%s has no go directive", file))
+		} else if got := goversion.Lang("go" + f.Go.Version); got != lang {
+			problems = append(problems, fmt.Sprintf("%s has go directive %s, want %s", file, f.Go.Version, strings.TrimPrefix(lang, "go")))
+		}
+		if f.Toolchain != nil {
+			tc := f.Toolchain.Name
+			if goversion.Lang(tc) != lang || goversion.Compare(tc, version) > 0 {
+				problems = append(problems, fmt.Sprintf("%s has toolchain directive %s, which doesn't match %s", file, tc, version))
+			}
+		}
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("version metadata at %s doesn't match %s:\n%s", rev, version, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
 func (b *BuildReleaseTasks) checkSourceMatch(ctx *wf.TaskContext, branch, versionFile string, source artifact) (head string, _ error) {
 	head, err := b.GerritClient.ReadBranchHead(ctx, b.GerritProject, branch)
 	if err != nil {
@@ -1065,6 +1201,51 @@ func (b *BuildReleaseTasks) mergeSignedToTGZ(ctx *wf.TaskContext, unsigned, sign
 	})
 }
 
+// verifySignedDarwinFiles runs the check cmd/gorebuild makes of a macOS
+// target's published .tgz and module zip, comparing the signed files
+// against the unsigned ones from the distpack, which gorebuild
+// reproduces. They must match apart from the code signatures of the
+// binaries. If they don't, the release coordinator must approve
+// publishing them anyway.
+func (b *BuildReleaseTasks) verifySignedDarwinFiles(ctx *wf.TaskContext, tgz, signedTGZ artifact, mod, signedMod moduleArtifact) error {
+	read := func(scratch string) ([]byte, error) {
+		r, err := b.ScratchFS.OpenRead(ctx, scratch)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+	var mismatched []string
+	for _, f := range []struct {
+		name             string // file name, for its extension
+		unsigned, signed string // scratch paths
+	}{
+		{"go.tar.gz", tgz.Scratch, signedTGZ.Scratch},
+		{"module.zip", mod.ZipScratch, signedMod.ZipScratch},
+	} {
+		unsigned, err := read(f.unsigned)
+		if err != nil {
+			return err
+		}
+		signed, err := read(f.signed)
+		if err != nil {
+			return err
+		}
+		log := gorebuild.Log{Name: f.name, Output: func(text string) {
+			ctx.Printf("%s: %s", f.name, text)
+		}}
+		if !gorebuild.MatchDarwin(&log, f.name, unsigned, signed) {
+			mismatched = append(mismatched, f.name)
+		}
+	}
+	if len(mismatched) > 0 {
+		ctx.Printf("Signed files don't match the distpack (%s), so gorebuild won't verify them as reproducible. Check the logs and approve this task if it's okay.", strings.Join(mismatched, ", "))
+		return b.ApproveAction(ctx)
+	}
+	return nil
+}
+
 func (b *BuildReleaseTasks) mergeSignedToModule(ctx *wf.TaskContext, version string, timestamp time.Time, mod moduleArtifact, signed artifact) (moduleArtifact, error) {
 	a, err := b.runBuildStep(ctx, nil, signed, "signedmod.zip", func(signed io.Reader, w io.Writer) error {
 		signedBinaries, err := task.ReadBinariesFromPKG(signed)
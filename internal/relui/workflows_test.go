@@ -174,6 +174,65 @@ func TestCheckTaskApproved(t *testing.T) {
 	}
 }
 
+func TestParseSubjectTemplate(t *testing.T) {
+	kind, tmpl, err := ParseSubjectTemplate("rc=[ANN] Go {{.Version|major}} RC {{.Version|build}}")
+	if err != nil || kind != task.KindRC || tmpl != "[ANN] Go {{.Version|major}} RC {{.Version|build}}" {
+		t.Errorf("ParseSubjectTemplate = %#v, %q, %v; want KindRC, the template, nil", kind, tmpl, err)
+	}
+	for _, s := range []string{
+		"[ANN] Go {{.Version|major}}", // no kind
+		"point=[ANN] Go {{.Version}}", // unknown kind
+		"minor=Go {{.Version",         // bad template
+	} {
+		if _, _, err := ParseSubjectTemplate(s); err == nil {
+			t.Errorf("ParseSubjectTemplate(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestAnnounceMailSubjectTemplate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("not running test that uses internet in short mode")
+	}
+	kind, tmpl, err := ParseSubjectTemplate("minor={{subjectPrefix .}} [ANN] Go {{short .Version}}{{with .SecondaryVersion}}, Go {{short .}}{{end}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotSubject string
+	comm := task.CommunicationTasks{
+		AnnounceMailTasks: task.AnnounceMailTasks{
+			SendMail: func(_ task.MailHeader, c task.MailContent) error {
+				gotSubject = c.Subject
+				return nil
+			},
+			SubjectTemplates: map[task.ReleaseKind]string{kind: tmpl},
+		},
+	}
+	wd := workflow.New(workflow.ACL{})
+	// Intentionally not 1.17.9 so the real email doesn't get in the way.
+	published := workflow.Const([]task.Published{{Version: "go1.18.1"}, {Version: "go1.17.8"}})
+	sent := addAnnounceMail(wd, comm, task.KindMinor, published, workflow.Const([]string(nil)), workflow.Const([]string{"heschi"}))
+	workflow.Output(wd, "sent", sent)
+	w, err := workflow.Start(wd, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Not runWorkflow: AnnounceRelease wants more time than its deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	outputs, err := w.Run(ctx, &verboseListener{t: t})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "[ANN] Go 1.18.1, Go 1.17.8"
+	if gotSubject != want {
+		t.Errorf("mailed subject = %q, want %q", gotSubject, want)
+	}
+	if got := outputs["sent"].(task.SentMail).Subject; got != want {
+		t.Errorf("sent mail subject = %q, want %q", got, want)
+	}
+}
+
 func runWorkflow(t *testing.T, ctx context.Context, w *workflow.Workflow, listener workflow.Listener) (map[string]interface{}, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
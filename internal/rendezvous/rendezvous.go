@@ -184,7 +184,7 @@ func (rdv *Rendezvous) HandleReverse(w http.ResponseWriter, r *http.Request) {
 		res.ch <- &result{err: err}
 		return
 	}
-	bc, err := connToClient(conn, hostname, "swarming_task")
+	bc, err := connToClient(conn, id, hostname, "swarming_task")
 	if err != nil {
 		log.Printf("rendezvous: unable to create buildlet client: %s", err)
 		conn.Close()
@@ -194,7 +194,10 @@ func (rdv *Rendezvous) HandleReverse(w http.ResponseWriter, r *http.Request) {
 	res.ch <- &result{bc: bc}
 }
 
-func connToClient(conn net.Conn, hostname, hostType string) (buildlet.Client, error) {
+// connToClient returns a client for the buildlet on the other end of conn.
+// The client is named id, so that a gomote server that restarts can
+// expect the same buildlet to reconnect.
+func connToClient(conn net.Conn, id, hostname, hostType string) (buildlet.Client, error) {
 	if err := (&http.Response{StatusCode: http.StatusSwitchingProtocols, Proto: "HTTP/1.1"}).Write(conn); err != nil {
 		log.Printf("gomote: error writing upgrade response to reverse buildlet %s (%s) at %s: %v", hostname, hostType, conn.RemoteAddr(), err)
 		conn.Close()
@@ -205,6 +208,7 @@ func connToClient(conn net.Conn, hostname, hostType string) (buildlet.Client, er
 	dialer := revDialer.Dial
 
 	client := buildlet.NewClient(conn.RemoteAddr().String(), buildlet.NoKeyPair)
+	client.SetName(id)
 	client.SetHTTPClient(&http.Client{
 		Transport: &http.Transport{
 			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"text/template"
 	"time"
+	"unicode/utf8"
 
 	sendgrid "github.com/sendgrid/sendgrid-go"
 	sendgridmail "github.com/sendgrid/sendgrid-go/helpers/mail"
@@ -123,6 +124,17 @@ type AnnounceMailTasks struct {
 	// AnnounceMailHeader is the header to use for the release (pre-)announcement email.
 	AnnounceMailHeader MailHeader
 
+	// SubjectTemplates optionally overrides the subject of release
+	// announcement emails, for lists whose subject conventions differ
+	// from the default. It maps a release kind to a text/template that
+	// is executed with the announcement and has the same functions as
+	// the email templates. For example:
+	//
+	//	{{subjectPrefix .}} Go {{short .Version}} is released
+	//
+	// Release kinds not in the map use the subject from the email template.
+	SubjectTemplates map[ReleaseKind]string
+
 	// testHookNow is optionally set by tests to override time.Now.
 	testHookNow func() time.Time
 }
@@ -161,6 +173,12 @@ func (t AnnounceMailTasks) AnnounceRelease(ctx *workflow.TaskContext, kind Relea
 	if err != nil {
 		return SentMail{}, err
 	}
+	if tmpl, ok := t.SubjectTemplates[kind]; ok {
+		m.Subject, err = announceSubject(tmpl, r)
+		if err != nil {
+			return SentMail{}, err
+		}
+	}
 	ctx.Printf("announcement subject: %s\n\n", m.Subject)
 	ctx.Printf("announcement body HTML:\n%s\n", m.BodyHTML)
 	ctx.Printf("announcement body text:\n%s", m.BodyText)
@@ -401,6 +419,9 @@ func announcementMail(data any) (MailContent, error) {
 		return MailContent{}, fmt.Errorf(`email template must have a single "Subject" value in its MIME header, but have %d values`, n)
 	}
 	subject := m.Header.Get("Subject")
+	if err := checkSubject(subject); err != nil {
+		return MailContent{}, fmt.Errorf("email template %q: %v", name, err)
+	}
 
 	// Render the email body, in Markdown format at this point, to HTML and plain text.
 	html, text, err := renderMarkdown(m.Body)
@@ -411,11 +432,85 @@ func announcementMail(data any) (MailContent, error) {
 	return MailContent{subject, html, text}, nil
 }
 
+// maxSubjectLen is the maximum length of an announcement email subject,
+// in characters. Longer subjects are truncated by some mail clients and
+// list archives, which makes them hard to recognize.
+const maxSubjectLen = 120
+
+// checkSubject reports whether subject is acceptable for an announcement email.
+func checkSubject(subject string) error {
+	switch {
+	case strings.TrimSpace(subject) == "":
+		return errors.New("subject is empty")
+	case strings.ContainsAny(subject, "\r\n"):
+		return fmt.Errorf("subject %q spans multiple lines", subject)
+	case utf8.RuneCountInString(subject) > maxSubjectLen:
+		return fmt.Errorf("subject %q is longer than %d characters", subject, maxSubjectLen)
+	}
+	return nil
+}
+
+// announceSubject generates the subject of the release announcement r
+// using the subject template tmpl, and checks that it's acceptable.
+func announceSubject(tmpl string, r releaseAnnouncement) (string, error) {
+	t, err := template.New("subject").Funcs(announceFuncs).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing subject template for %#v release: %v", r.Kind, err)
+	}
+	var buf strings.Builder
+	if err := t.Execute(&buf, r); err != nil {
+		return "", fmt.Errorf("executing subject template for %#v release: %v", r.Kind, err)
+	}
+	// Collapse the spaces left by actions that produce no output,
+	// such as an empty subjectPrefix.
+	subject := strings.Join(strings.Fields(buf.String()), " ")
+	if err := checkSubject(subject); err != nil {
+		return "", err
+	}
+	return subject, nil
+}
+
+// CheckSubjectTemplate reports whether tmpl is a usable subject template
+// for announcements of the given kind of release, as set in
+// AnnounceMailTasks.SubjectTemplates, by executing it for an example
+// release of that kind.
+func CheckSubjectTemplate(kind ReleaseKind, tmpl string) error {
+	r := releaseAnnouncement{Kind: kind}
+	switch kind {
+	case KindBeta:
+		r.Version = "go1.23beta1"
+	case KindRC:
+		r.Version = "go1.23rc1"
+	case KindMajor:
+		r.Version = "go1.23.0"
+	case KindMinor:
+		r.Version, r.SecondaryVersion = "go1.23.1", "go1.22.7"
+	default:
+		return fmt.Errorf("unknown release kind %#v", kind)
+	}
+	_, err := announceSubject(tmpl, r)
+	return err
+}
+
 // announceTmpl holds templates for Go release announcement emails.
 //
 // Each email template starts with a MIME-style header with a Subject key,
 // and the rest of it is Markdown for the email body.
-var announceTmpl = template.Must(template.New("").Funcs(template.FuncMap{
+var announceTmpl = template.Must(template.New("").Funcs(announceFuncs).ParseFS(tmplDir,
+	"template/announce-*.md",
+	"template/pre-announce-minor.md",
+	// Gopls release announcements.
+	"template/gopls-announce.md",
+	"template/gopls-pre-announce.md",
+	// VSCode Go release announcements.
+	"template/vscode-go-pre-announce.md",
+	"template/vscode-go-insider-announce.md",
+	"template/vscode-go-announce.md",
+))
+
+// announceFuncs are the functions available to announcement email
+// and subject templates.
+var announceFuncs = template.FuncMap{
 	"join": func(s []string) string {
 		switch len(s) {
 		case 0:
@@ -479,17 +574,7 @@ var announceTmpl = template.Must(template.New("").Funcs(template.FuncMap{
 		}
 		return "", fmt.Errorf("internal error: unhandled pre-release Go version %q", v)
 	},
-}).ParseFS(tmplDir,
-	"template/announce-*.md",
-	"template/pre-announce-minor.md",
-	// Gopls release announcements.
-	"template/gopls-announce.md",
-	"template/gopls-pre-announce.md",
-	// VSCode Go release announcements.
-	"template/vscode-go-pre-announce.md",
-	"template/vscode-go-insider-announce.md",
-	"template/vscode-go-announce.md",
-))
+}
 
 //go:embed template
 var tmplDir embed.FS
@@ -213,6 +213,72 @@ This is CVE-2022-27536 and https://go.dev/issue/51759.`,
 	}
 }
 
+func TestAnnounceSubject(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		in   releaseAnnouncement
+		want string
+	}{
+		{
+			name: "beta",
+			tmpl: "[ANN] Go {{.Version|major}} beta {{.Version|build}}",
+			in:   releaseAnnouncement{Kind: KindBeta, Version: "go1.19beta1"},
+			want: "[ANN] Go 1.19 beta 1",
+		},
+		{
+			name: "rc",
+			tmpl: "[ANN] Go {{.Version|major}} RC {{.Version|build}}",
+			in:   releaseAnnouncement{Kind: KindRC, Version: "go1.23rc2"},
+			want: "[ANN] Go 1.23 RC 2",
+		},
+		{
+			name: "major",
+			tmpl: "[ANN] Go {{.Version|major}} released",
+			in:   releaseAnnouncement{Kind: KindMajor, Version: "go1.21.0"},
+			want: "[ANN] Go 1.21 released",
+		},
+		{
+			name: "minor",
+			tmpl: "{{subjectPrefix .}} [ANN] Go {{short .Version}}{{with .SecondaryVersion}}, Go {{short .}}{{end}}",
+			in:   releaseAnnouncement{Kind: KindMinor, Version: "go1.18.1", SecondaryVersion: "go1.17.9"},
+			want: "[ANN] Go 1.18.1, Go 1.17.9",
+		},
+		{
+			name: "minor-security",
+			tmpl: "{{subjectPrefix .}} [ANN] Go {{short .Version}}",
+			in:   releaseAnnouncement{Kind: KindMinor, Version: "go1.11.1", Security: []string{"a fix"}},
+			want: "[security] [ANN] Go 1.11.1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := announceSubject(tc.tmpl, tc.in)
+			if err != nil {
+				t.Fatal("announceSubject returned non-nil error:", err)
+			}
+			if got != tc.want {
+				t.Errorf("announceSubject = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAnnounceSubjectInvalid(t *testing.T) {
+	r := releaseAnnouncement{Kind: KindMinor, Version: "go1.18.1"}
+	for _, tmpl := range []string{
+		"",                    // empty
+		"{{subjectPrefix .}}", // empty after execution
+		"Go {{short .Version}}" + strings.Repeat(" is released", 20), // too long
+		"Go {{.Version|build}}", // execution error
+		"Go {{short .Version",   // parse error
+	} {
+		if got, err := announceSubject(tmpl, r); err == nil {
+			t.Errorf("announceSubject(%q) = %q, want error", tmpl, got)
+		}
+	}
+}
+
 // testdataFile reads the named file in the testdata directory.
 func testdataFile(t *testing.T, name string) string {
 	t.Helper()
@@ -232,6 +298,25 @@ func writeTestdataFile(t *testing.T, name string, data []byte) {
 	}
 }
 
+func TestCheckSubjectTemplate(t *testing.T) {
+	if err := CheckSubjectTemplate(KindMinor, "{{subjectPrefix .}} Go {{short .Version}}{{with .SecondaryVersion}} and Go {{short .}}{{end}} are released"); err != nil {
+		t.Errorf("CheckSubjectTemplate for a good minor template: %v", err)
+	}
+	for _, tc := range []struct {
+		kind ReleaseKind
+		tmpl string
+	}{
+		{KindBeta, "Go {{.Version|major}"},            // doesn't parse
+		{KindRC, "Go {{.Nonexistent}}"},               // doesn't execute
+		{KindMajor, "{{if false}}x{{end}}"},           // empty subject
+		{KindUnknown, "Go {{short .Version}} is out"}, // unknown kind
+	} {
+		if err := CheckSubjectTemplate(tc.kind, tc.tmpl); err == nil {
+			t.Errorf("CheckSubjectTemplate(%#v, %q) = nil, want error", tc.kind, tc.tmpl)
+		}
+	}
+}
+
 func TestAnnounceRelease(t *testing.T) {
 	if testing.Short() {
 		t.Skip("not running test that uses internet in short mode")
@@ -7,6 +7,7 @@ package task
 import (
 	"context"
 	"fmt"
+	"time"
 
 	pb "go.chromium.org/luci/buildbucket/proto"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -22,6 +23,17 @@ type BuildBucketClient interface {
 	Completed(ctx context.Context, id int64) (string, bool, error)
 	// SearchBuilds searches for builds matching pred and returns their IDs.
 	SearchBuilds(ctx context.Context, pred *pb.BuildPredicate) ([]int64, error)
+	// SearchBuildResults searches for builds matching pred and returns
+	// their builders and statuses, newest first.
+	SearchBuildResults(ctx context.Context, pred *pb.BuildPredicate) ([]BuildResult, error)
+}
+
+// A BuildResult is the result, possibly not final, of a build.
+type BuildResult struct {
+	ID      int64
+	Builder string
+	Status  pb.Status
+	EndTime time.Time // zero if the build hasn't ended
 }
 
 type RealBuildBucketClient struct {
@@ -100,3 +112,29 @@ func (c *RealBuildBucketClient) SearchBuilds(ctx context.Context, pred *pb.Build
 	}
 	return results, nil
 }
+
+func (c *RealBuildBucketClient) SearchBuildResults(ctx context.Context, pred *pb.BuildPredicate) ([]BuildResult, error) {
+	var results []BuildResult
+	var pageToken string
+nextPage:
+	resp, err := c.BuildsClient.SearchBuilds(ctx, &pb.SearchBuildsRequest{
+		Predicate: pred,
+		PageSize:  1000,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range resp.Builds {
+		r := BuildResult{ID: b.Id, Builder: b.GetBuilder().GetBuilder(), Status: b.Status}
+		if b.EndTime != nil {
+			r.EndTime = b.EndTime.AsTime()
+		}
+		results = append(results, r)
+	}
+	if resp.NextPageToken != "" {
+		pageToken = resp.NextPageToken
+		goto nextPage
+	}
+	return results, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "go.chromium.org/luci/buildbucket/proto"
+	wf "golang.org/x/build/internal/workflow"
+)
+
+// A BuildersSnapshot is a record of the post-submit builders' results
+// for a commit at some point in time, such as when it was released.
+type BuildersSnapshot struct {
+	Project  string
+	Commit   string
+	Time     time.Time // when the snapshot was taken
+	Green    int       // number of builders that passed
+	Builders []BuilderStatus
+}
+
+// BuilderStatus is the status of one builder in a BuildersSnapshot.
+type BuilderStatus struct {
+	Builder string
+	// Status is the status of the builder's latest build of the commit,
+	// such as "SUCCESS", "FAILURE", or "STARTED", or "MISSING"
+	// if the builder has no build of the commit.
+	Status   string
+	BuildURL string `json:",omitempty"`
+}
+
+// SnapshotBuilders records the results of the post-submit builders whose
+// names start with builderPrefix, such as "go1.23-", for commit in project
+// on the Gitiles host. Builders with no build of the commit are included
+// with status "MISSING", so the snapshot shows which builders were green
+// and which weren't.
+func SnapshotBuilders(ctx *wf.TaskContext, bb BuildBucketClient, host, project, commit, builderPrefix string) (BuildersSnapshot, error) {
+	builders, err := bb.ListBuilders(ctx, "ci")
+	if err != nil {
+		return BuildersSnapshot{}, err
+	}
+	results, err := bb.SearchBuildResults(ctx, &pb.BuildPredicate{
+		Builder: &pb.BuilderID{Project: "golang", Bucket: "ci"},
+		Tags: []*pb.StringPair{
+			{Key: "buildset", Value: fmt.Sprintf("commit/gitiles/%s/%s/+/%s", host, project, commit)},
+		},
+	})
+	if err != nil {
+		return BuildersSnapshot{}, err
+	}
+	// Results are newest first, so the first one seen for each
+	// builder is its latest build.
+	latest := make(map[string]BuildResult)
+	for _, r := range results {
+		if _, ok := latest[r.Builder]; !ok {
+			latest[r.Builder] = r
+		}
+	}
+
+	snap := BuildersSnapshot{Project: project, Commit: commit, Time: time.Now().UTC().Round(time.Second)}
+	for name := range builders {
+		if !strings.HasPrefix(name, builderPrefix) {
+			continue
+		}
+		st := BuilderStatus{Builder: name, Status: "MISSING"}
+		if r, ok := latest[name]; ok {
+			st.Status = r.Status.String()
+			st.BuildURL = fmt.Sprintf("https://ci.chromium.org/b/%d", r.ID)
+			if r.Status == pb.Status_SUCCESS {
+				snap.Green++
+			}
+		}
+		snap.Builders = append(snap.Builders, st)
+	}
+	sort.Slice(snap.Builders, func(i, j int) bool { return snap.Builders[i].Builder < snap.Builders[j].Builder })
+	ctx.Printf("%d of %d %s* builders passed at %s", snap.Green, len(snap.Builders), builderPrefix, commit)
+	return snap, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	wf "golang.org/x/build/internal/workflow"
+)
+
+func TestSnapshotBuilders(t *testing.T) {
+	bb := NewFakeBuildBucketClient(23, "https://go.googlesource.com", "ci", []string{"go", "tools"})
+	bb.FailBuilds = []string{"go1.23-darwin-amd64_13"}
+	bb.MissingBuilds = []string{"go1.23-linux-amd64-longtest"}
+	ctx := &wf.TaskContext{Context: context.Background(), Logger: &testLogger{t: t}}
+
+	snap, err := SnapshotBuilders(ctx, bb, "go.googlesource.com", "go", "0123456789012345678901234567890123456789", "go1.23-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, b := range snap.Builders {
+		got = append(got, b.Builder+" "+b.Status)
+		if (b.Status == "MISSING") != (b.BuildURL == "") {
+			t.Errorf("builder %s with status %s has build URL %q", b.Builder, b.Status, b.BuildURL)
+		}
+	}
+	want := []string{
+		"go1.23-darwin-amd64_13 FAILURE",
+		"go1.23-linux-amd64 SUCCESS",
+		"go1.23-linux-amd64-longtest MISSING",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("snapshot builders mismatch (-want +got):\n%s", diff)
+	}
+	if snap.Green != 1 {
+		t.Errorf("Green = %d, want 1", snap.Green)
+	}
+}
@@ -63,7 +63,7 @@ func ReadBinariesFromPKG(pkg io.Reader) (map[string][]byte, error) {
 }
 
 // A minimal xar parser, enough to read macOS .pkg files.
-// Command golang.org/x/build/cmd/gorebuild also has one
+// Package golang.org/x/build/internal/gorebuild also has one
 // for its internal needs.
 //
 // See https://en.wikipedia.org/wiki/Xar_(archiver)
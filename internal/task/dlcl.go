@@ -27,6 +27,18 @@ import (
 //
 // On success, the ID of the change is returned, like "dl~1234".
 func (t *VersionTasks) MailDLCL(ctx *workflow.TaskContext, major int, kind ReleaseKind, version string, reviewers []string, dryRun bool) (changeID string, _ error) {
+	files, err := t.GenerateDLFiles(ctx, major, kind, version)
+	if err != nil {
+		return "", err
+	}
+	return t.MailDLFilesCL(ctx, version, files, reviewers, dryRun)
+}
+
+// GenerateDLFiles generates the files of the golang.org/dl commands
+// for the specified Go version, in the same format as MailDLCL.
+// The map key is the file's path relative to the root of the dl repo,
+// and the map value is its content.
+func (t *VersionTasks) GenerateDLFiles(ctx *workflow.TaskContext, major int, kind ReleaseKind, version string) (map[string]string, error) {
 	var files = make(map[string]string) // Map key is relative path, and map value is file content.
 
 	// Generate main.go files for versions from the template.
@@ -40,15 +52,22 @@ func (t *VersionTasks) MailDLCL(ctx *workflow.TaskContext, major int, kind Relea
 		Version: version,
 		DocLink: docLink(major, kind, version),
 	}); err != nil {
-		return "", fmt.Errorf("dlTmpl.Execute: %v", err)
+		return nil, fmt.Errorf("dlTmpl.Execute: %v", err)
 	}
 	gofmted, err := format.Source(buf.Bytes())
 	if err != nil {
-		return "", fmt.Errorf("could not gofmt: %v", err)
+		return nil, fmt.Errorf("could not gofmt: %v", err)
 	}
 	files[path.Join(version, "main.go")] = string(gofmted)
 	ctx.Printf("file %q (command %q):\n%s", path.Join(version, "main.go"), "golang.org/dl/"+version, gofmted)
+	return files, nil
+}
 
+// MailDLFilesCL mails a golang.org/dl CL that adds the given files,
+// as generated by GenerateDLFiles, for the specified Go version.
+//
+// On success, the ID of the change is returned, like "dl~1234".
+func (t *VersionTasks) MailDLFilesCL(ctx *workflow.TaskContext, version string, files map[string]string, reviewers []string, dryRun bool) (changeID string, _ error) {
 	// Create a Gerrit CL using the Gerrit API.
 	if dryRun {
 		return "(dry-run)", nil
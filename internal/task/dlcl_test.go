@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -169,3 +170,28 @@ func main() {
 		})
 	}
 }
+
+func TestGenerateDLFiles(t *testing.T) {
+	ctx := &workflow.TaskContext{Context: context.Background(), Logger: &testLogger{t, ""}}
+	tasks := &VersionTasks{Gerrit: nil}
+	files, err := tasks.GenerateDLFiles(ctx, 21, KindRC, "go1.21rc2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("got %d files, want 1: %q", len(files), files)
+	}
+	main, ok := files["go1.21rc2/main.go"]
+	if !ok {
+		t.Fatalf("no go1.21rc2/main.go in %q", files)
+	}
+	for _, want := range []string{
+		"// The go1.21rc2 command runs the go command from Go 1.21rc2.\n",
+		"// See the release notes at https://tip.golang.org/doc/go1.21.\n",
+		"\tversion.Run(\"go1.21rc2\")\n",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("go1.21rc2/main.go doesn't contain %q:\n%s", want, main)
+		}
+	}
+}
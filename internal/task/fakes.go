@@ -366,6 +366,35 @@ func (g *FakeGerrit) Tag(ctx context.Context, project, tag, commit string) error
 	return nil
 }
 
+func (g *FakeGerrit) ListCommits(ctx context.Context, project, base, head string) ([]gerrit.CommitInfo, error) {
+	repo, err := g.repo(project)
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.dir.RunCommand(ctx, "log", "-z", "--format=%H%n%an%n%ae%n%B", base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+	var commits []gerrit.CommitInfo
+	for _, entry := range strings.Split(string(out), "\x00") {
+		if entry == "" {
+			continue
+		}
+		f := strings.SplitN(entry, "\n", 4)
+		if len(f) != 4 {
+			return nil, fmt.Errorf("unexpected git log output %q", entry)
+		}
+		subject, _, _ := strings.Cut(f[3], "\n")
+		commits = append(commits, gerrit.CommitInfo{
+			CommitID: f[0],
+			Subject:  subject,
+			Message:  f[3],
+			Author:   gerrit.GitPersonInfo{Name: f[1], Email: f[2]},
+		})
+	}
+	return commits, nil
+}
+
 func (g *FakeGerrit) GetCommitsInRefs(ctx context.Context, project string, commits, refs []string) (map[string][]string, error) {
 	repo, err := g.repo(project)
 	if err != nil {
@@ -1080,7 +1109,9 @@ type FakeBuildBucketClient struct {
 var _ BuildBucketClient = (*FakeBuildBucketClient)(nil)
 
 func (c *FakeBuildBucketClient) ListBuilders(ctx context.Context, bucket string) (map[string]*pb.BuilderConfig, error) {
-	if bucket != c.Bucket {
+	// The post-submit builders in "ci" are always available,
+	// for example to snapshot their results.
+	if bucket != c.Bucket && bucket != "ci" {
 		return nil, fmt.Errorf("unexpected bucket %q", bucket)
 	}
 	res := map[string]*pb.BuilderConfig{}
@@ -1146,13 +1177,42 @@ func (c *FakeBuildBucketClient) SearchBuilds(ctx context.Context, pred *pb.Build
 	return []int64{rand.Int63()}, nil
 }
 
+// SearchBuildResults returns a successful build for each of the
+// builders of c's projects, except for those in MissingBuilds,
+// and a failed one for those in FailBuilds.
+func (c *FakeBuildBucketClient) SearchBuildResults(ctx context.Context, pred *pb.BuildPredicate) ([]BuildResult, error) {
+	builders, err := c.ListBuilders(ctx, c.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	var results []BuildResult
+	for name := range builders {
+		if pred.GetBuilder().GetBuilder() != "" && name != pred.GetBuilder().GetBuilder() || slices.Contains(c.MissingBuilds, name) {
+			continue
+		}
+		status := pb.Status_SUCCESS
+		for _, failBuild := range c.FailBuilds {
+			if strings.Contains(name, failBuild) {
+				status = pb.Status_FAILURE
+			}
+		}
+		results = append(results, BuildResult{ID: rand.Int63(), Builder: name, Status: status, EndTime: time.Now()})
+	}
+	return results, nil
+}
+
 type FakeGitHub struct {
 	// Milestones is a map from milestone ID to milestone name.
 	Milestones map[int]string
+	// ClosedMilestones is the set of IDs of milestones that are closed.
+	ClosedMilestones map[int]bool
 	// Issues is a map from issue number to issue details.
 	// this map contains all the Issues attached to all milestones and Issues that
 	// does not attach to milestone.
 	Issues map[int]*github.Issue
+	// Advisories is a map from GHSA or CVE ID to the published
+	// security advisory with that ID.
+	Advisories map[string]*SecurityAdvisory
 
 	// The following fields modify behavior of the fake to test
 	// certain special scenarios.
@@ -1261,10 +1321,32 @@ func (f *FakeGitHub) GetIssue(_ context.Context, owner, repo string, number int)
 	}
 }
 
-func (*FakeGitHub) EditMilestone(_ context.Context, owner, repo string, number int, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
+func (f *FakeGitHub) GetMilestone(_ context.Context, owner, repo string, number int) (*github.Milestone, *github.Response, error) {
+	name, ok := f.Milestones[number]
+	if !ok {
+		return nil, nil, fmt.Errorf("the milestone %v does not exist", number)
+	}
+	state := "open"
+	if f.ClosedMilestones[number] {
+		state = "closed"
+	}
+	return &github.Milestone{Number: github.Int(number), Title: github.String(name), State: github.String(state)}, nil, nil
+}
+
+func (f *FakeGitHub) EditMilestone(_ context.Context, owner, repo string, number int, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
+	if milestone.GetState() == "closed" {
+		if f.ClosedMilestones == nil {
+			f.ClosedMilestones = map[int]bool{}
+		}
+		f.ClosedMilestones[number] = true
+	}
 	return nil, nil, nil
 }
 
+func (f *FakeGitHub) FindSecurityAdvisory(_ context.Context, id string) (*SecurityAdvisory, error) {
+	return f.Advisories[id], nil
+}
+
 func (f *FakeGitHub) PostComment(_ context.Context, _ githubv4.ID, _ string) error {
 	if f.DisallowComments {
 		return fmt.Errorf("pretend that PostComment failed")
@@ -0,0 +1,108 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/build/internal/gcsfs"
+	"golang.org/x/build/internal/workflow"
+)
+
+// ReleaseFeedEntry is the structured metadata describing a published
+// Go release, for ingestion by systems that maintain a release feed.
+type ReleaseFeedEntry struct {
+	Date     string   `json:"date"`     // Release date, in YYYY-MM-DD format.
+	Versions []string `json:"versions"` // Released versions, in the same format as Go tags. For example, "go1.21.1".
+	Kind     string   `json:"kind"`     // One of "beta", "rc", "major", or "minor".
+	URL      string   `json:"url"`      // Announcement URL, or release notes URL for major releases.
+	Security bool     `json:"security"` // Whether the release includes security fixes.
+}
+
+// ReleaseFeedTasks contains tasks related to the release feed.
+type ReleaseFeedTasks struct {
+	// GCSClient and FeedURL specify where feed entries are written,
+	// one <version>.json file per release. FeedURL is a gs:// or file://
+	// URL. If FeedURL is empty, entries are logged but not written.
+	GCSClient *storage.Client
+	FeedURL   string
+
+	// testHookNow is optionally set by tests to override time.Now.
+	testHookNow func() time.Time
+}
+
+// PublishFeedEntry writes the release feed entry for the published
+// release(s) and returns it. The primary version's entry is written
+// to <version>.json under FeedURL, replacing any earlier entry.
+func (t ReleaseFeedTasks) PublishFeedEntry(ctx *workflow.TaskContext, kind ReleaseKind, published []Published, security []string, announcement string) (ReleaseFeedEntry, error) {
+	if len(published) < 1 || len(published) > 2 {
+		return ReleaseFeedEntry{}, fmt.Errorf("got %d published Go releases, PublishFeedEntry supports only 1 or 2 at once", len(published))
+	}
+	entry, err := releaseFeedEntry(t.now(), kind, published, security, announcement)
+	if err != nil {
+		return ReleaseFeedEntry{}, err
+	}
+	data, err := json.MarshalIndent(entry, "", "\t")
+	if err != nil {
+		return ReleaseFeedEntry{}, err
+	}
+	ctx.Printf("release feed entry:\n%s\n", data)
+
+	if t.FeedURL == "" {
+		ctx.Printf("FeedURL is not set; not writing the entry")
+		return entry, nil
+	}
+	feedFS, err := gcsfs.FromURL(ctx, t.GCSClient, t.FeedURL)
+	if err != nil {
+		return ReleaseFeedEntry{}, err
+	}
+	if err := gcsfs.WriteFile(feedFS, published[0].Version+".json", append(data, '\n')); err != nil {
+		return ReleaseFeedEntry{}, err
+	}
+	return entry, nil
+}
+
+// releaseFeedEntry returns the release feed entry for the published release(s).
+func releaseFeedEntry(now time.Time, kind ReleaseKind, published []Published, security []string, announcement string) (ReleaseFeedEntry, error) {
+	var kindName string
+	switch kind {
+	case KindBeta:
+		kindName = "beta"
+	case KindRC:
+		kindName = "rc"
+	case KindMajor:
+		kindName = "major"
+	case KindMinor:
+		kindName = "minor"
+	default:
+		return ReleaseFeedEntry{}, fmt.Errorf("unknown release kind %#v", kind)
+	}
+	if len(security) > 0 && kind != KindMinor {
+		return ReleaseFeedEntry{}, fmt.Errorf("security fixes are only expected in minor releases, not %s", kindName)
+	}
+	if announcement == "" {
+		return ReleaseFeedEntry{}, fmt.Errorf("missing announcement URL")
+	}
+	entry := ReleaseFeedEntry{
+		Date:     now.UTC().Format("2006-01-02"),
+		Kind:     kindName,
+		URL:      announcement,
+		Security: len(security) > 0,
+	}
+	for _, p := range published {
+		entry.Versions = append(entry.Versions, p.Version)
+	}
+	return entry, nil
+}
+
+func (t ReleaseFeedTasks) now() time.Time {
+	if t.testHookNow != nil {
+		return t.testHookNow()
+	}
+	return time.Now()
+}
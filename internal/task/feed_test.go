@@ -0,0 +1,79 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/internal/workflow"
+)
+
+func TestPublishFeedEntry(t *testing.T) {
+	dir := t.TempDir()
+	tasks := ReleaseFeedTasks{
+		FeedURL:     "file://" + filepath.ToSlash(dir),
+		testHookNow: func() time.Time { return time.Date(2023, time.October, 5, 16, 0, 0, 0, time.UTC) },
+	}
+	var buf bytes.Buffer
+	ctx := &workflow.TaskContext{Context: context.Background(), Logger: fmtWriter{&buf}}
+	got, err := tasks.PublishFeedEntry(ctx, KindMinor,
+		[]Published{{Version: "go1.21.2"}, {Version: "go1.20.9"}},
+		[]string{"cmd/go: line directives allows arbitrary execution during build"},
+		"https://groups.google.com/g/golang-announce/c/XBa1oHDevAo/m/desYyx3qAgAJ")
+	if err != nil {
+		t.Fatal("PublishFeedEntry:", err)
+	}
+	want := ReleaseFeedEntry{
+		Date:     "2023-10-05",
+		Versions: []string{"go1.21.2", "go1.20.9"},
+		Kind:     "minor",
+		URL:      "https://groups.google.com/g/golang-announce/c/XBa1oHDevAo/m/desYyx3qAgAJ",
+		Security: true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("entry mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "go1.21.2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written ReleaseFeedEntry
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("written entry is not valid JSON: %v\n%s", err, data)
+	}
+	if diff := cmp.Diff(want, written); diff != "" {
+		t.Errorf("written entry mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReleaseFeedEntryErrors(t *testing.T) {
+	now := time.Date(2023, time.October, 5, 16, 0, 0, 0, time.UTC)
+	published := []Published{{Version: "go1.22rc1"}}
+	const url = "https://groups.google.com/g/golang-announce/c/abc"
+	for _, tc := range [...]struct {
+		name         string
+		kind         ReleaseKind
+		security     []string
+		announcement string
+	}{
+		{name: "unknown kind", kind: KindUnknown, announcement: url},
+		{name: "security in rc", kind: KindRC, security: []string{"fix"}, announcement: url},
+		{name: "no announcement", kind: KindRC},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := releaseFeedEntry(now, tc.kind, published, tc.security, tc.announcement); err == nil {
+				t.Error("releaseFeedEntry: got nil error, want non-nil")
+			}
+		})
+	}
+}
@@ -58,6 +58,10 @@ type GerritClient interface {
 	ReadDir(ctx context.Context, project, commit, dir string) ([]struct{ Name string }, error)
 	// GetCommitsInRefs gets refs in which the specified commits were merged into.
 	GetCommitsInRefs(ctx context.Context, project string, commits, refs []string) (map[string][]string, error)
+	// ListCommits returns the commits in project that are reachable from
+	// head but not from base, newest first. Only the CommitID, Subject,
+	// Message, and Author name and email of each commit are set.
+	ListCommits(ctx context.Context, project, base, head string) ([]gerrit.CommitInfo, error)
 	// QueryChanges gets changes which match the query.
 	QueryChanges(ctx context.Context, query string) ([]*gerrit.ChangeInfo, error)
 	// SetHashtags modifies the hashtags for a CL.
@@ -305,6 +309,49 @@ func (c *RealGerritClient) GetCommitsInRefs(ctx context.Context, project string,
 	return c.Client.GetCommitsInRefs(ctx, project, commits, refs)
 }
 
+// maxListCommits is the most commits that ListCommits returns, so that
+// an unexpectedly large range doesn't take forever to list.
+const maxListCommits = 10000
+
+func (c *RealGerritClient) ListCommits(ctx context.Context, project, base, head string) ([]gerrit.CommitInfo, error) {
+	type person struct{ Name, Email string }
+	var commits []gerrit.CommitInfo
+	next := ""
+	for {
+		u := c.Gitiles + "/" + url.PathEscape(project) + "/+log/" + url.PathEscape(base) + ".." + url.PathEscape(head) + "?format=JSON&n=1000"
+		if next != "" {
+			u += "&s=" + url.QueryEscape(next)
+		}
+		var resp struct {
+			Log []struct {
+				Commit  string
+				Author  person
+				Message string
+			}
+			Next string
+		}
+		if err := fetchGitilesJSON(ctx, u, &resp); err != nil {
+			return nil, err
+		}
+		for _, l := range resp.Log {
+			subject, _, _ := strings.Cut(l.Message, "\n")
+			commits = append(commits, gerrit.CommitInfo{
+				CommitID: l.Commit,
+				Subject:  subject,
+				Message:  l.Message,
+				Author:   gerrit.GitPersonInfo{Name: l.Author.Name, Email: l.Author.Email},
+			})
+		}
+		if resp.Next == "" {
+			return commits, nil
+		}
+		if len(commits) >= maxListCommits {
+			return nil, fmt.Errorf("more than %d commits between %s and %s", maxListCommits, base, head)
+		}
+		next = resp.Next
+	}
+}
+
 // ChangeLink returns a link to the review page for the CL with the specified
 // change ID. The change ID must be in the project~cl# form.
 func ChangeLink(changeID string) string {
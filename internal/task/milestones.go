@@ -9,13 +9,16 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/go-github/v48/github"
 	"github.com/shurcooL/githubv4"
+	"golang.org/x/build/gerrit"
 	wf "golang.org/x/build/internal/workflow"
 	goversion "golang.org/x/build/maintner/maintnerd/maintapi/version"
 )
@@ -25,6 +28,10 @@ type MilestoneTasks struct {
 	Client              GitHubClientInterface
 	RepoOwner, RepoName string
 	ApproveAction       func(*wf.TaskContext) error
+
+	// Gerrit is used by CheckMilestoneCLs to find the CLs that
+	// reference issues. If nil, CheckMilestoneCLs does nothing.
+	Gerrit GerritClient
 }
 
 // ReleaseKind is the type of release being run.
@@ -137,6 +144,112 @@ func (m *MilestoneTasks) CheckBlockers(ctx *wf.TaskContext, milestones ReleaseMi
 	return m.ApproveAction(ctx)
 }
 
+// CheckMilestoneCLs checks whether the CLs that reference open issues
+// in the current milestone have been submitted, ahead of PushIssues
+// closing the milestone. If any of those CLs are still open, it lists
+// them and waits for approval. Issues that aren't referenced by any CL
+// are listed for information only.
+//
+// It does nothing for betas and RCs, since they don't close the milestone.
+func (m *MilestoneTasks) CheckMilestoneCLs(ctx *wf.TaskContext, milestones ReleaseMilestones, kind ReleaseKind) error {
+	if kind != KindMajor && kind != KindMinor {
+		return nil
+	}
+	if m.Gerrit == nil {
+		ctx.Printf("No Gerrit client configured, not checking CLs.")
+		return nil
+	}
+	issues, err := m.Client.FetchMilestoneIssues(ctx, m.RepoOwner, m.RepoName, milestones.Current)
+	if err != nil {
+		return err
+	}
+	var numbers []int
+	for number := range issues {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	var openCLs []string
+	var withoutCLs []string
+	for _, number := range numbers {
+		changes, err := m.Gerrit.QueryChanges(ctx, issueCLsQuery(m.RepoOwner, m.RepoName, number))
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			withoutCLs = append(withoutCLs, fmt.Sprintf("https://go.dev/issue/%d", number))
+			continue
+		}
+		for _, cl := range changes {
+			if cl.Status == gerrit.ChangeStatusNew {
+				openCLs = append(openCLs, fmt.Sprintf("• go.dev/cl/%d - %s (for https://go.dev/issue/%d)", cl.ChangeNumber, cl.Subject, number))
+			}
+		}
+	}
+	if len(withoutCLs) > 0 {
+		ctx.Printf("%d open issues in milestone %d aren't referenced by any CL:\n%s", len(withoutCLs), milestones.Current, strings.Join(withoutCLs, "\n"))
+	}
+	if len(openCLs) == 0 {
+		ctx.Printf("All CLs referencing the %d open issues in milestone %d are submitted or abandoned. Proceeding.", len(numbers), milestones.Current)
+		return nil
+	}
+	ctx.Printf("There are %d open CLs referencing issues in https://github.com/%s/%s/milestone/%d. Check that they're expected to miss this release and approve this task:\n%s",
+		len(openCLs), m.RepoOwner, m.RepoName, milestones.Current, strings.Join(openCLs, "\n"))
+	return m.ApproveAction(ctx)
+}
+
+// advisoryIDRE matches the CVE and GHSA IDs referenced by security fix descriptions.
+var advisoryIDRE = regexp.MustCompile(`\b(CVE-\d{4}-\d{4,}|GHSA(?:-[23456789cfghjmpqrvwx]{4}){3})\b`)
+
+// CheckSecurityAdvisories checks that each CVE and GHSA ID referenced
+// by securityFixes has a published, non-withdrawn GitHub security
+// advisory, so that the announcement doesn't link to missing ones.
+// If any don't, it lists them and waits for approval.
+func (m *MilestoneTasks) CheckSecurityAdvisories(ctx *wf.TaskContext, securityFixes []string) error {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, fix := range securityFixes {
+		for _, id := range advisoryIDRE.FindAllString(fix, -1) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var problems []string
+	for _, id := range ids {
+		adv, err := m.Client.FindSecurityAdvisory(ctx, id)
+		if err != nil {
+			return err
+		}
+		switch {
+		case adv == nil:
+			problems = append(problems, fmt.Sprintf("• %s: no published advisory found", id))
+		case adv.WithdrawnAt != nil:
+			problems = append(problems, fmt.Sprintf("• %s: advisory %s was withdrawn", id, adv.HTMLURL))
+		default:
+			ctx.Printf("%s: found advisory %s", id, adv.HTMLURL)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	ctx.Printf("Some of the security fixes reference IDs without a published GitHub security advisory. Publish them or check that they're expected and approve this task:\n%s",
+		strings.Join(problems, "\n"))
+	return m.ApproveAction(ctx)
+}
+
+// issueCLsQuery returns a Gerrit search query for the CLs whose commit
+// messages reference the GitHub issue owner/repo#number, using any of
+// the forms accepted by gopherbot.
+func issueCLsQuery(owner, repo string, number int) string {
+	return fmt.Sprintf(`message:"%[1]s/%[2]s#%[3]d" OR message:"go.dev/issue/%[3]d" OR message:"golang.org/issue/%[3]d" OR (repo:%[2]s message:"#%[3]d")`, owner, repo, number)
+}
+
 // PushIssues updates issues to reflect a finished release.
 // For major and minor releases, it moves issues to the next milestone and closes the current milestone.
 // For pre-releases, it cleans up any "okay-after-..." labels in the current milestone that are done serving their purpose.
@@ -185,17 +298,33 @@ func (m *MilestoneTasks) PushIssues(ctx *wf.TaskContext, milestones ReleaseMiles
 		ctx.Printf("Updated issue %d: %s.", issueNumber, strings.Join(actions, ", "))
 	}
 	if kind == KindMajor || kind == KindMinor {
-		_, _, err := m.Client.EditMilestone(ctx, m.RepoOwner, m.RepoName, milestones.Current, &github.Milestone{
-			State: github.String("closed"),
-		})
-		if err != nil {
-			return err
-		}
-		ctx.Printf("Closed milestone %d.", milestones.Current)
+		return m.closeMilestone(ctx, milestones.Current)
 	}
 	return nil
 }
 
+// closeMilestone closes the milestone with the given number.
+// It does nothing if the milestone is already closed, such as
+// when a workflow retries a task that closed it, so it's safe to retry.
+func (m *MilestoneTasks) closeMilestone(ctx *wf.TaskContext, number int) error {
+	milestone, _, err := m.Client.GetMilestone(ctx, m.RepoOwner, m.RepoName, number)
+	if err != nil {
+		return err
+	}
+	if milestone.GetState() == "closed" {
+		ctx.Printf("Milestone %d is already closed.", number)
+		return nil
+	}
+	_, _, err = m.Client.EditMilestone(ctx, m.RepoOwner, m.RepoName, number, &github.Milestone{
+		State: github.String("closed"),
+	})
+	if err != nil {
+		return err
+	}
+	ctx.Printf("Closed milestone %d.", number)
+	return nil
+}
+
 // PingEarlyIssues pings early-in-cycle issues in the development major release milestone.
 // This is done once at the opening of a release cycle, currently via a standalone workflow.
 //
@@ -312,9 +441,16 @@ type GitHubClientInterface interface {
 	// See github.Client.Issues.Get.
 	GetIssue(ctx context.Context, owner, repo string, number int) (*github.Issue, *github.Response, error)
 
+	// See github.Client.Issues.GetMilestone.
+	GetMilestone(ctx context.Context, owner, repo string, number int) (*github.Milestone, *github.Response, error)
+
 	// See github.Client.Issues.EditMilestone.
 	EditMilestone(ctx context.Context, owner, repo string, number int, milestone *github.Milestone) (*github.Milestone, *github.Response, error)
 
+	// FindSecurityAdvisory returns the published GitHub global security
+	// advisory with the given GHSA or CVE ID, or nil if there is none.
+	FindSecurityAdvisory(ctx context.Context, id string) (*SecurityAdvisory, error)
+
 	// PostComment creates a comment on a GitHub issue or pull request
 	// identified by the given GitHub Node ID.
 	PostComment(_ context.Context, id githubv4.ID, body string) error
@@ -506,10 +642,54 @@ func (c *GitHubClient) GetIssue(ctx context.Context, owner, repo string, number
 	return c.V3.Issues.Get(ctx, owner, repo, number)
 }
 
+func (c *GitHubClient) GetMilestone(ctx context.Context, owner, repo string, number int) (*github.Milestone, *github.Response, error) {
+	return c.V3.Issues.GetMilestone(ctx, owner, repo, number)
+}
+
 func (c *GitHubClient) EditMilestone(ctx context.Context, owner, repo string, number int, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
 	return c.V3.Issues.EditMilestone(ctx, owner, repo, number, milestone)
 }
 
+// SecurityAdvisory is a GitHub global security advisory.
+// See https://docs.github.com/en/rest/security-advisories/global-advisories.
+type SecurityAdvisory struct {
+	GHSAID      string     `json:"ghsa_id"`
+	CVEID       string     `json:"cve_id"`
+	HTMLURL     string     `json:"html_url"`
+	PublishedAt *time.Time `json:"published_at"`
+	WithdrawnAt *time.Time `json:"withdrawn_at"`
+}
+
+// FindSecurityAdvisory uses the global advisories endpoint directly,
+// since github.Client doesn't support it yet.
+func (c *GitHubClient) FindSecurityAdvisory(ctx context.Context, id string) (*SecurityAdvisory, error) {
+	if strings.HasPrefix(id, "GHSA-") {
+		req, err := c.V3.NewRequest("GET", "advisories/"+url.PathEscape(id), nil)
+		if err != nil {
+			return nil, err
+		}
+		adv := new(SecurityAdvisory)
+		if resp, err := c.V3.Do(ctx, req, adv); resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return adv, nil
+	}
+	req, err := c.V3.NewRequest("GET", "advisories?cve_id="+url.QueryEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var advs []*SecurityAdvisory
+	if _, err := c.V3.Do(ctx, req, &advs); err != nil {
+		return nil, err
+	}
+	if len(advs) == 0 {
+		return nil, nil
+	}
+	return advs[0], nil
+}
+
 func (c *GitHubClient) PostComment(ctx context.Context, id githubv4.ID, body string) error {
 	return c.V4.Mutate(ctx, new(struct {
 		AddComment struct {
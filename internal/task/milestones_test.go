@@ -11,9 +11,11 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v48/github"
 	"github.com/shurcooL/githubv4"
+	"golang.org/x/build/gerrit"
 	"golang.org/x/build/internal/workflow"
 	"golang.org/x/oauth2"
 )
@@ -82,6 +84,162 @@ func TestCheckBlockers(t *testing.T) {
 	}
 }
 
+type issueCLsGerrit struct {
+	GerritClient
+	changes map[int][]*gerrit.ChangeInfo // issue number -> CLs referencing it
+}
+
+func (g *issueCLsGerrit) QueryChanges(_ context.Context, query string) ([]*gerrit.ChangeInfo, error) {
+	for number, changes := range g.changes {
+		if query == issueCLsQuery("golang", "go", number) {
+			return changes, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestCheckMilestoneCLs(t *testing.T) {
+	var errManualApproval = fmt.Errorf("manual approval is required")
+	milestone := &github.Milestone{ID: github.Int64(1)}
+	issues := map[int]*github.Issue{100: {Milestone: milestone}, 200: {Milestone: milestone}}
+	for _, tc := range [...]struct {
+		name    string
+		kind    ReleaseKind
+		changes map[int][]*gerrit.ChangeInfo
+		want    error
+	}{
+		{
+			name: "no CLs",
+			kind: KindMinor,
+			want: nil, // Want no error.
+		},
+		{
+			name: "submitted and abandoned CLs",
+			kind: KindMajor,
+			changes: map[int][]*gerrit.ChangeInfo{
+				100: {{ChangeNumber: 1, Status: gerrit.ChangeStatusMerged}, {ChangeNumber: 2, Status: gerrit.ChangeStatusAbandoned}},
+			},
+			want: nil, // Want no error.
+		},
+		{
+			name: "open CL",
+			kind: KindMinor,
+			changes: map[int][]*gerrit.ChangeInfo{
+				100: {{ChangeNumber: 1, Status: gerrit.ChangeStatusMerged}},
+				200: {{ChangeNumber: 3, Status: gerrit.ChangeStatusNew}},
+			},
+			want: errManualApproval,
+		},
+		{
+			name: "open CL before an RC",
+			kind: KindRC,
+			changes: map[int][]*gerrit.ChangeInfo{
+				200: {{ChangeNumber: 3, Status: gerrit.ChangeStatusNew}},
+			},
+			want: nil, // RCs don't close the milestone, so there's nothing to check.
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks := &MilestoneTasks{
+				Client: &FakeGitHub{
+					Milestones:       map[int]string{1: "random-milestone"},
+					Issues:           issues,
+					DisallowComments: true,
+				},
+				RepoOwner:     "golang",
+				RepoName:      "go",
+				ApproveAction: func(*workflow.TaskContext) error { return errManualApproval },
+				Gerrit:        &issueCLsGerrit{changes: tc.changes},
+			}
+			ctx := &workflow.TaskContext{Context: context.Background(), Logger: &testLogger{t: t}}
+			got := tasks.CheckMilestoneCLs(ctx, ReleaseMilestones{1, 2}, tc.kind)
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// noEditMilestoneGitHub is a FakeGitHub that fails if a milestone is edited.
+type noEditMilestoneGitHub struct {
+	*FakeGitHub
+}
+
+func (noEditMilestoneGitHub) EditMilestone(_ context.Context, owner, repo string, number int, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
+	return nil, nil, fmt.Errorf("unexpected edit of milestone %d", number)
+}
+
+func TestPushIssuesClosesMilestone(t *testing.T) {
+	ctx := &workflow.TaskContext{Context: context.Background(), Logger: &testLogger{t: t}}
+	fake := &FakeGitHub{Milestones: map[int]string{1: "Go1.20.1", 2: "Go1.20.2"}}
+	tasks := &MilestoneTasks{Client: fake, RepoOwner: "golang", RepoName: "go"}
+	if err := tasks.PushIssues(ctx, ReleaseMilestones{1, 2}, "go1.20.1", KindMinor); err != nil {
+		t.Fatalf("PushIssues: %v", err)
+	}
+	if !fake.ClosedMilestones[1] || fake.ClosedMilestones[2] {
+		t.Errorf("closed milestones = %v, want only 1", fake.ClosedMilestones)
+	}
+
+	// Retrying the task, with the milestone already closed,
+	// should succeed without editing it again.
+	tasks.Client = noEditMilestoneGitHub{fake}
+	if err := tasks.PushIssues(ctx, ReleaseMilestones{1, 2}, "go1.20.1", KindMinor); err != nil {
+		t.Fatalf("PushIssues with the milestone already closed: %v", err)
+	}
+}
+
+func TestCheckSecurityAdvisories(t *testing.T) {
+	var errManualApproval = fmt.Errorf("manual approval is required")
+	withdrawn := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	advisories := map[string]*SecurityAdvisory{
+		"CVE-2022-24675":      {GHSAID: "GHSA-8v5j-pwr7-w5f8", CVEID: "CVE-2022-24675", HTMLURL: "https://github.com/advisories/GHSA-8v5j-pwr7-w5f8"},
+		"GHSA-8v5j-pwr7-w5f8": {GHSAID: "GHSA-8v5j-pwr7-w5f8", CVEID: "CVE-2022-24675", HTMLURL: "https://github.com/advisories/GHSA-8v5j-pwr7-w5f8"},
+		"CVE-2022-28327":      {GHSAID: "GHSA-9mx7-2x3c-xg6q", CVEID: "CVE-2022-28327", HTMLURL: "https://github.com/advisories/GHSA-9mx7-2x3c-xg6q", WithdrawnAt: &withdrawn},
+	}
+	for _, tc := range [...]struct {
+		name  string
+		fixes []string
+		want  error
+	}{
+		{
+			name:  "no security fixes",
+			fixes: nil,
+			want:  nil, // Want no error.
+		},
+		{
+			name:  "fix without IDs",
+			fixes: []string{"net/http: fix something\n\nThis is Go issue https://go.dev/issue/12345."},
+			want:  nil,
+		},
+		{
+			name:  "published advisories",
+			fixes: []string{"encoding/pem: fix stack overflow in Decode\n\nThis is CVE-2022-24675 (GHSA-8v5j-pwr7-w5f8) and Go issue https://go.dev/issue/51853."},
+			want:  nil,
+		},
+		{
+			name:  "missing advisory",
+			fixes: []string{"This is CVE-2022-24675 and Go issue https://go.dev/issue/51853.", "This is CVE-2022-27536 and Go issue https://go.dev/issue/51759."},
+			want:  errManualApproval,
+		},
+		{
+			name:  "withdrawn advisory",
+			fixes: []string{"This is CVE-2022-28327 and Go issue https://go.dev/issue/52075."},
+			want:  errManualApproval,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks := &MilestoneTasks{
+				Client:        &FakeGitHub{Advisories: advisories},
+				ApproveAction: func(*workflow.TaskContext) error { return errManualApproval },
+			}
+			ctx := &workflow.TaskContext{Context: context.Background(), Logger: &testLogger{t: t}}
+			if got := tasks.CheckSecurityAdvisories(ctx, tc.fixes); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 var (
 	flagRun   = flag.Bool("run-destructive-milestones-test", false, "Run the milestone test. Requires repository owner and name flags, and GITHUB_TOKEN set in the environment.")
 	flagOwner = flag.String("milestones-github-owner", "", "Owner of testing repository")
@@ -0,0 +1,71 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/build/internal/releasetargets"
+	wf "golang.org/x/build/internal/workflow"
+)
+
+// CheckPublishedFiles checks that the files published for each release
+// match the files expected for its release targets exactly, so that
+// packaging gaps are caught before the release is announced.
+func CheckPublishedFiles(ctx *wf.TaskContext, published []Published) error {
+	var problems []string
+	for _, p := range published {
+		want, err := expectedFiles(p.Version)
+		if err != nil {
+			return err
+		}
+		got := make(map[string]bool)
+		for _, f := range p.Files {
+			got[f.Filename] = true
+		}
+		for _, name := range want {
+			if !got[name] {
+				problems = append(problems, fmt.Sprintf("%s: missing file %s", p.Version, name))
+			}
+			delete(got, name)
+		}
+		for _, f := range p.Files {
+			if got[f.Filename] {
+				problems = append(problems, fmt.Sprintf("%s: unexpected file %s", p.Version, f.Filename))
+			}
+		}
+		ctx.Printf("Checked %d published files for %s.", len(p.Files), p.Version)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("published files don't match release targets:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// expectedFiles returns the sorted names of the files expected to be
+// published for the Go release version, such as "go1.23.1".
+// It must be kept in sync with the artifacts built by relui.
+func expectedFiles(version string) ([]string, error) {
+	targets, ok := releasetargets.TargetsForVersion(version)
+	if !ok {
+		return nil, fmt.Errorf("no release targets for version %q", version)
+	}
+	files := []string{version + ".src.tar.gz"}
+	for _, t := range targets {
+		prefix := version + "." + t.Name + "."
+		switch t.GOOS {
+		case "darwin":
+			files = append(files, prefix+"tar.gz", prefix+"pkg")
+		case "windows":
+			files = append(files, prefix+"zip", prefix+"msi")
+		default:
+			files = append(files, prefix+"tar.gz")
+		}
+	}
+	slices.Sort(files)
+	return files, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	wf "golang.org/x/build/internal/workflow"
+)
+
+func TestCheckPublishedFiles(t *testing.T) {
+	ctx := &wf.TaskContext{Context: context.Background(), Logger: &testLogger{t: t}}
+	const version = "go1.23.1"
+	want, err := expectedFiles(version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"go1.23.1.src.tar.gz",
+		"go1.23.1.linux-amd64.tar.gz",
+		"go1.23.1.linux-armv6l.tar.gz",
+		"go1.23.1.darwin-arm64.pkg",
+		"go1.23.1.windows-amd64.msi",
+		"go1.23.1.windows-amd64.zip",
+	} {
+		if !strings.Contains(strings.Join(want, "\n")+"\n", name+"\n") {
+			t.Errorf("expectedFiles(%q) doesn't include %s", version, name)
+		}
+	}
+
+	var files []WebsiteFile
+	for _, name := range want {
+		files = append(files, WebsiteFile{Filename: name, Version: version})
+	}
+	if err := CheckPublishedFiles(ctx, []Published{{Version: version, Files: files}}); err != nil {
+		t.Errorf("CheckPublishedFiles with expected files: %v", err)
+	}
+
+	// Drop the source archive and add an unexpected file.
+	bad := append(files[:0:0], files...)
+	bad = slices.DeleteFunc(bad, func(f WebsiteFile) bool { return f.Filename == "go1.23.1.src.tar.gz" })
+	bad = append(bad, WebsiteFile{Filename: "go1.23.1.linux-amd64.zip", Version: version})
+	err = CheckPublishedFiles(ctx, []Published{{Version: version, Files: bad}})
+	if err == nil {
+		t.Fatal("CheckPublishedFiles with bad files succeeded, want error")
+	}
+	for _, want := range []string{"missing file go1.23.1.src.tar.gz", "unexpected file go1.23.1.linux-amd64.zip"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("CheckPublishedFiles error %q doesn't mention %q", err, want)
+		}
+	}
+}
@@ -30,6 +30,7 @@ type Published struct {
 type CommunicationTasks struct {
 	AnnounceMailTasks
 	SocialMediaTasks
+	ReleaseFeedTasks
 }
 
 var AwaitDivisor int = 1
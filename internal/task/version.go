@@ -11,6 +11,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -210,6 +211,82 @@ func (t *VersionTasks) ReadBranchHead(ctx *workflow.TaskContext, branch string)
 	return t.Gerrit.ReadBranchHead(ctx, t.GoProject, branch)
 }
 
+// Changelog returns a summary of the commits in the go repository that
+// are new in version, which will be tagged at commit, since the previous
+// release from the same branch. It's for release coordinators to check
+// the contents of the release before approving it.
+func (t *VersionTasks) Changelog(ctx *workflow.TaskContext, version, commit string) (string, error) {
+	tags, _, _, err := t.tagInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	prev := previousVersion(version, tags)
+	if prev == "" {
+		ctx.Printf("No earlier release from the same branch as %s.", version)
+		return fmt.Sprintf("%s is the first release from its branch; there's no changelog.\n", version), nil
+	}
+	commits, err := t.Gerrit.ListCommits(ctx, t.GoProject, prev, commit)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d commits in %s since %s:\n\n", len(commits), version, prev)
+	for _, c := range commits {
+		fmt.Fprintf(&b, "%.12s %s (%s)\n", c.CommitID, c.Subject, c.Author.Email)
+	}
+	return b.String(), nil
+}
+
+// previousVersion returns the most recent release in tags that version
+// follows on the same branch, or "" if there isn't one. For example,
+// go1.23.1 follows go1.23.0, go1.23.0 follows the last release candidate
+// of Go 1.23, and go1.23rc1 follows the last beta. A first beta follows
+// no release, since the previous one was made from a release branch.
+func previousVersion(version string, tags map[string]bool) string {
+	m := releaseVersionRE.FindStringSubmatch(version)
+	if m == nil {
+		return ""
+	}
+	series := "go1." + m[1]
+	last := func(prefix string) string {
+		prev := ""
+		for i := 1; tags[fmt.Sprintf("%s%d", prefix, i)]; i++ {
+			prev = fmt.Sprintf("%s%d", prefix, i)
+		}
+		return prev
+	}
+	var candidates []string
+	if m[2] != "" {
+		switch n, _ := strconv.Atoi(m[2]); n {
+		case 0:
+			candidates = []string{last(series + "rc")}
+		case 1:
+			// Before Go 1.21, the initial release had no ".0".
+			candidates = []string{series + ".0", series}
+		default:
+			candidates = []string{fmt.Sprintf("%s.%d", series, n-1)}
+		}
+	} else {
+		switch n, _ := strconv.Atoi(m[4]); {
+		case n > 1:
+			candidates = []string{fmt.Sprintf("%s%s%d", series, m[3], n-1)}
+		case m[3] == "rc":
+			candidates = []string{last(series + "beta")}
+		}
+	}
+	for _, c := range candidates {
+		if c != "" && tags[c] {
+			return c
+		}
+	}
+	return ""
+}
+
+// releaseVersionRE matches Go release versions, such as go1.23.1 or
+// go1.24rc2, capturing the major version, the minor version, and the
+// kind and number of a pre-release.
+var releaseVersionRE = regexp.MustCompile(`^go1\.(\d+)(?:\.(\d+)|(beta|rc)(\d+))$`)
+
 // TagRelease tags commit as version.
 func (t *VersionTasks) TagRelease(ctx *workflow.TaskContext, version, commit string) error {
 	return t.Gerrit.Tag(ctx, t.GoProject, version, commit)
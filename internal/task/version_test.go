@@ -167,3 +167,75 @@ func TestVersion(t *testing.T) {
 		t.Fatalf("cleaning up VERSION: %v", err)
 	}
 }
+
+func TestPreviousVersion(t *testing.T) {
+	tags := map[string]bool{}
+	for _, tag := range []string{
+		"go1.20rc1", "go1.20", "go1.20.1",
+		"go1.21rc2", "go1.21rc3", "go1.21.0", "go1.21.1",
+		"go1.22beta1", "go1.22beta2", "go1.22rc1",
+	} {
+		tags[tag] = true
+	}
+	for _, tc := range []struct {
+		version, want string
+	}{
+		{"go1.20.2", "go1.20.1"},
+		{"go1.20.1", "go1.20"},
+		{"go1.21.2", "go1.21.1"},
+		{"go1.21.1", "go1.21.0"},
+		{"go1.22beta1", ""},
+		{"go1.22beta3", "go1.22beta2"},
+		{"go1.22rc1", "go1.22beta2"},
+		{"go1.22rc2", "go1.22rc1"},
+		{"go1.22.0", "go1.22rc1"},
+		{"go1.23rc1", ""},
+		{"go1.23.0", ""},
+		{"go1.20.5", ""},
+		{"not a version", ""},
+	} {
+		if got := previousVersion(tc.version, tags); got != tc.want {
+			t.Errorf("previousVersion(%q) = %q; want %q", tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestChangelog(t *testing.T) {
+	repo := NewFakeRepo(t, "go")
+	repo.Commit(map[string]string{"README": "hello"})
+	repo.runGit("tag", "go1.23.0")
+	repo.runGit("commit", "--allow-empty", "-m", "net/http: fix a bug\n\nMore details.")
+	repo.runGit("commit", "--allow-empty", "-m", "os: fix another bug")
+	head := strings.TrimSpace(string(repo.runGit("rev-parse", "HEAD")))
+	tasks := &VersionTasks{
+		Gerrit:    NewFakeGerrit(t, repo),
+		GoProject: "go",
+	}
+	ctx := &workflow.TaskContext{
+		Context: context.Background(),
+		Logger:  &testLogger{t, ""},
+	}
+
+	got, err := tasks.Changelog(ctx, "go1.23.1", head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLines := []string{
+		"2 commits in go1.23.1 since go1.23.0:",
+		head[:12] + " os: fix another bug (relui@example.com)",
+		"net/http: fix a bug (relui@example.com)",
+	}
+	for _, want := range wantLines {
+		if !strings.Contains(got, want) {
+			t.Errorf("Changelog = %q; want it to contain %q", got, want)
+		}
+	}
+
+	got, err = tasks.Changelog(ctx, "go1.24beta1", head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "first release") {
+		t.Errorf("Changelog for first beta = %q; want note that there's no previous release", got)
+	}
+}
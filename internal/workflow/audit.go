@@ -0,0 +1,88 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/google/uuid"
+	"golang.org/x/build/internal/redact"
+)
+
+// An AuditListener is a Listener that also records the inputs and
+// outputs of each task run, to provide an audit trail of exactly what
+// each step of a workflow received and produced.
+type AuditListener interface {
+	Listener
+	// TaskAudited is called once for each run of a task, including
+	// failed runs that will be retried.
+	TaskAudited(workflowID uuid.UUID, taskID string, record *AuditRecord)
+}
+
+// An AuditRecord is a structured record of one run of a task.
+//
+// Values are JSON-encoded. Object members whose names suggest they hold
+// secrets, such as "Password" or "APIToken", are redacted.
+type AuditRecord struct {
+	Inputs     []json.RawMessage `json:"inputs"`           // Task arguments, not including the TaskContext.
+	Output     json.RawMessage   `json:"output,omitempty"` // Task result, if it has one and succeeded.
+	Error      string            `json:"error,omitempty"`
+	RetryCount int               `json:"retryCount"`
+}
+
+// Redacted is the value that replaces secrets in an AuditRecord.
+const Redacted = redact.Redacted
+
+func newAuditRecord(args []reflect.Value, state taskState) *AuditRecord {
+	rec := &AuditRecord{RetryCount: state.retryCount}
+	for _, arg := range args {
+		rec.Inputs = append(rec.Inputs, redactedJSON(arg.Interface()))
+	}
+	if state.err != nil {
+		rec.Error = state.err.Error()
+	} else if state.serializedResult != nil {
+		rec.Output = redactedJSON(json.RawMessage(state.serializedResult))
+	}
+	return rec
+}
+
+// redactedJSON returns v encoded as JSON with secrets redacted.
+// Values that can't be encoded are replaced by a JSON string
+// describing the error.
+func redactedJSON(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal("unencodable value: " + err.Error())
+		return data
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		data, _ = json.Marshal("undecodable value: " + err.Error())
+		return data
+	}
+	data, _ = json.Marshal(redactSecrets(generic))
+	return data
+}
+
+// redactSecrets replaces the values of secret-looking object members in
+// the generic JSON value v.
+func redactSecrets(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, elem := range v {
+			if redact.IsSecretName(k) {
+				v[k] = Redacted
+			} else {
+				v[k] = redactSecrets(elem)
+			}
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = redactSecrets(elem)
+		}
+	}
+	return v
+}
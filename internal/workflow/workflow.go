@@ -954,6 +954,10 @@ func runTask(ctx context.Context, workflowID uuid.UUID, listener Listener, state
 		}
 	}
 
+	if al, ok := listener.(AuditListener); ok {
+		al.TaskAudited(workflowID, state.def.name, newAuditRecord(args, state))
+	}
+
 	if state.err != nil && !tctx.disableRetries && state.retryCount+1 < MaxRetries {
 		tctx.Printf("task failed, will retry (%v of %v): %v", state.retryCount+1, MaxRetries, state.err)
 		state = taskState{
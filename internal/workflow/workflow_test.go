@@ -6,10 +6,12 @@ package workflow_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -529,6 +531,48 @@ func (l *capturingLogger) Printf(format string, v ...interface{}) {
 	l.lines = append(l.lines, fmt.Sprintf(format, v...))
 }
 
+func TestAudit(t *testing.T) {
+	type creds struct {
+		User     string
+		Password string
+	}
+	login := func(ctx *wf.TaskContext, c creds, attempt int) (creds, error) {
+		return creds{User: c.User, Password: "new-" + c.Password}, nil
+	}
+
+	wd := wf.New(wf.ACL{})
+	out := wf.Task2(wd, "login", login, wf.Const(creds{"gopher", "hunter2"}), wf.Const(1))
+	wf.Output(wd, "out", out)
+
+	listener := &auditTestListener{Listener: &verboseListener{t}}
+	w := startWorkflow(t, wd, nil)
+	runWorkflow(t, w, listener)
+
+	want := []*wf.AuditRecord{{
+		Inputs: []json.RawMessage{
+			json.RawMessage(`{"Password":"[REDACTED]","User":"gopher"}`),
+			json.RawMessage(`1`),
+		},
+		Output: json.RawMessage(`{"Password":"[REDACTED]","User":"gopher"}`),
+	}}
+	if diff := cmp.Diff(want, listener.records); diff != "" {
+		t.Errorf("audit records mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type auditTestListener struct {
+	wf.Listener
+
+	mu      sync.Mutex
+	records []*wf.AuditRecord
+}
+
+func (l *auditTestListener) TaskAudited(_ uuid.UUID, _ string, record *wf.AuditRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, record)
+}
+
 func TestResume(t *testing.T) {
 	// We expect runOnlyOnce to only run once.
 	var runs int64
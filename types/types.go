@@ -92,16 +92,25 @@ type BuildRecord struct {
 	Rev           string // same as GoRev for repo "go"
 	Repo          string // "go", "net", etc.
 	Builder       string // "linux-amd64-foo"
-	ContainerHost string // "" means GKE; "cos" means Container-Optimized OS
+	ContainerHost string // "cos", "ec2", "reverse", or "" if not a container build
 	OS            string // "linux"
 	Arch          string // "amd64"
 
+	// Branch and CommitTime are the branch and committer time of Rev.
+	// They're empty in records written before they were added.
+	Branch     string
+	CommitTime time.Time
+
 	EndTime    time.Time
 	Seconds    float64
 	Result     string // empty string, "ok", "fail"
 	FailureURL string `datastore:",noindex"` // deprecated; use LogURL
 	LogURL     string `datastore:",noindex"`
 
+	// Restarts is the number of earlier attempts at this build
+	// that died before completion and were restarted.
+	Restarts int
+
 	// TODO(bradfitz): log which reverse buildlet we got?
 	// Buildlet string
 }
@@ -114,6 +123,7 @@ type ReverseBuilder struct {
 	BusySec      float64 `json:",omitempty"`
 	Version      string  // buildlet version
 	Busy         bool
+	Disabled     bool `json:",omitempty"` // disabled by an operator; not given new work
 }
 
 // ReverseHostStatus is part of ReverseBuilderStatus.
@@ -121,6 +131,7 @@ type ReverseHostStatus struct {
 	HostType  string // dashboard.Hosts key
 	Connected int    // number of connected buildlets
 	Expect    int    // expected number, from dashboard.Hosts config
+	Max       int    `json:",omitempty"` // maximum number accepted; 0 means no limit
 	Idle      int
 	Busy      int
 	Waiters   int // number of builds waiting on a buildlet host of this type
@@ -193,4 +204,23 @@ type ActivePostSubmitBuild struct {
 	Commit    string `json:"commit"`             // hash of commit being tested
 	GoCommit  string `json:"goCommit,omitempty"` // hash of Go commit, or empty for the main repo
 	StatusURL string `json:"statusURL"`
+
+	// ContainerHost is the container runtime the build runs on:
+	// "cos" for Container-Optimized OS on GCE, "ec2" for EC2,
+	// "reverse" for reverse buildlets, or empty if it's not a
+	// container build.
+	ContainerHost string `json:"containerHost,omitempty"`
+}
+
+// LastGreenBuild describes the most recent commit that a builder
+// built successfully, as reported by the coordinator's
+// /status/last-green.json endpoint.
+type LastGreenBuild struct {
+	Builder    string    `json:"builder"`            // "linux-amd64"
+	Repo       string    `json:"repo"`               // "go", "net", etc.
+	Branch     string    `json:"branch"`             // branch of Repo, such as "master"
+	Commit     string    `json:"commit"`             // hash of the commit in Repo
+	GoCommit   string    `json:"goCommit,omitempty"` // hash of Go commit, or empty for the main repo
+	CommitTime time.Time `json:"commitTime"`         // committer time of Commit
+	Recorded   time.Time `json:"recorded"`           // when the result was recorded
 }